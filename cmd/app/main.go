@@ -1,12 +1,24 @@
 package main
 
 import (
+	"context"
+	"errors"
+	"log/slog"
 	"os"
 
 	"github.com/fms85/desafio-tecnico-go-stone/internal/delivery/api"
+	"github.com/fms85/desafio-tecnico-go-stone/internal/delivery/grpc"
+	"github.com/fms85/desafio-tecnico-go-stone/internal/delivery/worker"
 	"github.com/fms85/desafio-tecnico-go-stone/internal/domain/common"
 	"github.com/fms85/desafio-tecnico-go-stone/internal/domain/migration"
+	"github.com/fms85/desafio-tecnico-go-stone/internal/driver/billnetwork"
+	"github.com/fms85/desafio-tecnico-go-stone/internal/driver/eventbus"
 	gormDriver "github.com/fms85/desafio-tecnico-go-stone/internal/driver/gorm"
+	"github.com/fms85/desafio-tecnico-go-stone/internal/driver/jwt"
+	"github.com/fms85/desafio-tecnico-go-stone/internal/driver/logger"
+	"github.com/fms85/desafio-tecnico-go-stone/internal/driver/notifier"
+	otelDriver "github.com/fms85/desafio-tecnico-go-stone/internal/driver/otel"
+	"github.com/fms85/desafio-tecnico-go-stone/internal/driver/prometheus"
 	"github.com/joho/godotenv"
 	"gorm.io/gorm"
 )
@@ -18,20 +30,66 @@ func main() {
 		}
 	}
 
+	log := logger.Setup(os.Getenv("LOG_LEVEL"))
+	slog.SetDefault(log)
+
+	shutdown, err := otelDriver.Setup(context.Background(), os.Getenv("TRACE_EXPORTER"))
+	if err != nil {
+		panic(err)
+	}
+	defer shutdown(context.Background())
+
+	level := logger.ParseLevel(os.Getenv("LOG_LEVEL"))
 	db := map[string]*gorm.DB{
-		"wr": gormDriver.Setup(os.Getenv("DB_CONNECTION_WRITE")),
-		"rd": gormDriver.Setup(os.Getenv("DB_CONNECTION_READ")),
+		"wr": gormDriver.Setup(os.Getenv("DB_CONNECTION_WRITE"), level),
+		"rd": gormDriver.Setup(os.Getenv("DB_CONNECTION_READ"), level),
+	}
+
+	keys, err := jwt.Load(os.Getenv("JWT_SECRET"), os.Getenv("JWT_HS256_UNTIL"), os.Getenv("JWT_SIGNING_KEY_FILE"), os.Getenv("JWT_VERIFICATION_KEY_FILES"))
+	if err != nil {
+		panic(err)
+	}
+
+	// Receipts signed with an empty secret would be forged by anyone.
+	if os.Getenv("RECEIPT_SECRET") == "" {
+		panic(errors.New("receipt secret required"))
 	}
 
 	migration.Run(db["wr"])
+	prometheus.Setup(db)
 
 	app := common.App{
-		DB: db,
+		DB:          db,
+		Logger:      log,
+		Bus:         eventbus.New(),
+		Notifier:    notifier.NewLog(log),
+		BillNetwork: billnetwork.NewSimulator(log),
+		JWT:         keys,
 		Env: common.Env{
-			HTTP_ADDR:  os.Getenv("HTTP_ADDR"),
-			JWT_SECRET: os.Getenv("JWT_SECRET"),
+			HTTP_ADDR:                  os.Getenv("HTTP_ADDR"),
+			GRPC_ADDR:                  os.Getenv("GRPC_ADDR"),
+			JWT_SECRET:                 os.Getenv("JWT_SECRET"),
+			JWT_HS256_UNTIL:            os.Getenv("JWT_HS256_UNTIL"),
+			JWT_SIGNING_KEY_FILE:       os.Getenv("JWT_SIGNING_KEY_FILE"),
+			JWT_VERIFICATION_KEY_FILES: os.Getenv("JWT_VERIFICATION_KEY_FILES"),
+			RECEIPT_SECRET:             os.Getenv("RECEIPT_SECRET"),
+			LOG_LEVEL:                  os.Getenv("LOG_LEVEL"),
+			TRACE_EXPORTER:             os.Getenv("TRACE_EXPORTER"),
+			OPENAPI_VALIDATION:         os.Getenv("OPENAPI_VALIDATION"),
+			LEGACY_ROUTES_SUNSET:       os.Getenv("LEGACY_ROUTES_SUNSET"),
+			OUTBOX_SINKS:               os.Getenv("OUTBOX_SINKS"),
+			PIX_MERCHANT_CITY:          os.Getenv("PIX_MERCHANT_CITY"),
+			PIX_LOCATION_HOST:          os.Getenv("PIX_LOCATION_HOST"),
+			BILL_SETTLEMENT_ACCOUNT_ID: os.Getenv("BILL_SETTLEMENT_ACCOUNT_ID"),
+			TOTP_STEP_UP_AMOUNT:        os.Getenv("TOTP_STEP_UP_AMOUNT"),
 		},
 	}
 
+	if app.Env.GRPC_ADDR != "" {
+		go grpc.Init(app)
+	}
+
+	go worker.Init(app)
+
 	api.Init(app)
 }
@@ -3,67 +3,174 @@ package api
 import (
 	"fmt"
 	"net/http"
+	"strconv"
 	"time"
 
 	accountHandler "github.com/fms85/desafio-tecnico-go-stone/internal/delivery/api/handler/account"
+	apiKeyHandler "github.com/fms85/desafio-tecnico-go-stone/internal/delivery/api/handler/apikey"
+	billPaymentHandler "github.com/fms85/desafio-tecnico-go-stone/internal/delivery/api/handler/billpayment"
+	chargeHandler "github.com/fms85/desafio-tecnico-go-stone/internal/delivery/api/handler/charge"
+	docsHandler "github.com/fms85/desafio-tecnico-go-stone/internal/delivery/api/handler/docs"
+	eventHandler "github.com/fms85/desafio-tecnico-go-stone/internal/delivery/api/handler/event"
+	jwksHandler "github.com/fms85/desafio-tecnico-go-stone/internal/delivery/api/handler/jwks"
+	locationHandler "github.com/fms85/desafio-tecnico-go-stone/internal/delivery/api/handler/location"
+	paymentRequestHandler "github.com/fms85/desafio-tecnico-go-stone/internal/delivery/api/handler/paymentrequest"
+	pixKeyHandler "github.com/fms85/desafio-tecnico-go-stone/internal/delivery/api/handler/pixkey"
+	profileHandler "github.com/fms85/desafio-tecnico-go-stone/internal/delivery/api/handler/profile"
+	receiptHandler "github.com/fms85/desafio-tecnico-go-stone/internal/delivery/api/handler/receipt"
+	statementHandler "github.com/fms85/desafio-tecnico-go-stone/internal/delivery/api/handler/statement"
 	transferHandler "github.com/fms85/desafio-tecnico-go-stone/internal/delivery/api/handler/transfer"
+	webhookHandler "github.com/fms85/desafio-tecnico-go-stone/internal/delivery/api/handler/webhook"
 	middleware "github.com/fms85/desafio-tecnico-go-stone/internal/delivery/api/middleware"
 	"github.com/fms85/desafio-tecnico-go-stone/internal/domain/common"
+	ginDriver "github.com/fms85/desafio-tecnico-go-stone/internal/driver/gin"
+	otelDriver "github.com/fms85/desafio-tecnico-go-stone/internal/driver/otel"
+	"github.com/fms85/desafio-tecnico-go-stone/internal/driver/prometheus"
 	accountRepository "github.com/fms85/desafio-tecnico-go-stone/internal/repository/account"
+	apiKeyRepository "github.com/fms85/desafio-tecnico-go-stone/internal/repository/apikey"
+	billPaymentRepository "github.com/fms85/desafio-tecnico-go-stone/internal/repository/billpayment"
+	chargeRepository "github.com/fms85/desafio-tecnico-go-stone/internal/repository/charge"
+	ledgerRepository "github.com/fms85/desafio-tecnico-go-stone/internal/repository/ledger"
+	paymentRequestRepository "github.com/fms85/desafio-tecnico-go-stone/internal/repository/paymentrequest"
+	pixKeyRepository "github.com/fms85/desafio-tecnico-go-stone/internal/repository/pixkey"
 	transferRepository "github.com/fms85/desafio-tecnico-go-stone/internal/repository/transfer"
+	webhookRepository "github.com/fms85/desafio-tecnico-go-stone/internal/repository/webhook"
 	accountUsecase "github.com/fms85/desafio-tecnico-go-stone/internal/usecase/account"
+	apiKeyUsecase "github.com/fms85/desafio-tecnico-go-stone/internal/usecase/apikey"
+	billPaymentUsecase "github.com/fms85/desafio-tecnico-go-stone/internal/usecase/billpayment"
+	chargeUsecase "github.com/fms85/desafio-tecnico-go-stone/internal/usecase/charge"
+	eventUsecase "github.com/fms85/desafio-tecnico-go-stone/internal/usecase/event"
+	paymentRequestUsecase "github.com/fms85/desafio-tecnico-go-stone/internal/usecase/paymentrequest"
+	pixKeyUsecase "github.com/fms85/desafio-tecnico-go-stone/internal/usecase/pixkey"
+	statementUsecase "github.com/fms85/desafio-tecnico-go-stone/internal/usecase/statement"
 	transferUsecase "github.com/fms85/desafio-tecnico-go-stone/internal/usecase/transfer"
+	webhookUsecase "github.com/fms85/desafio-tecnico-go-stone/internal/usecase/webhook"
 	"github.com/fms85/desafio-tecnico-go-stone/internal/util"
 	"github.com/gin-gonic/gin"
-	"github.com/gin-gonic/gin/binding"
-	"github.com/go-playground/validator/v10"
+	"go.opentelemetry.io/contrib/instrumentation/github.com/gin-gonic/gin/otelgin"
 )
 
-func Init(app common.App) {
-	router := Setup()
+// LEGACY_ROUTES_DEPRECATED_AT is when the unversioned routes were superseded by /v1.
+var LEGACY_ROUTES_DEPRECATED_AT = time.Date(2026, 10, 19, 0, 0, 0, 0, time.UTC)
 
+func Init(app common.App) {
 	s := &http.Server{
 		Addr:         fmt.Sprintf(":%s", app.Env.HTTP_ADDR),
-		Handler:      router,
+		Handler:      Setup(app),
 		ReadTimeout:  10 * time.Second,
 		WriteTimeout: 10 * time.Second,
 	}
 
+	app.Logger.Info("http server listening", "addr", s.Addr)
+
+	if err := s.ListenAndServe(); err != nil {
+		panic(err)
+	}
+}
+
+func Setup(app common.App) *gin.Engine {
+	doc, err := docsHandler.Load()
+	if err != nil {
+		panic(err)
+	}
+
+	router := ginDriver.Setup()
+	router.Use(
+		otelgin.Middleware(otelDriver.SERVICE_NAME),
+		middleware.RequestID(app.Logger),
+		middleware.AccessLog(),
+		middleware.Metrics(),
+		middleware.Error(),
+	)
+
+	if app.Env.OPENAPI_VALIDATION == "true" {
+		router.Use(middleware.OpenAPI(doc))
+	}
+
 	router.GET("/health", func(c *gin.Context) {
 		c.JSON(http.StatusOK, gin.H{
 			"message": "ok",
 		})
 	})
 
+	router.GET("/metrics", gin.WrapH(prometheus.Handler()))
+
+	docsHandler.New(doc).InitRoutes(&router.RouterGroup)
+	jwksHandler.New(app.JWT).InitRoutes(&router.RouterGroup)
+
 	accountRepository := accountRepository.New(app.DB)
 	transferRepository := transferRepository.New(app.DB)
+	ledgerRepository := ledgerRepository.New(app.DB)
+	webhookRepository := webhookRepository.New(app.DB)
+	pixKeyRepository := pixKeyRepository.New(app.DB)
+	paymentRequestRepository := paymentRequestRepository.New(app.DB)
+	chargeRepository := chargeRepository.New(app.DB)
+	billPaymentRepository := billPaymentRepository.New(app.DB)
+	apiKeyRepository := apiKeyRepository.New(app.DB)
 
-	accountUsecase := accountUsecase.New(accountRepository)
-	transferUsecase := transferUsecase.New(transferRepository, accountUsecase)
-
-	accountHandler := accountHandler.New(accountUsecase, app.Env.JWT_SECRET)
-	accountHandler.InitRoutes(router)
+	accountUsecase := accountUsecase.New(accountRepository, app.Notifier)
+	pixKeyUsecase := pixKeyUsecase.New(pixKeyRepository, accountUsecase, app.Notifier)
+	transferUsecase := transferUsecase.New(transferRepository, accountUsecase, pixKeyUsecase, app.Bus, app.Env.RECEIPT_SECRET, util.ParseAmount(app.Env.TOTP_STEP_UP_AMOUNT))
+	statementUsecase := statementUsecase.New(ledgerRepository)
+	eventUsecase := eventUsecase.New(ledgerRepository, app.Bus)
+	paymentRequestUsecase := paymentRequestUsecase.New(paymentRequestRepository, accountUsecase, pixKeyUsecase, app.Env.PIX_MERCHANT_CITY, app.Env.PIX_LOCATION_HOST)
+	chargeUsecase := chargeUsecase.New(chargeRepository, accountUsecase, transferUsecase)
+	billPaymentUsecase := billPaymentUsecase.New(billPaymentRepository, transferUsecase, app.BillNetwork, parseID(app.Env.BILL_SETTLEMENT_ACCOUNT_ID))
+	webhookUsecase := webhookUsecase.New(webhookRepository, webhookUsecase.NewClient())
+	apiKeyUsecase := apiKeyUsecase.New(apiKeyRepository, accountUsecase)
 
-	authorized := router.Group("/")
-	authorized.Use(middleware.Auth(app.Env.JWT_SECRET))
-	{
-		transferHandler := transferHandler.New(transferUsecase)
-		transferHandler.InitRoutes(authorized)
+	auth := []gin.HandlerFunc{
+		middleware.Auth(app.JWT, apiKeyUsecase),
+		middleware.Session(accountUsecase),
+		middleware.Scope(API_KEY_SCOPES),
 	}
 
-	if err := s.ListenAndServe(); err != nil {
-		panic(err)
+	v1 := version{
+		name: API_VERSION_V1,
+		public: []routesHandler{
+			accountHandler.New(accountUsecase, app.JWT),
+			receiptHandler.New(transferUsecase),
+			locationHandler.New(paymentRequestUsecase),
+		},
+		authorized: []routesHandler{
+			transferHandler.New(transferUsecase),
+			statementHandler.New(statementUsecase),
+			webhookHandler.New(webhookUsecase),
+			eventHandler.New(eventUsecase),
+			pixKeyHandler.New(pixKeyUsecase),
+			paymentRequestHandler.New(paymentRequestUsecase),
+			chargeHandler.New(chargeUsecase),
+			billPaymentHandler.New(billPaymentUsecase),
+			profileHandler.New(accountUsecase, app.JWT),
+			apiKeyHandler.New(apiKeyUsecase),
+		},
 	}
+	v1.register(&router.RouterGroup, auth...)
+
+	registerLegacy(router, router.Group("/", middleware.Deprecation(
+		LEGACY_ROUTES_DEPRECATED_AT,
+		parseDate(app.Env.LEGACY_ROUTES_SUNSET),
+		"/"+API_VERSION_V1,
+	)), auth...)
+
+	return router
 }
 
-func Setup() *gin.Engine {
-	router := gin.Default()
+func parseDate(value string) time.Time {
+	date, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return time.Time{}
+	}
+
+	return date
+}
 
-	if v, ok := binding.Validator.Engine().(*validator.Validate); ok {
-		if err := v.RegisterValidation("cpf", util.CpfValidator); err != nil {
-			panic(err)
-		}
+// parseID reads an optional id setting, zero when unset or invalid.
+func parseID(value string) uint {
+	id, err := strconv.ParseUint(value, 10, 0)
+	if err != nil {
+		return 0
 	}
 
-	return router
+	return uint(id)
 }
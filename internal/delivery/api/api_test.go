@@ -0,0 +1,153 @@
+package api
+
+import (
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"sort"
+	"strings"
+	"testing"
+
+	docsHandler "github.com/fms85/desafio-tecnico-go-stone/internal/delivery/api/handler/docs"
+	"github.com/fms85/desafio-tecnico-go-stone/internal/domain/common"
+	"github.com/google/go-cmp/cmp"
+	"gotest.tools/assert"
+)
+
+var ginParamPattern = regexp.MustCompile(`:([A-Za-z0-9_]+)`)
+
+func TestOpenAPISpecMatchesRoutes(t *testing.T) {
+	doc, err := docsHandler.Load()
+	assert.NilError(t, err)
+
+	router := Setup(common.App{Logger: slog.Default()})
+
+	routes := map[string]bool{}
+	for _, route := range router.Routes() {
+		routes[route.Method+" "+ginParamPattern.ReplaceAllString(route.Path, "{$1}")] = true
+	}
+
+	var registered []string
+	for _, route := range router.Routes() {
+		path := ginParamPattern.ReplaceAllString(route.Path, "{$1}")
+		if routes[route.Method+" /"+API_VERSION_V1+path] {
+			continue // legacy alias of a /v1 route
+		}
+
+		registered = append(registered, route.Method+" "+path)
+	}
+
+	var documented []string
+	for path, pathItem := range doc.Paths.Map() {
+		for method := range pathItem.Operations() {
+			documented = append(documented, strings.ToUpper(method)+" "+path)
+		}
+	}
+
+	sort.Strings(registered)
+	sort.Strings(documented)
+
+	if diff := cmp.Diff(documented, registered); diff != "" {
+		t.Errorf("openapi spec and gin routes drifted (-spec +routes):\n%s", diff)
+	}
+}
+
+func TestAPIKeyScopesMatchRoutes(t *testing.T) {
+	router := Setup(common.App{Logger: slog.Default()})
+
+	routes := map[string]bool{}
+	for _, route := range router.Routes() {
+		routes[route.Method+" "+route.Path] = true
+	}
+
+	for route := range API_KEY_SCOPES {
+		assert.Assert(t, routes[route], "api key scope of unknown route %s", route)
+	}
+}
+
+func TestOpenAPISpecIsServed(t *testing.T) {
+	router := Setup(common.App{Logger: slog.Default()})
+
+	tests := []struct {
+		name            string
+		path            string
+		wantContentType string
+	}{
+		{
+			name:            "should_serve_openapi_json",
+			path:            "/openapi.json",
+			wantContentType: "application/json; charset=utf-8",
+		},
+		{
+			name:            "should_serve_swagger_ui",
+			path:            "/docs",
+			wantContentType: "text/html; charset=utf-8",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			responseRecorder := httptest.NewRecorder()
+			request, _ := http.NewRequest("GET", tt.path, nil)
+
+			router.ServeHTTP(responseRecorder, request)
+			assert.Equal(t, http.StatusOK, responseRecorder.Code)
+			assert.Equal(t, tt.wantContentType, responseRecorder.Header().Get("Content-Type"))
+		})
+	}
+}
+
+func TestVersionedRoutes(t *testing.T) {
+	router := Setup(common.App{Logger: slog.Default(), Env: common.Env{LEGACY_ROUTES_SUNSET: "2027-04-17T00:00:00Z"}})
+
+	tests := []struct {
+		name            string
+		method          string
+		path            string
+		wantStatus      int
+		wantDeprecation string
+		wantSunset      string
+	}{
+		{
+			name:            "should_serve_v1_routes_without_deprecation",
+			method:          "GET",
+			path:            "/v1/transfers",
+			wantStatus:      http.StatusUnauthorized,
+			wantDeprecation: "",
+			wantSunset:      "",
+		},
+		{
+			name:            "should_flag_legacy_routes_as_deprecated",
+			method:          "GET",
+			path:            "/transfers",
+			wantStatus:      http.StatusUnauthorized,
+			wantDeprecation: "@1792368000",
+			wantSunset:      "Sat, 17 Apr 2027 00:00:00 GMT",
+		},
+		{
+			name:            "should_serve_legacy_public_routes",
+			method:          "POST",
+			path:            "/login",
+			wantStatus:      http.StatusBadRequest,
+			wantDeprecation: "@1792368000",
+			wantSunset:      "Sat, 17 Apr 2027 00:00:00 GMT",
+		},
+		{
+			name:       "should_not_alias_routes_added_after_versioning",
+			method:     "GET",
+			path:       "/webhooks",
+			wantStatus: http.StatusNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			responseRecorder := httptest.NewRecorder()
+			request, _ := http.NewRequest(tt.method, tt.path, nil)
+
+			router.ServeHTTP(responseRecorder, request)
+			assert.Equal(t, tt.wantStatus, responseRecorder.Code)
+			assert.Equal(t, tt.wantDeprecation, responseRecorder.Header().Get("Deprecation"))
+			assert.Equal(t, tt.wantSunset, responseRecorder.Header().Get("Sunset"))
+		})
+	}
+}
@@ -2,43 +2,45 @@ package account
 
 import (
 	"errors"
-	"log"
 	"net/http"
 
 	"github.com/fms85/desafio-tecnico-go-stone/internal/domain/common"
 	"github.com/fms85/desafio-tecnico-go-stone/internal/domain/types"
+	jwtDriver "github.com/fms85/desafio-tecnico-go-stone/internal/driver/jwt"
+	"github.com/fms85/desafio-tecnico-go-stone/internal/driver/prometheus"
 	accountUsecase "github.com/fms85/desafio-tecnico-go-stone/internal/usecase/account"
 	"github.com/fms85/desafio-tecnico-go-stone/internal/util"
 	"github.com/gin-gonic/gin"
 )
 
-var validationError *common.ValidationError
+var notFoundError *common.NotFoundError
 
 type AccountHandler struct {
 	accountUsecase accountUsecase.IAccountUsecase
-	secret         string
+	keys           jwtDriver.IKeySet
 }
 
-func New(accountUsecase accountUsecase.IAccountUsecase, secret string) *AccountHandler {
+func New(accountUsecase accountUsecase.IAccountUsecase, keys jwtDriver.IKeySet) *AccountHandler {
 	return &AccountHandler{
 		accountUsecase: accountUsecase,
-		secret:         secret,
+		keys:           keys,
 	}
 }
 
-func (handler *AccountHandler) InitRoutes(router *gin.Engine) {
+func (handler *AccountHandler) InitRoutes(router *gin.RouterGroup) {
 	router.GET("accounts", handler.getAll)
 	router.GET("accounts/:account_id/balance", handler.getBalance)
 	router.POST("accounts", handler.createBalance)
 	router.POST("login", handler.authAccount)
+	router.POST("login/totp", handler.completeLogin)
+	router.POST("accounts/secret-reset", handler.requestSecretReset)
+	router.POST("accounts/secret-reset/confirm", handler.resetSecret)
 }
 
 func (handler *AccountHandler) getAll(ctx *gin.Context) {
 	accounts, err := handler.accountUsecase.GetAll(ctx.Request.Context())
 	if err != nil {
-		log.Println(err)
-
-		ctx.JSON(http.StatusInternalServerError, gin.H{"message": common.INTERNAL_SERVER_ERROR})
+		_ = ctx.Error(err)
 
 		return
 	}
@@ -49,22 +51,14 @@ func (handler *AccountHandler) getAll(ctx *gin.Context) {
 func (handler *AccountHandler) getBalance(ctx *gin.Context) {
 	var uri types.GetBalanceAccountUri
 	if err := ctx.ShouldBindUri(&uri); err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"message": err.Error()})
+		_ = ctx.Error(err).SetType(gin.ErrorTypeBind)
 
 		return
 	}
 
 	account, err := handler.accountUsecase.Get(ctx.Request.Context(), types.AccountInput{ID: util.StringToUint(uri.AccountID)})
 	if err != nil {
-		if errors.As(err, &validationError) {
-			ctx.JSON(http.StatusBadRequest, gin.H{"message": err.Error()})
-
-			return
-		}
-
-		log.Println(err)
-
-		ctx.JSON(http.StatusInternalServerError, gin.H{"message": common.INTERNAL_SERVER_ERROR})
+		_ = ctx.Error(err)
 
 		return
 	}
@@ -75,22 +69,14 @@ func (handler *AccountHandler) getBalance(ctx *gin.Context) {
 func (handler *AccountHandler) createBalance(ctx *gin.Context) {
 	var accountInput types.AccountInput
 	if err := ctx.ShouldBindJSON(&accountInput); err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"message": err.Error()})
+		_ = ctx.Error(err).SetType(gin.ErrorTypeBind)
 
 		return
 	}
 
 	account, err := handler.accountUsecase.Create(ctx.Request.Context(), accountInput)
 	if err != nil {
-		if errors.As(err, &validationError) {
-			ctx.JSON(http.StatusBadRequest, gin.H{"message": err.Error()})
-
-			return
-		}
-
-		log.Println(err)
-
-		ctx.JSON(http.StatusInternalServerError, gin.H{"message": common.INTERNAL_SERVER_ERROR})
+		_ = ctx.Error(err)
 
 		return
 	}
@@ -101,30 +87,116 @@ func (handler *AccountHandler) createBalance(ctx *gin.Context) {
 func (handler *AccountHandler) authAccount(ctx *gin.Context) {
 	var credentialsInput *types.CredentialsInput
 	if err := ctx.ShouldBindJSON(&credentialsInput); err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"message": err.Error()})
+		_ = ctx.Error(err).SetType(gin.ErrorTypeBind)
 
 		return
 	}
 
 	account, err := handler.accountUsecase.Get(
 		ctx.Request.Context(),
-		types.AccountInput{CPF: credentialsInput.CPF, Secret: util.GenerateHash(credentialsInput.Secret)},
+		types.AccountInput{CPF: credentialsInput.CPF, CNPJ: credentialsInput.CNPJ, Secret: util.GenerateHash(credentialsInput.Secret)},
 	)
 	if err != nil {
-		if errors.As(err, &validationError) {
-			ctx.JSON(http.StatusBadRequest, gin.H{"message": err.Error()})
+		if errors.As(err, &notFoundError) {
+			prometheus.ObserveLogin(prometheus.LOGIN_RESULT_FAILURE)
+
+			_ = ctx.Error(&common.UnauthorizedError{Msg: "invalid credentials"})
 
 			return
 		}
 
-		log.Println(err)
+		prometheus.ObserveLogin(prometheus.LOGIN_RESULT_ERROR)
+
+		_ = ctx.Error(err)
+
+		return
+	}
+
+	prometheus.ObserveLogin(prometheus.LOGIN_RESULT_SUCCESS)
+
+	if account.TOTPEnabled {
+		challenge, err := handler.accountUsecase.CreateLoginChallenge(ctx.Request.Context(), account)
+		if err != nil {
+			_ = ctx.Error(err)
+
+			return
+		}
+
+		ctx.JSON(http.StatusAccepted, challenge)
+
+		return
+	}
+
+	token, err := handler.keys.Sign(types.AccountTokenClaims(account))
+	if err != nil {
+		_ = ctx.Error(err)
+
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, gin.H{"token": token})
+}
+
+// completeLogin is the second step of the login of accounts with two-factor
+// authentication, trading the challenge of the first one and a TOTP or
+// recovery code for a token.
+func (handler *AccountHandler) completeLogin(ctx *gin.Context) {
+	var loginChallengeInput types.LoginChallengeInput
+	if err := ctx.ShouldBindJSON(&loginChallengeInput); err != nil {
+		_ = ctx.Error(err).SetType(gin.ErrorTypeBind)
+
+		return
+	}
 
-		ctx.JSON(http.StatusInternalServerError, gin.H{"message": common.INTERNAL_SERVER_ERROR})
+	account, err := handler.accountUsecase.CompleteLoginChallenge(ctx.Request.Context(), loginChallengeInput)
+	if err != nil {
+		_ = ctx.Error(err)
 
 		return
 	}
 
-	token := util.GenerateJwtToken("account_id", account.ID, handler.secret)
+	token, err := handler.keys.Sign(types.AccountTokenClaims(account))
+	if err != nil {
+		_ = ctx.Error(err)
+
+		return
+	}
 
 	ctx.JSON(http.StatusCreated, gin.H{"token": token})
 }
+
+// requestSecretReset is accepted whether or not a code was sent, so it does
+// not tell who has an account.
+func (handler *AccountHandler) requestSecretReset(ctx *gin.Context) {
+	var secretResetInput types.SecretResetInput
+	if err := ctx.ShouldBindJSON(&secretResetInput); err != nil {
+		_ = ctx.Error(err).SetType(gin.ErrorTypeBind)
+
+		return
+	}
+
+	if err := handler.accountUsecase.RequestSecretReset(ctx.Request.Context(), secretResetInput); err != nil {
+		_ = ctx.Error(err)
+
+		return
+	}
+
+	ctx.Status(http.StatusAccepted)
+}
+
+func (handler *AccountHandler) resetSecret(ctx *gin.Context) {
+	var secretResetConfirmInput types.SecretResetConfirmInput
+	if err := ctx.ShouldBindJSON(&secretResetConfirmInput); err != nil {
+		_ = ctx.Error(err).SetType(gin.ErrorTypeBind)
+
+		return
+	}
+
+	if err := handler.accountUsecase.ResetSecret(ctx.Request.Context(), secretResetConfirmInput); err != nil {
+		_ = ctx.Error(err)
+
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
@@ -0,0 +1,84 @@
+package apikey
+
+import (
+	"net/http"
+
+	"github.com/fms85/desafio-tecnico-go-stone/internal/domain/types"
+	apiKeyUsecase "github.com/fms85/desafio-tecnico-go-stone/internal/usecase/apikey"
+	"github.com/fms85/desafio-tecnico-go-stone/internal/util"
+	"github.com/gin-gonic/gin"
+)
+
+// TOTP_CODE_HEADER carries the TOTP code accounts with two-factor
+// authentication create keys with, like transfers over the step-up amount.
+const TOTP_CODE_HEADER = "X-TOTP-Code"
+
+type APIKeyHandler struct {
+	apiKeyUsecase apiKeyUsecase.IAPIKeyUsecase
+}
+
+func New(apiKeyUsecase apiKeyUsecase.IAPIKeyUsecase) *APIKeyHandler {
+	return &APIKeyHandler{
+		apiKeyUsecase: apiKeyUsecase,
+	}
+}
+
+func (handler *APIKeyHandler) InitRoutes(router *gin.RouterGroup) {
+	router.GET("api-keys", handler.getAll)
+	router.POST("api-keys", handler.create)
+	router.DELETE("api-keys/:key_id", handler.revoke)
+}
+
+func (handler *APIKeyHandler) getAll(ctx *gin.Context) {
+	accountID := ctx.MustGet("account_id").(string)
+
+	apiKeys, err := handler.apiKeyUsecase.GetAll(ctx.Request.Context(), util.StringToUint(accountID))
+	if err != nil {
+		_ = ctx.Error(err)
+
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"data": apiKeys})
+}
+
+func (handler *APIKeyHandler) create(ctx *gin.Context) {
+	var apiKeyInput types.APIKeyInput
+	if err := ctx.ShouldBindJSON(&apiKeyInput); err != nil {
+		_ = ctx.Error(err).SetType(gin.ErrorTypeBind)
+
+		return
+	}
+
+	accountID := ctx.MustGet("account_id").(string)
+	apiKeyInput.AccountID = util.StringToUint(accountID)
+	apiKeyInput.TOTPCode = ctx.GetHeader(TOTP_CODE_HEADER)
+
+	credentials, err := handler.apiKeyUsecase.Create(ctx.Request.Context(), apiKeyInput)
+	if err != nil {
+		_ = ctx.Error(err)
+
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, gin.H{"data": credentials})
+}
+
+func (handler *APIKeyHandler) revoke(ctx *gin.Context) {
+	var uri types.APIKeyUri
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		_ = ctx.Error(err).SetType(gin.ErrorTypeBind)
+
+		return
+	}
+
+	accountID := ctx.MustGet("account_id").(string)
+
+	if err := handler.apiKeyUsecase.Revoke(ctx.Request.Context(), uri.KeyID, util.StringToUint(accountID)); err != nil {
+		_ = ctx.Error(err)
+
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
@@ -0,0 +1,102 @@
+package billpayment
+
+import (
+	"net/http"
+
+	transferHandler "github.com/fms85/desafio-tecnico-go-stone/internal/delivery/api/handler/transfer"
+	"github.com/fms85/desafio-tecnico-go-stone/internal/domain/types"
+	billPaymentUsecase "github.com/fms85/desafio-tecnico-go-stone/internal/usecase/billpayment"
+	"github.com/fms85/desafio-tecnico-go-stone/internal/util"
+	"github.com/gin-gonic/gin"
+)
+
+type BillPaymentHandler struct {
+	billPaymentUsecase billPaymentUsecase.IBillPaymentUsecase
+}
+
+func New(billPaymentUsecase billPaymentUsecase.IBillPaymentUsecase) *BillPaymentHandler {
+	return &BillPaymentHandler{
+		billPaymentUsecase: billPaymentUsecase,
+	}
+}
+
+func (handler *BillPaymentHandler) InitRoutes(router *gin.RouterGroup) {
+	router.POST("bills/parse", handler.parse)
+	router.GET("bills/payments", handler.getAll)
+	router.POST("bills/payments", handler.pay)
+	router.GET("bills/payments/:payment_id", handler.get)
+}
+
+func (handler *BillPaymentHandler) parse(ctx *gin.Context) {
+	var boletoInput types.BoletoInput
+	if err := ctx.ShouldBindJSON(&boletoInput); err != nil {
+		_ = ctx.Error(err).SetType(gin.ErrorTypeBind)
+
+		return
+	}
+
+	boleto, err := handler.billPaymentUsecase.Parse(ctx.Request.Context(), boletoInput.Code)
+	if err != nil {
+		_ = ctx.Error(err)
+
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"data": boleto})
+}
+
+func (handler *BillPaymentHandler) getAll(ctx *gin.Context) {
+	accountID := ctx.MustGet("account_id").(string)
+
+	payments, err := handler.billPaymentUsecase.GetAll(ctx.Request.Context(), util.StringToUint(accountID))
+	if err != nil {
+		_ = ctx.Error(err)
+
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"data": payments})
+}
+
+// pay asks for the transaction PIN as transfers do.
+func (handler *BillPaymentHandler) pay(ctx *gin.Context) {
+	var paymentInput types.BillPaymentInput
+	if err := ctx.ShouldBindJSON(&paymentInput); err != nil {
+		_ = ctx.Error(err).SetType(gin.ErrorTypeBind)
+
+		return
+	}
+
+	accountID := ctx.MustGet("account_id").(string)
+	paymentInput.AccountID = util.StringToUint(accountID)
+	paymentInput.Authorization = transferHandler.Authorization(ctx, paymentInput.PIN)
+
+	payment, err := handler.billPaymentUsecase.Pay(ctx.Request.Context(), paymentInput)
+	if err != nil {
+		_ = ctx.Error(err)
+
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, gin.H{"data": payment})
+}
+
+func (handler *BillPaymentHandler) get(ctx *gin.Context) {
+	var uri types.BillPaymentUri
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		_ = ctx.Error(err).SetType(gin.ErrorTypeBind)
+
+		return
+	}
+
+	accountID := ctx.MustGet("account_id").(string)
+
+	payment, err := handler.billPaymentUsecase.Get(ctx.Request.Context(), util.StringToUint(uri.PaymentID), util.StringToUint(accountID))
+	if err != nil {
+		_ = ctx.Error(err)
+
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"data": payment})
+}
@@ -0,0 +1,145 @@
+package charge
+
+import (
+	"net/http"
+
+	transferHandler "github.com/fms85/desafio-tecnico-go-stone/internal/delivery/api/handler/transfer"
+	"github.com/fms85/desafio-tecnico-go-stone/internal/domain/types"
+	chargeUsecase "github.com/fms85/desafio-tecnico-go-stone/internal/usecase/charge"
+	"github.com/fms85/desafio-tecnico-go-stone/internal/util"
+	"github.com/gin-gonic/gin"
+)
+
+type ChargeHandler struct {
+	chargeUsecase chargeUsecase.IChargeUsecase
+}
+
+func New(chargeUsecase chargeUsecase.IChargeUsecase) *ChargeHandler {
+	return &ChargeHandler{
+		chargeUsecase: chargeUsecase,
+	}
+}
+
+func (handler *ChargeHandler) InitRoutes(router *gin.RouterGroup) {
+	router.GET("charges", handler.getAll)
+	router.POST("charges", handler.create)
+	router.GET("charges/:charge_id", handler.get)
+	router.POST("charges/:charge_id/pay", handler.pay)
+	router.POST("charges/:charge_id/decline", handler.decline)
+}
+
+func (handler *ChargeHandler) getAll(ctx *gin.Context) {
+	var filterInput types.ChargeFilterInput
+	if err := ctx.ShouldBindQuery(&filterInput); err != nil {
+		_ = ctx.Error(err).SetType(gin.ErrorTypeBind)
+
+		return
+	}
+
+	accountID := ctx.MustGet("account_id").(string)
+	filterInput.AccountID = util.StringToUint(accountID)
+
+	charges, err := handler.chargeUsecase.GetAll(ctx.Request.Context(), filterInput)
+	if err != nil {
+		_ = ctx.Error(err)
+
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"data": charges})
+}
+
+func (handler *ChargeHandler) create(ctx *gin.Context) {
+	var chargeInput types.ChargeInput
+	if err := ctx.ShouldBindJSON(&chargeInput); err != nil {
+		_ = ctx.Error(err).SetType(gin.ErrorTypeBind)
+
+		return
+	}
+
+	accountID := ctx.MustGet("account_id").(string)
+	chargeInput.ChargerAccountID = util.StringToUint(accountID)
+
+	charge, err := handler.chargeUsecase.Create(ctx.Request.Context(), chargeInput)
+	if err != nil {
+		_ = ctx.Error(err)
+
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, gin.H{"data": charge})
+}
+
+func (handler *ChargeHandler) get(ctx *gin.Context) {
+	var uri types.ChargeUri
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		_ = ctx.Error(err).SetType(gin.ErrorTypeBind)
+
+		return
+	}
+
+	accountID := ctx.MustGet("account_id").(string)
+
+	charge, err := handler.chargeUsecase.Get(ctx.Request.Context(), util.StringToUint(uri.ChargeID), util.StringToUint(accountID))
+	if err != nil {
+		_ = ctx.Error(err)
+
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"data": charge})
+}
+
+// pay takes an optional body; without it what is left of the charge is paid.
+// The transaction PIN is asked for as in transfers.
+func (handler *ChargeHandler) pay(ctx *gin.Context) {
+	var uri types.ChargeUri
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		_ = ctx.Error(err).SetType(gin.ErrorTypeBind)
+
+		return
+	}
+
+	var paymentInput types.ChargePaymentInput
+	if ctx.Request.ContentLength != 0 {
+		if err := ctx.ShouldBindJSON(&paymentInput); err != nil {
+			_ = ctx.Error(err).SetType(gin.ErrorTypeBind)
+
+			return
+		}
+	}
+
+	accountID := ctx.MustGet("account_id").(string)
+	paymentInput.ChargeID = util.StringToUint(uri.ChargeID)
+	paymentInput.PayerAccountID = util.StringToUint(accountID)
+	paymentInput.Authorization = transferHandler.Authorization(ctx, paymentInput.PIN)
+
+	charge, err := handler.chargeUsecase.Pay(ctx.Request.Context(), paymentInput)
+	if err != nil {
+		_ = ctx.Error(err)
+
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"data": charge})
+}
+
+func (handler *ChargeHandler) decline(ctx *gin.Context) {
+	var uri types.ChargeUri
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		_ = ctx.Error(err).SetType(gin.ErrorTypeBind)
+
+		return
+	}
+
+	accountID := ctx.MustGet("account_id").(string)
+
+	charge, err := handler.chargeUsecase.Decline(ctx.Request.Context(), util.StringToUint(uri.ChargeID), util.StringToUint(accountID))
+	if err != nil {
+		_ = ctx.Error(err)
+
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"data": charge})
+}
@@ -0,0 +1,72 @@
+package docs
+
+import (
+	"context"
+	_ "embed"
+	"fmt"
+	"net/http"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/gin-gonic/gin"
+)
+
+//go:embed openapi.yaml
+var spec []byte
+
+const swaggerUI = `<!DOCTYPE html>
+<html lang="en">
+<head>
+  <meta charset="utf-8">
+  <title>desafio-tecnico-go-stone</title>
+  <link rel="stylesheet" href="https://unpkg.com/swagger-ui-dist@5/swagger-ui.css">
+</head>
+<body>
+  <div id="swagger-ui"></div>
+  <script src="https://unpkg.com/swagger-ui-dist@5/swagger-ui-bundle.js" crossorigin></script>
+  <script>
+    window.ui = SwaggerUIBundle({ url: "/openapi.json", dom_id: "#swagger-ui" });
+  </script>
+</body>
+</html>`
+
+type DocsHandler struct {
+	spec []byte
+}
+
+// Load parses and validates the embedded OpenAPI document.
+func Load() (*openapi3.T, error) {
+	doc, err := openapi3.NewLoader().LoadFromData(spec)
+	if err != nil {
+		return nil, fmt.Errorf("error to load openapi spec: %w", err)
+	}
+
+	if err := doc.Validate(context.Background()); err != nil {
+		return nil, fmt.Errorf("error to validate openapi spec: %w", err)
+	}
+
+	return doc, nil
+}
+
+func New(doc *openapi3.T) *DocsHandler {
+	specJSON, err := doc.MarshalJSON()
+	if err != nil {
+		panic(err)
+	}
+
+	return &DocsHandler{
+		spec: specJSON,
+	}
+}
+
+func (handler *DocsHandler) InitRoutes(router *gin.RouterGroup) {
+	router.GET("openapi.json", handler.getSpec)
+	router.GET("docs", handler.getUI)
+}
+
+func (handler *DocsHandler) getSpec(ctx *gin.Context) {
+	ctx.Data(http.StatusOK, "application/json; charset=utf-8", handler.spec)
+}
+
+func (handler *DocsHandler) getUI(ctx *gin.Context) {
+	ctx.Data(http.StatusOK, "text/html; charset=utf-8", []byte(swaggerUI))
+}
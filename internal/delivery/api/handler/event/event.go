@@ -0,0 +1,175 @@
+package event
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/fms85/desafio-tecnico-go-stone/internal/domain/common"
+	"github.com/fms85/desafio-tecnico-go-stone/internal/domain/types"
+	"github.com/fms85/desafio-tecnico-go-stone/internal/driver/logger"
+	eventUsecase "github.com/fms85/desafio-tecnico-go-stone/internal/usecase/event"
+	"github.com/fms85/desafio-tecnico-go-stone/internal/util"
+	"github.com/gin-gonic/gin"
+	"github.com/gorilla/websocket"
+)
+
+const INVALID_LAST_EVENT_ID_ERROR = "invalid last event id"
+
+// HEARTBEAT_INTERVAL keeps idle streams alive through proxies and lets the
+// server notice clients that went away.
+var HEARTBEAT_INTERVAL = 15 * time.Second
+
+// sender writes events to an open stream, over SSE or WebSocket.
+type sender interface {
+	send(event *types.AccountEvent) error
+	ping() error
+	// done is closed when the client goes away.
+	done() <-chan struct{}
+	close()
+}
+
+type EventHandler struct {
+	eventUsecase eventUsecase.IEventUsecase
+}
+
+func New(eventUsecase eventUsecase.IEventUsecase) *EventHandler {
+	return &EventHandler{
+		eventUsecase: eventUsecase,
+	}
+}
+
+func (handler *EventHandler) InitRoutes(router *gin.RouterGroup) {
+	router.GET("accounts/me/events", handler.stream)
+}
+
+// stream subscribes before reading the ledger, so a transfer committed while
+// catching up still wakes the loop and nothing falls in between.
+func (handler *EventHandler) stream(ctx *gin.Context) {
+	var eventsInput types.AccountEventsInput
+	if err := ctx.ShouldBindQuery(&eventsInput); err != nil {
+		_ = ctx.Error(err).SetType(gin.ErrorTypeBind)
+
+		return
+	}
+
+	if lastEventID := ctx.GetHeader("Last-Event-ID"); lastEventID != "" {
+		parsed, err := strconv.ParseUint(lastEventID, 10, 0)
+		if err != nil || parsed == 0 {
+			_ = ctx.Error(&common.ValidationError{Msg: INVALID_LAST_EVENT_ID_ERROR})
+
+			return
+		}
+
+		id := uint(parsed)
+		eventsInput.LastEventID = &id
+	}
+
+	accountID := ctx.MustGet("account_id").(string)
+	eventsInput.AccountID = util.StringToUint(accountID)
+
+	notifications, unsubscribe := handler.eventUsecase.Subscribe(eventsInput.AccountID)
+	defer unsubscribe()
+
+	var snapshot *types.AccountEvent
+	var lastID uint
+	if eventsInput.LastEventID != nil {
+		lastID = *eventsInput.LastEventID
+	} else {
+		var err error
+		snapshot, err = handler.eventUsecase.Snapshot(ctx.Request.Context(), eventsInput.AccountID)
+		if err != nil {
+			_ = ctx.Error(err)
+
+			return
+		}
+
+		lastID = snapshot.ID
+	}
+
+	log := logger.FromContext(ctx.Request.Context())
+
+	sender, err := open(ctx)
+	if err != nil {
+		log.Warn("event stream not opened", "error", err)
+		ctx.Abort()
+
+		return
+	}
+	defer sender.close()
+
+	if snapshot != nil {
+		if err := sender.send(snapshot); err != nil {
+			return
+		}
+	}
+
+	heartbeat := time.NewTicker(HEARTBEAT_INTERVAL)
+	defer heartbeat.Stop()
+
+	for {
+		if lastID, err = handler.catchUp(ctx.Request.Context(), sender, eventsInput.AccountID, lastID); err != nil {
+			log.Warn("event stream closed", "error", err)
+
+			return
+		}
+
+		if !wait(ctx.Request.Context(), sender, notifications, heartbeat.C) {
+			return
+		}
+	}
+}
+
+// wait blocks until there is something new to read, sending heartbeats in the
+// meantime. It returns false once the stream is over.
+func wait(ctx context.Context, sender sender, notifications <-chan struct{}, heartbeat <-chan time.Time) bool {
+	for {
+		select {
+		case <-notifications:
+			return true
+		case <-heartbeat:
+			if err := sender.ping(); err != nil {
+				return false
+			}
+		case <-sender.done():
+			return false
+		case <-ctx.Done():
+			return false
+		}
+	}
+}
+
+// catchUp sends every event after lastID and returns the id of the last one.
+func (handler *EventHandler) catchUp(ctx context.Context, sender sender, accountID uint, lastID uint) (uint, error) {
+	for {
+		events, err := handler.eventUsecase.After(ctx, accountID, lastID)
+		if err != nil {
+			return lastID, err
+		}
+
+		for _, event := range events {
+			if err := sender.send(event); err != nil {
+				return lastID, err
+			}
+
+			lastID = event.ID
+		}
+
+		if len(events) < eventUsecase.EVENT_BATCH_SIZE {
+			return lastID, nil
+		}
+	}
+}
+
+// open upgrades WebSocket handshakes and answers anything else with SSE. The
+// server write timeout is lifted, the stream lasts as long as the client.
+func open(ctx *gin.Context) (sender, error) {
+	_ = http.NewResponseController(ctx.Writer).SetWriteDeadline(time.Time{})
+
+	if websocket.IsWebSocketUpgrade(ctx.Request) {
+		return newWSSender(ctx)
+	}
+
+	return newSSESender(ctx), nil
+}
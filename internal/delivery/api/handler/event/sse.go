@@ -0,0 +1,58 @@
+package event
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/fms85/desafio-tecnico-go-stone/internal/domain/types"
+	"github.com/gin-contrib/sse"
+	"github.com/gin-gonic/gin"
+)
+
+type sseSender struct {
+	ctx *gin.Context
+}
+
+func newSSESender(ctx *gin.Context) *sseSender {
+	ctx.Header("Content-Type", "text/event-stream")
+	ctx.Header("Cache-Control", "no-cache")
+	ctx.Header("X-Accel-Buffering", "no")
+	ctx.Status(http.StatusOK)
+	ctx.Writer.WriteHeaderNow()
+	ctx.Writer.Flush()
+
+	return &sseSender{ctx: ctx}
+}
+
+func (sender *sseSender) send(event *types.AccountEvent) error {
+	err := sse.Encode(sender.ctx.Writer, sse.Event{
+		Id:    strconv.FormatUint(uint64(event.ID), 10),
+		Event: event.Type,
+		Data:  event,
+	})
+	if err != nil {
+		return err
+	}
+
+	sender.ctx.Writer.Flush()
+
+	return nil
+}
+
+// ping writes a comment line, which EventSource clients ignore.
+func (sender *sseSender) ping() error {
+	if _, err := sender.ctx.Writer.WriteString(": ping\n\n"); err != nil {
+		return err
+	}
+
+	sender.ctx.Writer.Flush()
+
+	return nil
+}
+
+// done is nil: a gone SSE client is seen through the request context.
+func (sender *sseSender) done() <-chan struct{} {
+	return nil
+}
+
+func (sender *sseSender) close() {}
@@ -0,0 +1,75 @@
+package event
+
+import (
+	"time"
+
+	"github.com/fms85/desafio-tecnico-go-stone/internal/domain/types"
+	"github.com/gin-gonic/gin"
+	"github.com/gorilla/websocket"
+)
+
+// WS_WRITE_TIMEOUT bounds each write to a WebSocket client.
+const WS_WRITE_TIMEOUT = 10 * time.Second
+
+var upgrader = websocket.Upgrader{
+	ReadBufferSize:  1024,
+	WriteBufferSize: 1024,
+}
+
+// wsSender sends each event as a JSON text message. The client is not
+// expected to send anything; reads only process control frames and notice
+// when the connection closes.
+type wsSender struct {
+	conn   *websocket.Conn
+	closed chan struct{}
+}
+
+func newWSSender(ctx *gin.Context) (*wsSender, error) {
+	conn, err := upgrader.Upgrade(ctx.Writer, ctx.Request, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	sender := &wsSender{conn: conn, closed: make(chan struct{})}
+
+	pongWait := 2 * HEARTBEAT_INTERVAL
+	_ = conn.SetReadDeadline(time.Now().Add(pongWait))
+	conn.SetPongHandler(func(string) error {
+		return conn.SetReadDeadline(time.Now().Add(pongWait))
+	})
+
+	go func() {
+		defer close(sender.closed)
+
+		for {
+			if _, _, err := conn.ReadMessage(); err != nil {
+				return
+			}
+		}
+	}()
+
+	return sender, nil
+}
+
+func (sender *wsSender) send(event *types.AccountEvent) error {
+	_ = sender.conn.SetWriteDeadline(time.Now().Add(WS_WRITE_TIMEOUT))
+
+	return sender.conn.WriteJSON(event)
+}
+
+func (sender *wsSender) ping() error {
+	return sender.conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(WS_WRITE_TIMEOUT))
+}
+
+func (sender *wsSender) done() <-chan struct{} {
+	return sender.closed
+}
+
+func (sender *wsSender) close() {
+	_ = sender.conn.WriteControl(
+		websocket.CloseMessage,
+		websocket.FormatCloseMessage(websocket.CloseNormalClosure, ""),
+		time.Now().Add(WS_WRITE_TIMEOUT),
+	)
+	_ = sender.conn.Close()
+}
@@ -0,0 +1,32 @@
+package jwks
+
+import (
+	"fmt"
+	"net/http"
+
+	jwtDriver "github.com/fms85/desafio-tecnico-go-stone/internal/driver/jwt"
+	"github.com/gin-gonic/gin"
+)
+
+// JWKS_MAX_AGE is how long verifiers may cache the key set. A key has to be
+// published at least this long before it signs any token.
+const JWKS_MAX_AGE = 300
+
+type JWKSHandler struct {
+	keys jwtDriver.IKeySet
+}
+
+func New(keys jwtDriver.IKeySet) *JWKSHandler {
+	return &JWKSHandler{
+		keys: keys,
+	}
+}
+
+func (handler *JWKSHandler) InitRoutes(router *gin.RouterGroup) {
+	router.GET(".well-known/jwks.json", handler.get)
+}
+
+func (handler *JWKSHandler) get(ctx *gin.Context) {
+	ctx.Header("Cache-Control", fmt.Sprintf("public, max-age=%d", JWKS_MAX_AGE))
+	ctx.JSON(http.StatusOK, handler.keys.JWKS())
+}
@@ -0,0 +1,66 @@
+package jwks
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	ginDriver "github.com/fms85/desafio-tecnico-go-stone/internal/driver/gin"
+	jwtDriver "github.com/fms85/desafio-tecnico-go-stone/internal/driver/jwt"
+	"github.com/google/go-cmp/cmp"
+	"gotest.tools/assert"
+)
+
+func TestJWKSHandler(t *testing.T) {
+	_, private, err := ed25519.GenerateKey(rand.Reader)
+	assert.NilError(t, err)
+	der, err := x509.MarshalPKCS8PrivateKey(private)
+	assert.NilError(t, err)
+
+	keySet, err := jwtDriver.New(jwtDriver.Config{SigningKey: pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der})})
+	assert.NilError(t, err)
+
+	tests := []struct {
+		name string
+		keys jwtDriver.IKeySet
+		want string
+	}{
+		{
+			name: "should_publish_the_public_keys",
+			keys: keySet,
+			want: func() string {
+				want, _ := json.Marshal(keySet.JWKS())
+
+				return string(want)
+			}(),
+		},
+		{
+			name: "should_publish_no_keys_for_the_legacy_secret",
+			keys: jwtDriver.NewHS256("secret"),
+			want: `{"keys":[]}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router := ginDriver.Setup()
+			New(tt.keys).InitRoutes(&router.RouterGroup)
+
+			responseRecorder := httptest.NewRecorder()
+			request, _ := http.NewRequest("GET", "/.well-known/jwks.json", nil)
+
+			router.ServeHTTP(responseRecorder, request)
+			assert.Equal(t, http.StatusOK, responseRecorder.Code)
+			assert.Equal(t, "public, max-age=300", responseRecorder.Header().Get("Cache-Control"))
+
+			if diff := cmp.Diff(responseRecorder.Body.String(), tt.want); diff != "" {
+				t.Error(diff)
+			}
+		})
+	}
+
+}
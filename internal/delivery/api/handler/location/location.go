@@ -0,0 +1,43 @@
+package location
+
+import (
+	"net/http"
+
+	"github.com/fms85/desafio-tecnico-go-stone/internal/domain/types"
+	paymentRequestUsecase "github.com/fms85/desafio-tecnico-go-stone/internal/usecase/paymentrequest"
+	"github.com/gin-gonic/gin"
+)
+
+// LocationHandler serves the URLs written in dynamic BR Codes, which payment
+// apps of any bank fetch without credentials.
+type LocationHandler struct {
+	paymentRequestUsecase paymentRequestUsecase.IPaymentRequestUsecase
+}
+
+func New(paymentRequestUsecase paymentRequestUsecase.IPaymentRequestUsecase) *LocationHandler {
+	return &LocationHandler{
+		paymentRequestUsecase: paymentRequestUsecase,
+	}
+}
+
+func (handler *LocationHandler) InitRoutes(router *gin.RouterGroup) {
+	router.GET("pix/qr/:txid", handler.get)
+}
+
+func (handler *LocationHandler) get(ctx *gin.Context) {
+	var uri types.PaymentRequestLocationUri
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		_ = ctx.Error(err).SetType(gin.ErrorTypeBind)
+
+		return
+	}
+
+	location, err := handler.paymentRequestUsecase.Location(ctx.Request.Context(), uri.TxID)
+	if err != nil {
+		_ = ctx.Error(err)
+
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"data": location})
+}
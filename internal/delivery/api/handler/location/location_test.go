@@ -0,0 +1,98 @@
+package location
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/fms85/desafio-tecnico-go-stone/internal/delivery/api/middleware"
+	"github.com/fms85/desafio-tecnico-go-stone/internal/domain/common"
+	"github.com/fms85/desafio-tecnico-go-stone/internal/domain/types"
+	ginDriver "github.com/fms85/desafio-tecnico-go-stone/internal/driver/gin"
+	paymentRequestUsecase "github.com/fms85/desafio-tecnico-go-stone/internal/usecase/paymentrequest"
+	"github.com/google/go-cmp/cmp"
+	"github.com/stretchr/testify/mock"
+	"gotest.tools/assert"
+)
+
+func TestLocationHandlerGet(t *testing.T) {
+	expiresAt := time.Date(2026, 10, 19, 13, 0, 0, 0, time.UTC)
+
+	type dependencies struct {
+		paymentRequestUsecase func() *paymentRequestUsecase.PaymentRequestUsecaseMock
+	}
+	tests := []struct {
+		name         string
+		dependencies dependencies
+		path         string
+		want         string
+		wantCode     int
+	}{
+		{
+			name: "should_return_the_payment_request",
+			dependencies: dependencies{
+				paymentRequestUsecase: func() *paymentRequestUsecase.PaymentRequestUsecaseMock {
+					usecase := &paymentRequestUsecase.PaymentRequestUsecaseMock{}
+					usecase.On("Location", mock.Anything, "9d36b84f").Return(&types.PaymentRequestLocation{
+						TxID:      "9d36b84f",
+						Amount:    25,
+						Receiver:  types.ReceiptParty{Name: "Loren I.", CPF: "***.376.430-**"},
+						ExpiresAt: &expiresAt,
+					}, nil)
+
+					return usecase
+				},
+			},
+			path:     "/pix/qr/9d36b84f",
+			want:     `{"data":{"txid":"9d36b84f","amount":25,"receiver":{"name":"Loren I.","cpf":"***.376.430-**"},"expires_at":"2026-10-19T13:00:00Z"}}`,
+			wantCode: http.StatusOK,
+		},
+		{
+			name: "should_return_not_found_for_an_unknown_txid",
+			dependencies: dependencies{
+				paymentRequestUsecase: func() *paymentRequestUsecase.PaymentRequestUsecaseMock {
+					usecase := &paymentRequestUsecase.PaymentRequestUsecaseMock{}
+					usecase.On("Location", mock.Anything, "unknown").Return(nil, fmt.Errorf("payment request %w", &common.NotFoundError{}))
+
+					return usecase
+				},
+			},
+			path:     "/pix/qr/unknown",
+			want:     `{"type":"about:blank","title":"Not Found","status":404,"detail":"payment request not found","instance":"/pix/qr/unknown","code":"NOT_FOUND"}`,
+			wantCode: http.StatusNotFound,
+		},
+		{
+			name: "should_reject_a_malformed_txid",
+			dependencies: dependencies{
+				paymentRequestUsecase: func() *paymentRequestUsecase.PaymentRequestUsecaseMock {
+					return &paymentRequestUsecase.PaymentRequestUsecaseMock{}
+				},
+			},
+			path:     "/pix/qr/not-a-txid",
+			want:     `{"type":"about:blank","title":"Bad Request","status":400,"detail":"validation failed","instance":"/pix/qr/not-a-txid","code":"VALIDATION_ERROR","errors":[{"field":"txid","code":"alphanum","message":"txid can only contain alphanumeric characters"}]}`,
+			wantCode: http.StatusBadRequest,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router := ginDriver.Setup()
+			router.Use(middleware.Error())
+			handler := New(tt.dependencies.paymentRequestUsecase())
+			handler.InitRoutes(&router.RouterGroup)
+			responseRecorder := httptest.NewRecorder()
+
+			request, _ := http.NewRequest("GET", tt.path, nil)
+
+			request.Header.Set("Accept-Language", "en")
+
+			router.ServeHTTP(responseRecorder, request)
+			assert.Equal(t, tt.wantCode, responseRecorder.Code)
+
+			if diff := cmp.Diff(responseRecorder.Body.String(), tt.want); diff != "" {
+				t.Error(diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,139 @@
+package paymentrequest
+
+import (
+	"net/http"
+
+	"github.com/fms85/desafio-tecnico-go-stone/internal/domain/types"
+	"github.com/fms85/desafio-tecnico-go-stone/internal/driver/qrcode"
+	paymentRequestUsecase "github.com/fms85/desafio-tecnico-go-stone/internal/usecase/paymentrequest"
+	"github.com/fms85/desafio-tecnico-go-stone/internal/util"
+	"github.com/gin-gonic/gin"
+)
+
+type PaymentRequestHandler struct {
+	paymentRequestUsecase paymentRequestUsecase.IPaymentRequestUsecase
+}
+
+func New(paymentRequestUsecase paymentRequestUsecase.IPaymentRequestUsecase) *PaymentRequestHandler {
+	return &PaymentRequestHandler{
+		paymentRequestUsecase: paymentRequestUsecase,
+	}
+}
+
+func (handler *PaymentRequestHandler) InitRoutes(router *gin.RouterGroup) {
+	router.GET("payment-requests", handler.getAll)
+	router.POST("payment-requests", handler.create)
+	router.POST("payment-requests/parse", handler.parse)
+	router.GET("payment-requests/:request_id", handler.get)
+	router.GET("payment-requests/:request_id/qrcode", handler.qrCode)
+}
+
+func (handler *PaymentRequestHandler) getAll(ctx *gin.Context) {
+	accountID := ctx.MustGet("account_id").(string)
+
+	paymentRequests, err := handler.paymentRequestUsecase.GetAll(ctx.Request.Context(), util.StringToUint(accountID))
+	if err != nil {
+		_ = ctx.Error(err)
+
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"data": paymentRequests})
+}
+
+func (handler *PaymentRequestHandler) create(ctx *gin.Context) {
+	var paymentRequestInput types.PaymentRequestInput
+	if err := ctx.ShouldBindJSON(&paymentRequestInput); err != nil {
+		_ = ctx.Error(err).SetType(gin.ErrorTypeBind)
+
+		return
+	}
+
+	accountID := ctx.MustGet("account_id").(string)
+	paymentRequestInput.AccountID = util.StringToUint(accountID)
+
+	paymentRequest, err := handler.paymentRequestUsecase.Create(ctx.Request.Context(), paymentRequestInput)
+	if err != nil {
+		_ = ctx.Error(err)
+
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, gin.H{"data": paymentRequest})
+}
+
+func (handler *PaymentRequestHandler) parse(ctx *gin.Context) {
+	var brCodeInput types.BRCodeInput
+	if err := ctx.ShouldBindJSON(&brCodeInput); err != nil {
+		_ = ctx.Error(err).SetType(gin.ErrorTypeBind)
+
+		return
+	}
+
+	quote, err := handler.paymentRequestUsecase.Parse(ctx.Request.Context(), brCodeInput.Payload)
+	if err != nil {
+		_ = ctx.Error(err)
+
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"data": quote})
+}
+
+func (handler *PaymentRequestHandler) get(ctx *gin.Context) {
+	var uri types.PaymentRequestUri
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		_ = ctx.Error(err).SetType(gin.ErrorTypeBind)
+
+		return
+	}
+
+	accountID := ctx.MustGet("account_id").(string)
+
+	paymentRequest, err := handler.paymentRequestUsecase.Get(ctx.Request.Context(), util.StringToUint(uri.RequestID), util.StringToUint(accountID))
+	if err != nil {
+		_ = ctx.Error(err)
+
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"data": paymentRequest})
+}
+
+func (handler *PaymentRequestHandler) qrCode(ctx *gin.Context) {
+	var uri types.PaymentRequestUri
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		_ = ctx.Error(err).SetType(gin.ErrorTypeBind)
+
+		return
+	}
+
+	var qrCodeInput types.PaymentRequestQRCodeInput
+	if err := ctx.ShouldBindQuery(&qrCodeInput); err != nil {
+		_ = ctx.Error(err).SetType(gin.ErrorTypeBind)
+
+		return
+	}
+
+	if qrCodeInput.Size == 0 {
+		qrCodeInput.Size = qrcode.SIZE_DEFAULT
+	}
+
+	accountID := ctx.MustGet("account_id").(string)
+
+	paymentRequest, err := handler.paymentRequestUsecase.Get(ctx.Request.Context(), util.StringToUint(uri.RequestID), util.StringToUint(accountID))
+	if err != nil {
+		_ = ctx.Error(err)
+
+		return
+	}
+
+	image, err := qrcode.PNG(paymentRequest.Payload, qrCodeInput.Size)
+	if err != nil {
+		_ = ctx.Error(err)
+
+		return
+	}
+
+	ctx.Data(http.StatusOK, "image/png", image)
+}
@@ -0,0 +1,191 @@
+package pixkey
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/fms85/desafio-tecnico-go-stone/internal/domain/entity"
+	"github.com/fms85/desafio-tecnico-go-stone/internal/domain/types"
+	pixKeyUsecase "github.com/fms85/desafio-tecnico-go-stone/internal/usecase/pixkey"
+	"github.com/fms85/desafio-tecnico-go-stone/internal/util"
+	"github.com/gin-gonic/gin"
+)
+
+type PixKeyHandler struct {
+	pixKeyUsecase pixKeyUsecase.IPixKeyUsecase
+}
+
+func New(pixKeyUsecase pixKeyUsecase.IPixKeyUsecase) *PixKeyHandler {
+	return &PixKeyHandler{
+		pixKeyUsecase: pixKeyUsecase,
+	}
+}
+
+func (handler *PixKeyHandler) InitRoutes(router *gin.RouterGroup) {
+	router.GET("pix/keys", handler.getAll)
+	router.POST("pix/keys", handler.create)
+	router.GET("pix/keys/lookup", handler.lookup)
+	router.DELETE("pix/keys/:key_id", handler.delete)
+	router.POST("pix/keys/:key_id/verify", handler.verify)
+	router.GET("pix/claims", handler.getClaims)
+	router.POST("pix/claims", handler.createClaim)
+	router.POST("pix/claims/:claim_id/confirm", handler.resolveClaim(handler.pixKeyUsecase.ConfirmClaim))
+	router.POST("pix/claims/:claim_id/cancel", handler.resolveClaim(handler.pixKeyUsecase.CancelClaim))
+	router.POST("pix/claims/:claim_id/complete", handler.resolveClaim(handler.pixKeyUsecase.CompleteClaim))
+}
+
+func (handler *PixKeyHandler) getAll(ctx *gin.Context) {
+	accountID := ctx.MustGet("account_id").(string)
+
+	pixKeys, err := handler.pixKeyUsecase.GetAll(ctx.Request.Context(), util.StringToUint(accountID))
+	if err != nil {
+		_ = ctx.Error(err)
+
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"data": pixKeys})
+}
+
+func (handler *PixKeyHandler) create(ctx *gin.Context) {
+	var pixKeyInput types.PixKeyInput
+	if err := ctx.ShouldBindJSON(&pixKeyInput); err != nil {
+		_ = ctx.Error(err).SetType(gin.ErrorTypeBind)
+
+		return
+	}
+
+	accountID := ctx.MustGet("account_id").(string)
+	pixKeyInput.AccountID = util.StringToUint(accountID)
+
+	pixKey, err := handler.pixKeyUsecase.Create(ctx.Request.Context(), pixKeyInput)
+	if err != nil {
+		_ = ctx.Error(err)
+
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, gin.H{"data": pixKey})
+}
+
+func (handler *PixKeyHandler) lookup(ctx *gin.Context) {
+	var lookupInput types.PixKeyLookupInput
+	if err := ctx.ShouldBindQuery(&lookupInput); err != nil {
+		_ = ctx.Error(err).SetType(gin.ErrorTypeBind)
+
+		return
+	}
+
+	lookup, err := handler.pixKeyUsecase.Lookup(ctx.Request.Context(), lookupInput.Key)
+	if err != nil {
+		_ = ctx.Error(err)
+
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"data": lookup})
+}
+
+func (handler *PixKeyHandler) delete(ctx *gin.Context) {
+	var uri types.PixKeyUri
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		_ = ctx.Error(err).SetType(gin.ErrorTypeBind)
+
+		return
+	}
+
+	accountID := ctx.MustGet("account_id").(string)
+
+	if err := handler.pixKeyUsecase.Delete(ctx.Request.Context(), util.StringToUint(uri.KeyID), util.StringToUint(accountID)); err != nil {
+		_ = ctx.Error(err)
+
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+func (handler *PixKeyHandler) verify(ctx *gin.Context) {
+	var uri types.PixKeyUri
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		_ = ctx.Error(err).SetType(gin.ErrorTypeBind)
+
+		return
+	}
+
+	var verifyInput types.PixKeyVerifyInput
+	if err := ctx.ShouldBindJSON(&verifyInput); err != nil {
+		_ = ctx.Error(err).SetType(gin.ErrorTypeBind)
+
+		return
+	}
+
+	accountID := ctx.MustGet("account_id").(string)
+
+	pixKey, err := handler.pixKeyUsecase.Verify(ctx.Request.Context(), util.StringToUint(uri.KeyID), util.StringToUint(accountID), verifyInput.Code)
+	if err != nil {
+		_ = ctx.Error(err)
+
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"data": pixKey})
+}
+
+func (handler *PixKeyHandler) getClaims(ctx *gin.Context) {
+	accountID := ctx.MustGet("account_id").(string)
+
+	claims, err := handler.pixKeyUsecase.GetClaims(ctx.Request.Context(), util.StringToUint(accountID))
+	if err != nil {
+		_ = ctx.Error(err)
+
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"data": claims})
+}
+
+func (handler *PixKeyHandler) createClaim(ctx *gin.Context) {
+	var claimInput types.PixClaimInput
+	if err := ctx.ShouldBindJSON(&claimInput); err != nil {
+		_ = ctx.Error(err).SetType(gin.ErrorTypeBind)
+
+		return
+	}
+
+	accountID := ctx.MustGet("account_id").(string)
+	claimInput.ClaimerAccountID = util.StringToUint(accountID)
+
+	claim, err := handler.pixKeyUsecase.CreateClaim(ctx.Request.Context(), claimInput)
+	if err != nil {
+		_ = ctx.Error(err)
+
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, gin.H{"data": claim})
+}
+
+// resolveClaim serves confirm, cancel and complete, which only differ in the
+// usecase method they call.
+func (handler *PixKeyHandler) resolveClaim(resolve func(ctx context.Context, claimID uint, accountID uint) (*entity.PixKeyClaim, error)) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		var uri types.PixClaimUri
+		if err := ctx.ShouldBindUri(&uri); err != nil {
+			_ = ctx.Error(err).SetType(gin.ErrorTypeBind)
+
+			return
+		}
+
+		accountID := ctx.MustGet("account_id").(string)
+
+		claim, err := resolve(ctx.Request.Context(), util.StringToUint(uri.ClaimID), util.StringToUint(accountID))
+		if err != nil {
+			_ = ctx.Error(err)
+
+			return
+		}
+
+		ctx.JSON(http.StatusOK, gin.H{"data": claim})
+	}
+}
@@ -0,0 +1,231 @@
+package profile
+
+import (
+	"net/http"
+
+	"github.com/fms85/desafio-tecnico-go-stone/internal/domain/types"
+	jwtDriver "github.com/fms85/desafio-tecnico-go-stone/internal/driver/jwt"
+	"github.com/fms85/desafio-tecnico-go-stone/internal/driver/qrcode"
+	accountUsecase "github.com/fms85/desafio-tecnico-go-stone/internal/usecase/account"
+	"github.com/fms85/desafio-tecnico-go-stone/internal/util"
+	"github.com/gin-gonic/gin"
+)
+
+type ProfileHandler struct {
+	accountUsecase accountUsecase.IAccountUsecase
+	keys           jwtDriver.IKeySet
+}
+
+func New(accountUsecase accountUsecase.IAccountUsecase, keys jwtDriver.IKeySet) *ProfileHandler {
+	return &ProfileHandler{
+		accountUsecase: accountUsecase,
+		keys:           keys,
+	}
+}
+
+func (handler *ProfileHandler) InitRoutes(router *gin.RouterGroup) {
+	router.GET("accounts/me", handler.get)
+	router.GET("accounts/me/balance", handler.getBalance)
+	router.PATCH("accounts/me", handler.update)
+	router.GET("accounts/me/history", handler.getHistory)
+	router.PUT("accounts/me/secret", handler.changeSecret)
+	router.PUT("accounts/me/pin", handler.setPIN)
+	router.POST("accounts/me/totp", handler.enrollTOTP)
+	router.GET("accounts/me/totp/qrcode", handler.totpQRCode)
+	router.POST("accounts/me/totp/confirm", handler.confirmTOTP)
+	router.POST("accounts/me/totp/disable", handler.disableTOTP)
+}
+
+func (handler *ProfileHandler) get(ctx *gin.Context) {
+	accountID := ctx.MustGet("account_id").(string)
+
+	account, err := handler.accountUsecase.Get(ctx.Request.Context(), types.AccountInput{ID: util.StringToUint(accountID)})
+	if err != nil {
+		_ = ctx.Error(err)
+
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"data": types.CreateProfile(account)})
+}
+
+// getBalance answers with the balance alone, for API keys allowed to read it
+// and not the rest of the profile.
+func (handler *ProfileHandler) getBalance(ctx *gin.Context) {
+	accountID := ctx.MustGet("account_id").(string)
+
+	account, err := handler.accountUsecase.Get(ctx.Request.Context(), types.AccountInput{ID: util.StringToUint(accountID)})
+	if err != nil {
+		_ = ctx.Error(err)
+
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"balance": account.Balance})
+}
+
+func (handler *ProfileHandler) update(ctx *gin.Context) {
+	var profileInput types.ProfileInput
+	if err := ctx.ShouldBindJSON(&profileInput); err != nil {
+		_ = ctx.Error(err).SetType(gin.ErrorTypeBind)
+
+		return
+	}
+
+	accountID := ctx.MustGet("account_id").(string)
+
+	account, err := handler.accountUsecase.UpdateProfile(ctx.Request.Context(), util.StringToUint(accountID), profileInput)
+	if err != nil {
+		_ = ctx.Error(err)
+
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"data": types.CreateProfile(account)})
+}
+
+func (handler *ProfileHandler) getHistory(ctx *gin.Context) {
+	accountID := ctx.MustGet("account_id").(string)
+
+	changes, err := handler.accountUsecase.GetChanges(ctx.Request.Context(), util.StringToUint(accountID))
+	if err != nil {
+		_ = ctx.Error(err)
+
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"data": changes})
+}
+
+// changeSecret answers with a token for the caller, since the one it used is
+// revoked along with every other.
+func (handler *ProfileHandler) changeSecret(ctx *gin.Context) {
+	var secretChangeInput types.SecretChangeInput
+	if err := ctx.ShouldBindJSON(&secretChangeInput); err != nil {
+		_ = ctx.Error(err).SetType(gin.ErrorTypeBind)
+
+		return
+	}
+
+	accountID := ctx.MustGet("account_id").(string)
+
+	account, err := handler.accountUsecase.ChangeSecret(ctx.Request.Context(), util.StringToUint(accountID), secretChangeInput)
+	if err != nil {
+		_ = ctx.Error(err)
+
+		return
+	}
+
+	token, err := handler.keys.Sign(types.AccountTokenClaims(account))
+	if err != nil {
+		_ = ctx.Error(err)
+
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"token": token})
+}
+
+func (handler *ProfileHandler) setPIN(ctx *gin.Context) {
+	var pinInput types.PINInput
+	if err := ctx.ShouldBindJSON(&pinInput); err != nil {
+		_ = ctx.Error(err).SetType(gin.ErrorTypeBind)
+
+		return
+	}
+
+	accountID := ctx.MustGet("account_id").(string)
+
+	if err := handler.accountUsecase.SetPIN(ctx.Request.Context(), util.StringToUint(accountID), pinInput); err != nil {
+		_ = ctx.Error(err)
+
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+func (handler *ProfileHandler) enrollTOTP(ctx *gin.Context) {
+	accountID := ctx.MustGet("account_id").(string)
+
+	enrollment, err := handler.accountUsecase.EnrollTOTP(ctx.Request.Context(), util.StringToUint(accountID))
+	if err != nil {
+		_ = ctx.Error(err)
+
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, gin.H{"data": enrollment})
+}
+
+// totpQRCode renders the provisioning URI of the pending enrollment for
+// authenticator apps to scan.
+func (handler *ProfileHandler) totpQRCode(ctx *gin.Context) {
+	var qrCodeInput types.TOTPQRCodeInput
+	if err := ctx.ShouldBindQuery(&qrCodeInput); err != nil {
+		_ = ctx.Error(err).SetType(gin.ErrorTypeBind)
+
+		return
+	}
+
+	if qrCodeInput.Size == 0 {
+		qrCodeInput.Size = qrcode.SIZE_DEFAULT
+	}
+
+	accountID := ctx.MustGet("account_id").(string)
+
+	enrollment, err := handler.accountUsecase.GetTOTPEnrollment(ctx.Request.Context(), util.StringToUint(accountID))
+	if err != nil {
+		_ = ctx.Error(err)
+
+		return
+	}
+
+	image, err := qrcode.PNG(enrollment.URI, qrCodeInput.Size)
+	if err != nil {
+		_ = ctx.Error(err)
+
+		return
+	}
+
+	ctx.Data(http.StatusOK, "image/png", image)
+}
+
+func (handler *ProfileHandler) confirmTOTP(ctx *gin.Context) {
+	var totpCodeInput types.TOTPCodeInput
+	if err := ctx.ShouldBindJSON(&totpCodeInput); err != nil {
+		_ = ctx.Error(err).SetType(gin.ErrorTypeBind)
+
+		return
+	}
+
+	accountID := ctx.MustGet("account_id").(string)
+
+	recoveryCodes, err := handler.accountUsecase.ConfirmTOTP(ctx.Request.Context(), util.StringToUint(accountID), totpCodeInput.Code)
+	if err != nil {
+		_ = ctx.Error(err)
+
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"data": recoveryCodes})
+}
+
+func (handler *ProfileHandler) disableTOTP(ctx *gin.Context) {
+	var totpCodeInput types.TOTPCodeInput
+	if err := ctx.ShouldBindJSON(&totpCodeInput); err != nil {
+		_ = ctx.Error(err).SetType(gin.ErrorTypeBind)
+
+		return
+	}
+
+	accountID := ctx.MustGet("account_id").(string)
+
+	if err := handler.accountUsecase.DisableTOTP(ctx.Request.Context(), util.StringToUint(accountID), totpCodeInput.Code); err != nil {
+		_ = ctx.Error(err)
+
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
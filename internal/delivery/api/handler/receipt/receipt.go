@@ -0,0 +1,41 @@
+package receipt
+
+import (
+	"net/http"
+
+	"github.com/fms85/desafio-tecnico-go-stone/internal/domain/types"
+	transferUsecase "github.com/fms85/desafio-tecnico-go-stone/internal/usecase/transfer"
+	"github.com/gin-gonic/gin"
+)
+
+type ReceiptHandler struct {
+	transferUsecase transferUsecase.ITransferUsecase
+}
+
+func New(transferUsecase transferUsecase.ITransferUsecase) *ReceiptHandler {
+	return &ReceiptHandler{
+		transferUsecase: transferUsecase,
+	}
+}
+
+func (handler *ReceiptHandler) InitRoutes(router *gin.RouterGroup) {
+	router.GET("receipts/:code", handler.verify)
+}
+
+func (handler *ReceiptHandler) verify(ctx *gin.Context) {
+	var uri types.VerifyReceiptUri
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		_ = ctx.Error(err).SetType(gin.ErrorTypeBind)
+
+		return
+	}
+
+	receipt, err := handler.transferUsecase.VerifyReceipt(ctx.Request.Context(), uri.Code)
+	if err != nil {
+		_ = ctx.Error(err)
+
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"data": receipt})
+}
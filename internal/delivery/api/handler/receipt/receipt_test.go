@@ -0,0 +1,100 @@
+package receipt
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/fms85/desafio-tecnico-go-stone/internal/delivery/api/middleware"
+	"github.com/fms85/desafio-tecnico-go-stone/internal/domain/common"
+	"github.com/fms85/desafio-tecnico-go-stone/internal/domain/types"
+	ginDriver "github.com/fms85/desafio-tecnico-go-stone/internal/driver/gin"
+	transferUsecase "github.com/fms85/desafio-tecnico-go-stone/internal/usecase/transfer"
+	"github.com/google/go-cmp/cmp"
+	"github.com/stretchr/testify/mock"
+	"gotest.tools/assert"
+)
+
+func TestReceiptHandlerVerify(t *testing.T) {
+	type dependencies struct {
+		transferUsecase func() *transferUsecase.TransferUsecaseMock
+	}
+	tests := []struct {
+		name         string
+		dependencies dependencies
+		path         string
+		want         string
+		wantCode     int
+	}{
+		{
+			name: "should_return_receipt_for_a_valid_code",
+			dependencies: dependencies{
+				transferUsecase: func() *transferUsecase.TransferUsecaseMock {
+					usecase := &transferUsecase.TransferUsecaseMock{}
+					usecase.On("VerifyReceipt", mock.Anything, "AAAAAAAAAAAHC6KK").Return(&types.TransferReceipt{
+						ID:               7,
+						Status:           "completed",
+						Amount:           10,
+						Total:            10,
+						Origin:           types.ReceiptParty{Name: "Loren I.", CPF: "***.625.570-**"},
+						Destination:      types.ReceiptParty{Name: "Dolor", CPF: "***.744.080-**"},
+						CreatedAt:        time.Date(2026, 10, 19, 10, 30, 0, 0, types.RECEIPT_LOCATION),
+						VerificationCode: "AAAAAAAAAAAHC6KK",
+					}, nil)
+
+					return usecase
+				},
+			},
+			path:     "/receipts/AAAAAAAAAAAHC6KK",
+			want:     `{"data":{"id":7,"status":"completed","amount":10,"fee":0,"total":10,"origin":{"name":"Loren I.","cpf":"***.625.570-**"},"destination":{"name":"Dolor","cpf":"***.744.080-**"},"created_at":"2026-10-19T10:30:00-03:00","verification_code":"AAAAAAAAAAAHC6KK"}}`,
+			wantCode: http.StatusOK,
+		},
+		{
+			name: "should_return_not_found_for_an_unknown_code",
+			dependencies: dependencies{
+				transferUsecase: func() *transferUsecase.TransferUsecaseMock {
+					usecase := &transferUsecase.TransferUsecaseMock{}
+					usecase.On("VerifyReceipt", mock.Anything, "UNKNOWN").Return(nil, fmt.Errorf("receipt %w", &common.NotFoundError{}))
+
+					return usecase
+				},
+			},
+			path:     "/receipts/UNKNOWN",
+			want:     `{"type":"about:blank","title":"Not Found","status":404,"detail":"receipt not found","instance":"/receipts/UNKNOWN","code":"NOT_FOUND"}`,
+			wantCode: http.StatusNotFound,
+		},
+		{
+			name: "should_reject_malformed_code",
+			dependencies: dependencies{
+				transferUsecase: func() *transferUsecase.TransferUsecaseMock {
+					return &transferUsecase.TransferUsecaseMock{}
+				},
+			},
+			path:     "/receipts/not-a-code",
+			want:     `{"type":"about:blank","title":"Bad Request","status":400,"detail":"validation failed","instance":"/receipts/not-a-code","code":"VALIDATION_ERROR","errors":[{"field":"code","code":"alphanum","message":"code can only contain alphanumeric characters"}]}`,
+			wantCode: http.StatusBadRequest,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router := ginDriver.Setup()
+			router.Use(middleware.Error())
+			handler := New(tt.dependencies.transferUsecase())
+			handler.InitRoutes(&router.RouterGroup)
+			responseRecorder := httptest.NewRecorder()
+
+			request, _ := http.NewRequest("GET", tt.path, nil)
+
+			request.Header.Set("Accept-Language", "en")
+
+			router.ServeHTTP(responseRecorder, request)
+			assert.Equal(t, tt.wantCode, responseRecorder.Code)
+
+			if diff := cmp.Diff(responseRecorder.Body.String(), tt.want); diff != "" {
+				t.Error(diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,62 @@
+package statement
+
+import (
+	"encoding/csv"
+	"io"
+	"strconv"
+	"time"
+
+	"github.com/fms85/desafio-tecnico-go-stone/internal/domain/types"
+	"github.com/fms85/desafio-tecnico-go-stone/internal/util"
+)
+
+// CSV_FLUSH_EVERY bounds how many rows are buffered before being sent.
+const CSV_FLUSH_EVERY = 100
+
+type csvWriter struct {
+	w    *csv.Writer
+	rows int
+}
+
+func newCSVWriter(w io.Writer) *csvWriter {
+	return &csvWriter{w: csv.NewWriter(w)}
+}
+
+func (writer *csvWriter) WriteHeader(statement *types.Statement) error {
+	return writer.w.Write([]string{"id", "date", "kind", "transfer_id", "amount", "balance"})
+}
+
+func (writer *csvWriter) WriteEntry(statement *types.Statement, entry types.StatementEntry) error {
+	transferID := ""
+	if entry.TransferID != nil {
+		transferID = strconv.FormatUint(uint64(*entry.TransferID), 10)
+	}
+
+	if err := writer.w.Write([]string{
+		strconv.FormatUint(uint64(entry.ID), 10),
+		entry.CreatedAt.Format(time.RFC3339),
+		entry.Kind,
+		transferID,
+		formatAmount(entry.Amount),
+		formatAmount(entry.Balance),
+	}); err != nil {
+		return err
+	}
+
+	writer.rows++
+	if writer.rows%CSV_FLUSH_EVERY == 0 {
+		writer.w.Flush()
+	}
+
+	return writer.w.Error()
+}
+
+func (writer *csvWriter) WriteFooter(statement *types.Statement) error {
+	writer.w.Flush()
+
+	return writer.w.Error()
+}
+
+func formatAmount(amount float64) string {
+	return util.ConvertToDecimal(amount).StringFixed(2)
+}
@@ -0,0 +1,119 @@
+package statement
+
+import (
+	"bufio"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/fms85/desafio-tecnico-go-stone/internal/domain/entity"
+	"github.com/fms85/desafio-tecnico-go-stone/internal/domain/types"
+	"github.com/fms85/desafio-tecnico-go-stone/internal/driver/i18n"
+)
+
+const (
+	OFX_BANK_ID  = "0000"
+	OFX_CURRENCY = "BRL"
+)
+
+var ofxTransactionTypes = map[string]string{
+	entity.LEDGER_ENTRY_KIND_DEPOSIT:      "DEP",
+	entity.LEDGER_ENTRY_KIND_TRANSFER_IN:  "XFER",
+	entity.LEDGER_ENTRY_KIND_TRANSFER_OUT: "XFER",
+	entity.LEDGER_ENTRY_KIND_FEE:          "FEE",
+}
+
+// ofxWriter writes OFX 2.2. FITIDs are ledger entry ids, which never change,
+// so importing overlapping ranges does not duplicate transactions.
+type ofxWriter struct {
+	w    *bufio.Writer
+	lang string
+	now  time.Time
+}
+
+func newOFXWriter(w io.Writer, lang string, now time.Time) *ofxWriter {
+	return &ofxWriter{w: bufio.NewWriter(w), lang: lang, now: now}
+}
+
+func (writer *ofxWriter) WriteHeader(statement *types.Statement) error {
+	fmt.Fprintf(writer.w, `<?xml version="1.0" encoding="UTF-8" standalone="no"?>
+<?OFX OFXHEADER="200" VERSION="220" SECURITY="NONE" OLDFILEUID="NONE" NEWFILEUID="NONE"?>
+<OFX>
+<SIGNONMSGSRSV1><SONRS><STATUS><CODE>0</CODE><SEVERITY>INFO</SEVERITY></STATUS><DTSERVER>%s</DTSERVER><LANGUAGE>%s</LANGUAGE></SONRS></SIGNONMSGSRSV1>
+<BANKMSGSRSV1><STMTTRNRS><TRNUID>0</TRNUID><STATUS><CODE>0</CODE><SEVERITY>INFO</SEVERITY></STATUS>
+<STMTRS><CURDEF>%s</CURDEF>
+<BANKACCTFROM><BANKID>%s</BANKID><ACCTID>%d</ACCTID><ACCTTYPE>CHECKING</ACCTTYPE></BANKACCTFROM>
+<BANKTRANLIST><DTSTART>%s</DTSTART><DTEND>%s</DTEND>
+`,
+		ofxDate(writer.now.In(types.RECEIPT_LOCATION)),
+		ofxLanguage(writer.lang),
+		OFX_CURRENCY,
+		OFX_BANK_ID,
+		statement.AccountID,
+		ofxDate(statement.From),
+		ofxDate(endOfDay(statement.To)),
+	)
+
+	return writer.w.Flush()
+}
+
+func (writer *ofxWriter) WriteEntry(statement *types.Statement, entry types.StatementEntry) error {
+	transactionType, ok := ofxTransactionTypes[entry.Kind]
+	if !ok {
+		transactionType = "CREDIT"
+		if entry.Amount < 0 {
+			transactionType = "DEBIT"
+		}
+	}
+
+	_, err := fmt.Fprintf(writer.w, "<STMTTRN><TRNTYPE>%s</TRNTYPE><DTPOSTED>%s</DTPOSTED><TRNAMT>%s</TRNAMT><FITID>%d</FITID><NAME>%s</NAME></STMTTRN>\n",
+		transactionType,
+		ofxDate(entry.CreatedAt),
+		formatAmount(entry.Amount),
+		entry.ID,
+		escapeXML(describe(writer.lang, entry)),
+	)
+
+	return err
+}
+
+func (writer *ofxWriter) WriteFooter(statement *types.Statement) error {
+	fmt.Fprintf(writer.w, `</BANKTRANLIST>
+<LEDGERBAL><BALAMT>%s</BALAMT><DTASOF>%s</DTASOF></LEDGERBAL>
+</STMTRS></STMTTRNRS></BANKMSGSRSV1>
+</OFX>
+`,
+		formatAmount(statement.ClosingBalance),
+		ofxDate(endOfDay(statement.To)),
+	)
+
+	return writer.w.Flush()
+}
+
+// ofxDate formats t as YYYYMMDDHHMMSS.XXX[gmt offset], keeping its zone.
+func ofxDate(t time.Time) string {
+	_, offset := t.Zone()
+
+	return fmt.Sprintf("%s[%d]", t.Format("20060102150405.000"), offset/3600)
+}
+
+func ofxLanguage(lang string) string {
+	if lang == i18n.LANGUAGE_EN {
+		return "ENG"
+	}
+
+	return "POR"
+}
+
+func endOfDay(t time.Time) time.Time {
+	return t.AddDate(0, 0, 1).Add(-time.Second)
+}
+
+func escapeXML(s string) string {
+	var b strings.Builder
+	_ = xml.EscapeText(&b, []byte(s))
+
+	return b.String()
+}
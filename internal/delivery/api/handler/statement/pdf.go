@@ -0,0 +1,119 @@
+package statement
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+
+	"github.com/fms85/desafio-tecnico-go-stone/internal/domain/entity"
+	"github.com/fms85/desafio-tecnico-go-stone/internal/domain/types"
+	"github.com/fms85/desafio-tecnico-go-stone/internal/driver/i18n"
+	"github.com/fms85/desafio-tecnico-go-stone/internal/driver/pdf"
+)
+
+const (
+	PDF_MARGIN      = 40.0
+	PDF_LINE_HEIGHT = 14.0
+	PDF_FONT_SIZE   = 9.0
+)
+
+var entryDescriptions = map[string]string{
+	entity.LEDGER_ENTRY_KIND_DEPOSIT:      "Deposit",
+	entity.LEDGER_ENTRY_KIND_TRANSFER_IN:  "Transfer received",
+	entity.LEDGER_ENTRY_KIND_TRANSFER_OUT: "Transfer sent",
+	entity.LEDGER_ENTRY_KIND_FEE:          "Fee",
+	entity.LEDGER_ENTRY_KIND_REVERSAL:     "Reversal",
+}
+
+// pdfWriter lays the statement out as an A4 table, repeating the header on
+// every page. Pages are sent as soon as they are full.
+type pdfWriter struct {
+	document *pdf.Document
+	lang     string
+	y        float64
+}
+
+func newPDFWriter(w io.Writer, lang string) *pdfWriter {
+	return &pdfWriter{document: pdf.New(w), lang: lang}
+}
+
+func (writer *pdfWriter) WriteHeader(statement *types.Statement) error {
+	writer.newPage(statement)
+	writer.row("", writer.t("Opening balance"), "", formatAmount(statement.OpeningBalance), pdf.FONT_BOLD)
+
+	return nil
+}
+
+func (writer *pdfWriter) WriteEntry(statement *types.Statement, entry types.StatementEntry) error {
+	if writer.y < PDF_MARGIN+PDF_LINE_HEIGHT {
+		writer.newPage(statement)
+	}
+
+	writer.row(
+		entry.CreatedAt.Format("02/01/2006 15:04"),
+		describe(writer.lang, entry),
+		formatAmount(entry.Amount),
+		formatAmount(entry.Balance),
+		pdf.FONT_REGULAR,
+	)
+
+	return nil
+}
+
+func (writer *pdfWriter) WriteFooter(statement *types.Statement) error {
+	if writer.y < PDF_MARGIN+4*PDF_LINE_HEIGHT {
+		writer.newPage(statement)
+	}
+
+	writer.document.Line(PDF_MARGIN, writer.y+PDF_LINE_HEIGHT-4, pdf.PAGE_WIDTH-PDF_MARGIN, writer.y+PDF_LINE_HEIGHT-4)
+	writer.row("", writer.t("Total credits"), formatAmount(statement.TotalCredits), "", pdf.FONT_REGULAR)
+	writer.row("", writer.t("Total debits"), formatAmount(-statement.TotalDebits), "", pdf.FONT_REGULAR)
+	writer.row("", writer.t("Closing balance"), "", formatAmount(statement.ClosingBalance), pdf.FONT_BOLD)
+
+	return writer.document.Close()
+}
+
+func (writer *pdfWriter) newPage(statement *types.Statement) {
+	writer.document.AddPage()
+
+	top := pdf.PAGE_HEIGHT - PDF_MARGIN
+	writer.document.Text(pdf.FONT_BOLD, 16, PDF_MARGIN, top, writer.t("Statement"))
+	writer.document.TextRight(pdf.FONT_REGULAR, PDF_FONT_SIZE, pdf.PAGE_WIDTH-PDF_MARGIN, top,
+		fmt.Sprintf("%s %d", writer.t("Page"), writer.document.PageCount()))
+	writer.document.Text(pdf.FONT_REGULAR, PDF_FONT_SIZE+1, PDF_MARGIN, top-20,
+		fmt.Sprintf("%s: %s", writer.t("Account"), strconv.FormatUint(uint64(statement.AccountID), 10)))
+	writer.document.Text(pdf.FONT_REGULAR, PDF_FONT_SIZE+1, PDF_MARGIN, top-34,
+		fmt.Sprintf("%s: %s - %s", writer.t("Period"), statement.From.Format("02/01/2006"), statement.To.Format("02/01/2006")))
+
+	writer.y = top - 60
+	writer.row(writer.t("Date"), writer.t("Description"), writer.t("Amount"), writer.t("Balance"), pdf.FONT_BOLD)
+	writer.document.Line(PDF_MARGIN, writer.y+PDF_LINE_HEIGHT-4, pdf.PAGE_WIDTH-PDF_MARGIN, writer.y+PDF_LINE_HEIGHT-4)
+}
+
+func (writer *pdfWriter) row(date string, description string, amount string, balance string, font string) {
+	writer.document.Text(font, PDF_FONT_SIZE, PDF_MARGIN, writer.y, date)
+	writer.document.Text(font, PDF_FONT_SIZE, PDF_MARGIN+90, writer.y, description)
+	writer.document.TextRight(font, PDF_FONT_SIZE, pdf.PAGE_WIDTH-PDF_MARGIN-90, writer.y, amount)
+	writer.document.TextRight(font, PDF_FONT_SIZE, pdf.PAGE_WIDTH-PDF_MARGIN, writer.y, balance)
+
+	writer.y -= PDF_LINE_HEIGHT
+}
+
+func (writer *pdfWriter) t(msg string) string {
+	return i18n.Translate(writer.lang, msg)
+}
+
+// describe is the human readable label of an entry, in lang.
+func describe(lang string, entry types.StatementEntry) string {
+	description, ok := entryDescriptions[entry.Kind]
+	if !ok {
+		description = entry.Kind
+	}
+
+	description = i18n.Translate(lang, description)
+	if entry.TransferID != nil {
+		description = fmt.Sprintf("%s #%d", description, *entry.TransferID)
+	}
+
+	return description
+}
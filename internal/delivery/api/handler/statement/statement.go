@@ -0,0 +1,149 @@
+package statement
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/fms85/desafio-tecnico-go-stone/internal/domain/types"
+	"github.com/fms85/desafio-tecnico-go-stone/internal/driver/i18n"
+	"github.com/fms85/desafio-tecnico-go-stone/internal/driver/logger"
+	statementUsecase "github.com/fms85/desafio-tecnico-go-stone/internal/usecase/statement"
+	"github.com/fms85/desafio-tecnico-go-stone/internal/util"
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	MIME_CSV = "text/csv"
+	MIME_OFX = "application/x-ofx"
+	MIME_PDF = "application/pdf"
+)
+
+// EXPORT_WRITE_TIMEOUT is how long each chunk of an export has to reach the
+// client. It replaces the server write timeout, which would cut any export
+// taking longer as a whole.
+const EXPORT_WRITE_TIMEOUT = 10 * time.Second
+
+var formats = map[string]string{
+	gin.MIMEJSON: types.STATEMENT_FORMAT_JSON,
+	MIME_CSV:     types.STATEMENT_FORMAT_CSV,
+	MIME_OFX:     types.STATEMENT_FORMAT_OFX,
+	MIME_PDF:     types.STATEMENT_FORMAT_PDF,
+}
+
+var contentTypes = map[string]string{
+	types.STATEMENT_FORMAT_CSV: MIME_CSV + "; charset=utf-8",
+	types.STATEMENT_FORMAT_OFX: MIME_OFX,
+	types.STATEMENT_FORMAT_PDF: MIME_PDF,
+}
+
+type StatementHandler struct {
+	statementUsecase statementUsecase.IStatementUsecase
+}
+
+func New(statementUsecase statementUsecase.IStatementUsecase) *StatementHandler {
+	return &StatementHandler{
+		statementUsecase: statementUsecase,
+	}
+}
+
+func (handler *StatementHandler) InitRoutes(router *gin.RouterGroup) {
+	router.GET("accounts/me/statement", handler.get)
+}
+
+func (handler *StatementHandler) get(ctx *gin.Context) {
+	var statementInput types.StatementInput
+	if err := ctx.ShouldBindQuery(&statementInput); err != nil {
+		_ = ctx.Error(err).SetType(gin.ErrorTypeBind)
+
+		return
+	}
+
+	accountID := ctx.MustGet("account_id").(string)
+	statementInput.AccountID = util.StringToUint(accountID)
+
+	if statementInput.Format == "" {
+		statementInput.Format = formats[ctx.NegotiateFormat(gin.MIMEJSON, MIME_CSV, MIME_OFX, MIME_PDF)]
+	}
+
+	if statementInput.Format == "" || statementInput.Format == types.STATEMENT_FORMAT_JSON {
+		statement, err := handler.statementUsecase.Get(ctx.Request.Context(), statementInput)
+		if err != nil {
+			_ = ctx.Error(err)
+
+			return
+		}
+
+		ctx.JSON(http.StatusOK, gin.H{"data": statement})
+
+		return
+	}
+
+	handler.export(ctx, statementInput)
+}
+
+// export streams the file as the ledger is read. Once the first bytes are out
+// the status can no longer change, so later failures only cut the download.
+func (handler *StatementHandler) export(ctx *gin.Context, statementInput types.StatementInput) {
+	lang := i18n.Language(ctx.GetHeader("Accept-Language"))
+
+	ctx.Header("Content-Type", contentTypes[statementInput.Format])
+	ctx.Header("Content-Disposition", fmt.Sprintf(
+		`attachment; filename="statement-%s-%s.%s"`,
+		statementInput.From.Format(time.DateOnly),
+		statementInput.To.Format(time.DateOnly),
+		statementInput.Format,
+	))
+
+	writer := newDeadlineWriter(ctx.Writer)
+	err := handler.statementUsecase.Export(ctx.Request.Context(), statementInput, newWriter(writer, statementInput.Format, lang))
+	if err == nil {
+		return
+	}
+
+	if !ctx.Writer.Written() {
+		ctx.Writer.Header().Del("Content-Disposition")
+		_ = ctx.Error(err)
+
+		return
+	}
+
+	logger.FromContext(ctx.Request.Context()).Error("statement export interrupted", "error", err)
+	ctx.Abort()
+}
+
+func newWriter(w io.Writer, format string, lang string) types.StatementWriter {
+	switch format {
+	case types.STATEMENT_FORMAT_OFX:
+		return newOFXWriter(w, lang, time.Now())
+	case types.STATEMENT_FORMAT_PDF:
+		return newPDFWriter(w, lang)
+	default:
+		return newCSVWriter(w)
+	}
+}
+
+// deadlineWriter pushes the write deadline forward before every chunk, so a
+// long export keeps going while a stalled client is still dropped.
+type deadlineWriter struct {
+	w          io.Writer
+	controller *http.ResponseController
+}
+
+func newDeadlineWriter(w http.ResponseWriter) *deadlineWriter {
+	writer := &deadlineWriter{w: w, controller: http.NewResponseController(w)}
+	writer.extend()
+
+	return writer
+}
+
+func (writer *deadlineWriter) Write(p []byte) (int, error) {
+	writer.extend()
+
+	return writer.w.Write(p)
+}
+
+func (writer *deadlineWriter) extend() {
+	_ = writer.controller.SetWriteDeadline(time.Now().Add(EXPORT_WRITE_TIMEOUT))
+}
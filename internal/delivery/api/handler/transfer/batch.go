@@ -0,0 +1,195 @@
+package transfer
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/fms85/desafio-tecnico-go-stone/internal/domain/common"
+	"github.com/fms85/desafio-tecnico-go-stone/internal/domain/types"
+	"github.com/fms85/desafio-tecnico-go-stone/internal/util"
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+)
+
+const (
+	MIME_CSV = "text/csv"
+
+	// BATCH_MAX_BODY_SIZE bounds uploads, far above what
+	// TRANSFER_BATCH_MAX_ITEMS lines take.
+	BATCH_MAX_BODY_SIZE = 1 << 20
+
+	INVALID_CSV_ERROR = "invalid csv file"
+)
+
+var batchCSVHeader = []string{"account_destination_id", "amount"}
+
+func (handler *TransferHandler) createBatch(ctx *gin.Context) {
+	ctx.Request.Body = http.MaxBytesReader(ctx.Writer, ctx.Request.Body, BATCH_MAX_BODY_SIZE)
+
+	var batchInput types.TransferBatchInput
+	if err := bindBatch(ctx, &batchInput); err != nil {
+		var validationError *common.ValidationError
+		if errors.As(err, &validationError) {
+			_ = ctx.Error(err)
+
+			return
+		}
+
+		_ = ctx.Error(err).SetType(gin.ErrorTypeBind)
+
+		return
+	}
+
+	accountID := ctx.MustGet("account_id").(string)
+	batchInput.AccountOriginID = util.StringToUint(accountID)
+
+	var total float64
+	for _, item := range batchInput.Items {
+		total = util.Sum(total, item.Amount)
+	}
+
+	if err := handler.transferUsecase.Authorize(ctx.Request.Context(), batchInput.AccountOriginID, total, Authorization(ctx, batchInput.PIN)); err != nil {
+		_ = ctx.Error(err)
+
+		return
+	}
+
+	batch, err := handler.transferUsecase.CreateBatch(ctx.Request.Context(), batchInput)
+	if err != nil {
+		_ = ctx.Error(err)
+
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, gin.H{"data": batch})
+}
+
+func (handler *TransferHandler) getBatch(ctx *gin.Context) {
+	var uri types.GetTransferBatchUri
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		_ = ctx.Error(err).SetType(gin.ErrorTypeBind)
+
+		return
+	}
+
+	accountID := ctx.MustGet("account_id").(string)
+
+	batch, err := handler.transferUsecase.GetBatch(
+		ctx.Request.Context(),
+		util.StringToUint(uri.BatchID),
+		util.StringToUint(accountID),
+	)
+	if err != nil {
+		_ = ctx.Error(err)
+
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"data": batch})
+}
+
+// bindBatch reads the items from a JSON body, a CSV body or a CSV file sent
+// as the "file" field of a form. CSV uploads take the mode from the query or
+// the form and go through the same validation as JSON.
+func bindBatch(ctx *gin.Context, batchInput *types.TransferBatchInput) error {
+	switch ctx.ContentType() {
+	case MIME_CSV:
+		items, err := parseBatchCSV(ctx.Request.Body)
+		if err != nil {
+			return err
+		}
+
+		batchInput.Items = items
+
+		return ctx.ShouldBindQuery(batchInput)
+	case binding.MIMEMultipartPOSTForm:
+		file, err := ctx.FormFile("file")
+		if err != nil {
+			return &common.ValidationError{Msg: INVALID_CSV_ERROR}
+		}
+
+		f, err := file.Open()
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+
+		items, err := parseBatchCSV(f)
+		if err != nil {
+			return err
+		}
+
+		batchInput.Items = items
+
+		return ctx.ShouldBindWith(batchInput, binding.FormMultipart)
+	default:
+		return ctx.ShouldBindJSON(batchInput)
+	}
+}
+
+// parseBatchCSV reads a header with account_destination_id and amount, in
+// any order, and one item per line. It stops one line past the limit and
+// lets validation reject the batch, without reading the rest.
+func parseBatchCSV(r io.Reader) ([]types.TransferBatchItemInput, error) {
+	reader := csv.NewReader(r)
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, csvError(1, err.Error())
+	}
+
+	columns := map[string]int{}
+	for i, name := range header {
+		columns[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+
+	for _, name := range batchCSVHeader {
+		if _, ok := columns[name]; !ok {
+			return nil, csvError(1, "missing column "+name)
+		}
+	}
+
+	items := []types.TransferBatchItemInput{}
+	for line := 2; len(items) <= types.TRANSFER_BATCH_MAX_ITEMS; line++ {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+
+		if err != nil {
+			return nil, csvError(line, err.Error())
+		}
+
+		destination, err := strconv.ParseUint(strings.TrimSpace(record[columns["account_destination_id"]]), 10, 0)
+		if err != nil {
+			return nil, csvError(line, "account_destination_id must be a number")
+		}
+
+		amount, err := strconv.ParseFloat(strings.TrimSpace(record[columns["amount"]]), 64)
+		if err != nil {
+			return nil, csvError(line, "amount must be a number")
+		}
+
+		items = append(items, types.TransferBatchItemInput{
+			AccountDestinationID: uint(destination),
+			Amount:               amount,
+		})
+	}
+
+	return items, nil
+}
+
+func csvError(line int, msg string) error {
+	return &common.ValidationError{
+		Msg: INVALID_CSV_ERROR,
+		Fields: []common.FieldError{
+			{Field: fmt.Sprintf("line %d", line), Code: "csv", Message: msg},
+		},
+	}
+}
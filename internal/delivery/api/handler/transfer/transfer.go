@@ -1,18 +1,22 @@
 package transfer
 
 import (
-	"errors"
-	"log"
 	"net/http"
 
-	"github.com/fms85/desafio-tecnico-go-stone/internal/domain/common"
+	"github.com/fms85/desafio-tecnico-go-stone/internal/domain/entity"
 	"github.com/fms85/desafio-tecnico-go-stone/internal/domain/types"
 	transferUsecase "github.com/fms85/desafio-tecnico-go-stone/internal/usecase/transfer"
 	"github.com/fms85/desafio-tecnico-go-stone/internal/util"
 	"github.com/gin-gonic/gin"
 )
 
-var validationError *common.ValidationError
+// TOTP_CODE_HEADER carries the TOTP code transfers over the step-up amount
+// ask for, on top of the token. PIN_HEADER carries the transaction PIN, which
+// may also come in the body.
+const (
+	TOTP_CODE_HEADER = "X-TOTP-Code"
+	PIN_HEADER       = "X-Transaction-PIN"
+)
 
 type TransferHandler struct {
 	transferUsecase transferUsecase.ITransferUsecase
@@ -26,7 +30,10 @@ func New(transferUsecase transferUsecase.ITransferUsecase) *TransferHandler {
 
 func (handler *TransferHandler) InitRoutes(router *gin.RouterGroup) {
 	router.GET("transfers", handler.getAll)
+	router.GET("transfers/:transfer_id", handler.getReceipt)
 	router.POST("transfers", handler.createTransfer)
+	router.POST("transfers/batch", handler.createBatch)
+	router.GET("transfers/batch/:batch_id", handler.getBatch)
 }
 
 func (handler *TransferHandler) getAll(ctx *gin.Context) {
@@ -34,9 +41,7 @@ func (handler *TransferHandler) getAll(ctx *gin.Context) {
 
 	transfers, err := handler.transferUsecase.GetAll(ctx.Request.Context(), types.TransferInput{AccountOriginID: util.StringToUint(accountID)})
 	if err != nil {
-		log.Println(err)
-
-		ctx.JSON(http.StatusInternalServerError, gin.H{"message": common.INTERNAL_SERVER_ERROR})
+		_ = ctx.Error(err)
 
 		return
 	}
@@ -44,10 +49,34 @@ func (handler *TransferHandler) getAll(ctx *gin.Context) {
 	ctx.JSON(http.StatusOK, gin.H{"data": transfers})
 }
 
+func (handler *TransferHandler) getReceipt(ctx *gin.Context) {
+	var uri types.GetTransferUri
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		_ = ctx.Error(err).SetType(gin.ErrorTypeBind)
+
+		return
+	}
+
+	accountID := ctx.MustGet("account_id").(string)
+
+	receipt, err := handler.transferUsecase.Receipt(
+		ctx.Request.Context(),
+		util.StringToUint(uri.TransferID),
+		util.StringToUint(accountID),
+	)
+	if err != nil {
+		_ = ctx.Error(err)
+
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"data": receipt})
+}
+
 func (handler *TransferHandler) createTransfer(ctx *gin.Context) {
 	var transferInput types.TransferInput
 	if err := ctx.ShouldBindJSON(&transferInput); err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"message": err.Error()})
+		_ = ctx.Error(err).SetType(gin.ErrorTypeBind)
 
 		return
 	}
@@ -55,20 +84,32 @@ func (handler *TransferHandler) createTransfer(ctx *gin.Context) {
 	accountID := ctx.MustGet("account_id").(string)
 	transferInput.AccountOriginID = util.StringToUint(accountID)
 
-	err := handler.transferUsecase.Create(ctx.Request.Context(), transferInput)
-	if err != nil {
-		if errors.As(err, &validationError) {
-			ctx.JSON(http.StatusBadRequest, gin.H{"message": err.Error()})
-
-			return
-		}
+	if err := handler.transferUsecase.Authorize(ctx.Request.Context(), transferInput.AccountOriginID, transferInput.Amount, Authorization(ctx, transferInput.PIN)); err != nil {
+		_ = ctx.Error(err)
 
-		log.Println(err)
+		return
+	}
 
-		ctx.JSON(http.StatusInternalServerError, gin.H{"message": common.INTERNAL_SERVER_ERROR})
+	if _, err := handler.transferUsecase.Create(ctx.Request.Context(), transferInput); err != nil {
+		_ = ctx.Error(err)
 
 		return
 	}
 
 	ctx.JSON(http.StatusCreated, nil)
 }
+
+// Authorization reads the PIN from its header, falling back to the one of
+// the body, and the TOTP code from its header, or the API key of the caller.
+// Charge and bill payments take it the same way.
+func Authorization(ctx *gin.Context, pin string) types.TransferAuthorization {
+	if apiKey, ok := ctx.Get("api_key"); ok {
+		return types.TransferAuthorization{APIKeyID: apiKey.(*entity.APIKey).KeyID}
+	}
+
+	if header := ctx.GetHeader(PIN_HEADER); header != "" {
+		pin = header
+	}
+
+	return types.TransferAuthorization{PIN: pin, TOTPCode: ctx.GetHeader(TOTP_CODE_HEADER)}
+}
@@ -0,0 +1,135 @@
+package webhook
+
+import (
+	"net/http"
+
+	"github.com/fms85/desafio-tecnico-go-stone/internal/domain/types"
+	webhookUsecase "github.com/fms85/desafio-tecnico-go-stone/internal/usecase/webhook"
+	"github.com/fms85/desafio-tecnico-go-stone/internal/util"
+	"github.com/gin-gonic/gin"
+)
+
+type WebhookHandler struct {
+	webhookUsecase webhookUsecase.IWebhookUsecase
+}
+
+func New(webhookUsecase webhookUsecase.IWebhookUsecase) *WebhookHandler {
+	return &WebhookHandler{
+		webhookUsecase: webhookUsecase,
+	}
+}
+
+func (handler *WebhookHandler) InitRoutes(router *gin.RouterGroup) {
+	router.GET("webhooks", handler.getAll)
+	router.POST("webhooks", handler.create)
+	router.DELETE("webhooks/:webhook_id", handler.delete)
+	router.GET("webhooks/:webhook_id/deliveries", handler.getDeliveries)
+	router.POST("webhooks/:webhook_id/deliveries/:delivery_id/redeliver", handler.redeliver)
+}
+
+func (handler *WebhookHandler) getAll(ctx *gin.Context) {
+	accountID := ctx.MustGet("account_id").(string)
+
+	webhooks, err := handler.webhookUsecase.GetAll(ctx.Request.Context(), util.StringToUint(accountID))
+	if err != nil {
+		_ = ctx.Error(err)
+
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"data": webhooks})
+}
+
+func (handler *WebhookHandler) create(ctx *gin.Context) {
+	var webhookInput types.WebhookInput
+	if err := ctx.ShouldBindJSON(&webhookInput); err != nil {
+		_ = ctx.Error(err).SetType(gin.ErrorTypeBind)
+
+		return
+	}
+
+	accountID := ctx.MustGet("account_id").(string)
+	webhookInput.AccountID = util.StringToUint(accountID)
+
+	subscription, err := handler.webhookUsecase.Create(ctx.Request.Context(), webhookInput)
+	if err != nil {
+		_ = ctx.Error(err)
+
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, gin.H{"data": subscription})
+}
+
+func (handler *WebhookHandler) delete(ctx *gin.Context) {
+	var uri types.WebhookUri
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		_ = ctx.Error(err).SetType(gin.ErrorTypeBind)
+
+		return
+	}
+
+	accountID := ctx.MustGet("account_id").(string)
+
+	if err := handler.webhookUsecase.Delete(ctx.Request.Context(), util.StringToUint(uri.WebhookID), util.StringToUint(accountID)); err != nil {
+		_ = ctx.Error(err)
+
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+func (handler *WebhookHandler) getDeliveries(ctx *gin.Context) {
+	var uri types.WebhookUri
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		_ = ctx.Error(err).SetType(gin.ErrorTypeBind)
+
+		return
+	}
+
+	var deliveryInput types.WebhookDeliveryInput
+	if err := ctx.ShouldBindQuery(&deliveryInput); err != nil {
+		_ = ctx.Error(err).SetType(gin.ErrorTypeBind)
+
+		return
+	}
+
+	accountID := ctx.MustGet("account_id").(string)
+	deliveryInput.AccountID = util.StringToUint(accountID)
+	deliveryInput.WebhookID = util.StringToUint(uri.WebhookID)
+
+	deliveries, err := handler.webhookUsecase.GetDeliveries(ctx.Request.Context(), deliveryInput)
+	if err != nil {
+		_ = ctx.Error(err)
+
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"data": deliveries})
+}
+
+func (handler *WebhookHandler) redeliver(ctx *gin.Context) {
+	var uri types.WebhookDeliveryUri
+	if err := ctx.ShouldBindUri(&uri); err != nil {
+		_ = ctx.Error(err).SetType(gin.ErrorTypeBind)
+
+		return
+	}
+
+	accountID := ctx.MustGet("account_id").(string)
+
+	delivery, err := handler.webhookUsecase.Redeliver(
+		ctx.Request.Context(),
+		util.StringToUint(uri.DeliveryID),
+		util.StringToUint(uri.WebhookID),
+		util.StringToUint(accountID),
+	)
+	if err != nil {
+		_ = ctx.Error(err)
+
+		return
+	}
+
+	ctx.JSON(http.StatusAccepted, gin.H{"data": delivery})
+}
@@ -0,0 +1,44 @@
+package api
+
+import (
+	"github.com/gin-gonic/gin"
+)
+
+// LEGACY_ROUTES lists the routes served before versioning, by method and
+// path, and whether they take authentication. Only these keep an unversioned
+// alias: routes added since are served under /v1 alone.
+var LEGACY_ROUTES = []struct {
+	method     string
+	path       string
+	authorized bool
+}{
+	{method: "GET", path: "accounts"},
+	{method: "GET", path: "accounts/:account_id/balance"},
+	{method: "POST", path: "accounts"},
+	{method: "POST", path: "login"},
+	{method: "GET", path: "transfers", authorized: true},
+	{method: "POST", path: "transfers", authorized: true},
+}
+
+// registerLegacy aliases the legacy routes to the handlers of their /v1
+// counterparts, which must be registered on engine first.
+func registerLegacy(engine *gin.Engine, router *gin.RouterGroup, auth ...gin.HandlerFunc) {
+	handlers := map[string]gin.HandlerFunc{}
+	for _, route := range engine.Routes() {
+		handlers[route.Method+" "+route.Path] = route.HandlerFunc
+	}
+
+	authorized := router.Group("/", auth...)
+	for _, route := range LEGACY_ROUTES {
+		handler, ok := handlers[route.method+" /"+API_VERSION_V1+"/"+route.path]
+		if !ok {
+			panic("legacy route without a /" + API_VERSION_V1 + " counterpart: " + route.method + " " + route.path)
+		}
+
+		if route.authorized {
+			authorized.Handle(route.method, route.path, handler)
+		} else {
+			router.Handle(route.method, route.path, handler)
+		}
+	}
+}
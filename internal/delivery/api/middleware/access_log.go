@@ -0,0 +1,31 @@
+package middleware
+
+import (
+	"log/slog"
+	"time"
+
+	"github.com/fms85/desafio-tecnico-go-stone/internal/driver/logger"
+	"github.com/gin-gonic/gin"
+)
+
+func AccessLog() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		start := time.Now()
+
+		ctx.Next()
+
+		level := slog.LevelInfo
+		if ctx.Writer.Status() >= 500 {
+			level = slog.LevelError
+		}
+
+		logger.FromContext(ctx.Request.Context()).Log(ctx.Request.Context(), level, "request",
+			"method", ctx.Request.Method,
+			"route", ctx.FullPath(),
+			"path", ctx.Request.URL.Path,
+			"status", ctx.Writer.Status(),
+			"latency", time.Since(start),
+			"client_ip", ctx.ClientIP(),
+		)
+	}
+}
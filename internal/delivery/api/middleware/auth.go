@@ -1,37 +1,79 @@
 package middleware
 
 import (
-	"net/http"
+	"context"
+	"strconv"
 	"strings"
 
-	"github.com/fms85/desafio-tecnico-go-stone/internal/util"
+	"github.com/fms85/desafio-tecnico-go-stone/internal/domain/common"
+	"github.com/fms85/desafio-tecnico-go-stone/internal/domain/entity"
+	"github.com/fms85/desafio-tecnico-go-stone/internal/domain/types"
+	jwtDriver "github.com/fms85/desafio-tecnico-go-stone/internal/driver/jwt"
 	"github.com/gin-gonic/gin"
 )
 
-func Auth(secret string) gin.HandlerFunc {
+type APIKeyAuthenticator interface {
+	Authenticate(ctx context.Context, keyID string, secret string) (*entity.APIKey, error)
+}
+
+// Auth accepts the bearer tokens of accounts and the API keys of service
+// accounts, sent as Basic credentials with the key id as user and its secret
+// as password. Both store the account id, and API keys store themselves
+// too, for Session and Scope to tell them apart.
+func Auth(keys jwtDriver.IKeySet, apiKeys APIKeyAuthenticator) gin.HandlerFunc {
 	return func(ctx *gin.Context) {
 		authHeader := ctx.Request.Header.Get("Authorization")
 
 		if authHeader == "" {
-			ctx.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"message": "Authorization header missing"})
+			_ = ctx.Error(&common.UnauthorizedError{Msg: "authorization header missing"})
+			ctx.Abort()
+			return
+		}
+
+		if keyID, secret, ok := ctx.Request.BasicAuth(); ok {
+			apiKey, err := apiKeys.Authenticate(ctx.Request.Context(), keyID, secret)
+			if err != nil {
+				_ = ctx.Error(err)
+				ctx.Abort()
+				return
+			}
+
+			ctx.Set("account_id", strconv.FormatUint(uint64(apiKey.AccountID), 10))
+			ctx.Set("api_key", apiKey)
+			ctx.Next()
 			return
 		}
 
 		tokenParts := strings.Split(authHeader, " ")
 		if len(tokenParts) != 2 || tokenParts[0] != "Bearer" {
-			ctx.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"message": "Invalid Authorization header format"})
+			_ = ctx.Error(&common.UnauthorizedError{Msg: "invalid authorization header format"})
+			ctx.Abort()
 			return
 		}
 
 		token := tokenParts[1]
 
-		account_id, err := util.ParseJwtToken("account_id", token, secret)
+		claims, err := keys.Verify(token)
 		if err != nil {
-			ctx.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"message": "Unauthorized"})
+			_ = ctx.Error(&common.UnauthorizedError{})
+			ctx.Abort()
 			return
 		}
 
+		account_id, err := claims.GetStr("account_id")
+		if err != nil {
+			_ = ctx.Error(&common.UnauthorizedError{})
+			ctx.Abort()
+			return
+		}
+
+		tokenVersion, err := claims.GetStr(types.TOKEN_VERSION_CLAIM)
+		if err != nil {
+			tokenVersion = "0"
+		}
+
 		ctx.Set("account_id", account_id)
+		ctx.Set("token_version", tokenVersion)
 		ctx.Next()
 	}
 }
@@ -0,0 +1,24 @@
+package middleware
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Deprecation flags legacy routes with the Deprecation (RFC 9745) and Sunset
+// (RFC 8594) headers, linking to the same path under the successor prefix.
+func Deprecation(deprecatedAt time.Time, sunset time.Time, successor string) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		ctx.Header("Deprecation", fmt.Sprintf("@%d", deprecatedAt.Unix()))
+		if !sunset.IsZero() {
+			ctx.Header("Sunset", sunset.UTC().Format(http.TimeFormat))
+		}
+
+		ctx.Header("Link", fmt.Sprintf(`<%s%s>; rel="successor-version"`, successor, ctx.Request.URL.Path))
+
+		ctx.Next()
+	}
+}
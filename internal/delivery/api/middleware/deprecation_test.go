@@ -0,0 +1,47 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDeprecation(t *testing.T) {
+	deprecatedAt := time.Date(2026, 10, 19, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name       string
+		sunset     time.Time
+		wantSunset string
+	}{
+		{
+			name:       "should_set_deprecation_and_sunset_headers",
+			sunset:     time.Date(2027, 4, 17, 0, 0, 0, 0, time.UTC),
+			wantSunset: "Sat, 17 Apr 2027 00:00:00 GMT",
+		},
+		{
+			name:       "should_omit_sunset_header_when_not_configured",
+			sunset:     time.Time{},
+			wantSunset: "",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router := gin.New()
+			router.Use(Deprecation(deprecatedAt, tt.sunset, "/v1"))
+			router.GET("/transfers", func(ctx *gin.Context) { ctx.Status(http.StatusOK) })
+			responseRecorder := httptest.NewRecorder()
+
+			request, _ := http.NewRequest("GET", "/transfers", nil)
+
+			router.ServeHTTP(responseRecorder, request)
+			assert.Equal(t, "@1792368000", responseRecorder.Header().Get("Deprecation"))
+			assert.Equal(t, tt.wantSunset, responseRecorder.Header().Get("Sunset"))
+			assert.Equal(t, `</v1/transfers>; rel="successor-version"`, responseRecorder.Header().Get("Link"))
+		})
+	}
+}
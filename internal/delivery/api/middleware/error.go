@@ -0,0 +1,114 @@
+package middleware
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/fms85/desafio-tecnico-go-stone/internal/domain/common"
+	"github.com/fms85/desafio-tecnico-go-stone/internal/driver/i18n"
+	"github.com/fms85/desafio-tecnico-go-stone/internal/driver/logger"
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+)
+
+const INVALID_BODY_ERROR = "invalid request body"
+
+var statusByCode = map[string]int{
+	common.NOT_FOUND_CODE:          http.StatusNotFound,
+	common.CONFLICT_CODE:           http.StatusConflict,
+	common.INSUFFICIENT_FUNDS_CODE: http.StatusUnprocessableEntity,
+	common.LIMIT_EXCEEDED_CODE:     http.StatusUnprocessableEntity,
+	common.UNAUTHORIZED_CODE:       http.StatusUnauthorized,
+	common.FORBIDDEN_CODE:          http.StatusForbidden,
+	common.VALIDATION_CODE:         http.StatusBadRequest,
+}
+
+// Error renders the last error attached to the context with ctx.Error as a
+// problem+json response. Handlers must not write a body when they fail.
+func Error() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		ctx.Next()
+
+		if len(ctx.Errors) == 0 || ctx.Writer.Written() {
+			return
+		}
+
+		lang := i18n.Language(ctx.GetHeader("Accept-Language"))
+
+		err := ctx.Errors.Last()
+		if err.IsType(gin.ErrorTypeBind) {
+			err.Err = toValidationError(err.Err, lang)
+		}
+
+		problem := common.Problem{
+			Type:     common.PROBLEM_DEFAULT_TYPE,
+			Status:   http.StatusInternalServerError,
+			Detail:   common.INTERNAL_SERVER_ERROR,
+			Instance: ctx.Request.URL.Path,
+			Code:     common.INTERNAL_CODE,
+		}
+
+		var codedError common.CodedError
+		if errors.As(err.Err, &codedError) {
+			problem.Status = statusByCode[codedError.Code()]
+			problem.Detail = err.Error()
+			problem.Code = codedError.Code()
+
+			var validationError *common.ValidationError
+			if errors.As(err.Err, &validationError) {
+				problem.Errors = translateFields(lang, validationError.Fields)
+			}
+		} else {
+			logger.FromContext(ctx.Request.Context()).Error("unhandled error", "error", err.Err)
+		}
+
+		problem.Detail = i18n.Translate(lang, problem.Detail)
+		problem.Title = i18n.Translate(lang, http.StatusText(problem.Status))
+
+		ctx.Header("Content-Type", common.PROBLEM_CONTENT_TYPE)
+		ctx.Header("Content-Language", lang)
+		ctx.JSON(problem.Status, problem)
+	}
+}
+
+// translateFields localizes the messages of field errors raised by usecases.
+// Binding errors are already translated and are left as they are.
+func translateFields(lang string, fields []common.FieldError) []common.FieldError {
+	translated := make([]common.FieldError, 0, len(fields))
+	for _, field := range fields {
+		field.Message = i18n.Translate(lang, field.Message)
+		translated = append(translated, field)
+	}
+
+	return translated
+}
+
+func toValidationError(err error, lang string) error {
+	var validationErrors validator.ValidationErrors
+	if !errors.As(err, &validationErrors) {
+		return &common.ValidationError{Msg: INVALID_BODY_ERROR}
+	}
+
+	trans := i18n.Translator(lang)
+	fields := make([]common.FieldError, 0, len(validationErrors))
+	for _, fieldError := range validationErrors {
+		fields = append(fields, common.FieldError{
+			Field:   fieldPath(fieldError),
+			Code:    fieldError.Tag(),
+			Message: fieldError.Translate(trans),
+		})
+	}
+
+	return &common.ValidationError{Msg: common.VALIDATION_ERROR, Fields: fields}
+}
+
+// fieldPath is the path of the field from the bound struct, which tells
+// nested fields apart, like items[2].amount.
+func fieldPath(fieldError validator.FieldError) string {
+	if _, path, found := strings.Cut(fieldError.Namespace(), "."); found {
+		return path
+	}
+
+	return fieldError.Field()
+}
@@ -0,0 +1,116 @@
+package middleware
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/fms85/desafio-tecnico-go-stone/internal/domain/common"
+	"github.com/gin-gonic/gin"
+	"github.com/google/go-cmp/cmp"
+	"gotest.tools/assert"
+)
+
+func TestError(t *testing.T) {
+	tests := []struct {
+		name           string
+		acceptLanguage string
+		err            error
+		want           string
+		wantCode       int
+	}{
+		{
+			name:           "should_render_not_found_problem",
+			acceptLanguage: "en",
+			err:            fmt.Errorf("account %w", &common.NotFoundError{}),
+			want:           `{"type":"about:blank","title":"Not Found","status":404,"detail":"account not found","instance":"/problem","code":"NOT_FOUND"}`,
+			wantCode:       http.StatusNotFound,
+		},
+		{
+			name:           "should_render_conflict_problem",
+			acceptLanguage: "en",
+			err:            fmt.Errorf("account %w", &common.ConflictError{}),
+			want:           `{"type":"about:blank","title":"Conflict","status":409,"detail":"account already exists","instance":"/problem","code":"CONFLICT"}`,
+			wantCode:       http.StatusConflict,
+		},
+		{
+			name:           "should_render_insufficient_funds_problem",
+			acceptLanguage: "en",
+			err:            &common.InsufficientFundsError{},
+			want:           `{"type":"about:blank","title":"Unprocessable Entity","status":422,"detail":"insufficient funds","instance":"/problem","code":"INSUFFICIENT_FUNDS"}`,
+			wantCode:       http.StatusUnprocessableEntity,
+		},
+		{
+			name:           "should_render_limit_exceeded_problem",
+			acceptLanguage: "en",
+			err:            &common.LimitExceededError{Msg: "daily limit exceeded"},
+			want:           `{"type":"about:blank","title":"Unprocessable Entity","status":422,"detail":"daily limit exceeded","instance":"/problem","code":"LIMIT_EXCEEDED"}`,
+			wantCode:       http.StatusUnprocessableEntity,
+		},
+		{
+			name:           "should_render_unauthorized_problem",
+			acceptLanguage: "en",
+			err:            &common.UnauthorizedError{},
+			want:           `{"type":"about:blank","title":"Unauthorized","status":401,"detail":"unauthorized","instance":"/problem","code":"UNAUTHORIZED"}`,
+			wantCode:       http.StatusUnauthorized,
+		},
+		{
+			name:           "should_render_forbidden_problem",
+			acceptLanguage: "en",
+			err:            &common.ForbiddenError{},
+			want:           `{"type":"about:blank","title":"Forbidden","status":403,"detail":"forbidden","instance":"/problem","code":"FORBIDDEN"}`,
+			wantCode:       http.StatusForbidden,
+		},
+		{
+			name:           "should_render_validation_problem_with_fields",
+			acceptLanguage: "en",
+			err:            &common.ValidationError{Msg: common.VALIDATION_ERROR, Fields: []common.FieldError{{Field: "cpf", Code: "cpf", Message: "invalid"}}},
+			want:           `{"type":"about:blank","title":"Bad Request","status":400,"detail":"validation failed","instance":"/problem","code":"VALIDATION_ERROR","errors":[{"field":"cpf","code":"cpf","message":"invalid"}]}`,
+			wantCode:       http.StatusBadRequest,
+		},
+		{
+			name:           "should_hide_internal_errors",
+			acceptLanguage: "en",
+			err:            errors.New("pq: connection refused"),
+			want:           `{"type":"about:blank","title":"Internal Server Error","status":500,"detail":"internal server error","instance":"/problem","code":"INTERNAL_ERROR"}`,
+			wantCode:       http.StatusInternalServerError,
+		},
+		{
+			name:           "should_render_problem_in_portuguese_by_default",
+			acceptLanguage: "",
+			err:            fmt.Errorf("account origin %w", &common.NotFoundError{}),
+			want:           `{"type":"about:blank","title":"Não encontrado","status":404,"detail":"conta de origem não encontrada","instance":"/problem","code":"NOT_FOUND"}`,
+			wantCode:       http.StatusNotFound,
+		},
+		{
+			name:           "should_render_problem_in_portuguese_when_requested",
+			acceptLanguage: "pt;q=0.9, en;q=0.8",
+			err:            &common.InsufficientFundsError{},
+			want:           `{"type":"about:blank","title":"Entidade não processável","status":422,"detail":"saldo insuficiente","instance":"/problem","code":"INSUFFICIENT_FUNDS"}`,
+			wantCode:       http.StatusUnprocessableEntity,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router := gin.New()
+			router.Use(Error())
+			router.GET("/problem", func(ctx *gin.Context) {
+				_ = ctx.Error(tt.err)
+			})
+			responseRecorder := httptest.NewRecorder()
+
+			request, _ := http.NewRequest("GET", "/problem", nil)
+			request.Header.Set("Accept-Language", tt.acceptLanguage)
+
+			router.ServeHTTP(responseRecorder, request)
+			assert.Equal(t, tt.wantCode, responseRecorder.Code)
+			assert.Equal(t, common.PROBLEM_CONTENT_TYPE, responseRecorder.Header().Get("Content-Type"))
+
+			if diff := cmp.Diff(responseRecorder.Body.String(), tt.want); diff != "" {
+				t.Error(diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,29 @@
+package middleware
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/fms85/desafio-tecnico-go-stone/internal/driver/prometheus"
+	"github.com/gin-gonic/gin"
+)
+
+const UNMATCHED_ROUTE = "unmatched"
+
+func Metrics() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		start := time.Now()
+
+		ctx.Next()
+
+		route := ctx.FullPath()
+		if route == "" {
+			route = UNMATCHED_ROUTE
+		}
+
+		status := strconv.Itoa(ctx.Writer.Status())
+
+		prometheus.HttpRequestsTotal.WithLabelValues(ctx.Request.Method, route, status).Inc()
+		prometheus.HttpRequestDuration.WithLabelValues(ctx.Request.Method, route, status).Observe(time.Since(start).Seconds())
+	}
+}
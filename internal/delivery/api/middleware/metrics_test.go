@@ -0,0 +1,51 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/fms85/desafio-tecnico-go-stone/internal/driver/prometheus"
+	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMetrics(t *testing.T) {
+	tests := []struct {
+		name       string
+		path       string
+		wantRoute  string
+		wantStatus string
+	}{
+		{
+			name:       "should_count_requests_by_route_template",
+			path:       "/accounts/1/balance",
+			wantRoute:  "/accounts/:account_id/balance",
+			wantStatus: "200",
+		},
+		{
+			name:       "should_count_unmatched_requests",
+			path:       "/unknown",
+			wantRoute:  UNMATCHED_ROUTE,
+			wantStatus: "404",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router := gin.New()
+			router.Use(Metrics())
+			router.GET("/accounts/:account_id/balance", func(ctx *gin.Context) {
+				ctx.Status(http.StatusOK)
+			})
+
+			counter := prometheus.HttpRequestsTotal.WithLabelValues("GET", tt.wantRoute, tt.wantStatus)
+			before := testutil.ToFloat64(counter)
+
+			request, _ := http.NewRequest("GET", tt.path, nil)
+			router.ServeHTTP(httptest.NewRecorder(), request)
+
+			assert.Equal(t, before+1, testutil.ToFloat64(counter))
+		})
+	}
+}
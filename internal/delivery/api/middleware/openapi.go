@@ -0,0 +1,69 @@
+package middleware
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/fms85/desafio-tecnico-go-stone/internal/domain/common"
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/getkin/kin-openapi/openapi3filter"
+	"github.com/getkin/kin-openapi/routers/gorillamux"
+	"github.com/gin-gonic/gin"
+)
+
+// OpenAPI rejects requests whose parameters or body do not match the
+// OpenAPI document. Routes missing from the document are left to gin.
+func OpenAPI(doc *openapi3.T) gin.HandlerFunc {
+	router, err := gorillamux.NewRouter(doc)
+	if err != nil {
+		panic(err)
+	}
+
+	options := &openapi3filter.Options{
+		AuthenticationFunc: openapi3filter.NoopAuthenticationFunc,
+	}
+
+	return func(ctx *gin.Context) {
+		route, pathParams, err := router.FindRoute(ctx.Request)
+		if err != nil {
+			ctx.Next()
+			return
+		}
+
+		input := &openapi3filter.RequestValidationInput{
+			Request:    ctx.Request,
+			PathParams: pathParams,
+			Route:      route,
+			Options:    options,
+		}
+
+		if err := openapi3filter.ValidateRequest(ctx.Request.Context(), input); err != nil {
+			_ = ctx.Error(toOpenAPIValidationError(err))
+			ctx.Abort()
+			return
+		}
+
+		ctx.Next()
+	}
+}
+
+func toOpenAPIValidationError(err error) error {
+	fieldError := common.FieldError{Code: "schema", Message: err.Error()}
+
+	var requestError *openapi3filter.RequestError
+	if errors.As(err, &requestError) {
+		fieldError.Message = requestError.Reason
+		if requestError.Parameter != nil {
+			fieldError.Field = requestError.Parameter.Name
+		}
+
+		var schemaError *openapi3.SchemaError
+		if errors.As(requestError.Err, &schemaError) {
+			fieldError.Field = strings.Join(schemaError.JSONPointer(), ".")
+			fieldError.Code = schemaError.SchemaField
+			fieldError.Message = schemaError.Reason
+		}
+	}
+
+	return &common.ValidationError{Msg: common.VALIDATION_ERROR, Fields: []common.FieldError{fieldError}}
+}
@@ -0,0 +1,95 @@
+package middleware
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/gin-gonic/gin"
+	"github.com/google/go-cmp/cmp"
+	"gotest.tools/assert"
+)
+
+const testSpec = `
+openapi: 3.0.3
+info:
+  title: test
+  version: 1.0.0
+servers:
+- url: /
+paths:
+  /transfers:
+    post:
+      requestBody:
+        required: true
+        content:
+          application/json:
+            schema:
+              type: object
+              required: [account_destination_id, amount]
+              properties:
+                account_destination_id:
+                  type: integer
+                amount:
+                  type: number
+      responses:
+        '201':
+          description: created
+`
+
+func TestOpenAPI(t *testing.T) {
+	doc, err := openapi3.NewLoader().LoadFromData([]byte(testSpec))
+	assert.NilError(t, err)
+
+	tests := []struct {
+		name     string
+		path     string
+		body     []byte
+		want     string
+		wantCode int
+	}{
+		{
+			name:     "should_accept_request_matching_spec",
+			path:     "/transfers",
+			body:     []byte(`{"account_destination_id": 2,"amount": 1}`),
+			want:     ``,
+			wantCode: http.StatusCreated,
+		},
+		{
+			name:     "should_reject_request_body_violating_spec",
+			path:     "/transfers",
+			body:     []byte(`{"account_destination_id": "two","amount": 1}`),
+			want:     `{"type":"about:blank","title":"Bad Request","status":400,"detail":"validation failed","instance":"/transfers","code":"VALIDATION_ERROR","errors":[{"field":"account_destination_id","code":"type","message":"value must be an integer"}]}`,
+			wantCode: http.StatusBadRequest,
+		},
+		{
+			name:     "should_ignore_routes_missing_from_spec",
+			path:     "/undocumented",
+			body:     []byte(`not json`),
+			want:     ``,
+			wantCode: http.StatusCreated,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router := gin.New()
+			router.Use(Error(), OpenAPI(doc))
+			router.POST("/transfers", func(ctx *gin.Context) { ctx.Status(http.StatusCreated) })
+			router.POST("/undocumented", func(ctx *gin.Context) { ctx.Status(http.StatusCreated) })
+			responseRecorder := httptest.NewRecorder()
+
+			request, _ := http.NewRequest("POST", tt.path, bytes.NewReader(tt.body))
+			request.Header.Set("Content-Type", "application/json")
+			request.Header.Set("Accept-Language", "en")
+
+			router.ServeHTTP(responseRecorder, request)
+			assert.Equal(t, tt.wantCode, responseRecorder.Code)
+
+			if diff := cmp.Diff(responseRecorder.Body.String(), tt.want); diff != "" {
+				t.Error(diff)
+			}
+		})
+	}
+}
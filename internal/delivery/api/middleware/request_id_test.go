@@ -0,0 +1,58 @@
+package middleware
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/fms85/desafio-tecnico-go-stone/internal/driver/logger"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRequestID(t *testing.T) {
+	tests := []struct {
+		name      string
+		requestID string
+		wantSame  bool
+	}{
+		{
+			name:      "should_propagate_incoming_request_id",
+			requestID: "abc-123",
+			wantSame:  true,
+		},
+		{
+			name:      "should_generate_request_id_when_missing",
+			requestID: "",
+			wantSame:  false,
+		},
+		{
+			name:      "should_replace_invalid_request_id",
+			requestID: "bad id\nwith newline",
+			wantSame:  false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			router := gin.New()
+			router.Use(RequestID(logger.New(&buf, "info")))
+			router.GET("/ping", func(ctx *gin.Context) {
+				logger.FromContext(ctx.Request.Context()).Info("ping")
+				ctx.Status(http.StatusNoContent)
+			})
+			responseRecorder := httptest.NewRecorder()
+
+			request, _ := http.NewRequest("GET", "/ping", nil)
+			request.Header.Set(REQUEST_ID_HEADER, tt.requestID)
+
+			router.ServeHTTP(responseRecorder, request)
+
+			got := responseRecorder.Header().Get(REQUEST_ID_HEADER)
+			assert.NotEmpty(t, got)
+			assert.Equal(t, tt.wantSame, got == tt.requestID)
+			assert.Contains(t, buf.String(), `"request_id":"`+got+`"`)
+		})
+	}
+}
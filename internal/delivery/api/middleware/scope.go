@@ -0,0 +1,34 @@
+package middleware
+
+import (
+	"slices"
+
+	"github.com/fms85/desafio-tecnico-go-stone/internal/domain/common"
+	"github.com/fms85/desafio-tecnico-go-stone/internal/domain/entity"
+	"github.com/gin-gonic/gin"
+)
+
+const SCOPE_REQUIRED_ERROR = "api key not allowed on this route"
+
+// Scope opens to API keys the routes of scopes, keyed by method and full
+// path, whose scope they were granted. It runs after Auth. Routes missing
+// from scopes are refused to every API key, and account tokens go through
+// untouched.
+func Scope(scopes map[string]string) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		apiKey, ok := ctx.Get("api_key")
+		if !ok {
+			ctx.Next()
+			return
+		}
+
+		scope, ok := scopes[ctx.Request.Method+" "+ctx.FullPath()]
+		if !ok || !slices.Contains(apiKey.(*entity.APIKey).Scopes, scope) {
+			_ = ctx.Error(&common.ForbiddenError{Msg: SCOPE_REQUIRED_ERROR})
+			ctx.Abort()
+			return
+		}
+
+		ctx.Next()
+	}
+}
@@ -0,0 +1,80 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/fms85/desafio-tecnico-go-stone/internal/domain/entity"
+	jwtDriver "github.com/fms85/desafio-tecnico-go-stone/internal/driver/jwt"
+	"github.com/fms85/desafio-tecnico-go-stone/internal/util"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestScope(t *testing.T) {
+	secret := "2aa5b62a718429b0645dc1be1bcac023821181859a181408b59c77d7c07d5349"
+	scopes := map[string]string{
+		"GET /accounts/:account_id/balance": entity.API_KEY_SCOPE_BALANCE_READ,
+		"POST /transfers":                   entity.API_KEY_SCOPE_TRANSFERS_WRITE,
+	}
+
+	tests := []struct {
+		name     string
+		method   string
+		path     string
+		token    string
+		wantCode int
+	}{
+		{
+			name:     "should_let_api_keys_use_routes_of_their_scopes",
+			method:   "GET",
+			path:     "/accounts/3/balance",
+			wantCode: http.StatusNoContent,
+		},
+		{
+			name:     "should_refuse_api_keys_routes_of_other_scopes",
+			method:   "POST",
+			path:     "/transfers",
+			wantCode: http.StatusForbidden,
+		},
+		{
+			name:     "should_refuse_api_keys_routes_without_scope",
+			method:   "GET",
+			path:     "/webhooks",
+			wantCode: http.StatusForbidden,
+		},
+		{
+			name:     "should_let_tokens_use_any_route",
+			method:   "GET",
+			path:     "/webhooks",
+			token:    util.GenerateJwtToken("account_id", 3, secret),
+			wantCode: http.StatusNoContent,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router := gin.New()
+			router.Use(Error(), Auth(jwtDriver.NewHS256(secret), apiKeysTestData), Scope(scopes))
+			for _, route := range []string{"GET /accounts/:account_id/balance", "POST /transfers", "GET /webhooks"} {
+				method, path, _ := strings.Cut(route, " ")
+				router.Handle(method, path, func(ctx *gin.Context) {
+					ctx.Status(http.StatusNoContent)
+				})
+			}
+			responseRecorder := httptest.NewRecorder()
+
+			request, _ := http.NewRequest(tt.method, tt.path, nil)
+			if tt.token != "" {
+				request.Header.Set("Authorization", "Bearer "+tt.token)
+			} else {
+				request.SetBasicAuth("ak_reader", "sk_test")
+			}
+
+			router.ServeHTTP(responseRecorder, request)
+
+			assert.Equal(t, tt.wantCode, responseRecorder.Code)
+		})
+	}
+}
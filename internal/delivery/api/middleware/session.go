@@ -0,0 +1,35 @@
+package middleware
+
+import (
+	"context"
+
+	"github.com/fms85/desafio-tecnico-go-stone/internal/util"
+	"github.com/gin-gonic/gin"
+)
+
+type SessionValidator interface {
+	ValidateSession(ctx context.Context, accountID uint, tokenVersion uint) error
+}
+
+// Session rejects tokens revoked since they were issued. It runs after Auth,
+// from the account id and token version Auth took from the token. API keys
+// have no version and Auth already refused the revoked ones.
+func Session(sessions SessionValidator) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		if _, ok := ctx.Get("api_key"); ok {
+			ctx.Next()
+			return
+		}
+
+		accountID := util.StringToUint(ctx.MustGet("account_id").(string))
+		tokenVersion := util.StringToUint(ctx.MustGet("token_version").(string))
+
+		if err := sessions.ValidateSession(ctx.Request.Context(), accountID, tokenVersion); err != nil {
+			_ = ctx.Error(err)
+			ctx.Abort()
+			return
+		}
+
+		ctx.Next()
+	}
+}
@@ -0,0 +1,98 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/fms85/desafio-tecnico-go-stone/internal/domain/common"
+	"github.com/fms85/desafio-tecnico-go-stone/internal/domain/entity"
+	jwtDriver "github.com/fms85/desafio-tecnico-go-stone/internal/driver/jwt"
+	"github.com/fms85/desafio-tecnico-go-stone/internal/util"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+type sessionsTest map[uint]uint
+
+func (sessions sessionsTest) ValidateSession(_ context.Context, accountID uint, tokenVersion uint) error {
+	if version, ok := sessions[accountID]; !ok || version != tokenVersion {
+		return &common.UnauthorizedError{Msg: "session revoked"}
+	}
+
+	return nil
+}
+
+type apiKeysTest map[string]*entity.APIKey
+
+func (apiKeys apiKeysTest) Authenticate(_ context.Context, keyID string, secret string) (*entity.APIKey, error) {
+	apiKey, ok := apiKeys[keyID]
+	if !ok || secret != "sk_test" {
+		return nil, &common.UnauthorizedError{Msg: "invalid api key"}
+	}
+
+	return apiKey, nil
+}
+
+var apiKeysTestData = apiKeysTest{
+	"ak_reader": {AccountID: 3, KeyID: "ak_reader", Scopes: []string{entity.API_KEY_SCOPE_BALANCE_READ}},
+}
+
+func TestSession(t *testing.T) {
+	secret := "2aa5b62a718429b0645dc1be1bcac023821181859a181408b59c77d7c07d5349"
+
+	tests := []struct {
+		name     string
+		token    string
+		apiKey   [2]string
+		wantCode int
+	}{
+		{
+			name:     "should_accept_tokens_without_version_of_accounts_at_version_zero",
+			token:    util.GenerateJwtToken("account_id", 3, secret),
+			wantCode: http.StatusNoContent,
+		},
+		{
+			name:     "should_accept_tokens_of_the_current_version",
+			token:    util.GenerateJwtClaims(map[string]interface{}{"account_id": 4, "token_version": 2}, secret),
+			wantCode: http.StatusNoContent,
+		},
+		{
+			name:     "should_reject_tokens_of_an_old_version",
+			token:    util.GenerateJwtClaims(map[string]interface{}{"account_id": 4, "token_version": 1}, secret),
+			wantCode: http.StatusUnauthorized,
+		},
+		{
+			name:     "should_accept_api_keys_without_version",
+			apiKey:   [2]string{"ak_reader", "sk_test"},
+			wantCode: http.StatusNoContent,
+		},
+		{
+			name:     "should_reject_api_keys_with_a_wrong_secret",
+			apiKey:   [2]string{"ak_reader", "sk_other"},
+			wantCode: http.StatusUnauthorized,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router := gin.New()
+			router.Use(Error(), Auth(jwtDriver.NewHS256(secret), apiKeysTestData), Session(sessionsTest{3: 0, 4: 2}))
+			router.GET("/ping", func(ctx *gin.Context) {
+				ctx.Status(http.StatusNoContent)
+			})
+			responseRecorder := httptest.NewRecorder()
+
+			request, _ := http.NewRequest("GET", "/ping", nil)
+			if tt.apiKey[0] != "" {
+				request.SetBasicAuth(tt.apiKey[0], tt.apiKey[1])
+			} else {
+				request.Header.Set("Authorization", "Bearer "+tt.token)
+			}
+
+			router.ServeHTTP(responseRecorder, request)
+
+			assert.Equal(t, tt.wantCode, responseRecorder.Code)
+		})
+	}
+}
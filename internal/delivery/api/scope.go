@@ -0,0 +1,18 @@
+package api
+
+import (
+	"github.com/fms85/desafio-tecnico-go-stone/internal/domain/entity"
+)
+
+// API_KEY_SCOPES lists the routes API keys may call, by method and full
+// path, with the scope each one takes. Only /v1 routes are listed: legacy
+// routes, like any route missing here, are refused to API keys.
+var API_KEY_SCOPES = map[string]string{
+	"GET /v1/accounts/me/balance":       entity.API_KEY_SCOPE_BALANCE_READ,
+	"GET /v1/accounts/me/statement":     entity.API_KEY_SCOPE_BALANCE_READ,
+	"GET /v1/transfers":                 entity.API_KEY_SCOPE_TRANSFERS_READ,
+	"GET /v1/transfers/:transfer_id":    entity.API_KEY_SCOPE_TRANSFERS_READ,
+	"GET /v1/transfers/batch/:batch_id": entity.API_KEY_SCOPE_TRANSFERS_READ,
+	"POST /v1/transfers":                entity.API_KEY_SCOPE_TRANSFERS_WRITE,
+	"POST /v1/transfers/batch":          entity.API_KEY_SCOPE_TRANSFERS_WRITE,
+}
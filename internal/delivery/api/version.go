@@ -0,0 +1,32 @@
+package api
+
+import (
+	"github.com/gin-gonic/gin"
+)
+
+const API_VERSION_V1 = "v1"
+
+type routesHandler interface {
+	InitRoutes(router *gin.RouterGroup)
+}
+
+// version groups the handlers served under one URL prefix. A new major
+// version only needs to list the handlers that changed; the others keep
+// being served by the previous versions side by side.
+type version struct {
+	name       string
+	public     []routesHandler
+	authorized []routesHandler
+}
+
+func (v version) register(router *gin.RouterGroup, auth ...gin.HandlerFunc) {
+	group := router.Group(v.name)
+	for _, handler := range v.public {
+		handler.InitRoutes(group)
+	}
+
+	authorized := group.Group("/", auth...)
+	for _, handler := range v.authorized {
+		handler.InitRoutes(authorized)
+	}
+}
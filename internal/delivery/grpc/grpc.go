@@ -0,0 +1,89 @@
+package grpc
+
+import (
+	"fmt"
+	"net"
+
+	accountHandler "github.com/fms85/desafio-tecnico-go-stone/internal/delivery/grpc/handler/account"
+	transferHandler "github.com/fms85/desafio-tecnico-go-stone/internal/delivery/grpc/handler/transfer"
+	"github.com/fms85/desafio-tecnico-go-stone/internal/delivery/grpc/interceptor"
+	bankv1 "github.com/fms85/desafio-tecnico-go-stone/internal/delivery/grpc/pb/bank/v1"
+	"github.com/fms85/desafio-tecnico-go-stone/internal/domain/common"
+	"github.com/fms85/desafio-tecnico-go-stone/internal/domain/entity"
+	ginDriver "github.com/fms85/desafio-tecnico-go-stone/internal/driver/gin"
+	accountRepository "github.com/fms85/desafio-tecnico-go-stone/internal/repository/account"
+	apiKeyRepository "github.com/fms85/desafio-tecnico-go-stone/internal/repository/apikey"
+	pixKeyRepository "github.com/fms85/desafio-tecnico-go-stone/internal/repository/pixkey"
+	transferRepository "github.com/fms85/desafio-tecnico-go-stone/internal/repository/transfer"
+	accountUsecase "github.com/fms85/desafio-tecnico-go-stone/internal/usecase/account"
+	apiKeyUsecase "github.com/fms85/desafio-tecnico-go-stone/internal/usecase/apikey"
+	pixKeyUsecase "github.com/fms85/desafio-tecnico-go-stone/internal/usecase/pixkey"
+	transferUsecase "github.com/fms85/desafio-tecnico-go-stone/internal/usecase/transfer"
+	"github.com/fms85/desafio-tecnico-go-stone/internal/util"
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
+	googleGrpc "google.golang.org/grpc"
+	"google.golang.org/grpc/reflection"
+	reflectionV1 "google.golang.org/grpc/reflection/grpc_reflection_v1"
+	reflectionV1Alpha "google.golang.org/grpc/reflection/grpc_reflection_v1alpha"
+)
+
+// PUBLIC_SERVICES can be called without a bearer token, like the public
+// HTTP routes.
+var PUBLIC_SERVICES = []string{
+	bankv1.AccountService_ServiceDesc.ServiceName,
+	bankv1.LoginService_ServiceDesc.ServiceName,
+	reflectionV1.ServerReflection_ServiceDesc.ServiceName,
+	reflectionV1Alpha.ServerReflection_ServiceDesc.ServiceName,
+}
+
+// API_KEY_SCOPES lists the methods API keys may call with the scope each
+// one takes, like the routes of the HTTP API.
+var API_KEY_SCOPES = map[string]string{
+	bankv1.TransferService_ListTransfers_FullMethodName:  entity.API_KEY_SCOPE_TRANSFERS_READ,
+	bankv1.TransferService_CreateTransfer_FullMethodName: entity.API_KEY_SCOPE_TRANSFERS_WRITE,
+}
+
+func Init(app common.App) {
+	listener, err := net.Listen("tcp", fmt.Sprintf(":%s", app.Env.GRPC_ADDR))
+	if err != nil {
+		panic(err)
+	}
+
+	app.Logger.Info("grpc server listening", "addr", listener.Addr().String())
+
+	if err := Setup(app).Serve(listener); err != nil {
+		panic(err)
+	}
+}
+
+func Setup(app common.App) *googleGrpc.Server {
+	ginDriver.SetupValidator()
+
+	accountRepository := accountRepository.New(app.DB)
+	transferRepository := transferRepository.New(app.DB)
+	pixKeyRepository := pixKeyRepository.New(app.DB)
+	apiKeyRepository := apiKeyRepository.New(app.DB)
+
+	accountUsecase := accountUsecase.New(accountRepository, app.Notifier)
+	pixKeyUsecase := pixKeyUsecase.New(pixKeyRepository, accountUsecase, app.Notifier)
+	transferUsecase := transferUsecase.New(transferRepository, accountUsecase, pixKeyUsecase, app.Bus, app.Env.RECEIPT_SECRET, util.ParseAmount(app.Env.TOTP_STEP_UP_AMOUNT))
+	apiKeyUsecase := apiKeyUsecase.New(apiKeyRepository, accountUsecase)
+
+	server := googleGrpc.NewServer(
+		googleGrpc.StatsHandler(otelgrpc.NewServerHandler()),
+		googleGrpc.ChainUnaryInterceptor(
+			interceptor.Logger(app.Logger),
+			interceptor.Error(),
+			interceptor.Auth(app.JWT, apiKeyUsecase, PUBLIC_SERVICES...),
+			interceptor.Session(accountUsecase, PUBLIC_SERVICES...),
+			interceptor.Scope(API_KEY_SCOPES),
+		),
+	)
+
+	accountHandler.New(accountUsecase, app.JWT).Register(server)
+	transferHandler.New(transferUsecase).Register(server)
+
+	reflection.Register(server)
+
+	return server
+}
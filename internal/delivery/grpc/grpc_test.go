@@ -0,0 +1,88 @@
+package grpc
+
+import (
+	"context"
+	"io"
+	"net"
+	"sort"
+	"testing"
+
+	bankv1 "github.com/fms85/desafio-tecnico-go-stone/internal/delivery/grpc/pb/bank/v1"
+	"github.com/fms85/desafio-tecnico-go-stone/internal/domain/common"
+	jwtDriver "github.com/fms85/desafio-tecnico-go-stone/internal/driver/jwt"
+	"github.com/fms85/desafio-tecnico-go-stone/internal/driver/logger"
+	googleGrpc "google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	reflectionV1 "google.golang.org/grpc/reflection/grpc_reflection_v1"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	"gotest.tools/assert"
+)
+
+func TestServer(t *testing.T) {
+	conn := dial(t)
+
+	t.Run("should_list_services_through_reflection", func(t *testing.T) {
+		stream, err := reflectionV1.NewServerReflectionClient(conn).ServerReflectionInfo(context.Background())
+		assert.NilError(t, err)
+
+		err = stream.Send(&reflectionV1.ServerReflectionRequest{
+			MessageRequest: &reflectionV1.ServerReflectionRequest_ListServices{},
+		})
+		assert.NilError(t, err)
+
+		response, err := stream.Recv()
+		assert.NilError(t, err)
+
+		var services []string
+		for _, service := range response.GetListServicesResponse().GetService() {
+			services = append(services, service.GetName())
+		}
+		sort.Strings(services)
+
+		assert.DeepEqual(t, []string{
+			"bank.v1.AccountService",
+			"bank.v1.LoginService",
+			"bank.v1.TransferService",
+			"grpc.reflection.v1.ServerReflection",
+			"grpc.reflection.v1alpha.ServerReflection",
+		}, services)
+	})
+
+	t.Run("should_require_token_for_transfers", func(t *testing.T) {
+		_, err := bankv1.NewTransferServiceClient(conn).ListTransfers(context.Background(), &bankv1.ListTransfersRequest{})
+
+		assert.Equal(t, codes.Unauthenticated, status.Code(err))
+	})
+
+	t.Run("should_validate_login_without_token", func(t *testing.T) {
+		_, err := bankv1.NewLoginServiceClient(conn).Login(context.Background(), &bankv1.LoginRequest{})
+
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+}
+
+func dial(t *testing.T) *googleGrpc.ClientConn {
+	listener := bufconn.Listen(1024 * 1024)
+	server := Setup(common.App{
+		Logger: logger.New(io.Discard, "error"),
+		JWT:    jwtDriver.NewHS256("secret"),
+	})
+	go func() {
+		_ = server.Serve(listener)
+	}()
+	t.Cleanup(server.Stop)
+
+	conn, err := googleGrpc.Dial(
+		"bufnet",
+		googleGrpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		}),
+		googleGrpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	assert.NilError(t, err)
+	t.Cleanup(func() { _ = conn.Close() })
+
+	return conn
+}
@@ -0,0 +1,165 @@
+package account
+
+import (
+	"context"
+	"errors"
+
+	bankv1 "github.com/fms85/desafio-tecnico-go-stone/internal/delivery/grpc/pb/bank/v1"
+	"github.com/fms85/desafio-tecnico-go-stone/internal/domain/common"
+	"github.com/fms85/desafio-tecnico-go-stone/internal/domain/entity"
+	"github.com/fms85/desafio-tecnico-go-stone/internal/domain/types"
+	jwtDriver "github.com/fms85/desafio-tecnico-go-stone/internal/driver/jwt"
+	"github.com/fms85/desafio-tecnico-go-stone/internal/driver/prometheus"
+	accountUsecase "github.com/fms85/desafio-tecnico-go-stone/internal/usecase/account"
+	"github.com/fms85/desafio-tecnico-go-stone/internal/util"
+	"github.com/gin-gonic/gin/binding"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+var notFoundError *common.NotFoundError
+
+type AccountHandler struct {
+	bankv1.UnimplementedAccountServiceServer
+	bankv1.UnimplementedLoginServiceServer
+	accountUsecase accountUsecase.IAccountUsecase
+	keys           jwtDriver.IKeySet
+}
+
+func New(accountUsecase accountUsecase.IAccountUsecase, keys jwtDriver.IKeySet) *AccountHandler {
+	return &AccountHandler{
+		accountUsecase: accountUsecase,
+		keys:           keys,
+	}
+}
+
+func (handler *AccountHandler) Register(server grpc.ServiceRegistrar) {
+	bankv1.RegisterAccountServiceServer(server, handler)
+	bankv1.RegisterLoginServiceServer(server, handler)
+}
+
+func (handler *AccountHandler) ListAccounts(ctx context.Context, _ *bankv1.ListAccountsRequest) (*bankv1.ListAccountsResponse, error) {
+	accounts, err := handler.accountUsecase.GetAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &bankv1.ListAccountsResponse{Accounts: make([]*bankv1.Account, 0, len(accounts))}
+	for _, account := range accounts {
+		response.Accounts = append(response.Accounts, toAccount(account))
+	}
+
+	return response, nil
+}
+
+func (handler *AccountHandler) GetBalance(ctx context.Context, request *bankv1.GetBalanceRequest) (*bankv1.GetBalanceResponse, error) {
+	account, err := handler.accountUsecase.Get(ctx, types.AccountInput{ID: uint(request.GetAccountId())})
+	if err != nil {
+		return nil, err
+	}
+
+	return &bankv1.GetBalanceResponse{Balance: account.Balance}, nil
+}
+
+func (handler *AccountHandler) CreateAccount(ctx context.Context, request *bankv1.CreateAccountRequest) (*bankv1.CreateAccountResponse, error) {
+	accountInput := types.AccountInput{
+		Type:      request.GetType(),
+		Name:      request.GetName(),
+		CPF:       request.GetCpf(),
+		CNPJ:      request.GetCnpj(),
+		LegalName: request.GetLegalName(),
+		TradeName: request.GetTradeName(),
+		Secret:    request.GetSecret(),
+	}
+	if err := binding.Validator.ValidateStruct(&accountInput); err != nil {
+		return nil, err
+	}
+
+	account, err := handler.accountUsecase.Create(ctx, accountInput)
+	if err != nil {
+		return nil, err
+	}
+
+	return &bankv1.CreateAccountResponse{Account: toAccount(account)}, nil
+}
+
+func (handler *AccountHandler) Login(ctx context.Context, request *bankv1.LoginRequest) (*bankv1.LoginResponse, error) {
+	credentialsInput := types.CredentialsInput{
+		CPF:    request.GetCpf(),
+		CNPJ:   request.GetCnpj(),
+		Secret: request.GetSecret(),
+	}
+	if err := binding.Validator.ValidateStruct(&credentialsInput); err != nil {
+		return nil, err
+	}
+
+	account, err := handler.accountUsecase.Get(
+		ctx,
+		types.AccountInput{CPF: credentialsInput.CPF, CNPJ: credentialsInput.CNPJ, Secret: util.GenerateHash(credentialsInput.Secret)},
+	)
+	if err != nil {
+		if errors.As(err, &notFoundError) {
+			prometheus.ObserveLogin(prometheus.LOGIN_RESULT_FAILURE)
+
+			return nil, &common.UnauthorizedError{Msg: "invalid credentials"}
+		}
+
+		prometheus.ObserveLogin(prometheus.LOGIN_RESULT_ERROR)
+
+		return nil, err
+	}
+
+	prometheus.ObserveLogin(prometheus.LOGIN_RESULT_SUCCESS)
+
+	if account.TOTPEnabled {
+		challenge, err := handler.accountUsecase.CreateLoginChallenge(ctx, account)
+		if err != nil {
+			return nil, err
+		}
+
+		return &bankv1.LoginResponse{Challenge: challenge.Challenge, ChallengeExpiresAt: timestamppb.New(challenge.ExpiresAt)}, nil
+	}
+
+	token, err := handler.keys.Sign(types.AccountTokenClaims(account))
+	if err != nil {
+		return nil, err
+	}
+
+	return &bankv1.LoginResponse{Token: token}, nil
+}
+
+func (handler *AccountHandler) LoginTotp(ctx context.Context, request *bankv1.LoginTotpRequest) (*bankv1.LoginResponse, error) {
+	loginChallengeInput := types.LoginChallengeInput{
+		Challenge: request.GetChallenge(),
+		Code:      request.GetCode(),
+	}
+	if err := binding.Validator.ValidateStruct(&loginChallengeInput); err != nil {
+		return nil, err
+	}
+
+	account, err := handler.accountUsecase.CompleteLoginChallenge(ctx, loginChallengeInput)
+	if err != nil {
+		return nil, err
+	}
+
+	token, err := handler.keys.Sign(types.AccountTokenClaims(account))
+	if err != nil {
+		return nil, err
+	}
+
+	return &bankv1.LoginResponse{Token: token}, nil
+}
+
+func toAccount(account *entity.Account) *bankv1.Account {
+	return &bankv1.Account{
+		Id:        uint64(account.ID),
+		Name:      account.Name,
+		Cpf:       account.CPF,
+		Balance:   account.Balance,
+		CreatedAt: timestamppb.New(account.CreatedAt),
+		Type:      account.Type,
+		Cnpj:      account.CNPJ,
+		LegalName: account.LegalName,
+		TradeName: account.TradeName,
+	}
+}
@@ -0,0 +1,223 @@
+package account
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	bankv1 "github.com/fms85/desafio-tecnico-go-stone/internal/delivery/grpc/pb/bank/v1"
+	"github.com/fms85/desafio-tecnico-go-stone/internal/domain/common"
+	"github.com/fms85/desafio-tecnico-go-stone/internal/domain/entity"
+	"github.com/fms85/desafio-tecnico-go-stone/internal/domain/types"
+	ginDriver "github.com/fms85/desafio-tecnico-go-stone/internal/driver/gin"
+	jwtDriver "github.com/fms85/desafio-tecnico-go-stone/internal/driver/jwt"
+	accountUsecase "github.com/fms85/desafio-tecnico-go-stone/internal/usecase/account"
+	"github.com/fms85/desafio-tecnico-go-stone/internal/util"
+	"github.com/go-playground/validator/v10"
+	"github.com/google/go-cmp/cmp"
+	"github.com/stretchr/testify/mock"
+	"google.golang.org/protobuf/testing/protocmp"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"gotest.tools/assert"
+)
+
+func TestAccountHandlerListAccounts(t *testing.T) {
+	tests := []struct {
+		name           string
+		accountUsecase func() *accountUsecase.AccountUsecaseMock
+		want           *bankv1.ListAccountsResponse
+		wantErr        bool
+	}{
+		{
+			name: "should_list_accounts_successfully",
+			accountUsecase: func() *accountUsecase.AccountUsecaseMock {
+				usecase := &accountUsecase.AccountUsecaseMock{}
+				usecase.On("GetAll", mock.Anything).Return([]*entity.Account{getAccountTest(1)}, nil)
+
+				return usecase
+			},
+			want: &bankv1.ListAccountsResponse{Accounts: []*bankv1.Account{
+				{Id: 1, Name: "Loren", Cpf: "25462557035", Balance: 100, CreatedAt: timestamppb.New(getAccountTest(1).CreatedAt)},
+			}},
+		},
+		{
+			name: "should_return_usecase_error",
+			accountUsecase: func() *accountUsecase.AccountUsecaseMock {
+				usecase := &accountUsecase.AccountUsecaseMock{}
+				usecase.On("GetAll", mock.Anything).Return(nil, errors.New(""))
+
+				return usecase
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := New(tt.accountUsecase(), jwtDriver.NewHS256("")).ListAccounts(context.Background(), &bankv1.ListAccountsRequest{})
+
+			assert.Equal(t, tt.wantErr, err != nil)
+			if diff := cmp.Diff(tt.want, got, protocmp.Transform()); diff != "" {
+				t.Error(diff)
+			}
+		})
+	}
+}
+
+func TestAccountHandlerCreateAccount(t *testing.T) {
+	ginDriver.SetupValidator()
+
+	tests := []struct {
+		name           string
+		request        *bankv1.CreateAccountRequest
+		accountUsecase func() *accountUsecase.AccountUsecaseMock
+		wantID         uint64
+		wantErr        func(err error) bool
+	}{
+		{
+			name:    "should_create_account_successfully",
+			request: &bankv1.CreateAccountRequest{Name: "Loren", Cpf: "25462557035", Secret: "123456"},
+			accountUsecase: func() *accountUsecase.AccountUsecaseMock {
+				usecase := &accountUsecase.AccountUsecaseMock{}
+				usecase.On("Create", mock.Anything, types.AccountInput{Name: "Loren", CPF: "25462557035", Secret: "123456"}).Return(getAccountTest(1), nil)
+
+				return usecase
+			},
+			wantID: 1,
+		},
+		{
+			name:    "should_reject_invalid_cpf",
+			request: &bankv1.CreateAccountRequest{Name: "Loren", Cpf: "xxxxxxxxxx", Secret: "123456"},
+			accountUsecase: func() *accountUsecase.AccountUsecaseMock {
+				return &accountUsecase.AccountUsecaseMock{}
+			},
+			wantErr: func(err error) bool {
+				var validationErrors validator.ValidationErrors
+
+				return errors.As(err, &validationErrors)
+			},
+		},
+		{
+			name:    "should_return_conflict_when_account_already_exists",
+			request: &bankv1.CreateAccountRequest{Name: "Loren", Cpf: "25462557035", Secret: "123456"},
+			accountUsecase: func() *accountUsecase.AccountUsecaseMock {
+				usecase := &accountUsecase.AccountUsecaseMock{}
+				usecase.On("Create", mock.Anything, mock.Anything).Return(nil, &common.ConflictError{})
+
+				return usecase
+			},
+			wantErr: func(err error) bool {
+				var conflictError *common.ConflictError
+
+				return errors.As(err, &conflictError)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := New(tt.accountUsecase(), jwtDriver.NewHS256("")).CreateAccount(context.Background(), tt.request)
+
+			if tt.wantErr != nil {
+				assert.Assert(t, tt.wantErr(err))
+
+				return
+			}
+
+			assert.NilError(t, err)
+			assert.Equal(t, tt.wantID, got.GetAccount().GetId())
+		})
+	}
+}
+
+func TestAccountHandlerLogin(t *testing.T) {
+	ginDriver.SetupValidator()
+
+	secret := "2aa5b62a718429b0645dc1be1bcac023821181859a181408b59c77d7c07d5349"
+
+	tests := []struct {
+		name           string
+		request        *bankv1.LoginRequest
+		accountUsecase func() *accountUsecase.AccountUsecaseMock
+		wantAccountID  string
+		wantErr        error
+	}{
+		{
+			name:    "should_issue_token_for_valid_credentials",
+			request: &bankv1.LoginRequest{Cpf: "25462557035", Secret: "123456"},
+			accountUsecase: func() *accountUsecase.AccountUsecaseMock {
+				usecase := &accountUsecase.AccountUsecaseMock{}
+				usecase.On("Get", mock.Anything, types.AccountInput{CPF: "25462557035", Secret: util.GenerateHash("123456")}).Return(getAccountTest(3), nil)
+
+				return usecase
+			},
+			wantAccountID: "3",
+		},
+		{
+			name:    "should_reject_invalid_credentials",
+			request: &bankv1.LoginRequest{Cpf: "25462557035", Secret: "654321"},
+			accountUsecase: func() *accountUsecase.AccountUsecaseMock {
+				usecase := &accountUsecase.AccountUsecaseMock{}
+				usecase.On("Get", mock.Anything, mock.Anything).Return(nil, &common.NotFoundError{})
+
+				return usecase
+			},
+			wantErr: &common.UnauthorizedError{Msg: "invalid credentials"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := New(tt.accountUsecase(), jwtDriver.NewHS256(secret)).Login(context.Background(), tt.request)
+
+			if tt.wantErr != nil {
+				assert.Error(t, err, tt.wantErr.Error())
+
+				return
+			}
+
+			assert.NilError(t, err)
+
+			accountID, err := util.ParseJwtToken("account_id", got.GetToken(), secret)
+			assert.NilError(t, err)
+			assert.Equal(t, tt.wantAccountID, accountID)
+		})
+	}
+}
+
+func TestAccountHandlerLoginWithTotp(t *testing.T) {
+	ginDriver.SetupValidator()
+
+	secret := "2aa5b62a718429b0645dc1be1bcac023821181859a181408b59c77d7c07d5349"
+	expiresAt := time.Date(2026, 10, 19, 12, 5, 0, 0, time.UTC)
+
+	account := getAccountTest(3)
+	account.TOTPEnabled = true
+
+	usecase := &accountUsecase.AccountUsecaseMock{}
+	usecase.On("Get", mock.Anything, mock.Anything).Return(account, nil)
+	usecase.On("CreateLoginChallenge", mock.Anything, account).Return(&types.LoginChallenge{Challenge: "3.8f1c2e", ExpiresAt: expiresAt}, nil)
+	usecase.On("CompleteLoginChallenge", mock.Anything, types.LoginChallengeInput{Challenge: "3.8f1c2e", Code: "123456"}).Return(account, nil)
+
+	handler := New(usecase, jwtDriver.NewHS256(secret))
+
+	got, err := handler.Login(context.Background(), &bankv1.LoginRequest{Cpf: "25462557035", Secret: "123456"})
+	assert.NilError(t, err)
+	assert.Equal(t, "", got.GetToken())
+	assert.Equal(t, "3.8f1c2e", got.GetChallenge())
+	assert.Equal(t, expiresAt, got.GetChallengeExpiresAt().AsTime())
+
+	got, err = handler.LoginTotp(context.Background(), &bankv1.LoginTotpRequest{Challenge: "3.8f1c2e", Code: "123456"})
+	assert.NilError(t, err)
+
+	accountID, err := util.ParseJwtToken("account_id", got.GetToken(), secret)
+	assert.NilError(t, err)
+	assert.Equal(t, "3", accountID)
+}
+
+func getAccountTest(id uint) *entity.Account {
+	return &entity.Account{
+		ID:      id,
+		Name:    "Loren",
+		CPF:     "25462557035",
+		Balance: 100,
+	}
+}
@@ -0,0 +1,100 @@
+package transfer
+
+import (
+	"context"
+
+	"github.com/fms85/desafio-tecnico-go-stone/internal/delivery/grpc/interceptor"
+	bankv1 "github.com/fms85/desafio-tecnico-go-stone/internal/delivery/grpc/pb/bank/v1"
+	"github.com/fms85/desafio-tecnico-go-stone/internal/domain/entity"
+	"github.com/fms85/desafio-tecnico-go-stone/internal/domain/types"
+	transferUsecase "github.com/fms85/desafio-tecnico-go-stone/internal/usecase/transfer"
+	"github.com/fms85/desafio-tecnico-go-stone/internal/util"
+	"github.com/gin-gonic/gin/binding"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// TOTP_CODE_METADATA carries the TOTP code transfers over the step-up amount
+// ask for and PIN_METADATA the transaction PIN, like the X-TOTP-Code and
+// X-Transaction-PIN headers of the HTTP API.
+const (
+	TOTP_CODE_METADATA = "x-totp-code"
+	PIN_METADATA       = "x-transaction-pin"
+)
+
+type TransferHandler struct {
+	bankv1.UnimplementedTransferServiceServer
+	transferUsecase transferUsecase.ITransferUsecase
+}
+
+func New(transferUsecase transferUsecase.ITransferUsecase) *TransferHandler {
+	return &TransferHandler{
+		transferUsecase: transferUsecase,
+	}
+}
+
+func (handler *TransferHandler) Register(server grpc.ServiceRegistrar) {
+	bankv1.RegisterTransferServiceServer(server, handler)
+}
+
+func (handler *TransferHandler) ListTransfers(ctx context.Context, _ *bankv1.ListTransfersRequest) (*bankv1.ListTransfersResponse, error) {
+	accountID := interceptor.AccountID(ctx)
+
+	transfers, err := handler.transferUsecase.GetAll(ctx, types.TransferInput{AccountOriginID: util.StringToUint(accountID)})
+	if err != nil {
+		return nil, err
+	}
+
+	response := &bankv1.ListTransfersResponse{Transfers: make([]*bankv1.Transfer, 0, len(transfers))}
+	for _, transfer := range transfers {
+		response.Transfers = append(response.Transfers, toTransfer(transfer))
+	}
+
+	return response, nil
+}
+
+func (handler *TransferHandler) CreateTransfer(ctx context.Context, request *bankv1.CreateTransferRequest) (*bankv1.CreateTransferResponse, error) {
+	transferInput := types.TransferInput{
+		AccountDestinationID: uint(request.GetAccountDestinationId()),
+		Amount:               request.GetAmount(),
+	}
+	if err := binding.Validator.ValidateStruct(&transferInput); err != nil {
+		return nil, err
+	}
+
+	accountID := interceptor.AccountID(ctx)
+	transferInput.AccountOriginID = util.StringToUint(accountID)
+
+	var authorization types.TransferAuthorization
+	md, _ := metadata.FromIncomingContext(ctx)
+	if values := md.Get(PIN_METADATA); len(values) > 0 {
+		authorization.PIN = values[0]
+	}
+	if values := md.Get(TOTP_CODE_METADATA); len(values) > 0 {
+		authorization.TOTPCode = values[0]
+	}
+	if apiKey := interceptor.APIKey(ctx); apiKey != nil {
+		authorization = types.TransferAuthorization{APIKeyID: apiKey.KeyID}
+	}
+
+	if err := handler.transferUsecase.Authorize(ctx, transferInput.AccountOriginID, transferInput.Amount, authorization); err != nil {
+		return nil, err
+	}
+
+	if _, err := handler.transferUsecase.Create(ctx, transferInput); err != nil {
+		return nil, err
+	}
+
+	return &bankv1.CreateTransferResponse{}, nil
+}
+
+func toTransfer(transfer *entity.Transfer) *bankv1.Transfer {
+	return &bankv1.Transfer{
+		Id:                   uint64(transfer.ID),
+		AccountOriginId:      uint64(transfer.AccountOriginID),
+		AccountDestinationId: uint64(transfer.AccountDestinationID),
+		Amount:               transfer.Amount,
+		CreatedAt:            timestamppb.New(transfer.CreatedAt),
+	}
+}
@@ -0,0 +1,213 @@
+package transfer
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/fms85/desafio-tecnico-go-stone/internal/delivery/grpc/interceptor"
+	bankv1 "github.com/fms85/desafio-tecnico-go-stone/internal/delivery/grpc/pb/bank/v1"
+	"github.com/fms85/desafio-tecnico-go-stone/internal/domain/common"
+	"github.com/fms85/desafio-tecnico-go-stone/internal/domain/entity"
+	"github.com/fms85/desafio-tecnico-go-stone/internal/domain/types"
+	ginDriver "github.com/fms85/desafio-tecnico-go-stone/internal/driver/gin"
+	transferUsecase "github.com/fms85/desafio-tecnico-go-stone/internal/usecase/transfer"
+	"github.com/go-playground/validator/v10"
+	"github.com/google/go-cmp/cmp"
+	"github.com/stretchr/testify/mock"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/protobuf/testing/protocmp"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"gotest.tools/assert"
+)
+
+func TestTransferHandlerListTransfers(t *testing.T) {
+	tests := []struct {
+		name            string
+		transferUsecase func() *transferUsecase.TransferUsecaseMock
+		want            *bankv1.ListTransfersResponse
+		wantErr         bool
+	}{
+		{
+			name: "should_list_transfers_of_the_token_account",
+			transferUsecase: func() *transferUsecase.TransferUsecaseMock {
+				usecase := &transferUsecase.TransferUsecaseMock{}
+				usecase.On("GetAll", mock.Anything, types.TransferInput{AccountOriginID: 3}).Return([]*entity.Transfer{
+					{ID: 1, AccountOriginID: 3, AccountDestinationID: 2, Amount: 10},
+				}, nil)
+
+				return usecase
+			},
+			want: &bankv1.ListTransfersResponse{Transfers: []*bankv1.Transfer{
+				{Id: 1, AccountOriginId: 3, AccountDestinationId: 2, Amount: 10, CreatedAt: timestamppb.New(entity.Transfer{}.CreatedAt)},
+			}},
+		},
+		{
+			name: "should_return_usecase_error",
+			transferUsecase: func() *transferUsecase.TransferUsecaseMock {
+				usecase := &transferUsecase.TransferUsecaseMock{}
+				usecase.On("GetAll", mock.Anything, mock.Anything).Return(nil, errors.New(""))
+
+				return usecase
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := interceptor.WithAccountID(context.Background(), "3")
+
+			got, err := New(tt.transferUsecase()).ListTransfers(ctx, &bankv1.ListTransfersRequest{})
+
+			assert.Equal(t, tt.wantErr, err != nil)
+			if diff := cmp.Diff(tt.want, got, protocmp.Transform()); diff != "" {
+				t.Error(diff)
+			}
+		})
+	}
+}
+
+func TestTransferHandlerCreateTransfer(t *testing.T) {
+	ginDriver.SetupValidator()
+
+	tests := []struct {
+		name            string
+		request         *bankv1.CreateTransferRequest
+		totpCode        string
+		pin             string
+		apiKey          *entity.APIKey
+		transferUsecase func() *transferUsecase.TransferUsecaseMock
+		wantErr         func(err error) bool
+	}{
+		{
+			name:    "should_create_transfer_from_the_token_account",
+			request: &bankv1.CreateTransferRequest{AccountDestinationId: 2, Amount: 10},
+			transferUsecase: func() *transferUsecase.TransferUsecaseMock {
+				usecase := &transferUsecase.TransferUsecaseMock{}
+				usecase.On("Authorize", mock.Anything, uint(3), float64(10), types.TransferAuthorization{}).Return(nil)
+				usecase.On("Create", mock.Anything, types.TransferInput{AccountOriginID: 3, AccountDestinationID: 2, Amount: 10}).Return(nil, nil)
+
+				return usecase
+			},
+		},
+		{
+			name:    "should_reject_missing_fields",
+			request: &bankv1.CreateTransferRequest{},
+			transferUsecase: func() *transferUsecase.TransferUsecaseMock {
+				return &transferUsecase.TransferUsecaseMock{}
+			},
+			wantErr: func(err error) bool {
+				var validationErrors validator.ValidationErrors
+
+				return errors.As(err, &validationErrors)
+			},
+		},
+		{
+			name:    "should_reject_a_negative_amount",
+			request: &bankv1.CreateTransferRequest{AccountDestinationId: 2, Amount: -10},
+			transferUsecase: func() *transferUsecase.TransferUsecaseMock {
+				return &transferUsecase.TransferUsecaseMock{}
+			},
+			wantErr: func(err error) bool {
+				var validationErrors validator.ValidationErrors
+
+				return errors.As(err, &validationErrors) && validationErrors[0].Tag() == "gt"
+			},
+		},
+		{
+			name:     "should_pass_the_totp_code_metadata_to_the_step_up",
+			request:  &bankv1.CreateTransferRequest{AccountDestinationId: 2, Amount: 2000},
+			totpCode: "123456",
+			transferUsecase: func() *transferUsecase.TransferUsecaseMock {
+				usecase := &transferUsecase.TransferUsecaseMock{}
+				usecase.On("Authorize", mock.Anything, uint(3), float64(2000), types.TransferAuthorization{TOTPCode: "123456"}).Return(nil)
+				usecase.On("Create", mock.Anything, types.TransferInput{AccountOriginID: 3, AccountDestinationID: 2, Amount: 2000}).Return(nil, nil)
+
+				return usecase
+			},
+		},
+		{
+			name:     "should_pass_the_pin_metadata",
+			request:  &bankv1.CreateTransferRequest{AccountDestinationId: 2, Amount: 2000},
+			pin:      "4321",
+			totpCode: "123456",
+			transferUsecase: func() *transferUsecase.TransferUsecaseMock {
+				usecase := &transferUsecase.TransferUsecaseMock{}
+				usecase.On("Authorize", mock.Anything, uint(3), float64(2000), types.TransferAuthorization{PIN: "4321", TOTPCode: "123456"}).Return(nil)
+				usecase.On("Create", mock.Anything, mock.Anything).Return(nil, nil)
+
+				return usecase
+			},
+		},
+		{
+			name:    "should_authorize_api_keys_by_their_id",
+			request: &bankv1.CreateTransferRequest{AccountDestinationId: 2, Amount: 2000},
+			pin:     "4321",
+			apiKey:  &entity.APIKey{AccountID: 3, KeyID: "ak_0123456789abcdef"},
+			transferUsecase: func() *transferUsecase.TransferUsecaseMock {
+				usecase := &transferUsecase.TransferUsecaseMock{}
+				usecase.On("Authorize", mock.Anything, uint(3), float64(2000), types.TransferAuthorization{APIKeyID: "ak_0123456789abcdef"}).Return(nil)
+				usecase.On("Create", mock.Anything, mock.Anything).Return(nil, nil)
+
+				return usecase
+			},
+		},
+		{
+			name:    "should_not_create_a_transfer_failing_the_step_up",
+			request: &bankv1.CreateTransferRequest{AccountDestinationId: 2, Amount: 2000},
+			transferUsecase: func() *transferUsecase.TransferUsecaseMock {
+				usecase := &transferUsecase.TransferUsecaseMock{}
+				usecase.On("Authorize", mock.Anything, uint(3), float64(2000), types.TransferAuthorization{}).Return(&common.UnauthorizedError{Msg: "totp code required"})
+
+				return usecase
+			},
+			wantErr: func(err error) bool {
+				var unauthorizedError *common.UnauthorizedError
+
+				return errors.As(err, &unauthorizedError)
+			},
+		},
+		{
+			name:    "should_return_insufficient_funds",
+			request: &bankv1.CreateTransferRequest{AccountDestinationId: 2, Amount: 1000},
+			transferUsecase: func() *transferUsecase.TransferUsecaseMock {
+				usecase := &transferUsecase.TransferUsecaseMock{}
+				usecase.On("Authorize", mock.Anything, uint(3), float64(1000), types.TransferAuthorization{}).Return(nil)
+				usecase.On("Create", mock.Anything, mock.Anything).Return(nil, &common.InsufficientFundsError{})
+
+				return usecase
+			},
+			wantErr: func(err error) bool {
+				var insufficientFundsError *common.InsufficientFundsError
+
+				return errors.As(err, &insufficientFundsError)
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := interceptor.WithAccountID(context.Background(), "3")
+			md := metadata.MD{}
+			if tt.totpCode != "" {
+				md.Set(TOTP_CODE_METADATA, tt.totpCode)
+			}
+			if tt.pin != "" {
+				md.Set(PIN_METADATA, tt.pin)
+			}
+			ctx = metadata.NewIncomingContext(ctx, md)
+			if tt.apiKey != nil {
+				ctx = interceptor.WithAPIKey(ctx, tt.apiKey)
+			}
+
+			_, err := New(tt.transferUsecase()).CreateTransfer(ctx, tt.request)
+
+			if tt.wantErr != nil {
+				assert.Assert(t, tt.wantErr(err))
+
+				return
+			}
+
+			assert.NilError(t, err)
+		})
+	}
+}
@@ -0,0 +1,144 @@
+package interceptor
+
+import (
+	"context"
+	"encoding/base64"
+	"strconv"
+	"strings"
+
+	"github.com/fms85/desafio-tecnico-go-stone/internal/domain/common"
+	"github.com/fms85/desafio-tecnico-go-stone/internal/domain/entity"
+	"github.com/fms85/desafio-tecnico-go-stone/internal/domain/types"
+	jwtDriver "github.com/fms85/desafio-tecnico-go-stone/internal/driver/jwt"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+type accountIDKey struct{}
+
+type tokenVersionKey struct{}
+
+type apiKeyKey struct{}
+
+type APIKeyAuthenticator interface {
+	Authenticate(ctx context.Context, keyID string, secret string) (*entity.APIKey, error)
+}
+
+// Auth validates the "authorization" metadata of every call except those to
+// publicServices, and stores its account id in the context. It takes the
+// same credentials as the HTTP API: "Bearer <token>" for accounts, whose
+// token version is stored too, and "Basic" API keys of service accounts,
+// which are stored themselves.
+func Auth(keys jwtDriver.IKeySet, apiKeys APIKeyAuthenticator, publicServices ...string) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		if isPublic(info.FullMethod, publicServices) {
+			return handler(ctx, req)
+		}
+
+		md, _ := metadata.FromIncomingContext(ctx)
+		values := md.Get("authorization")
+		if len(values) == 0 || values[0] == "" {
+			return nil, &common.UnauthorizedError{Msg: "authorization header missing"}
+		}
+
+		if keyID, secret, ok := parseBasicAuth(values[0]); ok {
+			apiKey, err := apiKeys.Authenticate(ctx, keyID, secret)
+			if err != nil {
+				return nil, err
+			}
+
+			ctx = WithAccountID(ctx, strconv.FormatUint(uint64(apiKey.AccountID), 10))
+
+			return handler(WithAPIKey(ctx, apiKey), req)
+		}
+
+		tokenParts := strings.Split(values[0], " ")
+		if len(tokenParts) != 2 || tokenParts[0] != "Bearer" {
+			return nil, &common.UnauthorizedError{Msg: "invalid authorization header format"}
+		}
+
+		claims, err := keys.Verify(tokenParts[1])
+		if err != nil {
+			return nil, &common.UnauthorizedError{}
+		}
+
+		accountID, err := claims.GetStr("account_id")
+		if err != nil {
+			return nil, &common.UnauthorizedError{}
+		}
+
+		tokenVersion, err := claims.GetStr(types.TOKEN_VERSION_CLAIM)
+		if err != nil {
+			tokenVersion = "0"
+		}
+
+		return handler(withTokenVersion(WithAccountID(ctx, accountID), tokenVersion), req)
+	}
+}
+
+// AccountID returns the account id stored by Auth.
+func AccountID(ctx context.Context) string {
+	accountID, _ := ctx.Value(accountIDKey{}).(string)
+
+	return accountID
+}
+
+// WithAccountID stores the authenticated account id in the context.
+func WithAccountID(ctx context.Context, accountID string) context.Context {
+	return context.WithValue(ctx, accountIDKey{}, accountID)
+}
+
+// TokenVersion returns the token version stored by Auth, "0" for tokens
+// without one.
+func TokenVersion(ctx context.Context) string {
+	tokenVersion, ok := ctx.Value(tokenVersionKey{}).(string)
+	if !ok {
+		return "0"
+	}
+
+	return tokenVersion
+}
+
+func withTokenVersion(ctx context.Context, tokenVersion string) context.Context {
+	return context.WithValue(ctx, tokenVersionKey{}, tokenVersion)
+}
+
+// APIKey returns the API key stored by Auth, nil for calls made with a
+// token.
+func APIKey(ctx context.Context) *entity.APIKey {
+	apiKey, _ := ctx.Value(apiKeyKey{}).(*entity.APIKey)
+
+	return apiKey
+}
+
+// WithAPIKey stores the API key a call was authenticated with in the
+// context.
+func WithAPIKey(ctx context.Context, apiKey *entity.APIKey) context.Context {
+	return context.WithValue(ctx, apiKeyKey{}, apiKey)
+}
+
+// parseBasicAuth reads "Basic <base64 of user:password>" like
+// http.Request.BasicAuth.
+func parseBasicAuth(authorization string) (user string, password string, ok bool) {
+	scheme, credentials, found := strings.Cut(authorization, " ")
+	if !found || !strings.EqualFold(scheme, "Basic") {
+		return "", "", false
+	}
+
+	decoded, err := base64.StdEncoding.DecodeString(credentials)
+	if err != nil {
+		return "", "", false
+	}
+
+	return strings.Cut(string(decoded), ":")
+}
+
+func isPublic(fullMethod string, publicServices []string) bool {
+	for _, service := range publicServices {
+		if strings.HasPrefix(fullMethod, "/"+service+"/") {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,121 @@
+package interceptor
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/pem"
+	"testing"
+	"time"
+
+	"github.com/fms85/desafio-tecnico-go-stone/internal/domain/common"
+	jwtDriver "github.com/fms85/desafio-tecnico-go-stone/internal/driver/jwt"
+	"github.com/fms85/desafio-tecnico-go-stone/internal/util"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"gotest.tools/assert"
+)
+
+func TestAuth(t *testing.T) {
+	secret := "2aa5b62a718429b0645dc1be1bcac023821181859a181408b59c77d7c07d5349"
+
+	_, private, err := ed25519.GenerateKey(rand.Reader)
+	assert.NilError(t, err)
+	der, err := x509.MarshalPKCS8PrivateKey(private)
+	assert.NilError(t, err)
+
+	keys, err := jwtDriver.New(jwtDriver.Config{
+		Secret:     secret,
+		HS256Until: time.Now().Add(time.Hour),
+		SigningKey: pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der}),
+	})
+	assert.NilError(t, err)
+
+	token, err := keys.Sign(map[string]interface{}{"account_id": 4})
+	assert.NilError(t, err)
+
+	tests := []struct {
+		name          string
+		fullMethod    string
+		authorization string
+		wantAccountID string
+		wantAPIKey    string
+		wantErr       string
+	}{
+		{
+			name:          "should_store_account_id_for_a_valid_token",
+			fullMethod:    "/bank.v1.TransferService/ListTransfers",
+			authorization: "Bearer " + util.GenerateJwtToken("account_id", 3, secret),
+			wantAccountID: "3",
+		},
+		{
+			name:          "should_store_account_id_for_a_token_of_the_signing_key",
+			fullMethod:    "/bank.v1.TransferService/ListTransfers",
+			authorization: "Bearer " + token,
+			wantAccountID: "4",
+		},
+		{
+			name:          "should_store_account_id_for_an_api_key",
+			fullMethod:    "/bank.v1.TransferService/ListTransfers",
+			authorization: basicAuthTest("ak_reader", "sk_test"),
+			wantAccountID: "3",
+			wantAPIKey:    "ak_reader",
+		},
+		{
+			name:          "should_reject_an_api_key_with_a_wrong_secret",
+			fullMethod:    "/bank.v1.TransferService/ListTransfers",
+			authorization: basicAuthTest("ak_reader", "sk_other"),
+			wantErr:       "invalid api key",
+		},
+		{
+			name:       "should_skip_public_services",
+			fullMethod: "/bank.v1.LoginService/Login",
+		},
+		{
+			name:       "should_reject_missing_authorization",
+			fullMethod: "/bank.v1.TransferService/ListTransfers",
+			wantErr:    "authorization header missing",
+		},
+		{
+			name:          "should_reject_invalid_authorization_format",
+			fullMethod:    "/bank.v1.TransferService/ListTransfers",
+			authorization: "Token abc",
+			wantErr:       "invalid authorization header format",
+		},
+		{
+			name:          "should_reject_invalid_token",
+			fullMethod:    "/bank.v1.TransferService/ListTransfers",
+			authorization: "Bearer " + util.GenerateJwtToken("account_id", 3, "another secret"),
+			wantErr:       common.UNAUTHORIZED_ERROR,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			if tt.authorization != "" {
+				ctx = metadata.NewIncomingContext(ctx, metadata.Pairs("authorization", tt.authorization))
+			}
+
+			var gotAccountID, gotAPIKey string
+			_, err := Auth(keys, apiKeysTestData, "bank.v1.LoginService")(ctx, nil, &grpc.UnaryServerInfo{FullMethod: tt.fullMethod}, func(ctx context.Context, req any) (any, error) {
+				gotAccountID = AccountID(ctx)
+				if apiKey := APIKey(ctx); apiKey != nil {
+					gotAPIKey = apiKey.KeyID
+				}
+
+				return nil, nil
+			})
+
+			if tt.wantErr != "" {
+				assert.Error(t, err, tt.wantErr)
+
+				return
+			}
+
+			assert.NilError(t, err)
+			assert.Equal(t, tt.wantAccountID, gotAccountID)
+			assert.Equal(t, tt.wantAPIKey, gotAPIKey)
+		})
+	}
+}
@@ -0,0 +1,124 @@
+package interceptor
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/fms85/desafio-tecnico-go-stone/internal/domain/common"
+	"github.com/fms85/desafio-tecnico-go-stone/internal/driver/i18n"
+	"github.com/fms85/desafio-tecnico-go-stone/internal/driver/logger"
+	"github.com/go-playground/validator/v10"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/protoadapt"
+)
+
+// ERROR_DOMAIN is reported in the ErrorInfo detail of every mapped error.
+const ERROR_DOMAIN = "desafio-tecnico-go-stone"
+
+var statusByCode = map[string]codes.Code{
+	common.NOT_FOUND_CODE:          codes.NotFound,
+	common.CONFLICT_CODE:           codes.AlreadyExists,
+	common.INSUFFICIENT_FUNDS_CODE: codes.FailedPrecondition,
+	common.LIMIT_EXCEEDED_CODE:     codes.ResourceExhausted,
+	common.UNAUTHORIZED_CODE:       codes.Unauthenticated,
+	common.FORBIDDEN_CODE:          codes.PermissionDenied,
+	common.VALIDATION_CODE:         codes.InvalidArgument,
+}
+
+// Error is the gRPC counterpart of middleware.Error: it turns the errors
+// returned by handlers and interceptors into localized status errors with an
+// ErrorInfo detail carrying the same code as the problem+json body, and a
+// BadRequest detail for validation errors. Panics become codes.Internal.
+func Error() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp any, err error) {
+		defer func() {
+			if r := recover(); r != nil {
+				logger.FromContext(ctx).Error("panic recovered", "method", info.FullMethod, "panic", fmt.Sprint(r))
+				resp, err = nil, toStatus(ctx, errors.New("panic"))
+			}
+		}()
+
+		resp, err = handler(ctx, req)
+		if err != nil {
+			return nil, toStatus(ctx, err)
+		}
+
+		return resp, nil
+	}
+}
+
+func toStatus(ctx context.Context, err error) error {
+	if _, ok := status.FromError(err); ok {
+		return err
+	}
+
+	lang := i18n.Language(acceptLanguage(ctx))
+
+	var validationErrors validator.ValidationErrors
+	if errors.As(err, &validationErrors) {
+		err = toValidationError(validationErrors, lang)
+	}
+
+	var codedError common.CodedError
+	if !errors.As(err, &codedError) {
+		logger.FromContext(ctx).Error("unhandled error", "error", err)
+
+		return withDetails(
+			status.New(codes.Internal, i18n.Translate(lang, common.INTERNAL_SERVER_ERROR)),
+			&errdetails.ErrorInfo{Reason: common.INTERNAL_CODE, Domain: ERROR_DOMAIN},
+		)
+	}
+
+	details := []protoadapt.MessageV1{&errdetails.ErrorInfo{Reason: codedError.Code(), Domain: ERROR_DOMAIN}}
+
+	var validationError *common.ValidationError
+	if errors.As(err, &validationError) && len(validationError.Fields) > 0 {
+		badRequest := &errdetails.BadRequest{}
+		for _, field := range validationError.Fields {
+			badRequest.FieldViolations = append(badRequest.FieldViolations, &errdetails.BadRequest_FieldViolation{
+				Field:       field.Field,
+				Description: field.Message,
+			})
+		}
+		details = append(details, badRequest)
+	}
+
+	return withDetails(status.New(statusByCode[codedError.Code()], i18n.Translate(lang, err.Error())), details...)
+}
+
+func withDetails(st *status.Status, details ...protoadapt.MessageV1) error {
+	detailed, err := st.WithDetails(details...)
+	if err != nil {
+		return st.Err()
+	}
+
+	return detailed.Err()
+}
+
+func toValidationError(validationErrors validator.ValidationErrors, lang string) error {
+	trans := i18n.Translator(lang)
+	fields := make([]common.FieldError, 0, len(validationErrors))
+	for _, fieldError := range validationErrors {
+		fields = append(fields, common.FieldError{
+			Field:   fieldError.Field(),
+			Code:    fieldError.Tag(),
+			Message: fieldError.Translate(trans),
+		})
+	}
+
+	return &common.ValidationError{Msg: common.VALIDATION_ERROR, Fields: fields}
+}
+
+func acceptLanguage(ctx context.Context) string {
+	md, _ := metadata.FromIncomingContext(ctx)
+	if values := md.Get("accept-language"); len(values) > 0 {
+		return values[0]
+	}
+
+	return ""
+}
@@ -0,0 +1,152 @@
+package interceptor
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/fms85/desafio-tecnico-go-stone/internal/domain/common"
+	"github.com/fms85/desafio-tecnico-go-stone/internal/domain/types"
+	ginDriver "github.com/fms85/desafio-tecnico-go-stone/internal/driver/gin"
+	"github.com/gin-gonic/gin/binding"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"gotest.tools/assert"
+)
+
+func TestError(t *testing.T) {
+	ginDriver.SetupValidator()
+
+	tests := []struct {
+		name           string
+		acceptLanguage string
+		handler        grpc.UnaryHandler
+		wantCode       codes.Code
+		wantMessage    string
+		wantReason     string
+		wantFields     []string
+	}{
+		{
+			name:           "should_map_not_found_error",
+			acceptLanguage: "en",
+			handler:        failWith(fmt.Errorf("account %w", &common.NotFoundError{})),
+			wantCode:       codes.NotFound,
+			wantMessage:    "account not found",
+			wantReason:     common.NOT_FOUND_CODE,
+		},
+		{
+			name:           "should_map_conflict_error",
+			acceptLanguage: "en",
+			handler:        failWith(fmt.Errorf("account %w", &common.ConflictError{})),
+			wantCode:       codes.AlreadyExists,
+			wantMessage:    "account already exists",
+			wantReason:     common.CONFLICT_CODE,
+		},
+		{
+			name:           "should_map_insufficient_funds_error",
+			acceptLanguage: "en",
+			handler:        failWith(&common.InsufficientFundsError{}),
+			wantCode:       codes.FailedPrecondition,
+			wantMessage:    "insufficient funds",
+			wantReason:     common.INSUFFICIENT_FUNDS_CODE,
+		},
+		{
+			name:           "should_map_limit_exceeded_error",
+			acceptLanguage: "en",
+			handler:        failWith(&common.LimitExceededError{}),
+			wantCode:       codes.ResourceExhausted,
+			wantMessage:    "limit exceeded",
+			wantReason:     common.LIMIT_EXCEEDED_CODE,
+		},
+		{
+			name:           "should_map_unauthorized_error",
+			acceptLanguage: "en",
+			handler:        failWith(&common.UnauthorizedError{}),
+			wantCode:       codes.Unauthenticated,
+			wantMessage:    "unauthorized",
+			wantReason:     common.UNAUTHORIZED_CODE,
+		},
+		{
+			name:           "should_map_forbidden_error",
+			acceptLanguage: "en",
+			handler:        failWith(&common.ForbiddenError{}),
+			wantCode:       codes.PermissionDenied,
+			wantMessage:    "forbidden",
+			wantReason:     common.FORBIDDEN_CODE,
+		},
+		{
+			name:           "should_map_validator_errors_with_field_violations",
+			acceptLanguage: "en",
+			handler: func(ctx context.Context, req any) (any, error) {
+				return nil, binding.Validator.ValidateStruct(&types.TransferInput{})
+			},
+			wantCode:    codes.InvalidArgument,
+			wantMessage: "validation failed",
+			wantReason:  common.VALIDATION_CODE,
+			wantFields:  []string{"account_destination_id", "amount"},
+		},
+		{
+			name:           "should_localize_message_in_default_language",
+			acceptLanguage: "",
+			handler:        failWith(&common.InsufficientFundsError{}),
+			wantCode:       codes.FailedPrecondition,
+			wantMessage:    "saldo insuficiente",
+			wantReason:     common.INSUFFICIENT_FUNDS_CODE,
+		},
+		{
+			name:           "should_hide_unknown_errors",
+			acceptLanguage: "en",
+			handler:        failWith(errors.New("pq: connection refused")),
+			wantCode:       codes.Internal,
+			wantMessage:    "internal server error",
+			wantReason:     common.INTERNAL_CODE,
+		},
+		{
+			name:           "should_recover_from_panics",
+			acceptLanguage: "en",
+			handler: func(ctx context.Context, req any) (any, error) {
+				panic("boom")
+			},
+			wantCode:    codes.Internal,
+			wantMessage: "internal server error",
+			wantReason:  common.INTERNAL_CODE,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("accept-language", tt.acceptLanguage))
+
+			_, err := Error()(ctx, nil, &grpc.UnaryServerInfo{FullMethod: "/bank.v1.TransferService/CreateTransfer"}, tt.handler)
+
+			st, ok := status.FromError(err)
+			assert.Assert(t, ok)
+			assert.Equal(t, tt.wantCode, st.Code())
+			assert.Equal(t, tt.wantMessage, st.Message())
+
+			var reason string
+			var fields []string
+			for _, detail := range st.Details() {
+				switch detail := detail.(type) {
+				case *errdetails.ErrorInfo:
+					reason = detail.GetReason()
+				case *errdetails.BadRequest:
+					for _, violation := range detail.GetFieldViolations() {
+						fields = append(fields, violation.GetField())
+					}
+				}
+			}
+			assert.Equal(t, tt.wantReason, reason)
+			assert.DeepEqual(t, tt.wantFields, fields)
+		})
+	}
+}
+
+func failWith(err error) grpc.UnaryHandler {
+	return func(ctx context.Context, req any) (any, error) {
+		return nil, err
+	}
+}
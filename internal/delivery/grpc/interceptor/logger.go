@@ -0,0 +1,72 @@
+package interceptor
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"log/slog"
+	"regexp"
+	"time"
+
+	"github.com/fms85/desafio-tecnico-go-stone/internal/driver/logger"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+const REQUEST_ID_METADATA = "x-request-id"
+
+var requestIDPattern = regexp.MustCompile(`^[A-Za-z0-9._-]{1,128}$`)
+
+// Logger mirrors middleware.RequestID and middleware.AccessLog: it stores a
+// request scoped logger in the context, echoes the x-request-id metadata and
+// logs one line per call.
+func Logger(log *slog.Logger) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		start := time.Now()
+
+		md, _ := metadata.FromIncomingContext(ctx)
+		requestID := ""
+		if values := md.Get(REQUEST_ID_METADATA); len(values) > 0 {
+			requestID = values[0]
+		}
+		if !requestIDPattern.MatchString(requestID) {
+			requestID = generateRequestID()
+		}
+
+		_ = grpc.SetHeader(ctx, metadata.Pairs(REQUEST_ID_METADATA, requestID))
+
+		requestLogger := log.With("request_id", requestID)
+		if spanContext := trace.SpanContextFromContext(ctx); spanContext.IsValid() {
+			requestLogger = requestLogger.With("trace_id", spanContext.TraceID().String())
+		}
+		ctx = logger.WithContext(ctx, requestLogger)
+
+		resp, err := handler(ctx, req)
+
+		code := status.Code(err)
+		level := slog.LevelInfo
+		if code == codes.Internal || code == codes.Unknown {
+			level = slog.LevelError
+		}
+
+		requestLogger.Log(ctx, level, "request",
+			"method", info.FullMethod,
+			"code", code.String(),
+			"latency", time.Since(start),
+		)
+
+		return resp, err
+	}
+}
+
+func generateRequestID() string {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		panic(err)
+	}
+
+	return hex.EncodeToString(b)
+}
@@ -0,0 +1,30 @@
+package interceptor
+
+import (
+	"context"
+	"slices"
+
+	"github.com/fms85/desafio-tecnico-go-stone/internal/domain/common"
+	"google.golang.org/grpc"
+)
+
+const SCOPE_REQUIRED_ERROR = "api key not allowed on this route"
+
+// Scope is the gRPC counterpart of middleware.Scope: API keys can only call
+// the methods of scopes, keyed by full method name, whose scope they were
+// granted. It runs after Auth.
+func Scope(scopes map[string]string) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		apiKey := APIKey(ctx)
+		if apiKey == nil {
+			return handler(ctx, req)
+		}
+
+		scope, ok := scopes[info.FullMethod]
+		if !ok || !slices.Contains(apiKey.Scopes, scope) {
+			return nil, &common.ForbiddenError{Msg: SCOPE_REQUIRED_ERROR}
+		}
+
+		return handler(ctx, req)
+	}
+}
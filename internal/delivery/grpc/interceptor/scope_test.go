@@ -0,0 +1,66 @@
+package interceptor
+
+import (
+	"context"
+	"testing"
+
+	"github.com/fms85/desafio-tecnico-go-stone/internal/domain/entity"
+	"google.golang.org/grpc"
+	"gotest.tools/assert"
+)
+
+func TestScope(t *testing.T) {
+	scopes := map[string]string{
+		"/bank.v1.TransferService/ListTransfers":  entity.API_KEY_SCOPE_TRANSFERS_READ,
+		"/bank.v1.TransferService/CreateTransfer": entity.API_KEY_SCOPE_TRANSFERS_WRITE,
+	}
+
+	tests := []struct {
+		name       string
+		fullMethod string
+		apiKey     *entity.APIKey
+		wantErr    string
+	}{
+		{
+			name:       "should_let_api_keys_call_methods_of_their_scopes",
+			fullMethod: "/bank.v1.TransferService/ListTransfers",
+			apiKey:     apiKeysTestData["ak_reader"],
+		},
+		{
+			name:       "should_refuse_api_keys_methods_of_other_scopes",
+			fullMethod: "/bank.v1.TransferService/CreateTransfer",
+			apiKey:     apiKeysTestData["ak_reader"],
+			wantErr:    SCOPE_REQUIRED_ERROR,
+		},
+		{
+			name:       "should_refuse_api_keys_methods_without_scope",
+			fullMethod: "/bank.v1.ProfileService/GetProfile",
+			apiKey:     apiKeysTestData["ak_reader"],
+			wantErr:    SCOPE_REQUIRED_ERROR,
+		},
+		{
+			name:       "should_let_tokens_call_any_method",
+			fullMethod: "/bank.v1.TransferService/CreateTransfer",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			if tt.apiKey != nil {
+				ctx = WithAPIKey(ctx, tt.apiKey)
+			}
+
+			_, err := Scope(scopes)(ctx, nil, &grpc.UnaryServerInfo{FullMethod: tt.fullMethod}, func(ctx context.Context, req any) (any, error) {
+				return nil, nil
+			})
+
+			if tt.wantErr != "" {
+				assert.Error(t, err, tt.wantErr)
+
+				return
+			}
+
+			assert.NilError(t, err)
+		})
+	}
+}
@@ -0,0 +1,29 @@
+package interceptor
+
+import (
+	"context"
+
+	"github.com/fms85/desafio-tecnico-go-stone/internal/util"
+	"google.golang.org/grpc"
+)
+
+type SessionValidator interface {
+	ValidateSession(ctx context.Context, accountID uint, tokenVersion uint) error
+}
+
+// Session rejects tokens revoked since they were issued, on every call except
+// those to publicServices. It runs after Auth. API keys have no version and
+// Auth already refused the revoked ones.
+func Session(sessions SessionValidator, publicServices ...string) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		if isPublic(info.FullMethod, publicServices) || APIKey(ctx) != nil {
+			return handler(ctx, req)
+		}
+
+		if err := sessions.ValidateSession(ctx, util.StringToUint(AccountID(ctx)), util.StringToUint(TokenVersion(ctx))); err != nil {
+			return nil, err
+		}
+
+		return handler(ctx, req)
+	}
+}
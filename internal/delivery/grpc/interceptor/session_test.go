@@ -0,0 +1,106 @@
+package interceptor
+
+import (
+	"context"
+	"encoding/base64"
+	"testing"
+
+	"github.com/fms85/desafio-tecnico-go-stone/internal/domain/common"
+	"github.com/fms85/desafio-tecnico-go-stone/internal/domain/entity"
+	jwtDriver "github.com/fms85/desafio-tecnico-go-stone/internal/driver/jwt"
+	"github.com/fms85/desafio-tecnico-go-stone/internal/util"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"gotest.tools/assert"
+)
+
+type sessionsTest map[uint]uint
+
+func (sessions sessionsTest) ValidateSession(_ context.Context, accountID uint, tokenVersion uint) error {
+	if version, ok := sessions[accountID]; !ok || version != tokenVersion {
+		return &common.UnauthorizedError{Msg: "session revoked"}
+	}
+
+	return nil
+}
+
+type apiKeysTest map[string]*entity.APIKey
+
+func (apiKeys apiKeysTest) Authenticate(_ context.Context, keyID string, secret string) (*entity.APIKey, error) {
+	apiKey, ok := apiKeys[keyID]
+	if !ok || secret != "sk_test" {
+		return nil, &common.UnauthorizedError{Msg: "invalid api key"}
+	}
+
+	return apiKey, nil
+}
+
+var apiKeysTestData = apiKeysTest{
+	"ak_reader": {AccountID: 3, KeyID: "ak_reader", Scopes: []string{entity.API_KEY_SCOPE_TRANSFERS_READ}},
+}
+
+func basicAuthTest(keyID string, secret string) string {
+	return "Basic " + base64.StdEncoding.EncodeToString([]byte(keyID+":"+secret))
+}
+
+func TestSession(t *testing.T) {
+	secret := "2aa5b62a718429b0645dc1be1bcac023821181859a181408b59c77d7c07d5349"
+
+	tests := []struct {
+		name          string
+		fullMethod    string
+		authorization string
+		wantErr       string
+	}{
+		{
+			name:          "should_accept_tokens_without_version_of_accounts_at_version_zero",
+			fullMethod:    "/bank.v1.TransferService/ListTransfers",
+			authorization: "Bearer " + util.GenerateJwtToken("account_id", 3, secret),
+		},
+		{
+			name:          "should_accept_tokens_of_the_current_version",
+			fullMethod:    "/bank.v1.TransferService/ListTransfers",
+			authorization: "Bearer " + util.GenerateJwtClaims(map[string]interface{}{"account_id": 4, "token_version": 2}, secret),
+		},
+		{
+			name:          "should_reject_tokens_of_an_old_version",
+			fullMethod:    "/bank.v1.TransferService/ListTransfers",
+			authorization: "Bearer " + util.GenerateJwtClaims(map[string]interface{}{"account_id": 4, "token_version": 1}, secret),
+			wantErr:       "session revoked",
+		},
+		{
+			name:          "should_accept_api_keys_without_version",
+			fullMethod:    "/bank.v1.TransferService/ListTransfers",
+			authorization: basicAuthTest("ak_reader", "sk_test"),
+		},
+		{
+			name:       "should_skip_public_services",
+			fullMethod: "/bank.v1.LoginService/Login",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			if tt.authorization != "" {
+				ctx = metadata.NewIncomingContext(ctx, metadata.Pairs("authorization", tt.authorization))
+			}
+
+			info := &grpc.UnaryServerInfo{FullMethod: tt.fullMethod}
+			session := Session(sessionsTest{3: 0, 4: 2}, "bank.v1.LoginService")
+
+			_, err := Auth(jwtDriver.NewHS256(secret), apiKeysTestData, "bank.v1.LoginService")(ctx, nil, info, func(ctx context.Context, req any) (any, error) {
+				return session(ctx, req, info, func(ctx context.Context, req any) (any, error) {
+					return nil, nil
+				})
+			})
+
+			if tt.wantErr != "" {
+				assert.Error(t, err, tt.wantErr)
+
+				return
+			}
+
+			assert.NilError(t, err)
+		})
+	}
+}
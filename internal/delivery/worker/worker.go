@@ -0,0 +1,95 @@
+package worker
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/fms85/desafio-tecnico-go-stone/internal/domain/common"
+	"github.com/fms85/desafio-tecnico-go-stone/internal/driver/logger"
+	outboxRepository "github.com/fms85/desafio-tecnico-go-stone/internal/repository/outbox"
+	webhookRepository "github.com/fms85/desafio-tecnico-go-stone/internal/repository/webhook"
+	outboxUsecase "github.com/fms85/desafio-tecnico-go-stone/internal/usecase/outbox"
+	webhookUsecase "github.com/fms85/desafio-tecnico-go-stone/internal/usecase/webhook"
+)
+
+const (
+	WEBHOOK_DISPATCH_INTERVAL = 5 * time.Second
+	OUTBOX_RELAY_INTERVAL     = time.Second
+	OUTBOX_SINKS_DEFAULT      = outboxUsecase.SINK_WEBHOOK
+)
+
+// Job processes one batch of work and reports how many items it handled.
+type Job func(ctx context.Context) (int, error)
+
+func Init(app common.App) {
+	webhookUsecase := webhookUsecase.New(
+		webhookRepository.New(app.DB),
+		webhookUsecase.NewClient(),
+	)
+
+	sinks, err := Sinks(app.Env.OUTBOX_SINKS, app.Logger, webhookUsecase)
+	if err != nil {
+		panic(err)
+	}
+
+	outboxUsecase := outboxUsecase.New(outboxRepository.New(app.DB), sinks)
+
+	ctx := logger.WithContext(context.Background(), app.Logger)
+
+	app.Logger.Info("workers started", "outbox_sinks", app.Env.OUTBOX_SINKS)
+
+	go Run(ctx, app.Logger, OUTBOX_RELAY_INTERVAL, outboxUsecase.Relay)
+	Run(ctx, app.Logger, WEBHOOK_DISPATCH_INTERVAL, webhookUsecase.Dispatch)
+}
+
+// Sinks builds the sinks named in a comma separated list, webhook by default.
+func Sinks(names string, log *slog.Logger, webhookUsecase webhookUsecase.IWebhookUsecase) (outboxUsecase.MultiSink, error) {
+	if strings.TrimSpace(names) == "" {
+		names = OUTBOX_SINKS_DEFAULT
+	}
+
+	var sinks outboxUsecase.MultiSink
+	for _, name := range strings.Split(names, ",") {
+		switch strings.TrimSpace(name) {
+		case outboxUsecase.SINK_WEBHOOK:
+			sinks = append(sinks, outboxUsecase.NewWebhookSink(webhookUsecase))
+		case outboxUsecase.SINK_LOG:
+			sinks = append(sinks, outboxUsecase.NewLogSink(log))
+		case outboxUsecase.SINK_MEMORY:
+			sinks = append(sinks, outboxUsecase.NewMemorySink())
+		default:
+			return nil, fmt.Errorf("unknown outbox sink %q", name)
+		}
+	}
+
+	return sinks, nil
+}
+
+// Run calls job every interval until ctx is done. While batches come back
+// non-empty the job is called again right away to drain the backlog.
+func Run(ctx context.Context, log *slog.Logger, interval time.Duration, job Job) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		for {
+			count, err := job(ctx)
+			if err != nil {
+				log.Error("worker job failed", "error", err)
+			}
+
+			if err != nil || count == 0 || ctx.Err() != nil {
+				break
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
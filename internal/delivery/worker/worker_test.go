@@ -0,0 +1,82 @@
+package worker
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"testing"
+	"time"
+
+	"gotest.tools/assert"
+)
+
+func TestRun(t *testing.T) {
+	tests := []struct {
+		name      string
+		batches   []int
+		err       error
+		wantCalls int
+	}{
+		{
+			name:      "should_drain_the_backlog_before_waiting",
+			batches:   []int{20, 20, 3, 0},
+			wantCalls: 4,
+		},
+		{
+			name:      "should_wait_after_an_error",
+			batches:   []int{20, 20},
+			err:       errors.New(""),
+			wantCalls: 1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			calls := 0
+			job := func(ctx context.Context) (int, error) {
+				calls++
+				if calls >= len(tt.batches) || tt.err != nil {
+					cancel()
+				}
+
+				return tt.batches[calls-1], tt.err
+			}
+
+			done := make(chan struct{})
+			go func() {
+				Run(ctx, slog.Default(), time.Hour, job)
+				close(done)
+			}()
+
+			select {
+			case <-done:
+			case <-time.After(time.Second):
+				t.Fatal("worker did not stop")
+			}
+
+			assert.Equal(t, tt.wantCalls, calls)
+		})
+	}
+}
+
+func TestSinks(t *testing.T) {
+	tests := []struct {
+		name    string
+		names   string
+		want    int
+		wantErr bool
+	}{
+		{name: "should_default_to_webhook", names: "", want: 1},
+		{name: "should_build_every_listed_sink", names: "webhook, log,memory", want: 3},
+		{name: "should_reject_unknown_sinks", names: "webhook,kafka", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sinks, err := Sinks(tt.names, slog.Default(), nil)
+			assert.Equal(t, tt.wantErr, err != nil)
+			assert.Equal(t, tt.want, len(sinks))
+		})
+	}
+}
@@ -2,4 +2,17 @@ package common
 
 const (
 	INTERNAL_SERVER_ERROR = "internal server error"
+	PROBLEM_CONTENT_TYPE  = "application/problem+json"
+	PROBLEM_DEFAULT_TYPE  = "about:blank"
 )
+
+// Problem is the RFC 7807 body returned for every error response.
+type Problem struct {
+	Type     string       `json:"type"`
+	Title    string       `json:"title"`
+	Status   int          `json:"status"`
+	Detail   string       `json:"detail,omitempty"`
+	Instance string       `json:"instance,omitempty"`
+	Code     string       `json:"code"`
+	Errors   []FieldError `json:"errors,omitempty"`
+}
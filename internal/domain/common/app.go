@@ -1,13 +1,40 @@
 package common
 
-import "gorm.io/gorm"
+import (
+	"log/slog"
+
+	"github.com/fms85/desafio-tecnico-go-stone/internal/driver/billnetwork"
+	"github.com/fms85/desafio-tecnico-go-stone/internal/driver/eventbus"
+	"github.com/fms85/desafio-tecnico-go-stone/internal/driver/jwt"
+	"github.com/fms85/desafio-tecnico-go-stone/internal/driver/notifier"
+	"gorm.io/gorm"
+)
 
 type App struct {
-	DB  map[string]*gorm.DB
-	Env Env
+	DB          map[string]*gorm.DB
+	Env         Env
+	Logger      *slog.Logger
+	Bus         eventbus.IBus
+	Notifier    notifier.INotifier
+	BillNetwork billnetwork.INetwork
+	JWT         jwt.IKeySet
 }
 
 type Env struct {
-	HTTP_ADDR  string
-	JWT_SECRET string
+	HTTP_ADDR                  string
+	GRPC_ADDR                  string
+	JWT_SECRET                 string
+	JWT_HS256_UNTIL            string
+	JWT_SIGNING_KEY_FILE       string
+	JWT_VERIFICATION_KEY_FILES string
+	RECEIPT_SECRET             string
+	LOG_LEVEL                  string
+	TRACE_EXPORTER             string
+	OPENAPI_VALIDATION         string
+	LEGACY_ROUTES_SUNSET       string
+	OUTBOX_SINKS               string
+	PIX_MERCHANT_CITY          string
+	PIX_LOCATION_HOST          string
+	BILL_SETTLEMENT_ACCOUNT_ID string
+	TOTP_STEP_UP_AMOUNT        string
 }
@@ -3,14 +3,126 @@ package common
 import "fmt"
 
 const (
-	NOT_FOUND_ERROR = "not found"
-	FOUND_ERROR     = "already exists"
+	NOT_FOUND_ERROR          = "not found"
+	FOUND_ERROR              = "already exists"
+	INSUFFICIENT_FUNDS_ERROR = "insufficient funds"
+	LIMIT_EXCEEDED_ERROR     = "limit exceeded"
+	UNAUTHORIZED_ERROR       = "unauthorized"
+	FORBIDDEN_ERROR          = "forbidden"
+	VALIDATION_ERROR         = "validation failed"
 )
 
-type ValidationError struct {
+const (
+	NOT_FOUND_CODE          = "NOT_FOUND"
+	CONFLICT_CODE           = "CONFLICT"
+	INSUFFICIENT_FUNDS_CODE = "INSUFFICIENT_FUNDS"
+	LIMIT_EXCEEDED_CODE     = "LIMIT_EXCEEDED"
+	UNAUTHORIZED_CODE       = "UNAUTHORIZED"
+	FORBIDDEN_CODE          = "FORBIDDEN"
+	VALIDATION_CODE         = "VALIDATION_ERROR"
+	INTERNAL_CODE           = "INTERNAL_ERROR"
+)
+
+// CodedError is implemented by every error that is safe to expose to
+// clients. Anything else is treated as an internal error.
+type CodedError interface {
+	error
+	Code() string
+}
+
+type NotFoundError struct{}
+
+func (e *NotFoundError) Error() string {
+	return NOT_FOUND_ERROR
+}
+
+func (e *NotFoundError) Code() string {
+	return NOT_FOUND_CODE
+}
+
+type ConflictError struct{}
+
+func (e *ConflictError) Error() string {
+	return FOUND_ERROR
+}
+
+func (e *ConflictError) Code() string {
+	return CONFLICT_CODE
+}
+
+type InsufficientFundsError struct{}
+
+func (e *InsufficientFundsError) Error() string {
+	return INSUFFICIENT_FUNDS_ERROR
+}
+
+func (e *InsufficientFundsError) Code() string {
+	return INSUFFICIENT_FUNDS_CODE
+}
+
+type LimitExceededError struct {
 	Msg string
 }
 
+func (e *LimitExceededError) Error() string {
+	if e.Msg == "" {
+		return LIMIT_EXCEEDED_ERROR
+	}
+
+	return e.Msg
+}
+
+func (e *LimitExceededError) Code() string {
+	return LIMIT_EXCEEDED_CODE
+}
+
+type UnauthorizedError struct {
+	Msg string
+}
+
+func (e *UnauthorizedError) Error() string {
+	if e.Msg == "" {
+		return UNAUTHORIZED_ERROR
+	}
+
+	return e.Msg
+}
+
+func (e *UnauthorizedError) Code() string {
+	return UNAUTHORIZED_CODE
+}
+
+type ForbiddenError struct {
+	Msg string
+}
+
+func (e *ForbiddenError) Error() string {
+	if e.Msg == "" {
+		return FORBIDDEN_ERROR
+	}
+
+	return e.Msg
+}
+
+func (e *ForbiddenError) Code() string {
+	return FORBIDDEN_CODE
+}
+
+type FieldError struct {
+	Field   string `json:"field"`
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+type ValidationError struct {
+	Msg    string
+	Fields []FieldError
+}
+
 func (e *ValidationError) Error() string {
 	return fmt.Sprintf(e.Msg)
 }
+
+func (e *ValidationError) Code() string {
+	return VALIDATION_CODE
+}
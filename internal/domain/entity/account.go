@@ -6,11 +6,78 @@ import (
 
 const ENTITY_BALANCE_DEFAULT = 100
 
+// Accounts belong to individuals (PF), identified by their CPF, or to
+// businesses (PJ), identified by their CNPJ.
+const (
+	ACCOUNT_TYPE_PF = "PF"
+	ACCOUNT_TYPE_PJ = "PJ"
+)
+
+const (
+	ACCOUNT_CHANGE_FIELD_NAME         = "name"
+	ACCOUNT_CHANGE_FIELD_EMAIL        = "email"
+	ACCOUNT_CHANGE_FIELD_PHONE        = "phone"
+	ACCOUNT_CHANGE_FIELD_SECRET       = "secret"
+	ACCOUNT_CHANGE_FIELD_SECRET_RESET = "secret_reset"
+	ACCOUNT_CHANGE_FIELD_TOTP         = "totp"
+	ACCOUNT_CHANGE_FIELD_PIN          = "pin"
+)
+
+// Account keeps the document of its type and leaves the other one empty, so
+// the unique index over both documents holds a single account per CPF and
+// per CNPJ. Business accounts also keep their legal and trade names.
+//
+// TokenVersion is carried by the tokens issued to the account; bumping it
+// revokes every token issued before. A pending secret reset keeps only the
+// hash of the code sent to the account contact.
+//
+// A TOTP secret without TOTPEnabled is an enrollment waiting for its first
+// code. TOTPLastStep is the step of the last code accepted, which cannot be
+// used again, and failed codes count towards a lock shared by logins and
+// step-ups. Recovery codes and login challenges are kept hashed.
+//
+// The transaction PIN authorizes transfers and is kept apart from the secret,
+// with a slow hash and failed attempts of its own.
+//
+// Contact data and security settings are left out of its JSON, which public
+// routes list; owners see them through types.Profile.
 type Account struct {
+	ID                     uint       `gorm:"primarykey" json:"id"`
+	Type                   string     `gorm:"column:type;NOT NULL;default:'PF'" json:"type"`
+	Name                   string     `gorm:"column:name;NOT NULL" json:"name"`
+	CPF                    string     `gorm:"column:cpf;NOT NULL;default:'';uniqueIndex:idx_accounts_document" json:"cpf,omitempty"`
+	CNPJ                   string     `gorm:"column:cnpj;NOT NULL;default:'';uniqueIndex:idx_accounts_document" json:"cnpj,omitempty"`
+	LegalName              string     `gorm:"column:legal_name" json:"legal_name,omitempty"`
+	TradeName              string     `gorm:"column:trade_name" json:"trade_name,omitempty"`
+	Email                  string     `gorm:"column:email" json:"-"`
+	Phone                  string     `gorm:"column:phone" json:"-"`
+	Secret                 string     `gorm:"column:secret;NOT NULL" json:"-"`
+	TokenVersion           uint       `gorm:"column:token_version;NOT NULL;default:0" json:"-"`
+	SecretResetHash        string     `gorm:"column:secret_reset_hash" json:"-"`
+	SecretResetExpiresAt   *time.Time `gorm:"column:secret_reset_expires_at" json:"-"`
+	SecretResetAttempts    int        `gorm:"column:secret_reset_attempts;NOT NULL;default:0" json:"-"`
+	TOTPSecret             string     `gorm:"column:totp_secret" json:"-"`
+	TOTPEnabled            bool       `gorm:"column:totp_enabled;NOT NULL;default:false" json:"-"`
+	TOTPLastStep           int64      `gorm:"column:totp_last_step;NOT NULL;default:0" json:"-"`
+	TOTPRecoveryCodes      []string   `gorm:"column:totp_recovery_codes;serializer:json" json:"-"`
+	TOTPAttempts           int        `gorm:"column:totp_attempts;NOT NULL;default:0" json:"-"`
+	TOTPLockedUntil        *time.Time `gorm:"column:totp_locked_until" json:"-"`
+	TOTPChallengeHash      string     `gorm:"column:totp_challenge_hash" json:"-"`
+	TOTPChallengeExpiresAt *time.Time `gorm:"column:totp_challenge_expires_at" json:"-"`
+	PINHash                string     `gorm:"column:pin_hash" json:"-"`
+	PINAttempts            int        `gorm:"column:pin_attempts;NOT NULL;default:0" json:"-"`
+	PINLockedUntil         *time.Time `gorm:"column:pin_locked_until" json:"-"`
+	Balance                float64    `gorm:"column:balance;NOT NULL" json:"balance"`
+	CreatedAt              time.Time  `gorm:"column:createdAt" json:"createdAt"`
+}
+
+// AccountChange records one field of an account changed by its owner. Old
+// and new values are left empty for secrets.
+type AccountChange struct {
 	ID        uint      `gorm:"primarykey" json:"id"`
-	Name      string    `gorm:"column:name;NOT NULL" json:"name"`
-	CPF       string    `gorm:"column:cpf;NOT NULL;unique" json:"cpf"`
-	Secret    string    `gorm:"column:secret;NOT NULL" json:"-"`
-	Balance   float64   `gorm:"column:balance;NOT NULL" json:"balance"`
+	AccountID uint      `gorm:"column:account_id;NOT NULL;index" json:"account_id"`
+	Field     string    `gorm:"column:field;NOT NULL" json:"field"`
+	OldValue  string    `gorm:"column:old_value" json:"old_value,omitempty"`
+	NewValue  string    `gorm:"column:new_value" json:"new_value,omitempty"`
 	CreatedAt time.Time `gorm:"column:createdAt" json:"createdAt"`
 }
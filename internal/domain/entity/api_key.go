@@ -0,0 +1,28 @@
+package entity
+
+import (
+	"time"
+)
+
+const (
+	API_KEY_SCOPE_BALANCE_READ    = "balance:read"
+	API_KEY_SCOPE_TRANSFERS_READ  = "transfers:read"
+	API_KEY_SCOPE_TRANSFERS_WRITE = "transfers:write"
+)
+
+// APIKey is the credential of a service account, a machine client acting on
+// the account that created it within its scopes. KeyID is public; only the
+// hash of the secret is stored, the secret being shown once on creation.
+// Revoked keys are kept so their usage can still be looked up.
+type APIKey struct {
+	ID         uint       `gorm:"primarykey" json:"-"`
+	AccountID  uint       `gorm:"column:account_id;NOT NULL;index" json:"account_id"`
+	KeyID      string     `gorm:"column:key_id;NOT NULL;uniqueIndex" json:"key_id"`
+	Name       string     `gorm:"column:name;NOT NULL" json:"name"`
+	SecretHash string     `gorm:"column:secret_hash;NOT NULL" json:"-"`
+	Scopes     []string   `gorm:"column:scopes;NOT NULL;serializer:json" json:"scopes"`
+	ExpiresAt  time.Time  `gorm:"column:expires_at;NOT NULL" json:"expires_at"`
+	LastUsedAt *time.Time `gorm:"column:last_used_at" json:"last_used_at"`
+	RevokedAt  *time.Time `gorm:"column:revoked_at" json:"revoked_at,omitempty"`
+	CreatedAt  time.Time  `gorm:"column:createdAt" json:"createdAt"`
+}
@@ -0,0 +1,32 @@
+package entity
+
+import (
+	"time"
+)
+
+const (
+	BILL_PAYMENT_STATUS_PENDING   = "pending"
+	BILL_PAYMENT_STATUS_COMPLETED = "completed"
+	BILL_PAYMENT_STATUS_REFUNDED  = "refunded"
+)
+
+// BillPayment is a boleto an account paid. The amount goes to the settlement
+// account by the transfer TransferID while the payment network settles the
+// bill, and comes back by RefundTransferID when the network declines it. A
+// barcode has at most one payment that was not refunded.
+type BillPayment struct {
+	ID               uint       `gorm:"primarykey" json:"id"`
+	AccountID        uint       `gorm:"column:account_id;NOT NULL;index" json:"account_id"`
+	Type             string     `gorm:"column:type;NOT NULL" json:"type"`
+	Barcode          string     `gorm:"column:barcode;NOT NULL;uniqueIndex:idx_bill_payments_barcode_open,where:status <> 'refunded'" json:"barcode"`
+	DigitableLine    string     `gorm:"column:digitable_line;NOT NULL" json:"digitable_line"`
+	Amount           float64    `gorm:"column:amount;NOT NULL" json:"amount"`
+	DueDate          string     `gorm:"column:due_date" json:"due_date,omitempty"`
+	Status           string     `gorm:"column:status;NOT NULL" json:"status"`
+	TransferID       uint       `gorm:"column:transfer_id;NOT NULL" json:"transfer_id"`
+	RefundTransferID *uint      `gorm:"column:refund_transfer_id" json:"refund_transfer_id,omitempty"`
+	Authentication   string     `gorm:"column:authentication" json:"authentication,omitempty"`
+	SettledAt        *time.Time `gorm:"column:settled_at" json:"settled_at,omitempty"`
+	CreatedAt        time.Time  `gorm:"column:createdAt" json:"createdAt"`
+	UpdatedAt        time.Time  `gorm:"column:updatedAt" json:"updatedAt"`
+}
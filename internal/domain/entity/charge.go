@@ -0,0 +1,42 @@
+package entity
+
+import (
+	"time"
+)
+
+const (
+	CHARGE_STATUS_PENDING        = "pending"
+	CHARGE_STATUS_PARTIALLY_PAID = "partially_paid"
+	CHARGE_STATUS_PAID           = "paid"
+	CHARGE_STATUS_DECLINED       = "declined"
+	CHARGE_STATUS_EXPIRED        = "expired"
+)
+
+// Charge is an amount one account asks another to pay by a due date. The
+// payer pays it with transfers, in several parts when AllowPartial is set,
+// or declines it. Charges still open after ExpiresAt can no longer be paid.
+type Charge struct {
+	ID               uint             `gorm:"primarykey" json:"id"`
+	ChargerAccountID uint             `gorm:"column:charger_account_id;NOT NULL;index" json:"charger_account_id"`
+	PayerAccountID   uint             `gorm:"column:payer_account_id;NOT NULL;index" json:"payer_account_id"`
+	Amount           float64          `gorm:"column:amount;NOT NULL" json:"amount"`
+	PaidAmount       float64          `gorm:"column:paid_amount;NOT NULL;default:0" json:"paid_amount"`
+	Description      string           `gorm:"column:description" json:"description,omitempty"`
+	DueDate          string           `gorm:"column:due_date;NOT NULL" json:"due_date"`
+	ExpiresAt        time.Time        `gorm:"column:expires_at;NOT NULL" json:"expires_at"`
+	AllowPartial     bool             `gorm:"column:allow_partial;NOT NULL;default:false" json:"allow_partial"`
+	MinPartialAmount float64          `gorm:"column:min_partial_amount;NOT NULL;default:0" json:"min_partial_amount,omitempty"`
+	Status           string           `gorm:"column:status;NOT NULL" json:"status"`
+	CreatedAt        time.Time        `gorm:"column:createdAt" json:"createdAt"`
+	UpdatedAt        time.Time        `gorm:"column:updatedAt" json:"updatedAt"`
+	Payments         []*ChargePayment `gorm:"foreignKey:ChargeID" json:"payments,omitempty"`
+}
+
+// ChargePayment links a charge to a transfer that paid part or all of it.
+type ChargePayment struct {
+	ID         uint      `gorm:"primarykey" json:"-"`
+	ChargeID   uint      `gorm:"column:charge_id;NOT NULL;index" json:"-"`
+	TransferID uint      `gorm:"column:transfer_id;NOT NULL" json:"transfer_id"`
+	Amount     float64   `gorm:"column:amount;NOT NULL" json:"amount"`
+	CreatedAt  time.Time `gorm:"column:createdAt" json:"createdAt"`
+}
@@ -0,0 +1,28 @@
+package entity
+
+import (
+	"time"
+)
+
+const (
+	EVENT_ACCOUNT_CREATED   = "account.created"
+	EVENT_ACCOUNT_BLOCKED   = "account.blocked"
+	EVENT_TRANSFER_CREATED  = "transfer.created"
+	EVENT_TRANSFER_RECEIVED = "transfer.received"
+	EVENT_TRANSFER_REVERSED = "transfer.reversed"
+)
+
+// OutboxEvent is a domain event written in the same transaction as the change
+// it describes, so it exists if and only if the change was committed. The
+// relay publishes events of the same account in id order.
+type OutboxEvent struct {
+	ID          uint       `gorm:"primarykey" json:"id"`
+	AccountID   uint       `gorm:"column:account_id;NOT NULL;index:idx_outbox_events_account" json:"account_id"`
+	Type        string     `gorm:"column:type;NOT NULL" json:"type"`
+	Payload     string     `gorm:"column:payload;NOT NULL;type:jsonb" json:"payload"`
+	Attempts    int        `gorm:"column:attempts;NOT NULL;default:0" json:"attempts"`
+	LockedUntil *time.Time `gorm:"column:locked_until" json:"locked_until,omitempty"`
+	LastError   string     `gorm:"column:last_error" json:"last_error,omitempty"`
+	PublishedAt *time.Time `gorm:"column:published_at;index" json:"published_at,omitempty"`
+	CreatedAt   time.Time  `gorm:"column:createdAt" json:"createdAt"`
+}
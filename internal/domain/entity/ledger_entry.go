@@ -0,0 +1,25 @@
+package entity
+
+import (
+	"time"
+)
+
+const (
+	LEDGER_ENTRY_KIND_DEPOSIT      = "deposit"
+	LEDGER_ENTRY_KIND_TRANSFER_IN  = "transfer_in"
+	LEDGER_ENTRY_KIND_TRANSFER_OUT = "transfer_out"
+	LEDGER_ENTRY_KIND_FEE          = "fee"
+	LEDGER_ENTRY_KIND_REVERSAL     = "reversal"
+)
+
+// LedgerEntry is an append-only movement of an account balance. Credits are
+// positive and debits negative, so the balance at any instant is the sum of
+// the entries created before it.
+type LedgerEntry struct {
+	ID         uint      `gorm:"primarykey" json:"id"`
+	AccountID  uint      `gorm:"column:account_id;NOT NULL;index:idx_ledger_entries_account_created" json:"account_id"`
+	Kind       string    `gorm:"column:kind;NOT NULL" json:"kind"`
+	Amount     float64   `gorm:"column:amount;NOT NULL" json:"amount"`
+	TransferID *uint     `gorm:"column:transfer_id" json:"transfer_id,omitempty"`
+	CreatedAt  time.Time `gorm:"column:createdAt;index:idx_ledger_entries_account_created" json:"createdAt"`
+}
@@ -0,0 +1,26 @@
+package entity
+
+import (
+	"time"
+)
+
+const (
+	PAYMENT_REQUEST_TYPE_STATIC  = "static"
+	PAYMENT_REQUEST_TYPE_DYNAMIC = "dynamic"
+)
+
+// PaymentRequest is a charge shown as a BR Code. Static codes name a Pix key
+// of the account and can be paid many times; dynamic codes point to this
+// request by TxID and expire.
+type PaymentRequest struct {
+	ID          uint       `gorm:"primarykey" json:"id"`
+	AccountID   uint       `gorm:"column:account_id;NOT NULL;index" json:"account_id"`
+	Type        string     `gorm:"column:type;NOT NULL" json:"type"`
+	TxID        string     `gorm:"column:txid;NOT NULL;uniqueIndex" json:"txid"`
+	Key         string     `gorm:"column:key" json:"key,omitempty"`
+	Amount      float64    `gorm:"column:amount;NOT NULL;default:0" json:"amount"`
+	Description string     `gorm:"column:description" json:"description,omitempty"`
+	Payload     string     `gorm:"column:payload;NOT NULL" json:"payload"`
+	ExpiresAt   *time.Time `gorm:"column:expires_at" json:"expires_at,omitempty"`
+	CreatedAt   time.Time  `gorm:"column:createdAt" json:"createdAt"`
+}
@@ -0,0 +1,59 @@
+package entity
+
+import (
+	"time"
+)
+
+const (
+	PIX_KEY_TYPE_CPF   = "cpf"
+	PIX_KEY_TYPE_EMAIL = "email"
+	PIX_KEY_TYPE_PHONE = "phone"
+	PIX_KEY_TYPE_EVP   = "evp"
+)
+
+const (
+	PIX_KEY_STATUS_PENDING = "pending"
+	PIX_KEY_STATUS_ACTIVE  = "active"
+)
+
+const (
+	PIX_CLAIM_TYPE_OWNERSHIP   = "ownership"
+	PIX_CLAIM_TYPE_PORTABILITY = "portability"
+)
+
+const (
+	PIX_CLAIM_STATUS_OPEN      = "open"
+	PIX_CLAIM_STATUS_COMPLETED = "completed"
+	PIX_CLAIM_STATUS_CANCELLED = "cancelled"
+)
+
+// PixKey is an alias that resolves to an account. Email and phone keys stay
+// pending until the code sent to them is confirmed.
+type PixKey struct {
+	ID                    uint       `gorm:"primarykey" json:"id"`
+	AccountID             uint       `gorm:"column:account_id;NOT NULL;index" json:"account_id"`
+	Type                  string     `gorm:"column:type;NOT NULL" json:"type"`
+	Key                   string     `gorm:"column:key;NOT NULL;uniqueIndex" json:"key"`
+	Status                string     `gorm:"column:status;NOT NULL" json:"status"`
+	VerificationHash      string     `gorm:"column:verification_hash" json:"-"`
+	VerificationExpiresAt *time.Time `gorm:"column:verification_expires_at" json:"-"`
+	VerificationAttempts  int        `gorm:"column:verification_attempts;NOT NULL;default:0" json:"-"`
+	CreatedAt             time.Time  `gorm:"column:createdAt" json:"createdAt"`
+	UpdatedAt             time.Time  `gorm:"column:updatedAt" json:"updatedAt"`
+}
+
+// PixKeyClaim asks for a key registered to another account. The owner can
+// confirm or cancel it; ownership claims the owner ignores can be completed
+// by the claimer once ResolveBy has passed.
+type PixKeyClaim struct {
+	ID               uint      `gorm:"primarykey" json:"id"`
+	KeyID            uint      `gorm:"column:key_id;NOT NULL;index" json:"key_id"`
+	Key              string    `gorm:"column:key;NOT NULL;uniqueIndex:idx_pix_key_claims_open,where:status = 'open'" json:"key"`
+	Type             string    `gorm:"column:type;NOT NULL" json:"type"`
+	Status           string    `gorm:"column:status;NOT NULL" json:"status"`
+	ClaimerAccountID uint      `gorm:"column:claimer_account_id;NOT NULL;index" json:"claimer_account_id"`
+	OwnerAccountID   uint      `gorm:"column:owner_account_id;NOT NULL;index" json:"owner_account_id"`
+	ResolveBy        time.Time `gorm:"column:resolve_by;NOT NULL" json:"resolve_by"`
+	CreatedAt        time.Time `gorm:"column:createdAt" json:"createdAt"`
+	UpdatedAt        time.Time `gorm:"column:updatedAt" json:"updatedAt"`
+}
@@ -4,11 +4,15 @@ import (
 	"time"
 )
 
+const TRANSFER_STATUS_COMPLETED = "completed"
+
 type Transfer struct {
 	ID                   uint      `gorm:"primarykey" json:"id"`
 	AccountOriginID      uint      `gorm:"column:account_origin_id;NOT NULL" json:"account_origin_id"`
 	AccountDestinationID uint      `gorm:"column:account_destination_id;NOT NULL" json:"account_destination_id"`
 	Amount               float64   `gorm:"column:amount;NOT NULL" json:"amount"`
+	Fee                  float64   `gorm:"column:fee;NOT NULL;default:0" json:"fee"`
+	Status               string    `gorm:"column:status;NOT NULL;default:completed" json:"status"`
 	CreatedAt            time.Time `gorm:"column:createdAt" json:"createdAt"`
 	AccountOrigin        *Account  `gorm:"foreignKey:AccountOriginID" json:"-"`
 	AccountDestination   *Account  `gorm:"foreignKey:AccountDestinationID" json:"-"`
@@ -0,0 +1,48 @@
+package entity
+
+import (
+	"time"
+)
+
+const (
+	TRANSFER_BATCH_MODE_ATOMIC      = "atomic"
+	TRANSFER_BATCH_MODE_BEST_EFFORT = "best_effort"
+)
+
+const (
+	TRANSFER_BATCH_STATUS_COMPLETED = "completed"
+	TRANSFER_BATCH_STATUS_PARTIAL   = "partial"
+	TRANSFER_BATCH_STATUS_FAILED    = "failed"
+)
+
+const (
+	TRANSFER_BATCH_ITEM_STATUS_COMPLETED = "completed"
+	TRANSFER_BATCH_ITEM_STATUS_FAILED    = "failed"
+)
+
+// TransferBatch groups transfers sent from one account in a single request.
+// Items keep the order they were sent in; completed ones point to their
+// transfer and failed ones say why.
+type TransferBatch struct {
+	ID        uint                 `gorm:"primarykey" json:"id"`
+	AccountID uint                 `gorm:"column:account_id;NOT NULL;index" json:"account_id"`
+	Mode      string               `gorm:"column:mode;NOT NULL" json:"mode"`
+	Status    string               `gorm:"column:status;NOT NULL" json:"status"`
+	Total     float64              `gorm:"column:total;NOT NULL" json:"total"`
+	Completed int                  `gorm:"column:completed;NOT NULL" json:"completed"`
+	Failed    int                  `gorm:"column:failed;NOT NULL" json:"failed"`
+	CreatedAt time.Time            `gorm:"column:createdAt" json:"createdAt"`
+	Items     []*TransferBatchItem `gorm:"foreignKey:BatchID" json:"items"`
+}
+
+type TransferBatchItem struct {
+	ID                   uint    `gorm:"primarykey" json:"-"`
+	BatchID              uint    `gorm:"column:batch_id;NOT NULL;uniqueIndex:idx_transfer_batch_items_position" json:"-"`
+	Position             int     `gorm:"column:position;NOT NULL;uniqueIndex:idx_transfer_batch_items_position" json:"position"`
+	AccountDestinationID uint    `gorm:"column:account_destination_id;NOT NULL" json:"account_destination_id"`
+	Amount               float64 `gorm:"column:amount;NOT NULL" json:"amount"`
+	Status               string  `gorm:"column:status;NOT NULL" json:"status"`
+	TransferID           *uint   `gorm:"column:transfer_id" json:"transfer_id,omitempty"`
+	ErrorCode            string  `gorm:"column:error_code" json:"error_code,omitempty"`
+	Error                string  `gorm:"column:error" json:"error,omitempty"`
+}
@@ -0,0 +1,41 @@
+package entity
+
+import (
+	"time"
+)
+
+const (
+	WEBHOOK_DELIVERY_STATUS_PENDING   = "pending"
+	WEBHOOK_DELIVERY_STATUS_DELIVERED = "delivered"
+	WEBHOOK_DELIVERY_STATUS_DEAD      = "dead"
+)
+
+// Webhook is a subscription of an account to some of its events. Secret signs
+// every delivery and is only shown when the subscription is created.
+type Webhook struct {
+	ID        uint      `gorm:"primarykey" json:"id"`
+	AccountID uint      `gorm:"column:account_id;NOT NULL;index" json:"account_id"`
+	URL       string    `gorm:"column:url;NOT NULL" json:"url"`
+	Events    []string  `gorm:"column:events;NOT NULL;serializer:json" json:"events"`
+	Secret    string    `gorm:"column:secret;NOT NULL" json:"-"`
+	CreatedAt time.Time `gorm:"column:createdAt" json:"createdAt"`
+}
+
+// WebhookDelivery is one event on its way to one webhook. An event is queued
+// at most once per webhook. Deliveries that run out of attempts stay as dead
+// letters until they are redelivered.
+type WebhookDelivery struct {
+	ID             uint       `gorm:"primarykey" json:"id"`
+	WebhookID      uint       `gorm:"column:webhook_id;NOT NULL;uniqueIndex:idx_webhook_deliveries_event" json:"webhook_id"`
+	EventID        string     `gorm:"column:event_id;NOT NULL;uniqueIndex:idx_webhook_deliveries_event" json:"event_id"`
+	Event          string     `gorm:"column:event;NOT NULL" json:"event"`
+	Payload        string     `gorm:"column:payload;NOT NULL;type:text" json:"payload"`
+	Status         string     `gorm:"column:status;NOT NULL;index:idx_webhook_deliveries_due" json:"status"`
+	Attempts       int        `gorm:"column:attempts;NOT NULL;default:0" json:"attempts"`
+	NextAttemptAt  time.Time  `gorm:"column:next_attempt_at;NOT NULL;index:idx_webhook_deliveries_due" json:"next_attempt_at"`
+	ResponseStatus int        `gorm:"column:response_status" json:"response_status,omitempty"`
+	LastError      string     `gorm:"column:last_error" json:"last_error,omitempty"`
+	DeliveredAt    *time.Time `gorm:"column:delivered_at" json:"delivered_at,omitempty"`
+	CreatedAt      time.Time  `gorm:"column:createdAt" json:"createdAt"`
+	Webhook        *Webhook   `gorm:"foreignKey:WebhookID" json:"-"`
+}
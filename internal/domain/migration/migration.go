@@ -11,7 +11,80 @@ func Run(wr *gorm.DB) {
 	if err := wr.AutoMigrate(
 		&entity.Account{},
 		&entity.Transfer{},
+		&entity.LedgerEntry{},
+		&entity.Webhook{},
+		&entity.WebhookDelivery{},
+		&entity.OutboxEvent{},
+		&entity.TransferBatch{},
+		&entity.TransferBatchItem{},
+		&entity.PixKey{},
+		&entity.PixKeyClaim{},
+		&entity.PaymentRequest{},
+		&entity.Charge{},
+		&entity.ChargePayment{},
+		&entity.BillPayment{},
+		&entity.AccountChange{},
+		&entity.APIKey{},
 	); err != nil {
 		log.Fatal(err)
 	}
+
+	// The cpf used to be unique on its own; now it shares
+	// idx_accounts_document with the cnpj, and businesses leave it empty.
+	if wr.Migrator().HasConstraint(&entity.Account{}, "accounts_cpf_key") {
+		if err := wr.Migrator().DropConstraint(&entity.Account{}, "accounts_cpf_key"); err != nil {
+			log.Fatal(err)
+		}
+	}
+
+	// The barcode of bill payments used to have a plain index; now
+	// idx_bill_payments_barcode_open keeps it unique until refunded.
+	if wr.Migrator().HasIndex(&entity.BillPayment{}, "idx_bill_payments_barcode") {
+		if err := wr.Migrator().DropIndex(&entity.BillPayment{}, "idx_bill_payments_barcode"); err != nil {
+			log.Fatal(err)
+		}
+	}
+
+	if err := backfillLedger(wr); err != nil {
+		log.Fatal(err)
+	}
+}
+
+// backfillLedger rebuilds the ledger of databases created before it existed:
+// one entry per side of every transfer and its fee, plus an opening deposit making each
+// account history add up to its current balance.
+func backfillLedger(wr *gorm.DB) error {
+	return wr.Transaction(func(tx *gorm.DB) error {
+		var count int64
+		if err := tx.Model(&entity.LedgerEntry{}).Count(&count).Error; err != nil {
+			return err
+		}
+
+		if count > 0 {
+			return nil
+		}
+
+		if err := tx.Exec(`
+			INSERT INTO ledger_entries (account_id, kind, amount, transfer_id, "createdAt")
+			SELECT account_origin_id, ?, -amount, id, "createdAt" FROM transfers
+			UNION ALL
+			SELECT account_destination_id, ?, amount, id, "createdAt" FROM transfers
+			UNION ALL
+			SELECT account_origin_id, ?, -fee, id, "createdAt" FROM transfers WHERE fee > 0
+		`,
+			entity.LEDGER_ENTRY_KIND_TRANSFER_OUT,
+			entity.LEDGER_ENTRY_KIND_TRANSFER_IN,
+			entity.LEDGER_ENTRY_KIND_FEE,
+		).Error; err != nil {
+			return err
+		}
+
+		return tx.Exec(`
+			INSERT INTO ledger_entries (account_id, kind, amount, "createdAt")
+			SELECT accounts.id, ?, accounts.balance - COALESCE(SUM(ledger_entries.amount), 0), accounts."createdAt"
+			FROM accounts
+			LEFT JOIN ledger_entries ON ledger_entries.account_id = accounts.id
+			GROUP BY accounts.id, accounts.balance, accounts."createdAt"
+		`, entity.LEDGER_ENTRY_KIND_DEPOSIT).Error
+	})
 }
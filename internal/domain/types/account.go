@@ -1,17 +1,170 @@
 package types
 
+import (
+	"time"
+
+	"github.com/fms85/desafio-tecnico-go-stone/internal/domain/entity"
+)
+
+// AccountInput opens an individual account (PF, the default) with a CPF and
+// a name, or a business account (PJ) with a CNPJ and a legal name. Business
+// accounts are named after their trade name, or their legal name without one.
 type AccountInput struct {
-	ID     uint
-	Name   string `json:"name" binding:"required"`
-	CPF    string `json:"cpf" binding:"required,cpf"`
-	Secret string `json:"secret" binding:"required,min=6,max=12"`
+	ID        uint
+	Type      string `json:"type" binding:"omitempty,oneof=PF PJ"`
+	Name      string `json:"name" binding:"required_unless=Type PJ"`
+	CPF       string `json:"cpf" binding:"required_unless=Type PJ,omitempty,cpf"`
+	CNPJ      string `json:"cnpj" binding:"required_if=Type PJ,omitempty,cnpj"`
+	LegalName string `json:"legal_name" binding:"required_if=Type PJ,max=150"`
+	TradeName string `json:"trade_name" binding:"max=150"`
+	Secret    string `json:"secret" binding:"required,min=6,max=12"`
 }
 
 type GetBalanceAccountUri struct {
 	AccountID string `uri:"account_id" binding:"required,numeric"`
 }
 
+// CredentialsInput logs in with the CPF of an individual account or the CNPJ
+// of a business one.
 type CredentialsInput struct {
-	CPF    string `json:"cpf" binding:"required,cpf"`
+	CPF    string `json:"cpf" binding:"required_without=CNPJ,omitempty,cpf"`
+	CNPJ   string `json:"cnpj" binding:"omitempty,cnpj"`
+	Secret string `json:"secret" binding:"required,min=6,max=12"`
+}
+
+// TOKEN_VERSION_CLAIM carries the token version of the account in its
+// tokens. Tokens without it are of version zero, which accounts keep until
+// their secret first changes.
+const TOKEN_VERSION_CLAIM = "token_version"
+
+// AccountTokenClaims are the claims of the tokens issued to an account.
+func AccountTokenClaims(account *entity.Account) map[string]interface{} {
+	claims := map[string]interface{}{"account_id": account.ID}
+	if account.TokenVersion > 0 {
+		claims[TOKEN_VERSION_CLAIM] = account.TokenVersion
+	}
+
+	return claims
+}
+
+// Profile is the account as its owner sees it, with the contact data and
+// security settings the account alone leaves out.
+type Profile struct {
+	*entity.Account
+	Email       string `json:"email,omitempty"`
+	Phone       string `json:"phone,omitempty"`
+	TOTPEnabled bool   `json:"totp_enabled,omitempty"`
+}
+
+func CreateProfile(account *entity.Account) *Profile {
+	return &Profile{
+		Account:     account,
+		Email:       account.Email,
+		Phone:       account.Phone,
+		TOTPEnabled: account.TOTPEnabled,
+	}
+}
+
+// ProfileInput changes the fields it carries and leaves the others as they
+// are. An empty email or phone removes it.
+type ProfileInput struct {
+	Name  *string `json:"name" binding:"omitempty,max=150"`
+	Email *string `json:"email" binding:"omitempty,max=254"`
+	Phone *string `json:"phone" binding:"omitempty,max=20"`
+}
+
+type SecretChangeInput struct {
+	CurrentSecret string `json:"current_secret" binding:"required,min=6,max=12"`
+	Secret        string `json:"secret" binding:"required,min=6,max=12"`
+}
+
+// SecretResetInput asks for a reset code for the account of a CPF or CNPJ.
+type SecretResetInput struct {
+	CPF  string `json:"cpf" binding:"required_without=CNPJ,omitempty,cpf"`
+	CNPJ string `json:"cnpj" binding:"omitempty,cnpj"`
+}
+
+// AccountAttempt is a kind of attempt against an account, such as trying a
+// reset code, counted in AttemptsColumn up to MaxAttempts. With a
+// LockedUntilColumn, the attempt taking the last one locks the account out
+// for LockDuration from Now and starts the count over instead.
+type AccountAttempt struct {
+	AttemptsColumn    string
+	MaxAttempts       int
+	LockedUntilColumn string
+	LockDuration      time.Duration
+	Now               time.Time
+}
+
+// SecretResetConfirmInput sets a new secret with the code sent by a reset.
+type SecretResetConfirmInput struct {
+	CPF    string `json:"cpf" binding:"required_without=CNPJ,omitempty,cpf"`
+	CNPJ   string `json:"cnpj" binding:"omitempty,cnpj"`
+	Code   string `json:"code" binding:"required,numeric"`
 	Secret string `json:"secret" binding:"required,min=6,max=12"`
 }
+
+// PINInput sets the transaction PIN of an account, with its secret the first
+// time and with the current PIN afterwards.
+type PINInput struct {
+	Secret     string `json:"secret" binding:"omitempty,max=12"`
+	CurrentPIN string `json:"current_pin" binding:"omitempty,max=6"`
+	PIN        string `json:"pin" binding:"required,numeric,min=4,max=6"`
+}
+
+// TOTPEnrollment is what an authenticator app needs to add an account, either
+// typed in as the secret or read from a QR code of the URI.
+type TOTPEnrollment struct {
+	Secret string `json:"secret"`
+	URI    string `json:"uri"`
+}
+
+type TOTPQRCodeInput struct {
+	Size int `form:"size" binding:"omitempty,min=128,max=1024"`
+}
+
+type TOTPCodeInput struct {
+	Code string `json:"code" binding:"required,max=16"`
+}
+
+// TOTPRecoveryCodes are shown once, when two-factor authentication is
+// enabled; each of them logs in a single time without the authenticator.
+type TOTPRecoveryCodes struct {
+	RecoveryCodes []string `json:"recovery_codes"`
+}
+
+// LoginChallenge answers a login of an account with two-factor
+// authentication, to be completed with a TOTP or recovery code.
+type LoginChallenge struct {
+	Challenge string    `json:"challenge"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+// LoginChallengeInput completes a login challenge with a TOTP code or a
+// recovery code.
+type LoginChallengeInput struct {
+	Challenge string `json:"challenge" binding:"required,max=128"`
+	Code      string `json:"code" binding:"required,max=16"`
+}
+
+// AccountLimits are how much a single transfer of an account can move and
+// the fee charged to the origin for each transfer it sends.
+type AccountLimits struct {
+	TransferLimit float64 `json:"transfer_limit"`
+	TransferFee   float64 `json:"transfer_fee"`
+}
+
+var ACCOUNT_LIMITS = map[string]AccountLimits{
+	entity.ACCOUNT_TYPE_PF: {TransferLimit: 5000, TransferFee: 0},
+	entity.ACCOUNT_TYPE_PJ: {TransferLimit: 100000, TransferFee: 1.5},
+}
+
+// GetAccountLimits returns the limits of an account type. Accounts created
+// before types existed are individual ones.
+func GetAccountLimits(accountType string) AccountLimits {
+	if limits, ok := ACCOUNT_LIMITS[accountType]; ok {
+		return limits
+	}
+
+	return ACCOUNT_LIMITS[entity.ACCOUNT_TYPE_PF]
+}
@@ -0,0 +1,70 @@
+package types
+
+import (
+	"time"
+
+	"github.com/fms85/desafio-tecnico-go-stone/internal/domain/entity"
+	"github.com/fms85/desafio-tecnico-go-stone/internal/util"
+)
+
+const (
+	ACCOUNT_EVENT_BALANCE           = "balance"
+	ACCOUNT_EVENT_BALANCE_CHANGED   = "balance.changed"
+	ACCOUNT_EVENT_TRANSFER_RECEIVED = "transfer.received"
+)
+
+// AccountEventsInput resumes a stream after LastEventID, taken from the
+// Last-Event-ID header or, for clients that cannot set it, the query.
+type AccountEventsInput struct {
+	AccountID   uint
+	LastEventID *uint `form:"last_event_id" binding:"omitempty,min=1"`
+}
+
+// AccountEvent is one message of the account event stream. ID is the ledger
+// entry the event comes from, or the last one for the initial balance.
+type AccountEvent struct {
+	ID         uint      `json:"id"`
+	Type       string    `json:"type"`
+	Balance    float64   `json:"balance"`
+	Amount     float64   `json:"amount,omitempty"`
+	Kind       string    `json:"kind,omitempty"`
+	TransferID *uint     `json:"transfer_id,omitempty"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+func CreateBalanceEvent(lastEntryID uint, balance float64, now time.Time) *AccountEvent {
+	return &AccountEvent{
+		ID:        lastEntryID,
+		Type:      ACCOUNT_EVENT_BALANCE,
+		Balance:   balance,
+		CreatedAt: now.In(RECEIPT_LOCATION),
+	}
+}
+
+// CreateAccountEvents turns ledger entries into events carrying the balance
+// after each of them, starting from opening.
+func CreateAccountEvents(opening float64, entries []*entity.LedgerEntry) []*AccountEvent {
+	events := make([]*AccountEvent, 0, len(entries))
+
+	balance := opening
+	for _, entry := range entries {
+		balance = util.Sum(balance, entry.Amount)
+
+		eventType := ACCOUNT_EVENT_BALANCE_CHANGED
+		if entry.Kind == entity.LEDGER_ENTRY_KIND_TRANSFER_IN {
+			eventType = ACCOUNT_EVENT_TRANSFER_RECEIVED
+		}
+
+		events = append(events, &AccountEvent{
+			ID:         entry.ID,
+			Type:       eventType,
+			Balance:    balance,
+			Amount:     entry.Amount,
+			Kind:       entry.Kind,
+			TransferID: entry.TransferID,
+			CreatedAt:  entry.CreatedAt.In(RECEIPT_LOCATION),
+		})
+	}
+
+	return events
+}
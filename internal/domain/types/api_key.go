@@ -0,0 +1,28 @@
+package types
+
+import (
+	"github.com/fms85/desafio-tecnico-go-stone/internal/domain/entity"
+)
+
+// APIKeyInput creates a key. Secret is the one of the account, and TOTPCode
+// is taken from its header for accounts with two-factor authentication, as
+// a key is as good as a login. ExpiresIn is in seconds.
+type APIKeyInput struct {
+	AccountID uint     `json:"-"`
+	Name      string   `json:"name" binding:"required,max=64"`
+	Scopes    []string `json:"scopes" binding:"required,min=1,dive,oneof=balance:read transfers:read transfers:write"`
+	ExpiresIn int      `json:"expires_in" binding:"omitempty,min=3600,max=31536000"`
+	Secret    string   `json:"secret" binding:"required,max=12"`
+	TOTPCode  string   `json:"-"`
+}
+
+type APIKeyUri struct {
+	KeyID string `uri:"key_id" binding:"required,max=64"`
+}
+
+// APIKeyCredentials is the creation response, the only one carrying the
+// secret of the key.
+type APIKeyCredentials struct {
+	*entity.APIKey
+	Secret string `json:"secret"`
+}
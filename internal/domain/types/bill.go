@@ -0,0 +1,35 @@
+package types
+
+// BoletoInput is a boleto barcode or digitable line, with or without the dots
+// and spaces it is printed with.
+type BoletoInput struct {
+	Code string `json:"code" binding:"required,max=64"`
+}
+
+// Boleto is what a boleto barcode says. Amount is zero when the payer
+// chooses it, and DueDate is empty when there is none.
+type Boleto struct {
+	Type          string  `json:"type"`
+	Barcode       string  `json:"barcode"`
+	DigitableLine string  `json:"digitable_line"`
+	BankCode      string  `json:"bank_code,omitempty"`
+	Segment       string  `json:"segment,omitempty"`
+	Amount        float64 `json:"amount"`
+	DueDate       string  `json:"due_date,omitempty"`
+	Overdue       bool    `json:"overdue"`
+}
+
+// BillPaymentInput pays a boleto. Amount is only needed for boletos that do
+// not carry one, and must match it otherwise. Authorization is checked
+// against the amount paid, as in transfers.
+type BillPaymentInput struct {
+	AccountID     uint
+	Code          string  `json:"code" binding:"required,max=64"`
+	Amount        float64 `json:"amount" binding:"omitempty,gt=0"`
+	PIN           string  `json:"pin" binding:"max=6"`
+	Authorization TransferAuthorization
+}
+
+type BillPaymentUri struct {
+	PaymentID string `uri:"payment_id" binding:"required,numeric"`
+}
@@ -0,0 +1,43 @@
+package types
+
+const (
+	CHARGE_ROLE_CHARGER = "charger"
+	CHARGE_ROLE_PAYER   = "payer"
+)
+
+// ChargeInput asks PayerAccountID to pay by DueDate, a day in
+// RECEIPT_LOCATION. The charge can still be paid for GraceDays after it.
+// Partial payments are refused unless AllowPartial is set, and then they
+// must be at least MinPartialAmount, except for the one that settles it.
+type ChargeInput struct {
+	ChargerAccountID uint
+	PayerAccountID   uint    `json:"payer_account_id" binding:"required"`
+	Amount           float64 `json:"amount" binding:"required,gt=0"`
+	Description      string  `json:"description" binding:"max=140"`
+	DueDate          string  `json:"due_date" binding:"required,datetime=2006-01-02"`
+	GraceDays        int     `json:"grace_days" binding:"omitempty,min=0,max=60"`
+	AllowPartial     bool    `json:"allow_partial"`
+	MinPartialAmount float64 `json:"min_partial_amount" binding:"omitempty,gt=0"`
+}
+
+// ChargeFilterInput lists the charges an account made (charger) or was asked
+// to pay (payer); both when Role is empty.
+type ChargeFilterInput struct {
+	AccountID uint
+	Role      string `form:"role" binding:"omitempty,oneof=charger payer"`
+	Status    string `form:"status" binding:"omitempty,oneof=pending partially_paid paid declined expired"`
+}
+
+type ChargeUri struct {
+	ChargeID string `uri:"charge_id" binding:"required,numeric"`
+}
+
+// ChargePaymentInput pays a charge. Without Amount it pays what is left.
+// Authorization is checked against the amount paid, as in transfers.
+type ChargePaymentInput struct {
+	ChargeID       uint
+	PayerAccountID uint
+	Amount         float64 `json:"amount" binding:"omitempty,gt=0"`
+	PIN            string  `json:"pin" binding:"max=6"`
+	Authorization  TransferAuthorization
+}
@@ -0,0 +1,50 @@
+package types
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/fms85/desafio-tecnico-go-stone/internal/domain/entity"
+)
+
+// Event is how outbox events leave the service, to webhooks and any other
+// sink. ID is stable across redeliveries so consumers can deduplicate.
+type Event struct {
+	ID        string          `json:"id"`
+	Type      string          `json:"type"`
+	AccountID uint            `json:"account_id"`
+	CreatedAt time.Time       `json:"created_at"`
+	Data      json.RawMessage `json:"data"`
+}
+
+type AccountCreatedData struct {
+	ID        uint      `json:"id"`
+	Name      string    `json:"name"`
+	Balance   float64   `json:"balance"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+func CreateOutboxEvent(accountID uint, eventType string, data interface{}) (*entity.OutboxEvent, error) {
+	payload, err := json.Marshal(data)
+	if err != nil {
+		return nil, fmt.Errorf("error to encode %s event: %w", eventType, err)
+	}
+
+	return &entity.OutboxEvent{
+		AccountID: accountID,
+		Type:      eventType,
+		Payload:   string(payload),
+	}, nil
+}
+
+func CreateEvent(outboxEvent *entity.OutboxEvent) Event {
+	return Event{
+		ID:        strconv.FormatUint(uint64(outboxEvent.ID), 10),
+		Type:      outboxEvent.Type,
+		AccountID: outboxEvent.AccountID,
+		CreatedAt: outboxEvent.CreatedAt,
+		Data:      json.RawMessage(outboxEvent.Payload),
+	}
+}
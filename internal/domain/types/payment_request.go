@@ -0,0 +1,75 @@
+package types
+
+import (
+	"time"
+
+	"github.com/fms85/desafio-tecnico-go-stone/internal/domain/entity"
+)
+
+// PaymentRequestInput creates a charge. Amount is optional: without it the
+// payer chooses how much to send.
+type PaymentRequestInput struct {
+	AccountID   uint
+	Type        string  `json:"type" binding:"required,oneof=static dynamic"`
+	Key         string  `json:"key" binding:"required_if=Type static,max=77"`
+	Amount      float64 `json:"amount" binding:"omitempty,gt=0"`
+	Description string  `json:"description" binding:"max=72"`
+	ExpiresIn   int     `json:"expires_in" binding:"omitempty,min=60,max=86400"`
+}
+
+type PaymentRequestUri struct {
+	RequestID string `uri:"request_id" binding:"required,numeric"`
+}
+
+type PaymentRequestQRCodeInput struct {
+	Size int `form:"size" binding:"omitempty,min=128,max=1024"`
+}
+
+type PaymentRequestLocationUri struct {
+	TxID string `uri:"txid" binding:"required,alphanum,max=35"`
+}
+
+type BRCodeInput struct {
+	Payload string `json:"payload" binding:"required,max=512"`
+}
+
+// PaymentRequestLocation is what payment apps fetch from the URL of a
+// dynamic code.
+type PaymentRequestLocation struct {
+	TxID        string       `json:"txid"`
+	Amount      float64      `json:"amount"`
+	Description string       `json:"description,omitempty"`
+	Receiver    ReceiptParty `json:"receiver"`
+	ExpiresAt   *time.Time   `json:"expires_at,omitempty"`
+}
+
+func CreatePaymentRequestLocation(paymentRequest *entity.PaymentRequest, account *entity.Account) *PaymentRequestLocation {
+	return &PaymentRequestLocation{
+		TxID:        paymentRequest.TxID,
+		Amount:      paymentRequest.Amount,
+		Description: paymentRequest.Description,
+		Receiver:    CreateReceiptParty(account),
+		ExpiresAt:   paymentRequest.ExpiresAt,
+	}
+}
+
+// TransferQuote is a transfer filled in from a BR Code, ready to be posted to
+// /transfers once the payer confirms it. A zero amount is left for the payer
+// to choose.
+type TransferQuote struct {
+	AccountDestinationID uint    `json:"account_destination_id,omitempty"`
+	DestinationKey       string  `json:"destination_key,omitempty"`
+	Amount               float64 `json:"amount"`
+}
+
+// BRCodeQuote is a parsed BR Code with the receiver it resolves to.
+type BRCodeQuote struct {
+	Type         string        `json:"type"`
+	TxID         string        `json:"txid,omitempty"`
+	MerchantName string        `json:"merchant_name"`
+	MerchantCity string        `json:"merchant_city"`
+	Description  string        `json:"description,omitempty"`
+	Receiver     ReceiptParty  `json:"receiver"`
+	ExpiresAt    *time.Time    `json:"expires_at,omitempty"`
+	Transfer     TransferQuote `json:"transfer"`
+}
@@ -0,0 +1,98 @@
+package types
+
+import (
+	"strings"
+
+	"github.com/fms85/desafio-tecnico-go-stone/internal/domain/entity"
+	"github.com/fms85/desafio-tecnico-go-stone/internal/util"
+)
+
+// PixKeyInput registers a key. Key is only read for email and phone keys:
+// CPF keys take the CPF of the account and random keys are generated.
+type PixKeyInput struct {
+	AccountID uint
+	Type      string `json:"type" binding:"required,oneof=cpf email phone evp"`
+	Key       string `json:"key" binding:"required_if=Type email,required_if=Type phone,max=77"`
+}
+
+type PixKeyUri struct {
+	KeyID string `uri:"key_id" binding:"required,numeric"`
+}
+
+type PixKeyVerifyInput struct {
+	Code string `json:"code" binding:"required,len=6,numeric"`
+}
+
+type PixKeyLookupInput struct {
+	Key string `form:"key" binding:"required,max=77"`
+}
+
+type PixClaimInput struct {
+	ClaimerAccountID uint
+	Key              string `json:"key" binding:"required,max=77"`
+	Type             string `json:"type" binding:"omitempty,oneof=ownership portability"`
+}
+
+type PixClaimUri struct {
+	ClaimID string `uri:"claim_id" binding:"required,numeric"`
+}
+
+// PixKeyLookup is what anyone paying to a key gets to see: enough to confirm
+// they are paying the right person, as CreateReceiptParty shows them.
+type PixKeyLookup struct {
+	Key       string `json:"key"`
+	Type      string `json:"type"`
+	AccountID uint   `json:"account_id"`
+	Name      string `json:"name"`
+	CPF       string `json:"cpf,omitempty"`
+	CNPJ      string `json:"cnpj,omitempty"`
+}
+
+func CreatePixKeyLookup(key *entity.PixKey, account *entity.Account) *PixKeyLookup {
+	party := CreateReceiptParty(account)
+	lookup := &PixKeyLookup{
+		Key:       key.Key,
+		Type:      key.Type,
+		AccountID: account.ID,
+		Name:      party.Name,
+		CPF:       party.CPF,
+		CNPJ:      party.CNPJ,
+	}
+
+	switch key.Type {
+	case entity.PIX_KEY_TYPE_CPF:
+		lookup.Key = util.MaskCPF(key.Key)
+	case entity.PIX_KEY_TYPE_EMAIL:
+		lookup.Key = util.MaskEmail(key.Key)
+	case entity.PIX_KEY_TYPE_PHONE:
+		lookup.Key = util.MaskPhone(key.Key)
+	}
+
+	return lookup
+}
+
+// NormalizePixKey brings a key typed by a payer to the form it is stored in,
+// guessing its type from its shape.
+func NormalizePixKey(key string) string {
+	key = strings.TrimSpace(key)
+
+	if strings.Contains(key, "@") {
+		if email, ok := util.NormalizeEmail(key); ok {
+			return email
+		}
+
+		return key
+	}
+
+	if strings.HasPrefix(key, "+") {
+		phone, _ := util.NormalizePhone(key)
+
+		return phone
+	}
+
+	if digits := strings.NewReplacer(".", "", "-", "").Replace(key); util.ValidateCPF(digits) {
+		return digits
+	}
+
+	return strings.ToLower(key)
+}
@@ -0,0 +1,84 @@
+package types
+
+import (
+	"time"
+
+	"github.com/fms85/desafio-tecnico-go-stone/internal/domain/entity"
+	"github.com/fms85/desafio-tecnico-go-stone/internal/util"
+)
+
+const (
+	STATEMENT_FORMAT_JSON = "json"
+	STATEMENT_FORMAT_CSV  = "csv"
+	STATEMENT_FORMAT_OFX  = "ofx"
+	STATEMENT_FORMAT_PDF  = "pdf"
+)
+
+// StatementInput covers whole days in RECEIPT_LOCATION, both ends included.
+type StatementInput struct {
+	AccountID uint
+	From      time.Time `form:"from" binding:"required" time_format:"2006-01-02" time_location:"America/Sao_Paulo"`
+	To        time.Time `form:"to" binding:"required,gtefield=From" time_format:"2006-01-02" time_location:"America/Sao_Paulo"`
+	Format    string    `form:"format" binding:"omitempty,oneof=json csv ofx pdf"`
+}
+
+type StatementEntry struct {
+	ID         uint      `json:"id"`
+	Kind       string    `json:"kind"`
+	Amount     float64   `json:"amount"`
+	Balance    float64   `json:"balance"`
+	TransferID *uint     `json:"transfer_id,omitempty"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+type Statement struct {
+	AccountID      uint             `json:"account_id"`
+	From           time.Time        `json:"from"`
+	To             time.Time        `json:"to"`
+	OpeningBalance float64          `json:"opening_balance"`
+	TotalCredits   float64          `json:"total_credits"`
+	TotalDebits    float64          `json:"total_debits"`
+	ClosingBalance float64          `json:"closing_balance"`
+	Entries        []StatementEntry `json:"entries"`
+}
+
+// StatementWriter receives a statement as it is read: the header once the
+// opening balance is known, every entry in order, and the footer with the
+// totals and closing balance.
+type StatementWriter interface {
+	WriteHeader(statement *Statement) error
+	WriteEntry(statement *Statement, entry StatementEntry) error
+	WriteFooter(statement *Statement) error
+}
+
+func CreateStatement(statementInput StatementInput, opening float64) *Statement {
+	return &Statement{
+		AccountID:      statementInput.AccountID,
+		From:           statementInput.From.In(RECEIPT_LOCATION),
+		To:             statementInput.To.In(RECEIPT_LOCATION),
+		OpeningBalance: opening,
+		ClosingBalance: opening,
+		Entries:        []StatementEntry{},
+	}
+}
+
+// Add moves the running balance and totals by entry and returns its
+// statement line. It does not keep the line, so exports stay streaming.
+func (statement *Statement) Add(entry *entity.LedgerEntry) StatementEntry {
+	statement.ClosingBalance = util.Sum(statement.ClosingBalance, entry.Amount)
+
+	if entry.Amount >= 0 {
+		statement.TotalCredits = util.Sum(statement.TotalCredits, entry.Amount)
+	} else {
+		statement.TotalDebits = util.Sub(statement.TotalDebits, entry.Amount)
+	}
+
+	return StatementEntry{
+		ID:         entry.ID,
+		Kind:       entry.Kind,
+		Amount:     entry.Amount,
+		Balance:    statement.ClosingBalance,
+		TransferID: entry.TransferID,
+		CreatedAt:  entry.CreatedAt.In(RECEIPT_LOCATION),
+	}
+}
@@ -1,17 +1,88 @@
 package types
 
-import "github.com/fms85/desafio-tecnico-go-stone/internal/domain/entity"
+import (
+	"context"
+	"time"
+	_ "time/tzdata"
 
+	"github.com/fms85/desafio-tecnico-go-stone/internal/domain/entity"
+	"github.com/fms85/desafio-tecnico-go-stone/internal/util"
+)
+
+// RECEIPT_LOCATION is the time zone receipts are rendered in. The tz database
+// is embedded so it also works on images without zoneinfo.
+var RECEIPT_LOCATION, _ = time.LoadLocation("America/Sao_Paulo")
+
+// TransferInput takes the destination either by account id or by a Pix key,
+// which is resolved to the account it belongs to. The PIN may also come in a
+// header, so it is left to the delivery to check.
 type TransferInput struct {
 	AccountOriginID      uint
-	AccountDestinationID uint    `json:"account_destination_id" binding:"required"`
-	Amount               float64 `json:"amount" binding:"required"`
+	AccountDestinationID uint    `json:"account_destination_id" binding:"required_without=DestinationKey"`
+	DestinationKey       string  `json:"destination_key" binding:"max=77"`
+	Amount               float64 `json:"amount" binding:"required,gt=0"`
+	PIN                  string  `json:"pin" binding:"max=6"`
+}
+
+// TransferAuthorization is what the owner of the origin account sends, on top
+// of the token, to move money: the transaction PIN and, over the step-up
+// amount, a TOTP code. APIKeyID is set instead when a service account moves
+// the money with an API key.
+type TransferAuthorization struct {
+	PIN      string
+	TOTPCode string
+	APIKeyID string
+}
+
+type GetTransferUri struct {
+	TransferID string `uri:"transfer_id" binding:"required,numeric"`
+}
+
+type VerifyReceiptUri struct {
+	Code string `uri:"code" binding:"required,alphanum"`
+}
+
+type ReceiptParty struct {
+	Name string `json:"name"`
+	CPF  string `json:"cpf,omitempty"`
+	CNPJ string `json:"cnpj,omitempty"`
 }
 
+// CreateReceiptParty shows an account to the other side of a payment. Names
+// and CPFs of individuals are masked; businesses are shown as registered,
+// since their name and CNPJ are public.
+func CreateReceiptParty(account *entity.Account) ReceiptParty {
+	if account.Type == entity.ACCOUNT_TYPE_PJ {
+		return ReceiptParty{Name: account.Name, CNPJ: account.CNPJ}
+	}
+
+	return ReceiptParty{Name: util.MaskName(account.Name), CPF: util.MaskCPF(account.CPF)}
+}
+
+type TransferReceipt struct {
+	ID               uint         `json:"id"`
+	Status           string       `json:"status"`
+	Amount           float64      `json:"amount"`
+	Fee              float64      `json:"fee"`
+	Total            float64      `json:"total"`
+	Origin           ReceiptParty `json:"origin"`
+	Destination      ReceiptParty `json:"destination"`
+	CreatedAt        time.Time    `json:"created_at"`
+	VerificationCode string       `json:"verification_code"`
+}
+
+// TransferRecord writes what a transfer pays for, such as a charge payment,
+// in the transaction of the transfer once it has an id. Its error rolls the
+// transfer back.
+type TransferRecord func(ctx context.Context, transfer *entity.Transfer) error
+
+// TransferAggregation is what a transfer commits: the transfer, the balances
+// it leaves its accounts with and, when set, Record.
 type TransferAggregation struct {
 	AccountOrigin      *entity.Account
 	AccountDestination *entity.Account
 	Transfer           *entity.Transfer
+	Record             TransferRecord
 }
 
 func CreateTransferAggregation(TransferInput TransferInput, accountOrigin *entity.Account, accountDestination *entity.Account) *TransferAggregation {
@@ -23,7 +94,25 @@ func CreateTransferAggregation(TransferInput TransferInput, accountOrigin *entit
 		AccountOriginID:      TransferInput.AccountOriginID,
 		AccountDestinationID: TransferInput.AccountDestinationID,
 		Amount:               TransferInput.Amount,
+		Status:               entity.TRANSFER_STATUS_COMPLETED,
 	}
 
 	return TransferAggregation
 }
+
+// CreateTransferReceipt builds the receipt view of a transfer loaded with both
+// accounts. Individuals are masked because the receipt can be shown to
+// either party and to anyone holding the verification code.
+func CreateTransferReceipt(transfer *entity.Transfer, verificationCode string) *TransferReceipt {
+	return &TransferReceipt{
+		ID:               transfer.ID,
+		Status:           transfer.Status,
+		Amount:           transfer.Amount,
+		Fee:              transfer.Fee,
+		Total:            util.Sum(transfer.Amount, transfer.Fee),
+		Origin:           CreateReceiptParty(transfer.AccountOrigin),
+		Destination:      CreateReceiptParty(transfer.AccountDestination),
+		CreatedAt:        transfer.CreatedAt.In(RECEIPT_LOCATION),
+		VerificationCode: verificationCode,
+	}
+}
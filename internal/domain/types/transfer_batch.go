@@ -0,0 +1,72 @@
+package types
+
+import (
+	"errors"
+
+	"github.com/fms85/desafio-tecnico-go-stone/internal/domain/common"
+	"github.com/fms85/desafio-tecnico-go-stone/internal/domain/entity"
+	"github.com/fms85/desafio-tecnico-go-stone/internal/util"
+)
+
+// TRANSFER_BATCH_MAX_ITEMS caps the items of a batch. The binding tag of
+// TransferBatchInput.Items must be kept in sync.
+const TRANSFER_BATCH_MAX_ITEMS = 500
+
+// TransferBatchInput comes as JSON or as a CSV upload, in which case Mode is
+// read from the query or the form. The PIN of a CSV upload only comes in a
+// header, to keep it out of URLs.
+type TransferBatchInput struct {
+	AccountOriginID uint
+	Mode            string                   `json:"mode" form:"mode" binding:"omitempty,oneof=atomic best_effort"`
+	Items           []TransferBatchItemInput `json:"items" form:"-" binding:"required,min=1,max=500,dive"`
+	PIN             string                   `json:"pin" form:"-" binding:"max=6"`
+}
+
+type TransferBatchItemInput struct {
+	AccountDestinationID uint    `json:"account_destination_id" binding:"required"`
+	Amount               float64 `json:"amount" binding:"required,gt=0"`
+}
+
+type GetTransferBatchUri struct {
+	BatchID string `uri:"batch_id" binding:"required,numeric"`
+}
+
+// TransferBatchAggregation is what a batch commits: the batch with all of its
+// items and, aligned with them, the transfers of the completed ones.
+type TransferBatchAggregation struct {
+	Batch     *entity.TransferBatch
+	Transfers []*TransferAggregation
+}
+
+// FailItem turns the completed item at i into a failed one with err and drops
+// its transfer, for items that cannot go through in best effort mode.
+func (batchAggregation *TransferBatchAggregation) FailItem(i int, err error) {
+	batch, item := batchAggregation.Batch, batchAggregation.Batch.Items[i]
+
+	var codedError common.CodedError
+	errors.As(err, &codedError)
+
+	item.Status = entity.TRANSFER_BATCH_ITEM_STATUS_FAILED
+	item.ErrorCode = codedError.Code()
+	item.Error = err.Error()
+	batchAggregation.Transfers[i] = nil
+
+	batch.Total = util.Sub(batch.Total, item.Amount)
+	batch.Completed--
+	batch.Failed++
+}
+
+// SetStatus sets the status of the batch from how many items completed and
+// failed.
+func (batchAggregation *TransferBatchAggregation) SetStatus() {
+	batch := batchAggregation.Batch
+
+	switch {
+	case batch.Failed == 0:
+		batch.Status = entity.TRANSFER_BATCH_STATUS_COMPLETED
+	case batch.Completed == 0:
+		batch.Status = entity.TRANSFER_BATCH_STATUS_FAILED
+	default:
+		batch.Status = entity.TRANSFER_BATCH_STATUS_PARTIAL
+	}
+}
@@ -0,0 +1,34 @@
+package types
+
+import (
+	"github.com/fms85/desafio-tecnico-go-stone/internal/domain/entity"
+)
+
+type WebhookInput struct {
+	AccountID uint
+	URL       string   `json:"url" binding:"required,url,max=2048"`
+	Events    []string `json:"events" binding:"required,min=1,dive,oneof=transfer.created transfer.received transfer.reversed account.blocked"`
+	Secret    string   `json:"secret" binding:"omitempty,min=16,max=128"`
+}
+
+type WebhookUri struct {
+	WebhookID string `uri:"webhook_id" binding:"required,numeric"`
+}
+
+type WebhookDeliveryUri struct {
+	WebhookID  string `uri:"webhook_id" binding:"required,numeric"`
+	DeliveryID string `uri:"delivery_id" binding:"required,numeric"`
+}
+
+type WebhookDeliveryInput struct {
+	AccountID uint
+	WebhookID uint
+	Status    string `form:"status" binding:"omitempty,oneof=pending delivered dead"`
+}
+
+// WebhookSubscription is the creation response, the only one carrying the
+// signing secret.
+type WebhookSubscription struct {
+	*entity.Webhook
+	Secret string `json:"secret"`
+}
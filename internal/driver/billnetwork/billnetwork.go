@@ -0,0 +1,102 @@
+package billnetwork
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/fms85/desafio-tecnico-go-stone/internal/util"
+)
+
+// SIMULATOR_DECLINED_CENTS makes the simulator decline bills of amounts ending
+// in it, so local runs can go through a declined payment.
+const SIMULATOR_DECLINED_CENTS = 99
+
+var ErrDeclined = errors.New("bill payment declined by the payment network")
+
+// Bill is a boleto paid by its barcode. PaymentID identifies the payment on
+// our side, so the network can tell retries from new payments.
+type Bill struct {
+	PaymentID uint
+	Barcode   string
+	Amount    float64
+}
+
+// Settlement is the proof the network gives of a paid bill.
+type Settlement struct {
+	Authentication string
+	SettledAt      time.Time
+}
+
+// INetwork settles bills with whoever issued them. Pay returns ErrDeclined
+// when the network refuses a bill; other errors leave the outcome unknown.
+type INetwork interface {
+	Pay(ctx context.Context, bill Bill) (*Settlement, error)
+}
+
+type simulator struct {
+	log *slog.Logger
+}
+
+// NewSimulator returns a network that settles every bill on the spot, except
+// those of amounts ending in SIMULATOR_DECLINED_CENTS, for local runs where
+// there is no network to talk to.
+func NewSimulator(log *slog.Logger) INetwork {
+	return &simulator{log: log}
+}
+
+func (network *simulator) Pay(ctx context.Context, bill Bill) (*Settlement, error) {
+	if util.ConvertToDecimal(bill.Amount).Shift(2).IntPart()%100 == SIMULATOR_DECLINED_CENTS {
+		network.log.InfoContext(ctx, "bill declined", "payment_id", bill.PaymentID, "barcode", bill.Barcode)
+
+		return nil, ErrDeclined
+	}
+
+	settlement := &Settlement{
+		Authentication: strings.ToUpper(strings.ReplaceAll(util.GenerateUUID(), "-", "")),
+		SettledAt:      time.Now(),
+	}
+
+	network.log.InfoContext(ctx, "bill settled",
+		"payment_id", bill.PaymentID,
+		"barcode", bill.Barcode,
+		"amount", bill.Amount,
+		"authentication", settlement.Authentication,
+	)
+
+	return settlement, nil
+}
+
+// MemoryNetwork keeps paid bills, for tests. It fails with Err when set.
+type MemoryNetwork struct {
+	Err error
+
+	mu    sync.Mutex
+	bills []Bill
+}
+
+func (network *MemoryNetwork) Pay(_ context.Context, bill Bill) (*Settlement, error) {
+	if network.Err != nil {
+		return nil, network.Err
+	}
+
+	network.mu.Lock()
+	defer network.mu.Unlock()
+
+	network.bills = append(network.bills, bill)
+
+	return &Settlement{
+		Authentication: "MEMORY",
+		SettledAt:      time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC),
+	}, nil
+}
+
+func (network *MemoryNetwork) Bills() []Bill {
+	network.mu.Lock()
+	defer network.mu.Unlock()
+
+	return append([]Bill(nil), network.bills...)
+}
@@ -0,0 +1,46 @@
+package billnetwork
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSimulatorPay(t *testing.T) {
+	tests := []struct {
+		name    string
+		amount  float64
+		wantErr error
+	}{
+		{
+			name:   "should_settle_a_bill",
+			amount: 143.59,
+		},
+		{
+			name:    "should_decline_amounts_ending_in_99_cents",
+			amount:  10.99,
+			wantErr: ErrDeclined,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			network := NewSimulator(slog.New(slog.NewTextHandler(io.Discard, nil)))
+
+			settlement, err := network.Pay(context.Background(), Bill{PaymentID: 1, Barcode: "84670000001435900240200240500024384221010811", Amount: tt.amount})
+
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				assert.Nil(t, settlement)
+
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Regexp(t, `^[0-9A-F]{32}$`, settlement.Authentication)
+		})
+	}
+}
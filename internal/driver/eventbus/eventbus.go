@@ -0,0 +1,61 @@
+package eventbus
+
+import (
+	"sync"
+)
+
+// IBus tells the subscribers of an account that something changed in it.
+// Notifications carry no data and coalesce: a subscriber that has not read
+// the previous one gets a single wake-up, then reads the changes from the
+// source of truth.
+type IBus interface {
+	Publish(accountID uint)
+	Subscribe(accountID uint) (<-chan struct{}, func())
+}
+
+type bus struct {
+	mu          sync.Mutex
+	subscribers map[uint]map[chan struct{}]struct{}
+}
+
+func New() IBus {
+	return &bus{subscribers: map[uint]map[chan struct{}]struct{}{}}
+}
+
+func (b *bus) Publish(accountID uint) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	for subscriber := range b.subscribers[accountID] {
+		select {
+		case subscriber <- struct{}{}:
+		default:
+		}
+	}
+}
+
+// Subscribe returns the notification channel of an account and the function
+// that unsubscribes from it.
+func (b *bus) Subscribe(accountID uint) (<-chan struct{}, func()) {
+	subscriber := make(chan struct{}, 1)
+
+	b.mu.Lock()
+	if b.subscribers[accountID] == nil {
+		b.subscribers[accountID] = map[chan struct{}]struct{}{}
+	}
+	b.subscribers[accountID][subscriber] = struct{}{}
+	b.mu.Unlock()
+
+	var once sync.Once
+	return subscriber, func() {
+		once.Do(func() {
+			b.mu.Lock()
+			defer b.mu.Unlock()
+
+			delete(b.subscribers[accountID], subscriber)
+			if len(b.subscribers[accountID]) == 0 {
+				delete(b.subscribers, accountID)
+			}
+		})
+	}
+}
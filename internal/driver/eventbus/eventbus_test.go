@@ -0,0 +1,58 @@
+package eventbus
+
+import (
+	"testing"
+
+	"gotest.tools/assert"
+)
+
+func received(ch <-chan struct{}) bool {
+	select {
+	case <-ch:
+		return true
+	default:
+		return false
+	}
+}
+
+func TestBus(t *testing.T) {
+	t.Run("should_fan_out_to_every_subscriber_of_the_account", func(t *testing.T) {
+		b := New()
+		first, unsubscribeFirst := b.Subscribe(3)
+		defer unsubscribeFirst()
+		second, unsubscribeSecond := b.Subscribe(3)
+		defer unsubscribeSecond()
+		other, unsubscribeOther := b.Subscribe(4)
+		defer unsubscribeOther()
+
+		b.Publish(3)
+
+		assert.Assert(t, received(first))
+		assert.Assert(t, received(second))
+		assert.Assert(t, !received(other))
+	})
+
+	t.Run("should_coalesce_unread_notifications", func(t *testing.T) {
+		b := New()
+		ch, unsubscribe := b.Subscribe(3)
+		defer unsubscribe()
+
+		b.Publish(3)
+		b.Publish(3)
+
+		assert.Assert(t, received(ch))
+		assert.Assert(t, !received(ch))
+	})
+
+	t.Run("should_stop_notifying_after_unsubscribe", func(t *testing.T) {
+		b := New()
+		ch, unsubscribe := b.Subscribe(3)
+		unsubscribe()
+		unsubscribe()
+
+		b.Publish(3)
+
+		assert.Assert(t, !received(ch))
+		assert.Equal(t, 0, len(b.(*bus).subscribers))
+	})
+}
@@ -1,6 +1,7 @@
 package gin
 
 import (
+	"github.com/fms85/desafio-tecnico-go-stone/internal/driver/i18n"
 	"github.com/fms85/desafio-tecnico-go-stone/internal/util"
 	"github.com/gin-gonic/gin"
 	"github.com/gin-gonic/gin/binding"
@@ -8,13 +9,26 @@ import (
 )
 
 func Setup() *gin.Engine {
-	router := gin.Default()
+	router := gin.New()
+	router.Use(gin.Recovery())
 
+	SetupValidator()
+
+	return router
+}
+
+// SetupValidator registers the custom tags and translations on the shared
+// binding validator, which the gRPC handlers reuse to validate their inputs.
+func SetupValidator() {
 	if v, ok := binding.Validator.Engine().(*validator.Validate); ok {
 		if err := v.RegisterValidation("cpf", util.CpfValidator); err != nil {
 			panic(err)
 		}
-	}
 
-	return router
+		if err := v.RegisterValidation("cnpj", util.CnpjValidator); err != nil {
+			panic(err)
+		}
+
+		i18n.Setup(v)
+	}
 }
@@ -1,21 +1,33 @@
 package gorm
 
 import (
+	"log/slog"
+
+	"github.com/fms85/desafio-tecnico-go-stone/internal/driver/logger"
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
-	"gorm.io/gorm/logger"
+	"gorm.io/plugin/opentelemetry/tracing"
 )
 
-func Setup(connection string) *gorm.DB {
+func Setup(connection string, level slog.Level) *gorm.DB {
 	db, err := gorm.Open(postgres.Open(connection), &gorm.Config{
 		SkipDefaultTransaction:   true,
 		DisableNestedTransaction: true,
-		Logger:                   logger.Default.LogMode(logger.Info),
+		TranslateError:           true,
+		Logger:                   newLogger(level),
 	})
 
 	if err != nil {
 		panic(err)
 	}
 
+	if err := db.Use(tracing.NewPlugin(
+		tracing.WithoutMetrics(),
+		tracing.WithoutQueryVariables(),
+		tracing.WithQueryFormatter(logger.RedactString),
+	)); err != nil {
+		panic(err)
+	}
+
 	return db
 }
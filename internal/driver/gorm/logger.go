@@ -0,0 +1,79 @@
+package gorm
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"time"
+
+	"github.com/fms85/desafio-tecnico-go-stone/internal/driver/logger"
+	"gorm.io/gorm"
+	gormLogger "gorm.io/gorm/logger"
+)
+
+const SLOW_QUERY_THRESHOLD = 200 * time.Millisecond
+
+// slogLogger forwards gorm logs to the request logger found in the context.
+// Statements are only traced at debug level and never carry bound values,
+// and neither do messages, whose args may be the values of a statement.
+type slogLogger struct {
+	level gormLogger.LogLevel
+}
+
+func newLogger(level slog.Level) gormLogger.Interface {
+	gormLevel := gormLogger.Warn
+	if level <= slog.LevelDebug {
+		gormLevel = gormLogger.Info
+	} else if level >= slog.LevelError {
+		gormLevel = gormLogger.Error
+	}
+
+	return &slogLogger{level: gormLevel}
+}
+
+func (l *slogLogger) LogMode(level gormLogger.LogLevel) gormLogger.Interface {
+	return &slogLogger{level: level}
+}
+
+func (l *slogLogger) Info(ctx context.Context, msg string, args ...interface{}) {
+	if l.level >= gormLogger.Info {
+		logger.FromContext(ctx).InfoContext(ctx, msg)
+	}
+}
+
+func (l *slogLogger) Warn(ctx context.Context, msg string, args ...interface{}) {
+	if l.level >= gormLogger.Warn {
+		logger.FromContext(ctx).WarnContext(ctx, msg)
+	}
+}
+
+func (l *slogLogger) Error(ctx context.Context, msg string, args ...interface{}) {
+	if l.level >= gormLogger.Error {
+		logger.FromContext(ctx).ErrorContext(ctx, msg)
+	}
+}
+
+func (l *slogLogger) Trace(ctx context.Context, begin time.Time, fc func() (string, int64), err error) {
+	if l.level <= gormLogger.Silent {
+		return
+	}
+
+	elapsed := time.Since(begin)
+	log := logger.FromContext(ctx)
+
+	switch {
+	case err != nil && l.level >= gormLogger.Error && !errors.Is(err, gorm.ErrRecordNotFound):
+		sql, rows := fc()
+		log.ErrorContext(ctx, "sql error", "sql", sql, "rows", rows, "elapsed", elapsed, "error", err)
+	case elapsed > SLOW_QUERY_THRESHOLD && l.level >= gormLogger.Warn:
+		sql, rows := fc()
+		log.WarnContext(ctx, "slow sql", "sql", sql, "rows", rows, "elapsed", elapsed)
+	case l.level >= gormLogger.Info:
+		sql, rows := fc()
+		log.DebugContext(ctx, "sql", "sql", sql, "rows", rows, "elapsed", elapsed)
+	}
+}
+
+func (l *slogLogger) ParamsFilter(ctx context.Context, sql string, params ...interface{}) (string, []interface{}) {
+	return sql, nil
+}
@@ -0,0 +1,161 @@
+package i18n
+
+var validationCatalog = map[string]map[string]string{
+	"cpf": {
+		LANGUAGE_PT_BR: "{0} deve ser um CPF válido",
+		LANGUAGE_EN:    "{0} must be a valid CPF",
+	},
+	"cnpj": {
+		LANGUAGE_PT_BR: "{0} deve ser um CNPJ válido",
+		LANGUAGE_EN:    "{0} must be a valid CNPJ",
+	},
+	"required_without": {
+		LANGUAGE_PT_BR: "{0} é um campo obrigatório",
+		LANGUAGE_EN:    "{0} is a required field",
+	},
+	"required_if": {
+		LANGUAGE_PT_BR: "{0} é um campo obrigatório",
+		LANGUAGE_EN:    "{0} is a required field",
+	},
+	"required_unless": {
+		LANGUAGE_PT_BR: "{0} é um campo obrigatório",
+		LANGUAGE_EN:    "{0} is a required field",
+	},
+}
+
+var catalog = map[string]map[string]string{
+	LANGUAGE_PT_BR: {
+		"Bad Request":           "Requisição inválida",
+		"Unauthorized":          "Não autorizado",
+		"Forbidden":             "Proibido",
+		"Not Found":             "Não encontrado",
+		"Conflict":              "Conflito",
+		"Unprocessable Entity":  "Entidade não processável",
+		"Internal Server Error": "Erro interno do servidor",
+
+		"account not found":                                     "conta não encontrada",
+		"account origin not found":                              "conta de origem não encontrada",
+		"account destination not found":                         "conta de destino não encontrada",
+		"account already exists":                                "conta já existe",
+		"transfer not found":                                    "transferência não encontrada",
+		"receipt not found":                                     "comprovante não encontrado",
+		"webhook not found":                                     "webhook não encontrado",
+		"webhook delivery not found":                            "entrega de webhook não encontrada",
+		"webhook url must use http or https":                    "a url do webhook deve usar http ou https",
+		"webhook url must point to a public address":            "a url do webhook deve apontar para um endereço público",
+		"invalid last event id":                                 "last event id inválido",
+		"already exists":                                        "já existe",
+		"not found":                                             "não encontrado",
+		"insufficient funds":                                    "saldo insuficiente",
+		"limit exceeded":                                        "limite excedido",
+		"unauthorized":                                          "não autorizado",
+		"forbidden":                                             "proibido",
+		"invalid credentials":                                   "credenciais inválidas",
+		"authorization header missing":                          "cabeçalho Authorization ausente",
+		"invalid authorization header format":                   "formato do cabeçalho Authorization inválido",
+		"validation failed":                                     "falha de validação",
+		"invalid request body":                                  "corpo da requisição inválido",
+		"internal server error":                                 "erro interno do servidor",
+		"origin and destination accounts are equal":             "contas de origem e destino são iguais",
+		"transfer batch not found":                              "lote de transferências não encontrado",
+		"invalid transfer batch destinations":                   "lote de transferências com destinos inválidos",
+		"invalid csv file":                                      "arquivo csv inválido",
+		"missing column account_destination_id":                 "coluna account_destination_id ausente",
+		"missing column amount":                                 "coluna amount ausente",
+		"account_destination_id must be a number":               "account_destination_id deve ser um número",
+		"amount must be a number":                               "amount deve ser um número",
+		"pix key not found":                                     "chave pix não encontrada",
+		"pix key already exists":                                "chave pix já existe",
+		"pix key claim not found":                               "reivindicação de chave pix não encontrada",
+		"pix key claim already exists":                          "reivindicação de chave pix já existe",
+		"pix key limit reached":                                 "limite de chaves pix atingido",
+		"invalid email key":                                     "chave de e-mail inválida",
+		"invalid phone key":                                     "chave de telefone inválida",
+		"pix key is not pending verification":                   "chave pix não está pendente de verificação",
+		"invalid verification code":                             "código de verificação inválido",
+		"verification code expired":                             "código de verificação expirado",
+		"too many verification attempts":                        "tentativas de verificação excedidas",
+		"only email and phone keys can be claimed":              "somente chaves de e-mail e telefone podem ser reivindicadas",
+		"pix key already belongs to the account":                "chave pix já pertence à conta",
+		"pix key claim is not open":                             "reivindicação de chave pix não está aberta",
+		"only the key owner can confirm a claim":                "somente o dono da chave pode confirmar a reivindicação",
+		"only the claimer can complete a claim":                 "somente o reivindicante pode concluir a reivindicação",
+		"portability claims must be confirmed by the key owner": "reivindicações de portabilidade devem ser confirmadas pelo dono da chave",
+		"pix key claim resolution period has not ended":         "prazo de resolução da reivindicação de chave pix não terminou",
+		"payment request not found":                             "solicitação de pagamento não encontrada",
+		"payment request expired":                               "solicitação de pagamento expirada",
+		"pix key does not belong to the account":                "chave pix não pertence à conta",
+		"pix key and description do not fit in a br code":       "chave pix e descrição não cabem em um br code",
+		"invalid br code":                                       "br code inválido",
+		"br codes of other institutions are not supported":      "br codes de outras instituições não são suportados",
+		"charge not found":                                      "cobrança não encontrada",
+		"payer account not found":                               "conta pagadora não encontrada",
+		"charger and payer accounts are equal":                  "contas cobradora e pagadora são iguais",
+		"invalid due date":                                      "data de vencimento inválida",
+		"due date is in the past":                               "data de vencimento está no passado",
+		"min partial amount is greater than the amount":         "valor mínimo parcial é maior que o valor",
+		"charge is not open":                                    "cobrança não está em aberto",
+		"charge expired":                                        "cobrança expirada",
+		"only the payer can pay or decline a charge":            "somente o pagador pode pagar ou recusar a cobrança",
+		"charge does not allow partial payments":                "cobrança não permite pagamentos parciais",
+		"amount is greater than what is left to pay":            "valor é maior que o restante a pagar",
+		"amount is less than the min partial amount":            "valor é menor que o valor mínimo parcial",
+		"bill payment not found":                                "pagamento de conta não encontrado",
+		"bill payment already exists":                           "pagamento de conta já existe",
+		"invalid boleto":                                        "boleto inválido",
+		"boleto is overdue":                                     "boleto vencido",
+		"boleto has no amount, inform one":                      "boleto sem valor, informe um",
+		"amount differs from the boleto amount":                 "valor difere do valor do boleto",
+		"bill payment declined by the payment network":          "pagamento de conta recusado pela rede de pagamentos",
+		"amount is over the transfer limit of the account":      "valor acima do limite por transferência da conta",
+		"statement range over 366 days":                         "período do extrato acima de 366 dias",
+		"account has no cpf to register as a key":               "conta não tem cpf para cadastrar como chave",
+		"name cannot be empty":                                  "nome não pode ser vazio",
+		"invalid email":                                         "email inválido",
+		"invalid phone":                                         "telefone inválido",
+		"current secret is wrong":                               "senha atual incorreta",
+		"new secret must differ from the current one":           "nova senha deve ser diferente da atual",
+		"invalid reset code":                                    "código de redefinição inválido",
+		"reset code expired":                                    "código de redefinição expirado",
+		"too many reset attempts":                               "tentativas de redefinição esgotadas",
+		"session revoked":                                       "sessão revogada",
+		"totp is already enabled":                               "totp já está ativado",
+		"totp is not enabled":                                   "totp não está ativado",
+		"totp enrollment is not pending":                        "cadastro de totp não está pendente",
+		"totp enrollment not found":                             "cadastro de totp não encontrado",
+		"invalid totp code":                                     "código totp inválido",
+		"too many totp attempts":                                "tentativas de código totp excedidas",
+		"totp code required":                                    "código totp obrigatório",
+		"invalid login challenge":                               "desafio de login inválido",
+		"login challenge expired":                               "desafio de login expirado",
+		"pin must have 4 to 6 digits":                           "a senha de transação deve ter de 4 a 6 dígitos",
+		"wrong pin":                                             "senha de transação incorreta",
+		"current pin required":                                  "senha de transação atual obrigatória",
+		"pin required":                                          "senha de transação obrigatória",
+		"too many pin attempts":                                 "tentativas de senha de transação excedidas",
+		"api key not found":                                     "chave de api não encontrada",
+		"api key limit reached":                                 "limite de chaves de api atingido",
+		"invalid api key":                                       "chave de api inválida",
+		"api key revoked":                                       "chave de api revogada",
+		"api key expired":                                       "chave de api expirada",
+		"api key not allowed on this route":                     "chave de api não permitida nesta rota",
+
+		"Statement":         "Extrato",
+		"Account":           "Conta",
+		"Period":            "Período",
+		"Page":              "Página",
+		"Date":              "Data",
+		"Description":       "Descrição",
+		"Amount":            "Valor",
+		"Balance":           "Saldo",
+		"Opening balance":   "Saldo inicial",
+		"Closing balance":   "Saldo final",
+		"Total credits":     "Total de créditos",
+		"Total debits":      "Total de débitos",
+		"Deposit":           "Depósito",
+		"Transfer received": "Transferência recebida",
+		"Transfer sent":     "Transferência enviada",
+		"Fee":               "Tarifa",
+		"Reversal":          "Estorno",
+	},
+}
@@ -0,0 +1,117 @@
+package i18n
+
+import (
+	"reflect"
+	"strings"
+	"sync"
+
+	"github.com/go-playground/locales/en"
+	"github.com/go-playground/locales/pt_BR"
+	ut "github.com/go-playground/universal-translator"
+	"github.com/go-playground/validator/v10"
+	enTranslations "github.com/go-playground/validator/v10/translations/en"
+	ptBRTranslations "github.com/go-playground/validator/v10/translations/pt_BR"
+	"golang.org/x/text/language"
+)
+
+const (
+	LANGUAGE_PT_BR   = "pt-BR"
+	LANGUAGE_EN      = "en"
+	DEFAULT_LANGUAGE = LANGUAGE_PT_BR
+)
+
+var (
+	universalTranslator = ut.New(pt_BR.New(), pt_BR.New(), en.New())
+	matcher             = language.NewMatcher([]language.Tag{language.BrazilianPortuguese, language.English})
+	languages           = []string{LANGUAGE_PT_BR, LANGUAGE_EN}
+	setupOnce           sync.Once
+)
+
+// Setup makes validator report JSON field names and registers the pt-BR and
+// en messages for every built-in and custom tag. It is safe to call more than
+// once for the shared gin validator.
+func Setup(v *validator.Validate) {
+	setupOnce.Do(func() {
+		v.RegisterTagNameFunc(fieldName)
+
+		if err := ptBRTranslations.RegisterDefaultTranslations(v, Translator(LANGUAGE_PT_BR)); err != nil {
+			panic(err)
+		}
+
+		if err := enTranslations.RegisterDefaultTranslations(v, Translator(LANGUAGE_EN)); err != nil {
+			panic(err)
+		}
+
+		for tag, messages := range validationCatalog {
+			registerTranslation(v, tag, messages)
+		}
+	})
+}
+
+// Language picks the best supported language for an Accept-Language header,
+// falling back to DEFAULT_LANGUAGE.
+func Language(acceptLanguage string) string {
+	tags, _, err := language.ParseAcceptLanguage(acceptLanguage)
+	if err != nil || len(tags) == 0 {
+		return DEFAULT_LANGUAGE
+	}
+
+	_, index, confidence := matcher.Match(tags...)
+	if confidence == language.No {
+		return DEFAULT_LANGUAGE
+	}
+
+	return languages[index]
+}
+
+func Translator(lang string) ut.Translator {
+	trans, _ := universalTranslator.GetTranslator(strings.ReplaceAll(lang, "-", "_"))
+
+	return trans
+}
+
+// Translate localizes a domain message. English messages are the catalog
+// keys, so unknown messages are returned untouched.
+func Translate(lang string, msg string) string {
+	if translated, ok := catalog[lang][msg]; ok {
+		return translated
+	}
+
+	return msg
+}
+
+func registerTranslation(v *validator.Validate, tag string, messages map[string]string) {
+	for lang, message := range messages {
+		message := message
+		if err := v.RegisterTranslation(tag, Translator(lang),
+			func(trans ut.Translator) error {
+				return trans.Add(tag, message, true)
+			},
+			func(trans ut.Translator, fe validator.FieldError) string {
+				translated, err := trans.T(tag, fe.Field())
+				if err != nil {
+					return fe.Error()
+				}
+
+				return translated
+			},
+		); err != nil {
+			panic(err)
+		}
+	}
+}
+
+func fieldName(field reflect.StructField) string {
+	for _, tag := range []string{"json", "uri", "form"} {
+		name := strings.SplitN(field.Tag.Get(tag), ",", 2)[0]
+		if name == "-" {
+			return ""
+		}
+
+		if name != "" {
+			return name
+		}
+	}
+
+	return field.Name
+}
@@ -0,0 +1,97 @@
+package i18n
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/fms85/desafio-tecnico-go-stone/internal/util"
+	"github.com/go-playground/validator/v10"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLanguage(t *testing.T) {
+	tests := []struct {
+		name           string
+		acceptLanguage string
+		want           string
+	}{
+		{
+			name:           "should_default_to_portuguese_when_header_is_empty",
+			acceptLanguage: "",
+			want:           LANGUAGE_PT_BR,
+		},
+		{
+			name:           "should_match_english_variants",
+			acceptLanguage: "en-US,en;q=0.9",
+			want:           LANGUAGE_EN,
+		},
+		{
+			name:           "should_match_generic_portuguese",
+			acceptLanguage: "pt",
+			want:           LANGUAGE_PT_BR,
+		},
+		{
+			name:           "should_respect_quality_weights",
+			acceptLanguage: "pt-BR;q=0.5, en;q=0.9",
+			want:           LANGUAGE_EN,
+		},
+		{
+			name:           "should_default_to_portuguese_when_language_is_unsupported",
+			acceptLanguage: "ja",
+			want:           LANGUAGE_PT_BR,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, Language(tt.acceptLanguage))
+		})
+	}
+}
+
+func TestTranslateValidation(t *testing.T) {
+	type input struct {
+		CPF    string `json:"cpf" validate:"required,cpf"`
+		Secret string `json:"secret" validate:"required,min=6"`
+	}
+
+	v := validator.New()
+	assert.NoError(t, v.RegisterValidation("cpf", util.CpfValidator))
+	Setup(v)
+
+	err := v.Struct(input{CPF: "123", Secret: "1"})
+
+	var validationErrors validator.ValidationErrors
+	assert.True(t, errors.As(err, &validationErrors))
+	assert.Len(t, validationErrors, 2)
+
+	tests := []struct {
+		name string
+		lang string
+		want []string
+	}{
+		{
+			name: "should_translate_to_portuguese_with_json_names",
+			lang: LANGUAGE_PT_BR,
+			want: []string{"cpf deve ser um CPF válido", "secret deve ter pelo menos 6 caracteres"},
+		},
+		{
+			name: "should_translate_to_english_with_json_names",
+			lang: LANGUAGE_EN,
+			want: []string{"cpf must be a valid CPF", "secret must be at least 6 characters in length"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			trans := Translator(tt.lang)
+			for i, fieldError := range validationErrors {
+				assert.Equal(t, tt.want[i], fieldError.Translate(trans))
+			}
+		})
+	}
+}
+
+func TestTranslate(t *testing.T) {
+	assert.Equal(t, "saldo insuficiente", Translate(LANGUAGE_PT_BR, "insufficient funds"))
+	assert.Equal(t, "insufficient funds", Translate(LANGUAGE_EN, "insufficient funds"))
+	assert.Equal(t, "unknown message", Translate(LANGUAGE_PT_BR, "unknown message"))
+}
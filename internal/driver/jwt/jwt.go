@@ -0,0 +1,392 @@
+package jwt
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/brianvoe/sjwt"
+	"github.com/fms85/desafio-tecnico-go-stone/internal/util"
+)
+
+// TOKEN_TTL is how long tokens signed with a key pair last, and so how long
+// a retired key has to keep verifying after a rotation.
+const TOKEN_TTL = 24 * time.Hour
+
+const RSA_MIN_BITS = 2048
+
+const (
+	ALG_HS256 = "HS256"
+	ALG_RS256 = "RS256"
+	ALG_EDDSA = "EdDSA"
+)
+
+var (
+	ErrInvalidToken = errors.New("invalid token")
+	ErrExpiredToken = errors.New("token expired")
+)
+
+// JWK is a public key as published in a JWKS, following RFC 7517. RSA keys
+// fill N and E and Ed25519 keys fill Crv and X.
+type JWK struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Use string `json:"use"`
+	Alg string `json:"alg"`
+	N   string `json:"n,omitempty"`
+	E   string `json:"e,omitempty"`
+	Crv string `json:"crv,omitempty"`
+	X   string `json:"x,omitempty"`
+}
+
+type JWKS struct {
+	Keys []JWK `json:"keys"`
+}
+
+// IKeySet signs the tokens of accounts with the current key and verifies
+// them with any of the active ones, which are published in JWKS.
+type IKeySet interface {
+	Sign(claims map[string]interface{}) (string, error)
+	Verify(token string) (sjwt.Claims, error)
+	JWKS() JWKS
+}
+
+// Config takes PEM encoded keys. SigningKey is a PKCS #8 RSA or Ed25519
+// private key, whose public key is verified and published along with the
+// public keys of VerificationKeys. Secret is the legacy HS256 secret: it
+// signs tokens when there is no SigningKey and otherwise only verifies the
+// tokens it signed before, until HS256Until. Those tokens do not expire, so
+// the overlap must be bounded.
+type Config struct {
+	Secret           string
+	HS256Until       time.Time
+	SigningKey       []byte
+	VerificationKeys []byte
+}
+
+type key struct {
+	kid    string
+	alg    string
+	public crypto.PublicKey
+	jwk    JWK
+}
+
+type keySet struct {
+	secret     []byte
+	hs256Until time.Time
+	signer     crypto.Signer
+	signingKey *key
+	keys       map[string]*key
+	jwks       JWKS
+	now        func() time.Time
+}
+
+// Load reads the signing key from signingKeyFile and the verification keys
+// from the comma separated verificationKeyFiles, either of which may be
+// empty. hs256Until is an RFC 3339 time, also optional.
+func Load(secret string, hs256Until string, signingKeyFile string, verificationKeyFiles string) (IKeySet, error) {
+	config := Config{Secret: secret}
+
+	if hs256Until != "" {
+		until, err := time.Parse(time.RFC3339, hs256Until)
+		if err != nil {
+			return nil, fmt.Errorf("error to parse jwt hs256 until: %w", err)
+		}
+
+		config.HS256Until = until
+	}
+
+	if signingKeyFile != "" {
+		signingKey, err := os.ReadFile(signingKeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("error to read jwt signing key: %w", err)
+		}
+
+		config.SigningKey = signingKey
+	}
+
+	for _, file := range strings.Split(verificationKeyFiles, ",") {
+		if file = strings.TrimSpace(file); file == "" {
+			continue
+		}
+
+		verificationKey, err := os.ReadFile(file)
+		if err != nil {
+			return nil, fmt.Errorf("error to read jwt verification key: %w", err)
+		}
+
+		config.VerificationKeys = append(config.VerificationKeys, verificationKey...)
+	}
+
+	return New(config)
+}
+
+func New(config Config) (IKeySet, error) {
+	keySet := &keySet{
+		keys: map[string]*key{},
+		jwks: JWKS{Keys: []JWK{}},
+		now:  time.Now,
+	}
+
+	if config.Secret != "" {
+		keySet.secret = []byte(config.Secret)
+		keySet.hs256Until = config.HS256Until
+	}
+
+	if len(config.SigningKey) > 0 {
+		signer, err := parsePrivateKey(config.SigningKey)
+		if err != nil {
+			return nil, err
+		}
+
+		signingKey, err := newKey(signer.Public())
+		if err != nil {
+			return nil, err
+		}
+
+		keySet.signer = signer
+		keySet.signingKey = signingKey
+		keySet.add(signingKey)
+	}
+
+	rest := config.VerificationKeys
+	for {
+		var block *pem.Block
+		if block, rest = pem.Decode(rest); block == nil {
+			break
+		}
+
+		public, err := x509.ParsePKIXPublicKey(block.Bytes)
+		if err != nil {
+			return nil, fmt.Errorf("error to parse jwt verification key: %w", err)
+		}
+
+		verificationKey, err := newKey(public)
+		if err != nil {
+			return nil, err
+		}
+
+		keySet.add(verificationKey)
+	}
+
+	if keySet.secret == nil && keySet.signer == nil {
+		return nil, errors.New("jwt secret or signing key required")
+	}
+
+	if keySet.secret != nil && keySet.signer != nil && keySet.hs256Until.IsZero() {
+		return nil, errors.New("jwt hs256 until required along with the secret and a signing key")
+	}
+
+	return keySet, nil
+}
+
+// NewHS256 returns a key set of the legacy HS256 secret alone.
+func NewHS256(secret string) IKeySet {
+	return &keySet{
+		secret: []byte(secret),
+		keys:   map[string]*key{},
+		jwks:   JWKS{Keys: []JWK{}},
+		now:    time.Now,
+	}
+}
+
+// Sign issues a token of claims. Tokens of a key pair carry the key id in
+// their header and expire after TOKEN_TTL; legacy HS256 tokens are issued as
+// they always were, without either.
+func (keySet *keySet) Sign(claims map[string]interface{}) (string, error) {
+	if keySet.signer == nil {
+		return util.GenerateJwtClaims(claims, string(keySet.secret)), nil
+	}
+
+	payload := sjwt.New()
+	for name, value := range claims {
+		payload.Set(name, value)
+	}
+
+	now := keySet.now()
+	payload.SetIssuedAt(now)
+	payload.SetExpiresAt(now.Add(TOKEN_TTL))
+
+	header, err := json.Marshal(map[string]string{"alg": keySet.signingKey.alg, "kid": keySet.signingKey.kid, "typ": "JWT"})
+	if err != nil {
+		return "", err
+	}
+
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return "", err
+	}
+
+	signingInput := base64.RawURLEncoding.EncodeToString(header) + "." + base64.RawURLEncoding.EncodeToString(body)
+
+	var signature []byte
+	switch keySet.signingKey.alg {
+	case ALG_RS256:
+		digest := sha256.Sum256([]byte(signingInput))
+		signature, err = keySet.signer.Sign(rand.Reader, digest[:], crypto.SHA256)
+	case ALG_EDDSA:
+		signature, err = keySet.signer.Sign(rand.Reader, []byte(signingInput), crypto.Hash(0))
+	}
+	if err != nil {
+		return "", err
+	}
+
+	return signingInput + "." + base64.RawURLEncoding.EncodeToString(signature), nil
+}
+
+// Verify checks the signature of a token with the key its header names,
+// which must be of the algorithm the header claims, and its expiry. Once
+// there is a signing key, HS256 tokens expire all together at hs256Until.
+func (keySet *keySet) Verify(token string) (sjwt.Claims, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return nil, ErrInvalidToken
+	}
+
+	headerJSON, err := base64.RawURLEncoding.DecodeString(parts[0])
+	if err != nil {
+		return nil, ErrInvalidToken
+	}
+
+	var header struct {
+		Alg string `json:"alg"`
+		Kid string `json:"kid"`
+	}
+	if err := json.Unmarshal(headerJSON, &header); err != nil {
+		return nil, ErrInvalidToken
+	}
+
+	switch header.Alg {
+	case ALG_HS256:
+		if keySet.secret == nil || !sjwt.Verify(token, keySet.secret) {
+			return nil, ErrInvalidToken
+		}
+
+		if keySet.signer != nil && !keySet.now().Before(keySet.hs256Until) {
+			return nil, ErrExpiredToken
+		}
+	case ALG_RS256, ALG_EDDSA:
+		key, ok := keySet.keys[header.Kid]
+		if !ok || key.alg != header.Alg {
+			return nil, ErrInvalidToken
+		}
+
+		signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+		if err != nil || !key.verify(parts[0]+"."+parts[1], signature) {
+			return nil, ErrInvalidToken
+		}
+	default:
+		return nil, ErrInvalidToken
+	}
+
+	claims, err := sjwt.Parse(token)
+	if err != nil {
+		return nil, ErrInvalidToken
+	}
+
+	if claims.Has(sjwt.ExpiresAt) {
+		expiresAt, err := claims.GetExpiresAt()
+		if err != nil || keySet.now().Unix() >= expiresAt {
+			return nil, ErrExpiredToken
+		}
+	}
+
+	return claims, nil
+}
+
+// JWKS returns the public keys tokens are verified with, the signing one
+// first.
+func (keySet *keySet) JWKS() JWKS {
+	return keySet.jwks
+}
+
+func (keySet *keySet) add(key *key) {
+	if _, ok := keySet.keys[key.kid]; ok {
+		return
+	}
+
+	keySet.keys[key.kid] = key
+	keySet.jwks.Keys = append(keySet.jwks.Keys, key.jwk)
+}
+
+func (key *key) verify(signingInput string, signature []byte) bool {
+	switch public := key.public.(type) {
+	case *rsa.PublicKey:
+		digest := sha256.Sum256([]byte(signingInput))
+
+		return rsa.VerifyPKCS1v15(public, crypto.SHA256, digest[:], signature) == nil
+	case ed25519.PublicKey:
+		return ed25519.Verify(public, []byte(signingInput), signature)
+	}
+
+	return false
+}
+
+func parsePrivateKey(data []byte) (crypto.Signer, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, errors.New("error to decode jwt signing key")
+	}
+
+	private, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("error to parse jwt signing key: %w", err)
+	}
+
+	signer, ok := private.(crypto.Signer)
+	if !ok {
+		return nil, errors.New("unsupported jwt signing key")
+	}
+
+	return signer, nil
+}
+
+// newKey identifies a public key by its RFC 7638 thumbprint, so the same key
+// gets the same id wherever it is loaded and no id has to be configured.
+func newKey(public crypto.PublicKey) (*key, error) {
+	var jwk JWK
+	var thumbprint string
+
+	switch public := public.(type) {
+	case *rsa.PublicKey:
+		if public.N.BitLen() < RSA_MIN_BITS {
+			return nil, fmt.Errorf("jwt rsa keys must have at least %d bits", RSA_MIN_BITS)
+		}
+
+		jwk = JWK{
+			Kty: "RSA",
+			Alg: ALG_RS256,
+			N:   base64.RawURLEncoding.EncodeToString(public.N.Bytes()),
+			E:   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(public.E)).Bytes()),
+		}
+		thumbprint = fmt.Sprintf(`{"e":"%s","kty":"RSA","n":"%s"}`, jwk.E, jwk.N)
+	case ed25519.PublicKey:
+		jwk = JWK{
+			Kty: "OKP",
+			Alg: ALG_EDDSA,
+			Crv: "Ed25519",
+			X:   base64.RawURLEncoding.EncodeToString(public),
+		}
+		thumbprint = fmt.Sprintf(`{"crv":"Ed25519","kty":"OKP","x":"%s"}`, jwk.X)
+	default:
+		return nil, errors.New("unsupported jwt key, use rsa or ed25519")
+	}
+
+	digest := sha256.Sum256([]byte(thumbprint))
+	jwk.Kid = base64.RawURLEncoding.EncodeToString(digest[:])
+	jwk.Use = "sig"
+
+	return &key{kid: jwk.Kid, alg: jwk.Alg, public: public, jwk: jwk}, nil
+}
@@ -0,0 +1,304 @@
+package jwt
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/fms85/desafio-tecnico-go-stone/internal/util"
+	"gotest.tools/assert"
+)
+
+const secretTest = "2aa5b62a718429b0645dc1be1bcac023821181859a181408b59c77d7c07d5349"
+
+func privateKeyTest(t *testing.T, private crypto.Signer) []byte {
+	t.Helper()
+
+	der, err := x509.MarshalPKCS8PrivateKey(private)
+	assert.NilError(t, err)
+
+	return pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der})
+}
+
+func publicKeyTest(t *testing.T, private crypto.Signer) []byte {
+	t.Helper()
+
+	der, err := x509.MarshalPKIXPublicKey(private.Public())
+	assert.NilError(t, err)
+
+	return pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der})
+}
+
+func ed25519KeyTest(t *testing.T) crypto.Signer {
+	t.Helper()
+
+	_, private, err := ed25519.GenerateKey(rand.Reader)
+	assert.NilError(t, err)
+
+	return private
+}
+
+func rsaKeyTest(t *testing.T, bits int) crypto.Signer {
+	t.Helper()
+
+	private, err := rsa.GenerateKey(rand.Reader, bits)
+	assert.NilError(t, err)
+
+	return private
+}
+
+func newKeySetTest(t *testing.T, config Config) IKeySet {
+	t.Helper()
+
+	keySet, err := New(config)
+	assert.NilError(t, err)
+
+	return keySet
+}
+
+func headerTest(t *testing.T, token string) map[string]string {
+	t.Helper()
+
+	headerJSON, err := base64.RawURLEncoding.DecodeString(strings.Split(token, ".")[0])
+	assert.NilError(t, err)
+
+	var header map[string]string
+	assert.NilError(t, json.Unmarshal(headerJSON, &header))
+
+	return header
+}
+
+func TestKeySetHS256(t *testing.T) {
+	keySet := NewHS256(secretTest)
+
+	token, err := keySet.Sign(map[string]interface{}{"account_id": 3})
+	assert.NilError(t, err)
+	assert.Equal(t, util.GenerateJwtClaims(map[string]interface{}{"account_id": 3}, secretTest), token)
+
+	claims, err := keySet.Verify(token)
+	assert.NilError(t, err)
+	accountID, _ := claims.GetStr("account_id")
+	assert.Equal(t, "3", accountID)
+	assert.Assert(t, !claims.Has("exp"))
+
+	_, err = keySet.Verify(util.GenerateJwtClaims(map[string]interface{}{"account_id": 3}, "other"))
+	assert.Equal(t, ErrInvalidToken, err)
+
+	assert.Equal(t, 0, len(keySet.JWKS().Keys))
+}
+
+func TestKeySetSign(t *testing.T) {
+	tests := []struct {
+		name    string
+		private crypto.Signer
+		wantAlg string
+		wantKty string
+	}{
+		{name: "should_sign_with_eddsa", private: ed25519KeyTest(t), wantAlg: ALG_EDDSA, wantKty: "OKP"},
+		{name: "should_sign_with_rs256", private: rsaKeyTest(t, 2048), wantAlg: ALG_RS256, wantKty: "RSA"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			keySet := newKeySetTest(t, Config{SigningKey: privateKeyTest(t, tt.private)})
+
+			token, err := keySet.Sign(map[string]interface{}{"account_id": 3, "token_version": 2})
+			assert.NilError(t, err)
+
+			jwks := keySet.JWKS()
+			assert.Equal(t, 1, len(jwks.Keys))
+			assert.Equal(t, tt.wantKty, jwks.Keys[0].Kty)
+			assert.Equal(t, tt.wantAlg, jwks.Keys[0].Alg)
+
+			header := headerTest(t, token)
+			assert.Equal(t, tt.wantAlg, header["alg"])
+			assert.Equal(t, jwks.Keys[0].Kid, header["kid"])
+
+			claims, err := keySet.Verify(token)
+			assert.NilError(t, err)
+			tokenVersion, _ := claims.GetStr("token_version")
+			assert.Equal(t, "2", tokenVersion)
+			expiresAt, _ := claims.GetExpiresAt()
+			issuedAt, _ := claims.GetIssuedAt()
+			assert.Equal(t, int64(TOKEN_TTL.Seconds()), expiresAt-issuedAt)
+
+			parts := strings.Split(token, ".")
+			_, err = keySet.Verify(parts[0] + "." + parts[1] + "." + base64.RawURLEncoding.EncodeToString([]byte("forged")))
+			assert.Equal(t, ErrInvalidToken, err)
+		})
+	}
+}
+
+func TestKeySetRotation(t *testing.T) {
+	previous := ed25519KeyTest(t)
+	current := rsaKeyTest(t, 2048)
+
+	previousKeySet := newKeySetTest(t, Config{SigningKey: privateKeyTest(t, previous)})
+	previousToken, err := previousKeySet.Sign(map[string]interface{}{"account_id": 3})
+	assert.NilError(t, err)
+
+	t.Run("should_verify_tokens_of_the_previous_key_during_the_overlap", func(t *testing.T) {
+		keySet := newKeySetTest(t, Config{
+			SigningKey:       privateKeyTest(t, current),
+			VerificationKeys: publicKeyTest(t, previous),
+		})
+
+		_, err := keySet.Verify(previousToken)
+		assert.NilError(t, err)
+
+		jwks := keySet.JWKS()
+		assert.Equal(t, 2, len(jwks.Keys))
+		assert.Equal(t, ALG_RS256, jwks.Keys[0].Alg)
+		assert.Equal(t, previousKeySet.JWKS().Keys[0], jwks.Keys[1])
+	})
+
+	t.Run("should_verify_tokens_of_a_published_next_key", func(t *testing.T) {
+		keySet := newKeySetTest(t, Config{
+			SigningKey:       privateKeyTest(t, previous),
+			VerificationKeys: publicKeyTest(t, current),
+		})
+		currentKeySet := newKeySetTest(t, Config{SigningKey: privateKeyTest(t, current)})
+
+		token, err := currentKeySet.Sign(map[string]interface{}{"account_id": 3})
+		assert.NilError(t, err)
+
+		_, err = keySet.Verify(token)
+		assert.NilError(t, err)
+	})
+
+	t.Run("should_refuse_tokens_of_a_retired_key", func(t *testing.T) {
+		keySet := newKeySetTest(t, Config{SigningKey: privateKeyTest(t, current)})
+
+		_, err := keySet.Verify(previousToken)
+		assert.Equal(t, ErrInvalidToken, err)
+	})
+
+	t.Run("should_verify_legacy_tokens_while_the_secret_is_kept", func(t *testing.T) {
+		legacyToken := util.GenerateJwtClaims(map[string]interface{}{"account_id": 3}, secretTest)
+
+		keySet := newKeySetTest(t, Config{Secret: secretTest, HS256Until: time.Now().Add(time.Hour), SigningKey: privateKeyTest(t, current)})
+		_, err := keySet.Verify(legacyToken)
+		assert.NilError(t, err)
+
+		token, err := keySet.Sign(map[string]interface{}{"account_id": 3})
+		assert.NilError(t, err)
+		assert.Equal(t, ALG_RS256, headerTest(t, token)["alg"])
+
+		keySet = newKeySetTest(t, Config{SigningKey: privateKeyTest(t, current)})
+		_, err = keySet.Verify(legacyToken)
+		assert.Equal(t, ErrInvalidToken, err)
+	})
+
+	t.Run("should_refuse_legacy_tokens_after_the_overlap", func(t *testing.T) {
+		legacyToken := util.GenerateJwtClaims(map[string]interface{}{"account_id": 3}, secretTest)
+
+		keySet := newKeySetTest(t, Config{Secret: secretTest, HS256Until: time.Now(), SigningKey: privateKeyTest(t, current)})
+		_, err := keySet.Verify(legacyToken)
+		assert.Equal(t, ErrExpiredToken, err)
+
+		_, err = keySet.Verify(util.GenerateJwtClaims(map[string]interface{}{"account_id": 3}, "other"))
+		assert.Equal(t, ErrInvalidToken, err)
+	})
+}
+
+func TestKeySetVerify(t *testing.T) {
+	private := ed25519KeyTest(t)
+	keySet := newKeySetTest(t, Config{Secret: secretTest, HS256Until: time.Now().Add(time.Hour), SigningKey: privateKeyTest(t, private)}).(*keySet)
+
+	token, err := keySet.Sign(map[string]interface{}{"account_id": 3})
+	assert.NilError(t, err)
+
+	t.Run("should_refuse_expired_tokens", func(t *testing.T) {
+		expired := *keySet
+		expired.now = func() time.Time { return time.Now().Add(TOKEN_TTL) }
+
+		_, err := expired.Verify(token)
+		assert.Equal(t, ErrExpiredToken, err)
+	})
+
+	t.Run("should_refuse_a_key_of_another_algorithm", func(t *testing.T) {
+		parts := strings.Split(token, ".")
+		header := base64.RawURLEncoding.EncodeToString([]byte(`{"alg":"RS256","kid":"` + keySet.signingKey.kid + `","typ":"JWT"}`))
+
+		_, err := keySet.Verify(header + "." + parts[1] + "." + parts[2])
+		assert.Equal(t, ErrInvalidToken, err)
+	})
+
+	t.Run("should_refuse_unsigned_tokens", func(t *testing.T) {
+		parts := strings.Split(token, ".")
+		header := base64.RawURLEncoding.EncodeToString([]byte(`{"alg":"none","typ":"JWT"}`))
+
+		_, err := keySet.Verify(header + "." + parts[1] + ".")
+		assert.Equal(t, ErrInvalidToken, err)
+	})
+
+	t.Run("should_refuse_malformed_tokens", func(t *testing.T) {
+		_, err := keySet.Verify("invalid_token")
+		assert.Equal(t, ErrInvalidToken, err)
+	})
+}
+
+func TestNew(t *testing.T) {
+	t.Run("should_require_a_secret_or_a_signing_key", func(t *testing.T) {
+		_, err := New(Config{VerificationKeys: publicKeyTest(t, ed25519KeyTest(t))})
+		assert.ErrorContains(t, err, "jwt secret or signing key required")
+	})
+
+	t.Run("should_require_the_end_of_the_hs256_overlap", func(t *testing.T) {
+		_, err := New(Config{Secret: secretTest, SigningKey: privateKeyTest(t, ed25519KeyTest(t))})
+		assert.ErrorContains(t, err, "jwt hs256 until required")
+	})
+
+	t.Run("should_refuse_short_rsa_keys", func(t *testing.T) {
+		_, err := New(Config{SigningKey: privateKeyTest(t, rsaKeyTest(t, 1024))})
+		assert.ErrorContains(t, err, "at least 2048 bits")
+	})
+
+	t.Run("should_refuse_invalid_pem", func(t *testing.T) {
+		_, err := New(Config{SigningKey: []byte("invalid")})
+		assert.ErrorContains(t, err, "error to decode jwt signing key")
+	})
+}
+
+func TestLoad(t *testing.T) {
+	current := ed25519KeyTest(t)
+	previous := ed25519KeyTest(t)
+	next := ed25519KeyTest(t)
+
+	dir := t.TempDir()
+	write := func(name string, data []byte) string {
+		path := filepath.Join(dir, name)
+		assert.NilError(t, os.WriteFile(path, data, 0o600))
+
+		return path
+	}
+
+	keySet, err := Load(
+		"",
+		"",
+		write("current.pem", privateKeyTest(t, current)),
+		write("previous.pub.pem", publicKeyTest(t, previous))+", "+write("next.pub.pem", publicKeyTest(t, next)),
+	)
+	assert.NilError(t, err)
+	assert.Equal(t, 3, len(keySet.JWKS().Keys))
+
+	_, err = Load("", "", filepath.Join(dir, "missing.pem"), "")
+	assert.ErrorContains(t, err, "error to read jwt signing key")
+
+	keySet, err = Load(secretTest, "2999-01-01T00:00:00Z", write("current-2.pem", privateKeyTest(t, current)), "")
+	assert.NilError(t, err)
+	_, err = keySet.Verify(util.GenerateJwtClaims(map[string]interface{}{"account_id": 3}, secretTest))
+	assert.NilError(t, err)
+
+	_, err = Load(secretTest, "2999-01-01", "", "")
+	assert.ErrorContains(t, err, "error to parse jwt hs256 until")
+}
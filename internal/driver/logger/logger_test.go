@@ -0,0 +1,87 @@
+package logger
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"log/slog"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRedact(t *testing.T) {
+	tests := []struct {
+		name string
+		attr slog.Attr
+		want string
+	}{
+		{
+			name: "should_redact_sensitive_keys",
+			attr: slog.String("secret", "123456"),
+			want: REDACTED,
+		},
+		{
+			name: "should_redact_sensitive_keys_case_insensitive",
+			attr: slog.String("Authorization", "Bearer abc"),
+			want: REDACTED,
+		},
+		{
+			name: "should_mask_cpf_inside_text",
+			attr: slog.String("msg", "account 25462557035 not found"),
+			want: "account ***.***.***-** not found",
+		},
+		{
+			name: "should_mask_formatted_cpf_inside_text",
+			attr: slog.String("msg", "account 254.625.570-35 not found"),
+			want: "account ***.***.***-** not found",
+		},
+		{
+			name: "should_redact_jwt_inside_text",
+			attr: slog.String("msg", "token eyJhbGciOiJIUzI1NiJ9.eyJhY2NvdW50X2lkIjoxfQ.abc-_1 rejected"),
+			want: "token [REDACTED] rejected",
+		},
+		{
+			name: "should_redact_errors",
+			attr: slog.Any("error", errors.New("cpf 25462557035 already exists")),
+			want: "cpf ***.***.***-** already exists",
+		},
+		{
+			name: "should_keep_other_values",
+			attr: slog.String("route", "/accounts"),
+			want: "/accounts",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Redact(nil, tt.attr)
+			assert.Equal(t, tt.want, got.Value.String())
+		})
+	}
+}
+
+func TestLogger(t *testing.T) {
+	t.Run("should_respect_configured_level", func(t *testing.T) {
+		var buf bytes.Buffer
+		log := New(&buf, "warn")
+
+		log.Info("hidden")
+		assert.Empty(t, buf.String())
+
+		log.Warn("shown")
+		assert.Contains(t, buf.String(), `"msg":"shown"`)
+	})
+
+	t.Run("should_fallback_to_info_on_invalid_level", func(t *testing.T) {
+		assert.Equal(t, slog.LevelInfo, ParseLevel("verbose"))
+	})
+
+	t.Run("should_retrieve_logger_from_context", func(t *testing.T) {
+		log := New(&bytes.Buffer{}, "info")
+		ctx := WithContext(context.Background(), log)
+
+		assert.Same(t, log, FromContext(ctx))
+		assert.Same(t, slog.Default(), FromContext(context.Background()))
+	})
+}
@@ -0,0 +1,50 @@
+package logger
+
+import (
+	"log/slog"
+	"regexp"
+	"strings"
+)
+
+const REDACTED = "[REDACTED]"
+
+var redactedKeys = map[string]bool{
+	"authorization": true,
+	"cpf":           true,
+	"jwt_secret":    true,
+	"password":      true,
+	"secret":        true,
+	"token":         true,
+}
+
+var (
+	cpfPattern    = regexp.MustCompile(`\b\d{3}\.?\d{3}\.?\d{3}-?\d{2}\b`)
+	jwtPattern    = regexp.MustCompile(`eyJ[\w-]*\.[\w-]*\.[\w-]*`)
+	bearerPattern = regexp.MustCompile(`(?i)bearer\s+\S+`)
+)
+
+// Redact is a slog ReplaceAttr hook that hides CPFs, secrets and tokens,
+// either by attribute key or by their shape inside free text.
+func Redact(groups []string, a slog.Attr) slog.Attr {
+	if redactedKeys[strings.ToLower(a.Key)] {
+		return slog.String(a.Key, REDACTED)
+	}
+
+	switch a.Value.Kind() {
+	case slog.KindString:
+		return slog.String(a.Key, RedactString(a.Value.String()))
+	case slog.KindAny:
+		if err, ok := a.Value.Any().(error); ok {
+			return slog.String(a.Key, RedactString(err.Error()))
+		}
+	}
+
+	return a
+}
+
+func RedactString(s string) string {
+	s = bearerPattern.ReplaceAllString(s, "Bearer "+REDACTED)
+	s = jwtPattern.ReplaceAllString(s, REDACTED)
+
+	return cpfPattern.ReplaceAllString(s, "***.***.***-**")
+}
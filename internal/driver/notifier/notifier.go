@@ -0,0 +1,61 @@
+package notifier
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+)
+
+// Message is sent to an email address or an E.164 phone number.
+type Message struct {
+	To      string
+	Subject string
+	Body    string
+}
+
+// INotifier delivers messages to customers, such as verification codes.
+type INotifier interface {
+	Send(ctx context.Context, message Message) error
+}
+
+type logNotifier struct {
+	log *slog.Logger
+}
+
+// NewLog returns a notifier that only logs messages, bodies included, for
+// local runs where nothing can actually be sent.
+func NewLog(log *slog.Logger) INotifier {
+	return &logNotifier{log: log}
+}
+
+func (notifier *logNotifier) Send(ctx context.Context, message Message) error {
+	notifier.log.InfoContext(ctx, "notification",
+		"to", message.To,
+		"subject", message.Subject,
+		"body", message.Body,
+	)
+
+	return nil
+}
+
+// MemoryNotifier keeps sent messages, for tests.
+type MemoryNotifier struct {
+	mu       sync.Mutex
+	messages []Message
+}
+
+func (notifier *MemoryNotifier) Send(_ context.Context, message Message) error {
+	notifier.mu.Lock()
+	defer notifier.mu.Unlock()
+
+	notifier.messages = append(notifier.messages, message)
+
+	return nil
+}
+
+func (notifier *MemoryNotifier) Messages() []Message {
+	notifier.mu.Lock()
+	defer notifier.mu.Unlock()
+
+	return append([]Message(nil), notifier.messages...)
+}
@@ -0,0 +1,201 @@
+// Package pdf writes simple text documents as PDF 1.4 straight to an
+// io.Writer. Only the current page is kept in memory, so documents of any
+// length can be streamed. Text uses the standard Helvetica fonts with
+// WinAnsi encoding, which covers Portuguese.
+package pdf
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"strings"
+
+	"golang.org/x/text/encoding"
+	"golang.org/x/text/encoding/charmap"
+)
+
+const (
+	PAGE_WIDTH  = 595.28
+	PAGE_HEIGHT = 841.89
+
+	FONT_REGULAR = "F1"
+	FONT_BOLD    = "F2"
+)
+
+const (
+	catalogID = iota + 1
+	pagesID
+	regularFontID
+	boldFontID
+	firstPageID
+)
+
+var textEncoder = encoding.ReplaceUnsupported(charmap.Windows1252.NewEncoder())
+
+// Helvetica advance widths per 1000 units for the characters amounts are
+// made of. Anything else is measured as a digit, which is close enough to
+// right-align short labels.
+var helveticaWidths = map[rune]float64{
+	' ': 278, '.': 278, ',': 278, '-': 333, '+': 584, '$': 556, 'R': 722,
+}
+
+type Document struct {
+	w       *bufio.Writer
+	offset  int64
+	offsets map[int]int64
+	pages   []int
+	nextID  int
+	content *bytes.Buffer
+	err     error
+}
+
+func New(out io.Writer) *Document {
+	d := &Document{
+		w:       bufio.NewWriter(out),
+		offsets: map[int]int64{},
+		nextID:  firstPageID,
+	}
+
+	d.write("%PDF-1.4\n%\xe2\xe3\xcf\xd3\n")
+	d.object(regularFontID, "<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica /Encoding /WinAnsiEncoding >>")
+	d.object(boldFontID, "<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica-Bold /Encoding /WinAnsiEncoding >>")
+
+	return d
+}
+
+// AddPage flushes the current page, if any, and starts a new one.
+func (d *Document) AddPage() {
+	d.flushPage()
+	d.content = &bytes.Buffer{}
+}
+
+// PageCount returns the number of pages started so far.
+func (d *Document) PageCount() int {
+	count := len(d.pages)
+	if d.content != nil {
+		count++
+	}
+
+	return count
+}
+
+// Text draws text with its baseline starting at x, y (points from the bottom
+// left corner).
+func (d *Document) Text(font string, size float64, x float64, y float64, text string) {
+	if d.content == nil {
+		d.AddPage()
+	}
+
+	fmt.Fprintf(d.content, "BT /%s %.2f Tf %.2f %.2f Td (%s) Tj ET\n", font, size, x, y, escape(text))
+}
+
+// TextRight draws text ending at x.
+func (d *Document) TextRight(font string, size float64, x float64, y float64, text string) {
+	d.Text(font, size, x-Width(size, text), y, text)
+}
+
+func (d *Document) Line(x1 float64, y1 float64, x2 float64, y2 float64) {
+	if d.content == nil {
+		d.AddPage()
+	}
+
+	fmt.Fprintf(d.content, "0.5 w %.2f %.2f m %.2f %.2f l S\n", x1, y1, x2, y2)
+}
+
+// Close writes the last page and the document trailer. It does not close
+// the underlying writer.
+func (d *Document) Close() error {
+	if d.content == nil {
+		d.AddPage()
+	}
+	d.flushPage()
+
+	kids := make([]string, 0, len(d.pages))
+	for _, id := range d.pages {
+		kids = append(kids, fmt.Sprintf("%d 0 R", id))
+	}
+
+	d.object(pagesID, fmt.Sprintf("<< /Type /Pages /Kids [%s] /Count %d >>", strings.Join(kids, " "), len(d.pages)))
+	d.object(catalogID, fmt.Sprintf("<< /Type /Catalog /Pages %d 0 R >>", pagesID))
+
+	xref := d.offset
+	size := d.nextID
+	d.write(fmt.Sprintf("xref\n0 %d\n0000000000 65535 f \n", size))
+	for id := 1; id < size; id++ {
+		d.write(fmt.Sprintf("%010d 00000 n \n", d.offsets[id]))
+	}
+	d.write(fmt.Sprintf("trailer\n<< /Size %d /Root %d 0 R >>\nstartxref\n%d\n%%%%EOF\n", size, catalogID, xref))
+
+	if d.err != nil {
+		return d.err
+	}
+
+	return d.w.Flush()
+}
+
+// Width returns the approximate width in points of text set in Helvetica.
+func Width(size float64, text string) float64 {
+	var width float64
+	for _, r := range text {
+		w, ok := helveticaWidths[r]
+		if !ok {
+			w = 556
+		}
+		width += w
+	}
+
+	return width * size / 1000
+}
+
+func (d *Document) flushPage() {
+	if d.content == nil {
+		return
+	}
+
+	contentID := d.nextID
+	pageID := d.nextID + 1
+	d.nextID += 2
+
+	d.offsets[contentID] = d.offset
+	d.write(fmt.Sprintf("%d 0 obj\n<< /Length %d >>\nstream\n", contentID, d.content.Len()))
+	d.write(d.content.String())
+	d.write("endstream\nendobj\n")
+
+	d.object(pageID, fmt.Sprintf(
+		"<< /Type /Page /Parent %d 0 R /MediaBox [0 0 %.2f %.2f] /Resources << /Font << /%s %d 0 R /%s %d 0 R >> >> /Contents %d 0 R >>",
+		pagesID, PAGE_WIDTH, PAGE_HEIGHT, FONT_REGULAR, regularFontID, FONT_BOLD, boldFontID, contentID,
+	))
+
+	d.pages = append(d.pages, pageID)
+	d.content = nil
+
+	// Hand finished pages to the client instead of buffering them.
+	if d.err == nil {
+		d.err = d.w.Flush()
+	}
+}
+
+func (d *Document) object(id int, body string) {
+	d.offsets[id] = d.offset
+	d.write(fmt.Sprintf("%d 0 obj\n%s\nendobj\n", id, body))
+}
+
+func (d *Document) write(s string) {
+	if d.err != nil {
+		return
+	}
+
+	n, err := d.w.WriteString(s)
+	d.offset += int64(n)
+	d.err = err
+}
+
+func escape(text string) string {
+	encoded, err := textEncoder.String(text)
+	if err != nil {
+		encoded = text
+	}
+
+	return strings.NewReplacer(`\`, `\\`, `(`, `\(`, `)`, `\)`, "\r", "", "\n", " ", "\x1a", "?").Replace(encoded)
+}
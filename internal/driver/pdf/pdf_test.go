@@ -0,0 +1,88 @@
+package pdf
+
+import (
+	"bytes"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDocument(t *testing.T) {
+	tests := []struct {
+		name      string
+		pages     int
+		wantPages int
+	}{
+		{
+			name:      "should_write_an_empty_page_when_nothing_is_drawn",
+			pages:     0,
+			wantPages: 1,
+		},
+		{
+			name:      "should_write_every_page",
+			pages:     3,
+			wantPages: 3,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var out bytes.Buffer
+			document := New(&out)
+			for i := 0; i < tt.pages; i++ {
+				document.AddPage()
+				document.Text(FONT_BOLD, 12, 40, 800, "Extrato (conta 1)")
+				document.TextRight(FONT_REGULAR, 10, 555, 780, "-10.00")
+				document.Line(40, 770, 555, 770)
+			}
+			assert.NoError(t, document.Close())
+
+			pdf := out.String()
+			assert.True(t, strings.HasPrefix(pdf, "%PDF-1.4\n"))
+			assert.True(t, strings.HasSuffix(pdf, "%%EOF\n"))
+			assert.Contains(t, pdf, "/Count "+strconv.Itoa(tt.wantPages)+" >>")
+
+			// Every xref entry must point at the start of its object.
+			startxref := regexp.MustCompile(`startxref\n(\d+)\n`).FindStringSubmatch(pdf)
+			xref, _ := strconv.Atoi(startxref[1])
+			entries := regexp.MustCompile(`(\d{10}) 00000 n `).FindAllStringSubmatch(pdf[xref:], -1)
+			for i, entry := range entries {
+				offset, _ := strconv.Atoi(entry[1])
+				assert.True(t, strings.HasPrefix(pdf[offset:], strconv.Itoa(i+1)+" 0 obj\n"), "object %d", i+1)
+			}
+		})
+	}
+}
+
+func TestEscape(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		want string
+	}{
+		{
+			name: "should_escape_parentheses_and_backslashes",
+			text: `a (b) \c`,
+			want: `a \(b\) \\c`,
+		},
+		{
+			name: "should_encode_accents_as_win_ansi",
+			text: "Transferência",
+			want: "Transfer\xeancia",
+		},
+		{
+			name: "should_replace_unsupported_characters",
+			text: "✓",
+			want: "?",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, escape(tt.text))
+		})
+	}
+}
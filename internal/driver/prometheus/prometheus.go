@@ -0,0 +1,77 @@
+package prometheus
+
+import (
+	"net/http"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"gorm.io/gorm"
+)
+
+const (
+	TRANSFER_OUTCOME_SUCCESS            = "success"
+	TRANSFER_OUTCOME_INSUFFICIENT_FUNDS = "insufficient_funds"
+	TRANSFER_OUTCOME_LIMIT_EXCEEDED     = "limit_exceeded"
+	TRANSFER_OUTCOME_NOT_FOUND          = "not_found"
+	TRANSFER_OUTCOME_INVALID            = "invalid"
+	TRANSFER_OUTCOME_ERROR              = "error"
+
+	LOGIN_RESULT_SUCCESS = "success"
+	LOGIN_RESULT_FAILURE = "failure"
+	LOGIN_RESULT_ERROR   = "error"
+)
+
+var (
+	HttpRequestsTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "http_requests_total",
+		Help: "Total number of HTTP requests by route and status.",
+	}, []string{"method", "route", "status"})
+
+	HttpRequestDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "http_request_duration_seconds",
+		Help:    "HTTP request latency by route and status.",
+		Buckets: prometheus.DefBuckets,
+	}, []string{"method", "route", "status"})
+
+	TransfersTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "transfers_total",
+		Help: "Total number of transfer attempts by outcome.",
+	}, []string{"outcome"})
+
+	TransferAmount = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "transfer_amount",
+		Help:    "Amount of transfer attempts by outcome.",
+		Buckets: []float64{1, 10, 50, 100, 500, 1000, 5000, 10000},
+	}, []string{"outcome"})
+
+	LoginsTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "logins_total",
+		Help: "Total number of login attempts by result.",
+	}, []string{"result"})
+)
+
+func Setup(db map[string]*gorm.DB) {
+	for name, connection := range db {
+		sqlDB, err := connection.DB()
+		if err != nil {
+			panic(err)
+		}
+
+		prometheus.MustRegister(collectors.NewDBStatsCollector(sqlDB, name))
+	}
+}
+
+func Handler() http.Handler {
+	return promhttp.Handler()
+}
+
+func ObserveTransfer(outcome string, amount float64) {
+	TransfersTotal.WithLabelValues(outcome).Inc()
+	TransferAmount.WithLabelValues(outcome).Observe(amount)
+}
+
+func ObserveLogin(result string) {
+	LoginsTotal.WithLabelValues(result).Inc()
+}
@@ -0,0 +1,26 @@
+// Package qrcode renders QR codes as PNG images.
+package qrcode
+
+import (
+	"fmt"
+
+	qr "github.com/skip2/go-qrcode"
+)
+
+const (
+	SIZE_DEFAULT = 256
+	SIZE_MIN     = 128
+	SIZE_MAX     = 1024
+)
+
+// PNG encodes content in a square image of size pixels with medium error
+// correction, which is what Pix payment apps expect and keeps long dynamic
+// codes readable.
+func PNG(content string, size int) ([]byte, error) {
+	image, err := qr.Encode(content, qr.Medium, size)
+	if err != nil {
+		return nil, fmt.Errorf("error to encode qr code: %w", err)
+	}
+
+	return image, nil
+}
@@ -0,0 +1,19 @@
+package qrcode
+
+import (
+	"bytes"
+	"image/png"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPNG(t *testing.T) {
+	image, err := PNG("00020126580014br.gov.bcb.pix0136123e4567-e12b-12d1-a456-4266554400005204000053039865802BR5913Fulano de Tal6008BRASILIA62070503***63041D3D", SIZE_DEFAULT)
+	assert.NoError(t, err)
+
+	decoded, err := png.Decode(bytes.NewReader(image))
+	assert.NoError(t, err)
+	assert.Equal(t, SIZE_DEFAULT, decoded.Bounds().Dx())
+	assert.Equal(t, SIZE_DEFAULT, decoded.Bounds().Dy())
+}
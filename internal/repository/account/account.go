@@ -41,10 +41,155 @@ func (repo *accountRepository) Get(ctx context.Context, accountInput types.Accou
 	return account, nil
 }
 
+// GetFromPrimary is Get for the checks that cannot trust a replica lagging
+// behind the last write, like the code of a reset just requested.
+func (repo *accountRepository) GetFromPrimary(ctx context.Context, accountInput types.AccountInput) (*entity.Account, error) {
+	var account *entity.Account
+
+	if err := repo.write.WithContext(ctx).Where(accountInput).Find(&account).Error; err != nil {
+		return nil, fmt.Errorf("error to get account: %w", err)
+	}
+
+	return account, nil
+}
+
+func (repo *accountRepository) GetByIDs(ctx context.Context, ids []uint) ([]*entity.Account, error) {
+	var accounts []*entity.Account
+
+	if err := repo.read.WithContext(ctx).Where("id IN ?", ids).Find(&accounts).Error; err != nil {
+		return nil, fmt.Errorf("error to get accounts: %w", err)
+	}
+
+	return accounts, nil
+}
+
+// Save creates or updates an account. New accounts get a deposit ledger
+// entry for their opening balance in the same transaction.
 func (repo *accountRepository) Save(ctx context.Context, account *entity.Account) error {
-	if err := repo.write.WithContext(ctx).Save(&account).Error; err != nil {
-		return fmt.Errorf("error to save account: %w", err)
+	return repo.write.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		isNew := account.ID == 0
+
+		if err := tx.WithContext(ctx).Save(&account).Error; err != nil {
+			return fmt.Errorf("error to save account: %w", err)
+		}
+
+		if !isNew {
+			return nil
+		}
+
+		if account.Balance != 0 {
+			if err := tx.WithContext(ctx).Create(&entity.LedgerEntry{
+				AccountID: account.ID,
+				Kind:      entity.LEDGER_ENTRY_KIND_DEPOSIT,
+				Amount:    account.Balance,
+				CreatedAt: account.CreatedAt,
+			}).Error; err != nil {
+				return fmt.Errorf("error to create ledger entry: %w", err)
+			}
+		}
+
+		event, err := types.CreateOutboxEvent(account.ID, entity.EVENT_ACCOUNT_CREATED, types.AccountCreatedData{
+			ID:        account.ID,
+			Name:      account.Name,
+			Balance:   account.Balance,
+			CreatedAt: account.CreatedAt,
+		})
+		if err != nil {
+			return err
+		}
+
+		if err := tx.WithContext(ctx).Create(event).Error; err != nil {
+			return fmt.Errorf("error to create outbox event: %w", err)
+		}
+
+		return nil
+	})
+}
+
+// Update writes only columns of account, so it cannot overwrite a balance
+// changed meanwhile by a transfer, and records changes in the same
+// transaction.
+func (repo *accountRepository) Update(ctx context.Context, account *entity.Account, columns []string, changes []*entity.AccountChange) error {
+	return repo.write.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.WithContext(ctx).Model(account).Select(columns).Updates(account).Error; err != nil {
+			return fmt.Errorf("error to update account: %w", err)
+		}
+
+		if len(changes) == 0 {
+			return nil
+		}
+
+		if err := tx.WithContext(ctx).Create(&changes).Error; err != nil {
+			return fmt.Errorf("error to create account changes: %w", err)
+		}
+
+		return nil
+	})
+}
+
+// ClaimAttempt counts an attempt before it is checked, in a single statement
+// on the primary, so parallel attempts cannot all see the same count and get
+// past the limit together. It reports false, counting nothing, when no
+// attempt is left or the account is locked out of them.
+func (repo *accountRepository) ClaimAttempt(ctx context.Context, accountID uint, attempt types.AccountAttempt) (bool, error) {
+	query := repo.write.WithContext(ctx).Model(&entity.Account{})
+
+	var result *gorm.DB
+	if attempt.LockedUntilColumn == "" {
+		result = query.
+			Where("id = ? AND "+attempt.AttemptsColumn+" < ?", accountID, attempt.MaxAttempts).
+			Update(attempt.AttemptsColumn, gorm.Expr(attempt.AttemptsColumn+" + 1"))
+	} else {
+		last := attempt.AttemptsColumn + " + 1 >= ?"
+		result = query.
+			Where("id = ? AND ("+attempt.LockedUntilColumn+" IS NULL OR "+attempt.LockedUntilColumn+" <= ?)", accountID, attempt.Now).
+			Updates(map[string]interface{}{
+				attempt.AttemptsColumn:    gorm.Expr("CASE WHEN "+last+" THEN 0 ELSE "+attempt.AttemptsColumn+" + 1 END", attempt.MaxAttempts),
+				attempt.LockedUntilColumn: gorm.Expr("CASE WHEN "+last+" THEN ? ELSE NULL END", attempt.MaxAttempts, attempt.Now.Add(attempt.LockDuration)),
+			})
+	}
+	if result.Error != nil {
+		return false, fmt.Errorf("error to claim account attempt: %w", result.Error)
+	}
+
+	return result.RowsAffected == 1, nil
+}
+
+// AdvanceTOTPStep moves the last TOTP step used by an account forward to
+// step, in a single statement on the primary. It reports false when step is
+// not past the stored one, so a code is used once even by parallel requests.
+func (repo *accountRepository) AdvanceTOTPStep(ctx context.Context, accountID uint, step int64) (bool, error) {
+	result := repo.write.WithContext(ctx).Model(&entity.Account{}).
+		Where("id = ? AND totp_last_step < ?", accountID, step).
+		Update("totp_last_step", step)
+	if result.Error != nil {
+		return false, fmt.Errorf("error to advance totp step: %w", result.Error)
+	}
+
+	return result.RowsAffected == 1, nil
+}
+
+// ConsumeRecoveryCode removes the hash of a recovery code from an account, in
+// a single statement on the primary. It reports false when the account does
+// not have it anymore, so a code is used once even by parallel requests.
+func (repo *accountRepository) ConsumeRecoveryCode(ctx context.Context, accountID uint, hash string) (bool, error) {
+	result := repo.write.WithContext(ctx).Model(&entity.Account{}).
+		Where("id = ? AND totp_recovery_codes::jsonb @> jsonb_build_array(?::text)", accountID, hash).
+		Update("totp_recovery_codes", gorm.Expr("(totp_recovery_codes::jsonb - ?::text)::text", hash))
+	if result.Error != nil {
+		return false, fmt.Errorf("error to consume recovery code: %w", result.Error)
+	}
+
+	return result.RowsAffected == 1, nil
+}
+
+// GetChanges returns the changes of an account, latest first.
+func (repo *accountRepository) GetChanges(ctx context.Context, accountID uint) ([]*entity.AccountChange, error) {
+	var changes []*entity.AccountChange
+
+	if err := repo.read.WithContext(ctx).Where("account_id = ?", accountID).Order("id DESC").Find(&changes).Error; err != nil {
+		return nil, fmt.Errorf("error to get account changes: %w", err)
 	}
 
-	return nil
+	return changes, nil
 }
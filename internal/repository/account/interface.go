@@ -10,5 +10,12 @@ import (
 type IAccountRepository interface {
 	GetAll(ctx context.Context) ([]*entity.Account, error)
 	Get(ctx context.Context, accountInput types.AccountInput) (*entity.Account, error)
+	GetFromPrimary(ctx context.Context, accountInput types.AccountInput) (*entity.Account, error)
+	GetByIDs(ctx context.Context, ids []uint) ([]*entity.Account, error)
 	Save(ctx context.Context, account *entity.Account) error
+	Update(ctx context.Context, account *entity.Account, columns []string, changes []*entity.AccountChange) error
+	ClaimAttempt(ctx context.Context, accountID uint, attempt types.AccountAttempt) (bool, error)
+	AdvanceTOTPStep(ctx context.Context, accountID uint, step int64) (bool, error)
+	ConsumeRecoveryCode(ctx context.Context, accountID uint, hash string) (bool, error)
+	GetChanges(ctx context.Context, accountID uint) ([]*entity.AccountChange, error)
 }
@@ -16,6 +16,78 @@ type AccountRepositoryMock struct {
 	mock.Mock
 }
 
+// AdvanceTOTPStep provides a mock function with given fields: ctx, accountID, step
+func (_m *AccountRepositoryMock) AdvanceTOTPStep(ctx context.Context, accountID uint, step int64) (bool, error) {
+	ret := _m.Called(ctx, accountID, step)
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint, int64) (bool, error)); ok {
+		return rf(ctx, accountID, step)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint, int64) bool); ok {
+		r0 = rf(ctx, accountID, step)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint, int64) error); ok {
+		r1 = rf(ctx, accountID, step)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ClaimAttempt provides a mock function with given fields: ctx, accountID, attempt
+func (_m *AccountRepositoryMock) ClaimAttempt(ctx context.Context, accountID uint, attempt types.AccountAttempt) (bool, error) {
+	ret := _m.Called(ctx, accountID, attempt)
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint, types.AccountAttempt) (bool, error)); ok {
+		return rf(ctx, accountID, attempt)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint, types.AccountAttempt) bool); ok {
+		r0 = rf(ctx, accountID, attempt)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint, types.AccountAttempt) error); ok {
+		r1 = rf(ctx, accountID, attempt)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ConsumeRecoveryCode provides a mock function with given fields: ctx, accountID, hash
+func (_m *AccountRepositoryMock) ConsumeRecoveryCode(ctx context.Context, accountID uint, hash string) (bool, error) {
+	ret := _m.Called(ctx, accountID, hash)
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint, string) (bool, error)); ok {
+		return rf(ctx, accountID, hash)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint, string) bool); ok {
+		r0 = rf(ctx, accountID, hash)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint, string) error); ok {
+		r1 = rf(ctx, accountID, hash)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Get provides a mock function with given fields: ctx, accountInput
 func (_m *AccountRepositoryMock) Get(ctx context.Context, accountInput types.AccountInput) (*entity.Account, error) {
 	ret := _m.Called(ctx, accountInput)
@@ -68,13 +140,105 @@ func (_m *AccountRepositoryMock) GetAll(ctx context.Context) ([]*entity.Account,
 	return r0, r1
 }
 
-// Save provides a mock function with given fields: ctx, _a1
-func (_m *AccountRepositoryMock) Save(ctx context.Context, _a1 *entity.Account) error {
-	ret := _m.Called(ctx, _a1)
+// GetByIDs provides a mock function with given fields: ctx, ids
+func (_m *AccountRepositoryMock) GetByIDs(ctx context.Context, ids []uint) ([]*entity.Account, error) {
+	ret := _m.Called(ctx, ids)
+
+	var r0 []*entity.Account
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []uint) ([]*entity.Account, error)); ok {
+		return rf(ctx, ids)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []uint) []*entity.Account); ok {
+		r0 = rf(ctx, ids)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*entity.Account)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []uint) error); ok {
+		r1 = rf(ctx, ids)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetChanges provides a mock function with given fields: ctx, accountID
+func (_m *AccountRepositoryMock) GetChanges(ctx context.Context, accountID uint) ([]*entity.AccountChange, error) {
+	ret := _m.Called(ctx, accountID)
+
+	var r0 []*entity.AccountChange
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint) ([]*entity.AccountChange, error)); ok {
+		return rf(ctx, accountID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint) []*entity.AccountChange); ok {
+		r0 = rf(ctx, accountID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*entity.AccountChange)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint) error); ok {
+		r1 = rf(ctx, accountID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetFromPrimary provides a mock function with given fields: ctx, accountInput
+func (_m *AccountRepositoryMock) GetFromPrimary(ctx context.Context, accountInput types.AccountInput) (*entity.Account, error) {
+	ret := _m.Called(ctx, accountInput)
+
+	var r0 *entity.Account
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, types.AccountInput) (*entity.Account, error)); ok {
+		return rf(ctx, accountInput)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, types.AccountInput) *entity.Account); ok {
+		r0 = rf(ctx, accountInput)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.Account)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, types.AccountInput) error); ok {
+		r1 = rf(ctx, accountInput)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Save provides a mock function with given fields: ctx, account
+func (_m *AccountRepositoryMock) Save(ctx context.Context, account *entity.Account) error {
+	ret := _m.Called(ctx, account)
 
 	var r0 error
 	if rf, ok := ret.Get(0).(func(context.Context, *entity.Account) error); ok {
-		r0 = rf(ctx, _a1)
+		r0 = rf(ctx, account)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Update provides a mock function with given fields: ctx, account, columns, changes
+func (_m *AccountRepositoryMock) Update(ctx context.Context, account *entity.Account, columns []string, changes []*entity.AccountChange) error {
+	ret := _m.Called(ctx, account, columns, changes)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *entity.Account, []string, []*entity.AccountChange) error); ok {
+		r0 = rf(ctx, account, columns, changes)
 	} else {
 		r0 = ret.Error(0)
 	}
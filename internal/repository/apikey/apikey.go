@@ -0,0 +1,59 @@
+package apikey
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/fms85/desafio-tecnico-go-stone/internal/domain/entity"
+	"gorm.io/gorm"
+)
+
+type apiKeyRepository struct {
+	read  *gorm.DB
+	write *gorm.DB
+}
+
+func New(connections map[string]*gorm.DB) IAPIKeyRepository {
+	return &apiKeyRepository{
+		write: connections["wr"],
+		read:  connections["rd"],
+	}
+}
+
+func (repo *apiKeyRepository) Create(ctx context.Context, apiKey *entity.APIKey) error {
+	if err := repo.write.WithContext(ctx).Create(apiKey).Error; err != nil {
+		return fmt.Errorf("error to create api key: %w", err)
+	}
+
+	return nil
+}
+
+func (repo *apiKeyRepository) GetAll(ctx context.Context, accountID uint) ([]*entity.APIKey, error) {
+	var apiKeys []*entity.APIKey
+
+	if err := repo.read.WithContext(ctx).Where("account_id = ?", accountID).Order("id").Find(&apiKeys).Error; err != nil {
+		return nil, fmt.Errorf("error to get all api keys: %w", err)
+	}
+
+	return apiKeys, nil
+}
+
+func (repo *apiKeyRepository) Get(ctx context.Context, keyID string) (*entity.APIKey, error) {
+	var apiKey *entity.APIKey
+
+	if err := repo.read.WithContext(ctx).Where("key_id = ?", keyID).Find(&apiKey).Error; err != nil {
+		return nil, fmt.Errorf("error to get api key: %w", err)
+	}
+
+	return apiKey, nil
+}
+
+// Update writes only columns, so recording the use of a key never undoes
+// its revocation.
+func (repo *apiKeyRepository) Update(ctx context.Context, apiKey *entity.APIKey, columns []string) error {
+	if err := repo.write.WithContext(ctx).Model(apiKey).Select(columns).Updates(apiKey).Error; err != nil {
+		return fmt.Errorf("error to update api key: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,14 @@
+package apikey
+
+import (
+	"context"
+
+	"github.com/fms85/desafio-tecnico-go-stone/internal/domain/entity"
+)
+
+type IAPIKeyRepository interface {
+	Create(ctx context.Context, apiKey *entity.APIKey) error
+	GetAll(ctx context.Context, accountID uint) ([]*entity.APIKey, error)
+	Get(ctx context.Context, keyID string) (*entity.APIKey, error)
+	Update(ctx context.Context, apiKey *entity.APIKey, columns []string) error
+}
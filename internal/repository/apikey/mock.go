@@ -0,0 +1,109 @@
+// Code generated by mockery v2.33.0. DO NOT EDIT.
+
+package apikey
+
+import (
+	context "context"
+
+	entity "github.com/fms85/desafio-tecnico-go-stone/internal/domain/entity"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// APIKeyRepositoryMock is an autogenerated mock type for the IAPIKeyRepository type
+type APIKeyRepositoryMock struct {
+	mock.Mock
+}
+
+// Create provides a mock function with given fields: ctx, apiKey
+func (_m *APIKeyRepositoryMock) Create(ctx context.Context, apiKey *entity.APIKey) error {
+	ret := _m.Called(ctx, apiKey)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *entity.APIKey) error); ok {
+		r0 = rf(ctx, apiKey)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Get provides a mock function with given fields: ctx, keyID
+func (_m *APIKeyRepositoryMock) Get(ctx context.Context, keyID string) (*entity.APIKey, error) {
+	ret := _m.Called(ctx, keyID)
+
+	var r0 *entity.APIKey
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*entity.APIKey, error)); ok {
+		return rf(ctx, keyID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *entity.APIKey); ok {
+		r0 = rf(ctx, keyID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.APIKey)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, keyID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetAll provides a mock function with given fields: ctx, accountID
+func (_m *APIKeyRepositoryMock) GetAll(ctx context.Context, accountID uint) ([]*entity.APIKey, error) {
+	ret := _m.Called(ctx, accountID)
+
+	var r0 []*entity.APIKey
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint) ([]*entity.APIKey, error)); ok {
+		return rf(ctx, accountID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint) []*entity.APIKey); ok {
+		r0 = rf(ctx, accountID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*entity.APIKey)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint) error); ok {
+		r1 = rf(ctx, accountID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Update provides a mock function with given fields: ctx, apiKey, columns
+func (_m *APIKeyRepositoryMock) Update(ctx context.Context, apiKey *entity.APIKey, columns []string) error {
+	ret := _m.Called(ctx, apiKey, columns)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *entity.APIKey, []string) error); ok {
+		r0 = rf(ctx, apiKey, columns)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NewAPIKeyRepositoryMock creates a new instance of APIKeyRepositoryMock. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewAPIKeyRepositoryMock(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *APIKeyRepositoryMock {
+	mock := &APIKeyRepositoryMock{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,84 @@
+package billpayment
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/fms85/desafio-tecnico-go-stone/internal/domain/common"
+	"github.com/fms85/desafio-tecnico-go-stone/internal/domain/entity"
+	gormDriver "github.com/fms85/desafio-tecnico-go-stone/internal/driver/gorm"
+	"gorm.io/gorm"
+)
+
+type billPaymentRepository struct {
+	read  *gorm.DB
+	write *gorm.DB
+}
+
+func New(connections map[string]*gorm.DB) IBillPaymentRepository {
+	return &billPaymentRepository{
+		write: connections["wr"],
+		read:  connections["rd"],
+	}
+}
+
+// Create returns a ConflictError when the barcode already has a payment that
+// was not refunded.
+func (repo *billPaymentRepository) Create(ctx context.Context, payment *entity.BillPayment) error {
+	err := gormDriver.DB(ctx, repo.write).WithContext(ctx).Create(payment).Error
+	if errors.Is(err, gorm.ErrDuplicatedKey) {
+		return fmt.Errorf("bill payment %w", &common.ConflictError{})
+	}
+
+	if err != nil {
+		return fmt.Errorf("error to create bill payment: %w", err)
+	}
+
+	return nil
+}
+
+func (repo *billPaymentRepository) GetAll(ctx context.Context, accountID uint) ([]*entity.BillPayment, error) {
+	var payments []*entity.BillPayment
+
+	if err := repo.read.WithContext(ctx).
+		Where("account_id = ?", accountID).
+		Order("id DESC").
+		Find(&payments).Error; err != nil {
+		return nil, fmt.Errorf("error to get all bill payments: %w", err)
+	}
+
+	return payments, nil
+}
+
+func (repo *billPaymentRepository) Get(ctx context.Context, id uint) (*entity.BillPayment, error) {
+	var payment *entity.BillPayment
+
+	if err := repo.read.WithContext(ctx).Where("id = ?", id).Find(&payment).Error; err != nil {
+		return nil, fmt.Errorf("error to get bill payment: %w", err)
+	}
+
+	return payment, nil
+}
+
+// GetByBarcode returns the payment of a barcode that was not refunded, which
+// is at most one.
+func (repo *billPaymentRepository) GetByBarcode(ctx context.Context, barcode string) (*entity.BillPayment, error) {
+	var payment *entity.BillPayment
+
+	if err := repo.write.WithContext(ctx).
+		Where("barcode = ? AND status <> ?", barcode, entity.BILL_PAYMENT_STATUS_REFUNDED).
+		Find(&payment).Error; err != nil {
+		return nil, fmt.Errorf("error to get bill payment by barcode: %w", err)
+	}
+
+	return payment, nil
+}
+
+func (repo *billPaymentRepository) Save(ctx context.Context, payment *entity.BillPayment) error {
+	if err := repo.write.WithContext(ctx).Save(payment).Error; err != nil {
+		return fmt.Errorf("error to save bill payment: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,15 @@
+package billpayment
+
+import (
+	"context"
+
+	"github.com/fms85/desafio-tecnico-go-stone/internal/domain/entity"
+)
+
+type IBillPaymentRepository interface {
+	Create(ctx context.Context, payment *entity.BillPayment) error
+	GetAll(ctx context.Context, accountID uint) ([]*entity.BillPayment, error)
+	Get(ctx context.Context, id uint) (*entity.BillPayment, error)
+	GetByBarcode(ctx context.Context, barcode string) (*entity.BillPayment, error)
+	Save(ctx context.Context, payment *entity.BillPayment) error
+}
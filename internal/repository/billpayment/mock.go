@@ -0,0 +1,135 @@
+// Code generated by mockery v2.33.0. DO NOT EDIT.
+
+package billpayment
+
+import (
+	context "context"
+
+	entity "github.com/fms85/desafio-tecnico-go-stone/internal/domain/entity"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// BillPaymentRepositoryMock is an autogenerated mock type for the IBillPaymentRepository type
+type BillPaymentRepositoryMock struct {
+	mock.Mock
+}
+
+// Create provides a mock function with given fields: ctx, payment
+func (_m *BillPaymentRepositoryMock) Create(ctx context.Context, payment *entity.BillPayment) error {
+	ret := _m.Called(ctx, payment)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *entity.BillPayment) error); ok {
+		r0 = rf(ctx, payment)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Get provides a mock function with given fields: ctx, id
+func (_m *BillPaymentRepositoryMock) Get(ctx context.Context, id uint) (*entity.BillPayment, error) {
+	ret := _m.Called(ctx, id)
+
+	var r0 *entity.BillPayment
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint) (*entity.BillPayment, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint) *entity.BillPayment); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.BillPayment)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetAll provides a mock function with given fields: ctx, accountID
+func (_m *BillPaymentRepositoryMock) GetAll(ctx context.Context, accountID uint) ([]*entity.BillPayment, error) {
+	ret := _m.Called(ctx, accountID)
+
+	var r0 []*entity.BillPayment
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint) ([]*entity.BillPayment, error)); ok {
+		return rf(ctx, accountID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint) []*entity.BillPayment); ok {
+		r0 = rf(ctx, accountID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*entity.BillPayment)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint) error); ok {
+		r1 = rf(ctx, accountID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetByBarcode provides a mock function with given fields: ctx, barcode
+func (_m *BillPaymentRepositoryMock) GetByBarcode(ctx context.Context, barcode string) (*entity.BillPayment, error) {
+	ret := _m.Called(ctx, barcode)
+
+	var r0 *entity.BillPayment
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*entity.BillPayment, error)); ok {
+		return rf(ctx, barcode)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *entity.BillPayment); ok {
+		r0 = rf(ctx, barcode)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.BillPayment)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, barcode)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Save provides a mock function with given fields: ctx, payment
+func (_m *BillPaymentRepositoryMock) Save(ctx context.Context, payment *entity.BillPayment) error {
+	ret := _m.Called(ctx, payment)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *entity.BillPayment) error); ok {
+		r0 = rf(ctx, payment)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NewBillPaymentRepositoryMock creates a new instance of BillPaymentRepositoryMock. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewBillPaymentRepositoryMock(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *BillPaymentRepositoryMock {
+	mock := &BillPaymentRepositoryMock{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,114 @@
+package charge
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/fms85/desafio-tecnico-go-stone/internal/domain/entity"
+	"github.com/fms85/desafio-tecnico-go-stone/internal/domain/types"
+	gormDriver "github.com/fms85/desafio-tecnico-go-stone/internal/driver/gorm"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type chargeRepository struct {
+	read  *gorm.DB
+	write *gorm.DB
+}
+
+func New(connections map[string]*gorm.DB) IChargeRepository {
+	return &chargeRepository{
+		write: connections["wr"],
+		read:  connections["rd"],
+	}
+}
+
+func (repo *chargeRepository) Create(ctx context.Context, charge *entity.Charge) error {
+	if err := repo.write.WithContext(ctx).Create(charge).Error; err != nil {
+		return fmt.Errorf("error to create charge: %w", err)
+	}
+
+	return nil
+}
+
+func (repo *chargeRepository) GetAll(ctx context.Context, accountID uint, role string) ([]*entity.Charge, error) {
+	var charges []*entity.Charge
+
+	query := repo.read.WithContext(ctx)
+	switch role {
+	case types.CHARGE_ROLE_CHARGER:
+		query = query.Where("charger_account_id = ?", accountID)
+	case types.CHARGE_ROLE_PAYER:
+		query = query.Where("payer_account_id = ?", accountID)
+	default:
+		query = query.Where("charger_account_id = ? OR payer_account_id = ?", accountID, accountID)
+	}
+
+	if err := query.Order("id DESC").Find(&charges).Error; err != nil {
+		return nil, fmt.Errorf("error to get all charges: %w", err)
+	}
+
+	return charges, nil
+}
+
+func (repo *chargeRepository) Get(ctx context.Context, id uint) (*entity.Charge, error) {
+	var charge *entity.Charge
+
+	if err := repo.read.WithContext(ctx).
+		Preload("Payments", func(db *gorm.DB) *gorm.DB {
+			return db.Order("id")
+		}).
+		Where("id = ?", id).
+		Find(&charge).Error; err != nil {
+		return nil, fmt.Errorf("error to get charge: %w", err)
+	}
+
+	return charge, nil
+}
+
+// GetForUpdate locks a charge in the transaction of ctx until it commits, so
+// payments made in parallel are checked one after the other against the
+// amount already paid.
+func (repo *chargeRepository) GetForUpdate(ctx context.Context, id uint) (*entity.Charge, error) {
+	var charge *entity.Charge
+
+	if err := gormDriver.DB(ctx, repo.write).WithContext(ctx).
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where("id = ?", id).
+		Find(&charge).Error; err != nil {
+		return nil, fmt.Errorf("error to lock charge: %w", err)
+	}
+
+	return charge, nil
+}
+
+// Close writes the status an open charge is closed with, declined or
+// expired, and reports false when the charge was no longer open. Nothing else
+// is written, so a payment recorded meanwhile is kept.
+func (repo *chargeRepository) Close(ctx context.Context, charge *entity.Charge) (bool, error) {
+	result := repo.write.WithContext(ctx).Model(charge).
+		Where("status IN ?", []string{entity.CHARGE_STATUS_PENDING, entity.CHARGE_STATUS_PARTIALLY_PAID}).
+		Update("status", charge.Status)
+	if result.Error != nil {
+		return false, fmt.Errorf("error to close charge: %w", result.Error)
+	}
+
+	return result.RowsAffected == 1, nil
+}
+
+// AddPayment records a payment together with the paid amount and status it
+// leaves the charge in, in the transaction of ctx when it carries one, which
+// is the one of the transfer of the payment.
+func (repo *chargeRepository) AddPayment(ctx context.Context, charge *entity.Charge, payment *entity.ChargePayment) error {
+	return gormDriver.DB(ctx, repo.write).WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(payment).Error; err != nil {
+			return fmt.Errorf("error to create charge payment: %w", err)
+		}
+
+		if err := tx.Model(charge).Select("paid_amount", "status").Updates(charge).Error; err != nil {
+			return fmt.Errorf("error to update charge: %w", err)
+		}
+
+		return nil
+	})
+}
@@ -0,0 +1,16 @@
+package charge
+
+import (
+	"context"
+
+	"github.com/fms85/desafio-tecnico-go-stone/internal/domain/entity"
+)
+
+type IChargeRepository interface {
+	Create(ctx context.Context, charge *entity.Charge) error
+	GetAll(ctx context.Context, accountID uint, role string) ([]*entity.Charge, error)
+	Get(ctx context.Context, id uint) (*entity.Charge, error)
+	GetForUpdate(ctx context.Context, id uint) (*entity.Charge, error)
+	Close(ctx context.Context, charge *entity.Charge) (bool, error)
+	AddPayment(ctx context.Context, charge *entity.Charge, payment *entity.ChargePayment) error
+}
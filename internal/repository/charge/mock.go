@@ -0,0 +1,159 @@
+// Code generated by mockery v2.33.0. DO NOT EDIT.
+
+package charge
+
+import (
+	context "context"
+
+	entity "github.com/fms85/desafio-tecnico-go-stone/internal/domain/entity"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// ChargeRepositoryMock is an autogenerated mock type for the IChargeRepository type
+type ChargeRepositoryMock struct {
+	mock.Mock
+}
+
+// AddPayment provides a mock function with given fields: ctx, charge, payment
+func (_m *ChargeRepositoryMock) AddPayment(ctx context.Context, charge *entity.Charge, payment *entity.ChargePayment) error {
+	ret := _m.Called(ctx, charge, payment)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *entity.Charge, *entity.ChargePayment) error); ok {
+		r0 = rf(ctx, charge, payment)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Close provides a mock function with given fields: ctx, charge
+func (_m *ChargeRepositoryMock) Close(ctx context.Context, charge *entity.Charge) (bool, error) {
+	ret := _m.Called(ctx, charge)
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *entity.Charge) (bool, error)); ok {
+		return rf(ctx, charge)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *entity.Charge) bool); ok {
+		r0 = rf(ctx, charge)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *entity.Charge) error); ok {
+		r1 = rf(ctx, charge)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Create provides a mock function with given fields: ctx, charge
+func (_m *ChargeRepositoryMock) Create(ctx context.Context, charge *entity.Charge) error {
+	ret := _m.Called(ctx, charge)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *entity.Charge) error); ok {
+		r0 = rf(ctx, charge)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Get provides a mock function with given fields: ctx, id
+func (_m *ChargeRepositoryMock) Get(ctx context.Context, id uint) (*entity.Charge, error) {
+	ret := _m.Called(ctx, id)
+
+	var r0 *entity.Charge
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint) (*entity.Charge, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint) *entity.Charge); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.Charge)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetAll provides a mock function with given fields: ctx, accountID, role
+func (_m *ChargeRepositoryMock) GetAll(ctx context.Context, accountID uint, role string) ([]*entity.Charge, error) {
+	ret := _m.Called(ctx, accountID, role)
+
+	var r0 []*entity.Charge
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint, string) ([]*entity.Charge, error)); ok {
+		return rf(ctx, accountID, role)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint, string) []*entity.Charge); ok {
+		r0 = rf(ctx, accountID, role)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*entity.Charge)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint, string) error); ok {
+		r1 = rf(ctx, accountID, role)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetForUpdate provides a mock function with given fields: ctx, id
+func (_m *ChargeRepositoryMock) GetForUpdate(ctx context.Context, id uint) (*entity.Charge, error) {
+	ret := _m.Called(ctx, id)
+
+	var r0 *entity.Charge
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint) (*entity.Charge, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint) *entity.Charge); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.Charge)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewChargeRepositoryMock creates a new instance of ChargeRepositoryMock. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewChargeRepositoryMock(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *ChargeRepositoryMock {
+	mock := &ChargeRepositoryMock{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,14 @@
+package ledger
+
+import (
+	"context"
+	"time"
+
+	"github.com/fms85/desafio-tecnico-go-stone/internal/domain/entity"
+)
+
+type ILedgerRepository interface {
+	Balance(ctx context.Context, accountID uint) (lastEntryID uint, balance float64, err error)
+	After(ctx context.Context, accountID uint, afterID uint, limit int) (opening float64, entries []*entity.LedgerEntry, err error)
+	Statement(ctx context.Context, accountID uint, from time.Time, to time.Time, opening func(balance float64) error, entry func(entry *entity.LedgerEntry) error) error
+}
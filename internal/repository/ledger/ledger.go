@@ -0,0 +1,127 @@
+package ledger
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/fms85/desafio-tecnico-go-stone/internal/domain/entity"
+	"gorm.io/gorm"
+)
+
+type ledgerRepository struct {
+	read  *gorm.DB
+	write *gorm.DB
+}
+
+func New(connections map[string]*gorm.DB) ILedgerRepository {
+	return &ledgerRepository{
+		write: connections["wr"],
+		read:  connections["rd"],
+	}
+}
+
+// Balance returns the balance of accountID according to its ledger and the
+// id of its last entry. It reads from the primary: it follows notifications
+// of commits the replica may not have yet.
+func (repo *ledgerRepository) Balance(ctx context.Context, accountID uint) (uint, float64, error) {
+	var result struct {
+		LastEntryID uint
+		Balance     float64
+	}
+
+	if err := repo.write.WithContext(ctx).Model(&entity.LedgerEntry{}).
+		Select("COALESCE(MAX(id), 0) AS last_entry_id, COALESCE(SUM(amount), 0) AS balance").
+		Where("account_id = ?", accountID).
+		Scan(&result).Error; err != nil {
+		return 0, 0, fmt.Errorf("error to get balance: %w", err)
+	}
+
+	return result.LastEntryID, result.Balance, nil
+}
+
+// After returns up to limit entries of accountID with ids greater than
+// afterID, in id order, and the balance right before them. Entries of one
+// account get their ids while the account row is locked, so id order is also
+// commit order. Like Balance, it reads from the primary.
+func (repo *ledgerRepository) After(ctx context.Context, accountID uint, afterID uint, limit int) (float64, []*entity.LedgerEntry, error) {
+	var opening float64
+	var entries []*entity.LedgerEntry
+
+	err := repo.write.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&entity.LedgerEntry{}).
+			Select("COALESCE(SUM(amount), 0)").
+			Where("account_id = ? AND id <= ?", accountID, afterID).
+			Scan(&opening).Error; err != nil {
+			return fmt.Errorf("error to get opening balance: %w", err)
+		}
+
+		if err := tx.Where("account_id = ? AND id > ?", accountID, afterID).
+			Order("id").
+			Limit(limit).
+			Find(&entries).Error; err != nil {
+			return fmt.Errorf("error to get ledger entries: %w", err)
+		}
+
+		return nil
+	}, &sql.TxOptions{Isolation: sql.LevelRepeatableRead, ReadOnly: true})
+	if err != nil {
+		return 0, nil, err
+	}
+
+	return opening, entries, nil
+}
+
+// Statement calls opening with the balance of accountID right before from,
+// then entry for each entry in [from, to) as rows are read, so exports never
+// hold the whole range in memory. Both queries run in a single repeatable
+// read snapshot, so transfers committed in between cannot make them disagree.
+func (repo *ledgerRepository) Statement(
+	ctx context.Context,
+	accountID uint,
+	from time.Time,
+	to time.Time,
+	opening func(balance float64) error,
+	entry func(entry *entity.LedgerEntry) error,
+) error {
+	return repo.read.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var balance float64
+		if err := tx.WithContext(ctx).Model(&entity.LedgerEntry{}).
+			Select("COALESCE(SUM(amount), 0)").
+			Where(`account_id = ? AND "createdAt" < ?`, accountID, from).
+			Scan(&balance).Error; err != nil {
+			return fmt.Errorf("error to get opening balance: %w", err)
+		}
+
+		if err := opening(balance); err != nil {
+			return err
+		}
+
+		rows, err := tx.WithContext(ctx).Model(&entity.LedgerEntry{}).
+			Where(`account_id = ? AND "createdAt" >= ? AND "createdAt" < ?`, accountID, from, to).
+			Order(`"createdAt", id`).
+			Rows()
+		if err != nil {
+			return fmt.Errorf("error to get ledger entries: %w", err)
+		}
+		defer rows.Close()
+
+		for rows.Next() {
+			var ledgerEntry entity.LedgerEntry
+			if err := tx.ScanRows(rows, &ledgerEntry); err != nil {
+				return fmt.Errorf("error to scan ledger entry: %w", err)
+			}
+
+			if err := entry(&ledgerEntry); err != nil {
+				return err
+			}
+		}
+
+		if err := rows.Err(); err != nil {
+			return fmt.Errorf("error to get ledger entries: %w", err)
+		}
+
+		return nil
+	}, &sql.TxOptions{Isolation: sql.LevelRepeatableRead, ReadOnly: true})
+}
@@ -0,0 +1,109 @@
+// Code generated by mockery v2.33.0. DO NOT EDIT.
+
+package ledger
+
+import (
+	context "context"
+
+	entity "github.com/fms85/desafio-tecnico-go-stone/internal/domain/entity"
+	mock "github.com/stretchr/testify/mock"
+
+	time "time"
+)
+
+// LedgerRepositoryMock is an autogenerated mock type for the ILedgerRepository type
+type LedgerRepositoryMock struct {
+	mock.Mock
+}
+
+// After provides a mock function with given fields: ctx, accountID, afterID, limit
+func (_m *LedgerRepositoryMock) After(ctx context.Context, accountID uint, afterID uint, limit int) (float64, []*entity.LedgerEntry, error) {
+	ret := _m.Called(ctx, accountID, afterID, limit)
+
+	var r0 float64
+	var r1 []*entity.LedgerEntry
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint, uint, int) (float64, []*entity.LedgerEntry, error)); ok {
+		return rf(ctx, accountID, afterID, limit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint, uint, int) float64); ok {
+		r0 = rf(ctx, accountID, afterID, limit)
+	} else {
+		r0 = ret.Get(0).(float64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint, uint, int) []*entity.LedgerEntry); ok {
+		r1 = rf(ctx, accountID, afterID, limit)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).([]*entity.LedgerEntry)
+		}
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, uint, uint, int) error); ok {
+		r2 = rf(ctx, accountID, afterID, limit)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// Balance provides a mock function with given fields: ctx, accountID
+func (_m *LedgerRepositoryMock) Balance(ctx context.Context, accountID uint) (uint, float64, error) {
+	ret := _m.Called(ctx, accountID)
+
+	var r0 uint
+	var r1 float64
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint) (uint, float64, error)); ok {
+		return rf(ctx, accountID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint) uint); ok {
+		r0 = rf(ctx, accountID)
+	} else {
+		r0 = ret.Get(0).(uint)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint) float64); ok {
+		r1 = rf(ctx, accountID)
+	} else {
+		r1 = ret.Get(1).(float64)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, uint) error); ok {
+		r2 = rf(ctx, accountID)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// Statement provides a mock function with given fields: ctx, accountID, from, to, opening, entry
+func (_m *LedgerRepositoryMock) Statement(ctx context.Context, accountID uint, from time.Time, to time.Time, opening func(float64) error, entry func(*entity.LedgerEntry) error) error {
+	ret := _m.Called(ctx, accountID, from, to, opening, entry)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint, time.Time, time.Time, func(float64) error, func(*entity.LedgerEntry) error) error); ok {
+		r0 = rf(ctx, accountID, from, to, opening, entry)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NewLedgerRepositoryMock creates a new instance of LedgerRepositoryMock. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewLedgerRepositoryMock(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *LedgerRepositoryMock {
+	mock := &LedgerRepositoryMock{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,15 @@
+package outbox
+
+import (
+	"context"
+	"time"
+
+	"github.com/fms85/desafio-tecnico-go-stone/internal/domain/entity"
+)
+
+type IOutboxRepository interface {
+	Claim(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]*entity.OutboxEvent, error)
+	MarkPublished(ctx context.Context, event *entity.OutboxEvent) error
+	MarkFailed(ctx context.Context, event *entity.OutboxEvent) error
+	Release(ctx context.Context, events []*entity.OutboxEvent) error
+}
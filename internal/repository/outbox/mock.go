@@ -0,0 +1,99 @@
+// Code generated by mockery v2.33.0. DO NOT EDIT.
+
+package outbox
+
+import (
+	context "context"
+
+	entity "github.com/fms85/desafio-tecnico-go-stone/internal/domain/entity"
+	mock "github.com/stretchr/testify/mock"
+
+	time "time"
+)
+
+// OutboxRepositoryMock is an autogenerated mock type for the IOutboxRepository type
+type OutboxRepositoryMock struct {
+	mock.Mock
+}
+
+// Claim provides a mock function with given fields: ctx, now, lease, limit
+func (_m *OutboxRepositoryMock) Claim(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]*entity.OutboxEvent, error) {
+	ret := _m.Called(ctx, now, lease, limit)
+
+	var r0 []*entity.OutboxEvent
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time, time.Duration, int) ([]*entity.OutboxEvent, error)); ok {
+		return rf(ctx, now, lease, limit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time, time.Duration, int) []*entity.OutboxEvent); ok {
+		r0 = rf(ctx, now, lease, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*entity.OutboxEvent)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time, time.Duration, int) error); ok {
+		r1 = rf(ctx, now, lease, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MarkFailed provides a mock function with given fields: ctx, event
+func (_m *OutboxRepositoryMock) MarkFailed(ctx context.Context, event *entity.OutboxEvent) error {
+	ret := _m.Called(ctx, event)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *entity.OutboxEvent) error); ok {
+		r0 = rf(ctx, event)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MarkPublished provides a mock function with given fields: ctx, event
+func (_m *OutboxRepositoryMock) MarkPublished(ctx context.Context, event *entity.OutboxEvent) error {
+	ret := _m.Called(ctx, event)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *entity.OutboxEvent) error); ok {
+		r0 = rf(ctx, event)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Release provides a mock function with given fields: ctx, events
+func (_m *OutboxRepositoryMock) Release(ctx context.Context, events []*entity.OutboxEvent) error {
+	ret := _m.Called(ctx, events)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, []*entity.OutboxEvent) error); ok {
+		r0 = rf(ctx, events)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NewOutboxRepositoryMock creates a new instance of OutboxRepositoryMock. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewOutboxRepositoryMock(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *OutboxRepositoryMock {
+	mock := &OutboxRepositoryMock{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,106 @@
+package outbox
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/fms85/desafio-tecnico-go-stone/internal/domain/entity"
+	"gorm.io/gorm"
+)
+
+// OUTBOX_CLAIM_LOCK is the advisory lock serializing claims, so two relays
+// never split the pending events of one account between them.
+const OUTBOX_CLAIM_LOCK = 7_310_038
+
+type outboxRepository struct {
+	read  *gorm.DB
+	write *gorm.DB
+}
+
+func New(connections map[string]*gorm.DB) IOutboxRepository {
+	return &outboxRepository{
+		write: connections["wr"],
+		read:  connections["rd"],
+	}
+}
+
+// Claim leases the oldest unpublished events. An event is only eligible when
+// every earlier unpublished event of its account is eligible too, which keeps
+// each account's events in order even across relays and retries.
+func (repo *outboxRepository) Claim(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]*entity.OutboxEvent, error) {
+	var events []*entity.OutboxEvent
+
+	err := repo.write.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec("SELECT pg_advisory_xact_lock(?)", OUTBOX_CLAIM_LOCK).Error; err != nil {
+			return fmt.Errorf("error to lock outbox: %w", err)
+		}
+
+		if err := tx.
+			Where("published_at IS NULL AND (locked_until IS NULL OR locked_until <= ?)", now).
+			Where(`NOT EXISTS (
+				SELECT 1 FROM outbox_events earlier
+				WHERE earlier.account_id = outbox_events.account_id
+				AND earlier.id < outbox_events.id
+				AND earlier.published_at IS NULL
+				AND earlier.locked_until > ?
+			)`, now).
+			Order("id").
+			Limit(limit).
+			Find(&events).Error; err != nil {
+			return fmt.Errorf("error to claim outbox events: %w", err)
+		}
+
+		if len(events) == 0 {
+			return nil
+		}
+
+		lockedUntil := now.Add(lease)
+		ids := make([]uint, 0, len(events))
+		for _, event := range events {
+			ids = append(ids, event.ID)
+			event.LockedUntil = &lockedUntil
+		}
+
+		if err := tx.Model(&entity.OutboxEvent{}).Where("id IN ?", ids).Update("locked_until", lockedUntil).Error; err != nil {
+			return fmt.Errorf("error to lease outbox events: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return events, nil
+}
+
+func (repo *outboxRepository) MarkPublished(ctx context.Context, event *entity.OutboxEvent) error {
+	if err := repo.write.WithContext(ctx).Model(event).Select("published_at", "attempts", "last_error", "locked_until").Updates(event).Error; err != nil {
+		return fmt.Errorf("error to mark outbox event as published: %w", err)
+	}
+
+	return nil
+}
+
+func (repo *outboxRepository) MarkFailed(ctx context.Context, event *entity.OutboxEvent) error {
+	if err := repo.write.WithContext(ctx).Model(event).Select("attempts", "last_error", "locked_until").Updates(event).Error; err != nil {
+		return fmt.Errorf("error to mark outbox event as failed: %w", err)
+	}
+
+	return nil
+}
+
+// Release gives back leased events that were not attempted.
+func (repo *outboxRepository) Release(ctx context.Context, events []*entity.OutboxEvent) error {
+	ids := make([]uint, 0, len(events))
+	for _, event := range events {
+		ids = append(ids, event.ID)
+	}
+
+	if err := repo.write.WithContext(ctx).Model(&entity.OutboxEvent{}).Where("id IN ?", ids).Update("locked_until", nil).Error; err != nil {
+		return fmt.Errorf("error to release outbox events: %w", err)
+	}
+
+	return nil
+}
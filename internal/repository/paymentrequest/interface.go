@@ -0,0 +1,14 @@
+package paymentrequest
+
+import (
+	"context"
+
+	"github.com/fms85/desafio-tecnico-go-stone/internal/domain/entity"
+)
+
+type IPaymentRequestRepository interface {
+	Create(ctx context.Context, paymentRequest *entity.PaymentRequest) error
+	GetAll(ctx context.Context, accountID uint) ([]*entity.PaymentRequest, error)
+	Get(ctx context.Context, id uint, accountID uint) (*entity.PaymentRequest, error)
+	GetByTxID(ctx context.Context, txID string) (*entity.PaymentRequest, error)
+}
@@ -0,0 +1,121 @@
+// Code generated by mockery v2.33.0. DO NOT EDIT.
+
+package paymentrequest
+
+import (
+	context "context"
+
+	entity "github.com/fms85/desafio-tecnico-go-stone/internal/domain/entity"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// PaymentRequestRepositoryMock is an autogenerated mock type for the IPaymentRequestRepository type
+type PaymentRequestRepositoryMock struct {
+	mock.Mock
+}
+
+// Create provides a mock function with given fields: ctx, paymentRequest
+func (_m *PaymentRequestRepositoryMock) Create(ctx context.Context, paymentRequest *entity.PaymentRequest) error {
+	ret := _m.Called(ctx, paymentRequest)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *entity.PaymentRequest) error); ok {
+		r0 = rf(ctx, paymentRequest)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Get provides a mock function with given fields: ctx, id, accountID
+func (_m *PaymentRequestRepositoryMock) Get(ctx context.Context, id uint, accountID uint) (*entity.PaymentRequest, error) {
+	ret := _m.Called(ctx, id, accountID)
+
+	var r0 *entity.PaymentRequest
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint, uint) (*entity.PaymentRequest, error)); ok {
+		return rf(ctx, id, accountID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint, uint) *entity.PaymentRequest); ok {
+		r0 = rf(ctx, id, accountID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.PaymentRequest)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint, uint) error); ok {
+		r1 = rf(ctx, id, accountID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetAll provides a mock function with given fields: ctx, accountID
+func (_m *PaymentRequestRepositoryMock) GetAll(ctx context.Context, accountID uint) ([]*entity.PaymentRequest, error) {
+	ret := _m.Called(ctx, accountID)
+
+	var r0 []*entity.PaymentRequest
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint) ([]*entity.PaymentRequest, error)); ok {
+		return rf(ctx, accountID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint) []*entity.PaymentRequest); ok {
+		r0 = rf(ctx, accountID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*entity.PaymentRequest)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint) error); ok {
+		r1 = rf(ctx, accountID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetByTxID provides a mock function with given fields: ctx, txID
+func (_m *PaymentRequestRepositoryMock) GetByTxID(ctx context.Context, txID string) (*entity.PaymentRequest, error) {
+	ret := _m.Called(ctx, txID)
+
+	var r0 *entity.PaymentRequest
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*entity.PaymentRequest, error)); ok {
+		return rf(ctx, txID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *entity.PaymentRequest); ok {
+		r0 = rf(ctx, txID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.PaymentRequest)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, txID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewPaymentRequestRepositoryMock creates a new instance of PaymentRequestRepositoryMock. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewPaymentRequestRepositoryMock(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *PaymentRequestRepositoryMock {
+	mock := &PaymentRequestRepositoryMock{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,59 @@
+package paymentrequest
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/fms85/desafio-tecnico-go-stone/internal/domain/entity"
+	"gorm.io/gorm"
+)
+
+type paymentRequestRepository struct {
+	read  *gorm.DB
+	write *gorm.DB
+}
+
+func New(connections map[string]*gorm.DB) IPaymentRequestRepository {
+	return &paymentRequestRepository{
+		write: connections["wr"],
+		read:  connections["rd"],
+	}
+}
+
+func (repo *paymentRequestRepository) Create(ctx context.Context, paymentRequest *entity.PaymentRequest) error {
+	if err := repo.write.WithContext(ctx).Create(paymentRequest).Error; err != nil {
+		return fmt.Errorf("error to create payment request: %w", err)
+	}
+
+	return nil
+}
+
+func (repo *paymentRequestRepository) GetAll(ctx context.Context, accountID uint) ([]*entity.PaymentRequest, error) {
+	var paymentRequests []*entity.PaymentRequest
+
+	if err := repo.read.WithContext(ctx).Where("account_id = ?", accountID).Order("id DESC").Find(&paymentRequests).Error; err != nil {
+		return nil, fmt.Errorf("error to get all payment requests: %w", err)
+	}
+
+	return paymentRequests, nil
+}
+
+func (repo *paymentRequestRepository) Get(ctx context.Context, id uint, accountID uint) (*entity.PaymentRequest, error) {
+	var paymentRequest *entity.PaymentRequest
+
+	if err := repo.read.WithContext(ctx).Where("id = ? AND account_id = ?", id, accountID).Find(&paymentRequest).Error; err != nil {
+		return nil, fmt.Errorf("error to get payment request: %w", err)
+	}
+
+	return paymentRequest, nil
+}
+
+func (repo *paymentRequestRepository) GetByTxID(ctx context.Context, txID string) (*entity.PaymentRequest, error) {
+	var paymentRequest *entity.PaymentRequest
+
+	if err := repo.read.WithContext(ctx).Where("txid = ?", txID).Find(&paymentRequest).Error; err != nil {
+		return nil, fmt.Errorf("error to get payment request: %w", err)
+	}
+
+	return paymentRequest, nil
+}
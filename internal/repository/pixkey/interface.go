@@ -0,0 +1,23 @@
+package pixkey
+
+import (
+	"context"
+
+	"github.com/fms85/desafio-tecnico-go-stone/internal/domain/entity"
+)
+
+type IPixKeyRepository interface {
+	Create(ctx context.Context, key *entity.PixKey) error
+	GetAll(ctx context.Context, accountID uint) ([]*entity.PixKey, error)
+	Get(ctx context.Context, key string) (*entity.PixKey, error)
+	GetByID(ctx context.Context, id uint) (*entity.PixKey, error)
+	Update(ctx context.Context, key *entity.PixKey, columns []string) error
+	ClaimVerificationAttempt(ctx context.Context, keyID uint, maxAttempts int) (bool, error)
+	Delete(ctx context.Context, key *entity.PixKey) error
+	CreateClaim(ctx context.Context, claim *entity.PixKeyClaim) error
+	GetClaims(ctx context.Context, accountID uint) ([]*entity.PixKeyClaim, error)
+	GetOpenClaim(ctx context.Context, key string) (*entity.PixKeyClaim, error)
+	GetClaim(ctx context.Context, id uint) (*entity.PixKeyClaim, error)
+	SaveClaim(ctx context.Context, claim *entity.PixKeyClaim) error
+	CompleteClaim(ctx context.Context, claim *entity.PixKeyClaim, key *entity.PixKey) error
+}
@@ -0,0 +1,293 @@
+// Code generated by mockery v2.33.0. DO NOT EDIT.
+
+package pixkey
+
+import (
+	context "context"
+
+	entity "github.com/fms85/desafio-tecnico-go-stone/internal/domain/entity"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// PixKeyRepositoryMock is an autogenerated mock type for the IPixKeyRepository type
+type PixKeyRepositoryMock struct {
+	mock.Mock
+}
+
+// ClaimVerificationAttempt provides a mock function with given fields: ctx, keyID, maxAttempts
+func (_m *PixKeyRepositoryMock) ClaimVerificationAttempt(ctx context.Context, keyID uint, maxAttempts int) (bool, error) {
+	ret := _m.Called(ctx, keyID, maxAttempts)
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint, int) (bool, error)); ok {
+		return rf(ctx, keyID, maxAttempts)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint, int) bool); ok {
+		r0 = rf(ctx, keyID, maxAttempts)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint, int) error); ok {
+		r1 = rf(ctx, keyID, maxAttempts)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CompleteClaim provides a mock function with given fields: ctx, claim, key
+func (_m *PixKeyRepositoryMock) CompleteClaim(ctx context.Context, claim *entity.PixKeyClaim, key *entity.PixKey) error {
+	ret := _m.Called(ctx, claim, key)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *entity.PixKeyClaim, *entity.PixKey) error); ok {
+		r0 = rf(ctx, claim, key)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Create provides a mock function with given fields: ctx, key
+func (_m *PixKeyRepositoryMock) Create(ctx context.Context, key *entity.PixKey) error {
+	ret := _m.Called(ctx, key)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *entity.PixKey) error); ok {
+		r0 = rf(ctx, key)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// CreateClaim provides a mock function with given fields: ctx, claim
+func (_m *PixKeyRepositoryMock) CreateClaim(ctx context.Context, claim *entity.PixKeyClaim) error {
+	ret := _m.Called(ctx, claim)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *entity.PixKeyClaim) error); ok {
+		r0 = rf(ctx, claim)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Delete provides a mock function with given fields: ctx, key
+func (_m *PixKeyRepositoryMock) Delete(ctx context.Context, key *entity.PixKey) error {
+	ret := _m.Called(ctx, key)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *entity.PixKey) error); ok {
+		r0 = rf(ctx, key)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Get provides a mock function with given fields: ctx, key
+func (_m *PixKeyRepositoryMock) Get(ctx context.Context, key string) (*entity.PixKey, error) {
+	ret := _m.Called(ctx, key)
+
+	var r0 *entity.PixKey
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*entity.PixKey, error)); ok {
+		return rf(ctx, key)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *entity.PixKey); ok {
+		r0 = rf(ctx, key)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.PixKey)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, key)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetAll provides a mock function with given fields: ctx, accountID
+func (_m *PixKeyRepositoryMock) GetAll(ctx context.Context, accountID uint) ([]*entity.PixKey, error) {
+	ret := _m.Called(ctx, accountID)
+
+	var r0 []*entity.PixKey
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint) ([]*entity.PixKey, error)); ok {
+		return rf(ctx, accountID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint) []*entity.PixKey); ok {
+		r0 = rf(ctx, accountID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*entity.PixKey)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint) error); ok {
+		r1 = rf(ctx, accountID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetByID provides a mock function with given fields: ctx, id
+func (_m *PixKeyRepositoryMock) GetByID(ctx context.Context, id uint) (*entity.PixKey, error) {
+	ret := _m.Called(ctx, id)
+
+	var r0 *entity.PixKey
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint) (*entity.PixKey, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint) *entity.PixKey); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.PixKey)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetClaim provides a mock function with given fields: ctx, id
+func (_m *PixKeyRepositoryMock) GetClaim(ctx context.Context, id uint) (*entity.PixKeyClaim, error) {
+	ret := _m.Called(ctx, id)
+
+	var r0 *entity.PixKeyClaim
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint) (*entity.PixKeyClaim, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint) *entity.PixKeyClaim); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.PixKeyClaim)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetClaims provides a mock function with given fields: ctx, accountID
+func (_m *PixKeyRepositoryMock) GetClaims(ctx context.Context, accountID uint) ([]*entity.PixKeyClaim, error) {
+	ret := _m.Called(ctx, accountID)
+
+	var r0 []*entity.PixKeyClaim
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint) ([]*entity.PixKeyClaim, error)); ok {
+		return rf(ctx, accountID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint) []*entity.PixKeyClaim); ok {
+		r0 = rf(ctx, accountID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*entity.PixKeyClaim)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint) error); ok {
+		r1 = rf(ctx, accountID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetOpenClaim provides a mock function with given fields: ctx, key
+func (_m *PixKeyRepositoryMock) GetOpenClaim(ctx context.Context, key string) (*entity.PixKeyClaim, error) {
+	ret := _m.Called(ctx, key)
+
+	var r0 *entity.PixKeyClaim
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*entity.PixKeyClaim, error)); ok {
+		return rf(ctx, key)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *entity.PixKeyClaim); ok {
+		r0 = rf(ctx, key)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.PixKeyClaim)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, key)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SaveClaim provides a mock function with given fields: ctx, claim
+func (_m *PixKeyRepositoryMock) SaveClaim(ctx context.Context, claim *entity.PixKeyClaim) error {
+	ret := _m.Called(ctx, claim)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *entity.PixKeyClaim) error); ok {
+		r0 = rf(ctx, claim)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Update provides a mock function with given fields: ctx, key, columns
+func (_m *PixKeyRepositoryMock) Update(ctx context.Context, key *entity.PixKey, columns []string) error {
+	ret := _m.Called(ctx, key, columns)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *entity.PixKey, []string) error); ok {
+		r0 = rf(ctx, key, columns)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NewPixKeyRepositoryMock creates a new instance of PixKeyRepositoryMock. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewPixKeyRepositoryMock(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *PixKeyRepositoryMock {
+	mock := &PixKeyRepositoryMock{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
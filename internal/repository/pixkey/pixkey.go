@@ -0,0 +1,167 @@
+package pixkey
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/fms85/desafio-tecnico-go-stone/internal/domain/entity"
+	"gorm.io/gorm"
+)
+
+type pixKeyRepository struct {
+	read  *gorm.DB
+	write *gorm.DB
+}
+
+func New(connections map[string]*gorm.DB) IPixKeyRepository {
+	return &pixKeyRepository{
+		write: connections["wr"],
+		read:  connections["rd"],
+	}
+}
+
+func (repo *pixKeyRepository) Create(ctx context.Context, key *entity.PixKey) error {
+	if err := repo.write.WithContext(ctx).Create(key).Error; err != nil {
+		return fmt.Errorf("error to create pix key: %w", err)
+	}
+
+	return nil
+}
+
+func (repo *pixKeyRepository) GetAll(ctx context.Context, accountID uint) ([]*entity.PixKey, error) {
+	var keys []*entity.PixKey
+
+	if err := repo.read.WithContext(ctx).Where("account_id = ?", accountID).Order("id").Find(&keys).Error; err != nil {
+		return nil, fmt.Errorf("error to get all pix keys: %w", err)
+	}
+
+	return keys, nil
+}
+
+func (repo *pixKeyRepository) Get(ctx context.Context, key string) (*entity.PixKey, error) {
+	var pixKey *entity.PixKey
+
+	if err := repo.read.WithContext(ctx).Where("key = ?", key).Find(&pixKey).Error; err != nil {
+		return nil, fmt.Errorf("error to get pix key: %w", err)
+	}
+
+	return pixKey, nil
+}
+
+func (repo *pixKeyRepository) GetByID(ctx context.Context, id uint) (*entity.PixKey, error) {
+	var pixKey *entity.PixKey
+
+	if err := repo.read.WithContext(ctx).Where("id = ?", id).Find(&pixKey).Error; err != nil {
+		return nil, fmt.Errorf("error to get pix key: %w", err)
+	}
+
+	return pixKey, nil
+}
+
+// Update writes only columns of key, so it cannot overwrite attempts counted
+// meanwhile by ClaimVerificationAttempt.
+func (repo *pixKeyRepository) Update(ctx context.Context, key *entity.PixKey, columns []string) error {
+	if err := repo.write.WithContext(ctx).Model(key).Select(columns).Updates(key).Error; err != nil {
+		return fmt.Errorf("error to update pix key: %w", err)
+	}
+
+	return nil
+}
+
+// ClaimVerificationAttempt counts a try of the code of a pending key before
+// it is checked, in a single statement on the primary, so parallel tries
+// cannot all see the same count and get past maxAttempts together. It
+// reports false, counting nothing, when no attempt is left.
+func (repo *pixKeyRepository) ClaimVerificationAttempt(ctx context.Context, keyID uint, maxAttempts int) (bool, error) {
+	result := repo.write.WithContext(ctx).Model(&entity.PixKey{}).
+		Where("id = ? AND status = ? AND verification_attempts < ?", keyID, entity.PIX_KEY_STATUS_PENDING, maxAttempts).
+		Update("verification_attempts", gorm.Expr("verification_attempts + 1"))
+	if result.Error != nil {
+		return false, fmt.Errorf("error to claim pix key verification attempt: %w", result.Error)
+	}
+
+	return result.RowsAffected == 1, nil
+}
+
+// Delete removes a key and cancels the claims still open on it.
+func (repo *pixKeyRepository) Delete(ctx context.Context, key *entity.PixKey) error {
+	return repo.write.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&entity.PixKeyClaim{}).
+			Where("key_id = ? AND status = ?", key.ID, entity.PIX_CLAIM_STATUS_OPEN).
+			Update("status", entity.PIX_CLAIM_STATUS_CANCELLED).Error; err != nil {
+			return fmt.Errorf("error to cancel pix key claims: %w", err)
+		}
+
+		if err := tx.Delete(key).Error; err != nil {
+			return fmt.Errorf("error to delete pix key: %w", err)
+		}
+
+		return nil
+	})
+}
+
+func (repo *pixKeyRepository) CreateClaim(ctx context.Context, claim *entity.PixKeyClaim) error {
+	if err := repo.write.WithContext(ctx).Create(claim).Error; err != nil {
+		return fmt.Errorf("error to create pix key claim: %w", err)
+	}
+
+	return nil
+}
+
+// GetClaims lists the claims made by or against an account.
+func (repo *pixKeyRepository) GetClaims(ctx context.Context, accountID uint) ([]*entity.PixKeyClaim, error) {
+	var claims []*entity.PixKeyClaim
+
+	if err := repo.read.WithContext(ctx).
+		Where("claimer_account_id = ? OR owner_account_id = ?", accountID, accountID).
+		Order("id DESC").
+		Find(&claims).Error; err != nil {
+		return nil, fmt.Errorf("error to get pix key claims: %w", err)
+	}
+
+	return claims, nil
+}
+
+func (repo *pixKeyRepository) GetOpenClaim(ctx context.Context, key string) (*entity.PixKeyClaim, error) {
+	var claim *entity.PixKeyClaim
+
+	if err := repo.read.WithContext(ctx).Where("key = ? AND status = ?", key, entity.PIX_CLAIM_STATUS_OPEN).Find(&claim).Error; err != nil {
+		return nil, fmt.Errorf("error to get open pix key claim: %w", err)
+	}
+
+	return claim, nil
+}
+
+func (repo *pixKeyRepository) GetClaim(ctx context.Context, id uint) (*entity.PixKeyClaim, error) {
+	var claim *entity.PixKeyClaim
+
+	if err := repo.read.WithContext(ctx).Where("id = ?", id).Find(&claim).Error; err != nil {
+		return nil, fmt.Errorf("error to get pix key claim: %w", err)
+	}
+
+	return claim, nil
+}
+
+func (repo *pixKeyRepository) SaveClaim(ctx context.Context, claim *entity.PixKeyClaim) error {
+	if err := repo.write.WithContext(ctx).Save(claim).Error; err != nil {
+		return fmt.Errorf("error to save pix key claim: %w", err)
+	}
+
+	return nil
+}
+
+// CompleteClaim closes the claim and hands the key to the claimer in one
+// transaction.
+func (repo *pixKeyRepository) CompleteClaim(ctx context.Context, claim *entity.PixKeyClaim, key *entity.PixKey) error {
+	return repo.write.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Save(claim).Error; err != nil {
+			return fmt.Errorf("error to complete pix key claim: %w", err)
+		}
+
+		if err := tx.Save(key).Error; err != nil {
+			return fmt.Errorf("error to transfer pix key: %w", err)
+		}
+
+		return nil
+	})
+}
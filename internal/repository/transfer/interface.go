@@ -9,5 +9,8 @@ import (
 
 type ITransferRepository interface {
 	Get(ctx context.Context, transferInput types.TransferInput) ([]*entity.Transfer, error)
+	GetByID(ctx context.Context, id uint) (*entity.Transfer, error)
 	Create(ctx context.Context, transferAggregation *types.TransferAggregation) error
+	CreateBatch(ctx context.Context, batchAggregation *types.TransferBatchAggregation) error
+	GetBatch(ctx context.Context, id uint) (*entity.TransferBatch, error)
 }
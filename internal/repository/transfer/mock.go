@@ -30,6 +30,20 @@ func (_m *TransferRepositoryMock) Create(ctx context.Context, transferAggregatio
 	return r0
 }
 
+// CreateBatch provides a mock function with given fields: ctx, batchAggregation
+func (_m *TransferRepositoryMock) CreateBatch(ctx context.Context, batchAggregation *types.TransferBatchAggregation) error {
+	ret := _m.Called(ctx, batchAggregation)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *types.TransferBatchAggregation) error); ok {
+		r0 = rf(ctx, batchAggregation)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // Get provides a mock function with given fields: ctx, transferInput
 func (_m *TransferRepositoryMock) Get(ctx context.Context, transferInput types.TransferInput) ([]*entity.Transfer, error) {
 	ret := _m.Called(ctx, transferInput)
@@ -56,6 +70,58 @@ func (_m *TransferRepositoryMock) Get(ctx context.Context, transferInput types.T
 	return r0, r1
 }
 
+// GetBatch provides a mock function with given fields: ctx, id
+func (_m *TransferRepositoryMock) GetBatch(ctx context.Context, id uint) (*entity.TransferBatch, error) {
+	ret := _m.Called(ctx, id)
+
+	var r0 *entity.TransferBatch
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint) (*entity.TransferBatch, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint) *entity.TransferBatch); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.TransferBatch)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetByID provides a mock function with given fields: ctx, id
+func (_m *TransferRepositoryMock) GetByID(ctx context.Context, id uint) (*entity.Transfer, error) {
+	ret := _m.Called(ctx, id)
+
+	var r0 *entity.Transfer
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint) (*entity.Transfer, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint) *entity.Transfer); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.Transfer)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // NewTransferRepositoryMock creates a new instance of TransferRepositoryMock. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewTransferRepositoryMock(t interface {
@@ -2,11 +2,16 @@ package transfer
 
 import (
 	"context"
+	"errors"
 	"fmt"
 
+	"github.com/fms85/desafio-tecnico-go-stone/internal/domain/common"
 	"github.com/fms85/desafio-tecnico-go-stone/internal/domain/entity"
 	"github.com/fms85/desafio-tecnico-go-stone/internal/domain/types"
+	gormDriver "github.com/fms85/desafio-tecnico-go-stone/internal/driver/gorm"
+	"github.com/fms85/desafio-tecnico-go-stone/internal/util"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type transferRepository struct {
@@ -31,33 +36,223 @@ func (repo *transferRepository) Get(ctx context.Context, transferInput types.Tra
 	return transfers, nil
 }
 
+func (repo *transferRepository) GetByID(ctx context.Context, id uint) (*entity.Transfer, error) {
+	var transfer *entity.Transfer
+
+	if err := repo.read.WithContext(ctx).
+		Preload("AccountOrigin").
+		Preload("AccountDestination").
+		Where("id = ?", id).
+		Find(&transfer).Error; err != nil {
+		return nil, fmt.Errorf("error to get transfer: %w", err)
+	}
+
+	return transfer, nil
+}
+
+// Create commits a transfer, with what its Record writes in the same
+// transaction.
 func (repo *transferRepository) Create(ctx context.Context, transferAggregation *types.TransferAggregation) error {
-	return repo.write.Transaction(func(tx *gorm.DB) error {
+	return repo.write.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := create(ctx, tx, transferAggregation); err != nil {
+			return err
+		}
+
+		if transferAggregation.Record == nil {
+			return nil
+		}
 
-		if err := tx.WithContext(ctx).Create(&transferAggregation.Transfer).Error; err != nil {
-			return fmt.Errorf("error to create transfer: %w", err)
+		return transferAggregation.Record(gormDriver.WithTx(ctx, tx), transferAggregation.Transfer)
+	})
+}
+
+// CreateBatch commits the transfers of a batch together with the batch and
+// its items, so the batch is either fully recorded or not at all. Every
+// account of the batch is locked up front. An item the locked balance no
+// longer covers fails the whole batch in atomic mode, and only that item in
+// best effort mode.
+func (repo *transferRepository) CreateBatch(ctx context.Context, batchAggregation *types.TransferBatchAggregation) error {
+	ids := []uint{}
+	for _, transferAggregation := range batchAggregation.Transfers {
+		if transferAggregation != nil {
+			ids = append(ids, transferAggregation.AccountOrigin.ID, transferAggregation.AccountDestination.ID)
+		}
+	}
+
+	return repo.write.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if len(ids) > 0 {
+			if _, err := lockAccounts(ctx, tx, ids...); err != nil {
+				return err
+			}
 		}
 
-		tx.WithContext(ctx).Model(&entity.Account{}).
-			Where("id = ?", transferAggregation.AccountOrigin.ID).
-			Updates(map[string]interface{}{
-				"balance": transferAggregation.AccountOrigin.Balance,
-			})
+		for i, transferAggregation := range batchAggregation.Transfers {
+			if transferAggregation == nil {
+				continue
+			}
+
+			if err := create(ctx, tx, transferAggregation); err != nil {
+				var insufficientFundsError *common.InsufficientFundsError
+				if batchAggregation.Batch.Mode != entity.TRANSFER_BATCH_MODE_BEST_EFFORT || !errors.As(err, &insufficientFundsError) {
+					return err
+				}
+
+				batchAggregation.FailItem(i, err)
+				continue
+			}
 
-		if tx.Error != nil {
-			return fmt.Errorf("error to update account origin: %w", tx.Error)
+			batchAggregation.Batch.Items[i].TransferID = &transferAggregation.Transfer.ID
 		}
 
-		tx.WithContext(ctx).Model(&entity.Account{}).
-			Where("id = ?", transferAggregation.AccountDestination.ID).
-			Updates(map[string]interface{}{
-				"balance": transferAggregation.AccountDestination.Balance,
-			})
+		batchAggregation.SetStatus()
 
-		if tx.Error != nil {
-			return fmt.Errorf("error to update account destination: %w", tx.Error)
+		if err := tx.WithContext(ctx).Create(batchAggregation.Batch).Error; err != nil {
+			return fmt.Errorf("error to create transfer batch: %w", err)
 		}
 
 		return nil
 	})
 }
+
+func (repo *transferRepository) GetBatch(ctx context.Context, id uint) (*entity.TransferBatch, error) {
+	var batch *entity.TransferBatch
+
+	if err := repo.read.WithContext(ctx).
+		Preload("Items", func(db *gorm.DB) *gorm.DB {
+			return db.Order("position")
+		}).
+		Where("id = ?", id).
+		Find(&batch).Error; err != nil {
+		return nil, fmt.Errorf("error to get transfer batch: %w", err)
+	}
+
+	return batch, nil
+}
+
+// create moves the amount of a transfer between the balances of its accounts
+// as locked in tx, which the balances read before the transaction may lag
+// behind, and checks again that the origin covers it with the fee.
+func create(ctx context.Context, tx *gorm.DB, transferAggregation *types.TransferAggregation) error {
+	accounts, err := lockAccounts(ctx, tx, transferAggregation.AccountOrigin.ID, transferAggregation.AccountDestination.ID)
+	if err != nil {
+		return err
+	}
+
+	accountOrigin, accountDestination := accounts[transferAggregation.AccountOrigin.ID], accounts[transferAggregation.AccountDestination.ID]
+	if accountOrigin == nil || accountDestination == nil {
+		return fmt.Errorf("error to lock accounts: %w", gorm.ErrRecordNotFound)
+	}
+
+	total := util.Sum(transferAggregation.Transfer.Amount, transferAggregation.Transfer.Fee)
+	if total > accountOrigin.Balance {
+		return &common.InsufficientFundsError{}
+	}
+
+	transferAggregation.AccountOrigin.Balance = util.Sub(accountOrigin.Balance, total)
+	transferAggregation.AccountDestination.Balance = util.Sum(accountDestination.Balance, transferAggregation.Transfer.Amount)
+
+	if err := tx.WithContext(ctx).Create(&transferAggregation.Transfer).Error; err != nil {
+		return fmt.Errorf("error to create transfer: %w", err)
+	}
+
+	if err := tx.WithContext(ctx).Model(&entity.Account{}).
+		Where("id = ?", transferAggregation.AccountOrigin.ID).
+		Updates(map[string]interface{}{
+			"balance": transferAggregation.AccountOrigin.Balance,
+		}).Error; err != nil {
+		return fmt.Errorf("error to update account origin: %w", err)
+	}
+
+	if err := tx.WithContext(ctx).Model(&entity.Account{}).
+		Where("id = ?", transferAggregation.AccountDestination.ID).
+		Updates(map[string]interface{}{
+			"balance": transferAggregation.AccountDestination.Balance,
+		}).Error; err != nil {
+		return fmt.Errorf("error to update account destination: %w", err)
+	}
+
+	if err := tx.WithContext(ctx).Create(ledgerEntries(transferAggregation.Transfer)).Error; err != nil {
+		return fmt.Errorf("error to create ledger entries: %w", err)
+	}
+
+	events, err := outboxEvents(transferAggregation.Transfer)
+	if err != nil {
+		return err
+	}
+
+	if err := tx.WithContext(ctx).Create(events).Error; err != nil {
+		return fmt.Errorf("error to create outbox events: %w", err)
+	}
+
+	return nil
+}
+
+// lockAccounts locks the rows of accounts for update in id order, so
+// transfers between the same accounts wait for each other instead of
+// deadlocking, and returns their current balances by id.
+func lockAccounts(ctx context.Context, tx *gorm.DB, ids ...uint) (map[uint]*entity.Account, error) {
+	var accounts []*entity.Account
+
+	if err := tx.WithContext(ctx).
+		Clauses(clause.Locking{Strength: "UPDATE"}).
+		Select("id", "balance").
+		Where("id IN ?", ids).
+		Order("id").
+		Find(&accounts).Error; err != nil {
+		return nil, fmt.Errorf("error to lock accounts: %w", err)
+	}
+
+	accountsByID := make(map[uint]*entity.Account, len(accounts))
+	for _, account := range accounts {
+		accountsByID[account.ID] = account
+	}
+
+	return accountsByID, nil
+}
+
+// outboxEvents tells each party about the transfer: the origin that it was
+// sent and the destination that it was received.
+func outboxEvents(transfer *entity.Transfer) ([]*entity.OutboxEvent, error) {
+	created, err := types.CreateOutboxEvent(transfer.AccountOriginID, entity.EVENT_TRANSFER_CREATED, transfer)
+	if err != nil {
+		return nil, err
+	}
+
+	received, err := types.CreateOutboxEvent(transfer.AccountDestinationID, entity.EVENT_TRANSFER_RECEIVED, transfer)
+	if err != nil {
+		return nil, err
+	}
+
+	return []*entity.OutboxEvent{created, received}, nil
+}
+
+func ledgerEntries(transfer *entity.Transfer) []*entity.LedgerEntry {
+	entries := []*entity.LedgerEntry{
+		{
+			AccountID:  transfer.AccountOriginID,
+			Kind:       entity.LEDGER_ENTRY_KIND_TRANSFER_OUT,
+			Amount:     -transfer.Amount,
+			TransferID: &transfer.ID,
+			CreatedAt:  transfer.CreatedAt,
+		},
+		{
+			AccountID:  transfer.AccountDestinationID,
+			Kind:       entity.LEDGER_ENTRY_KIND_TRANSFER_IN,
+			Amount:     transfer.Amount,
+			TransferID: &transfer.ID,
+			CreatedAt:  transfer.CreatedAt,
+		},
+	}
+
+	if transfer.Fee > 0 {
+		entries = append(entries, &entity.LedgerEntry{
+			AccountID:  transfer.AccountOriginID,
+			Kind:       entity.LEDGER_ENTRY_KIND_FEE,
+			Amount:     -transfer.Fee,
+			TransferID: &transfer.ID,
+			CreatedAt:  transfer.CreatedAt,
+		})
+	}
+
+	return entries
+}
@@ -0,0 +1,21 @@
+package webhook
+
+import (
+	"context"
+	"time"
+
+	"github.com/fms85/desafio-tecnico-go-stone/internal/domain/entity"
+	"github.com/fms85/desafio-tecnico-go-stone/internal/domain/types"
+)
+
+type IWebhookRepository interface {
+	Create(ctx context.Context, webhook *entity.Webhook) error
+	GetAll(ctx context.Context, accountID uint) ([]*entity.Webhook, error)
+	Get(ctx context.Context, id uint, accountID uint) (*entity.Webhook, error)
+	Delete(ctx context.Context, webhook *entity.Webhook) error
+	CreateDeliveries(ctx context.Context, deliveries []*entity.WebhookDelivery) error
+	GetDeliveries(ctx context.Context, deliveryInput types.WebhookDeliveryInput) ([]*entity.WebhookDelivery, error)
+	GetDelivery(ctx context.Context, id uint, webhookID uint) (*entity.WebhookDelivery, error)
+	ClaimDeliveries(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]*entity.WebhookDelivery, error)
+	UpdateDelivery(ctx context.Context, delivery *entity.WebhookDelivery) error
+}
@@ -0,0 +1,219 @@
+// Code generated by mockery v2.33.0. DO NOT EDIT.
+
+package webhook
+
+import (
+	context "context"
+
+	entity "github.com/fms85/desafio-tecnico-go-stone/internal/domain/entity"
+	mock "github.com/stretchr/testify/mock"
+
+	time "time"
+
+	types "github.com/fms85/desafio-tecnico-go-stone/internal/domain/types"
+)
+
+// WebhookRepositoryMock is an autogenerated mock type for the IWebhookRepository type
+type WebhookRepositoryMock struct {
+	mock.Mock
+}
+
+// ClaimDeliveries provides a mock function with given fields: ctx, now, lease, limit
+func (_m *WebhookRepositoryMock) ClaimDeliveries(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]*entity.WebhookDelivery, error) {
+	ret := _m.Called(ctx, now, lease, limit)
+
+	var r0 []*entity.WebhookDelivery
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time, time.Duration, int) ([]*entity.WebhookDelivery, error)); ok {
+		return rf(ctx, now, lease, limit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time, time.Duration, int) []*entity.WebhookDelivery); ok {
+		r0 = rf(ctx, now, lease, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*entity.WebhookDelivery)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time, time.Duration, int) error); ok {
+		r1 = rf(ctx, now, lease, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Create provides a mock function with given fields: ctx, webhook
+func (_m *WebhookRepositoryMock) Create(ctx context.Context, webhook *entity.Webhook) error {
+	ret := _m.Called(ctx, webhook)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *entity.Webhook) error); ok {
+		r0 = rf(ctx, webhook)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// CreateDeliveries provides a mock function with given fields: ctx, deliveries
+func (_m *WebhookRepositoryMock) CreateDeliveries(ctx context.Context, deliveries []*entity.WebhookDelivery) error {
+	ret := _m.Called(ctx, deliveries)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, []*entity.WebhookDelivery) error); ok {
+		r0 = rf(ctx, deliveries)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Delete provides a mock function with given fields: ctx, webhook
+func (_m *WebhookRepositoryMock) Delete(ctx context.Context, webhook *entity.Webhook) error {
+	ret := _m.Called(ctx, webhook)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *entity.Webhook) error); ok {
+		r0 = rf(ctx, webhook)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Get provides a mock function with given fields: ctx, id, accountID
+func (_m *WebhookRepositoryMock) Get(ctx context.Context, id uint, accountID uint) (*entity.Webhook, error) {
+	ret := _m.Called(ctx, id, accountID)
+
+	var r0 *entity.Webhook
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint, uint) (*entity.Webhook, error)); ok {
+		return rf(ctx, id, accountID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint, uint) *entity.Webhook); ok {
+		r0 = rf(ctx, id, accountID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.Webhook)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint, uint) error); ok {
+		r1 = rf(ctx, id, accountID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetAll provides a mock function with given fields: ctx, accountID
+func (_m *WebhookRepositoryMock) GetAll(ctx context.Context, accountID uint) ([]*entity.Webhook, error) {
+	ret := _m.Called(ctx, accountID)
+
+	var r0 []*entity.Webhook
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint) ([]*entity.Webhook, error)); ok {
+		return rf(ctx, accountID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint) []*entity.Webhook); ok {
+		r0 = rf(ctx, accountID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*entity.Webhook)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint) error); ok {
+		r1 = rf(ctx, accountID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetDeliveries provides a mock function with given fields: ctx, deliveryInput
+func (_m *WebhookRepositoryMock) GetDeliveries(ctx context.Context, deliveryInput types.WebhookDeliveryInput) ([]*entity.WebhookDelivery, error) {
+	ret := _m.Called(ctx, deliveryInput)
+
+	var r0 []*entity.WebhookDelivery
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, types.WebhookDeliveryInput) ([]*entity.WebhookDelivery, error)); ok {
+		return rf(ctx, deliveryInput)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, types.WebhookDeliveryInput) []*entity.WebhookDelivery); ok {
+		r0 = rf(ctx, deliveryInput)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*entity.WebhookDelivery)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, types.WebhookDeliveryInput) error); ok {
+		r1 = rf(ctx, deliveryInput)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetDelivery provides a mock function with given fields: ctx, id, webhookID
+func (_m *WebhookRepositoryMock) GetDelivery(ctx context.Context, id uint, webhookID uint) (*entity.WebhookDelivery, error) {
+	ret := _m.Called(ctx, id, webhookID)
+
+	var r0 *entity.WebhookDelivery
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint, uint) (*entity.WebhookDelivery, error)); ok {
+		return rf(ctx, id, webhookID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint, uint) *entity.WebhookDelivery); ok {
+		r0 = rf(ctx, id, webhookID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.WebhookDelivery)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint, uint) error); ok {
+		r1 = rf(ctx, id, webhookID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UpdateDelivery provides a mock function with given fields: ctx, delivery
+func (_m *WebhookRepositoryMock) UpdateDelivery(ctx context.Context, delivery *entity.WebhookDelivery) error {
+	ret := _m.Called(ctx, delivery)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *entity.WebhookDelivery) error); ok {
+		r0 = rf(ctx, delivery)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NewWebhookRepositoryMock creates a new instance of WebhookRepositoryMock. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewWebhookRepositoryMock(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *WebhookRepositoryMock {
+	mock := &WebhookRepositoryMock{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
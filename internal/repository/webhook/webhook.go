@@ -0,0 +1,155 @@
+package webhook
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/fms85/desafio-tecnico-go-stone/internal/domain/entity"
+	"github.com/fms85/desafio-tecnico-go-stone/internal/domain/types"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// WEBHOOK_DELIVERIES_LIMIT caps how many of the latest deliveries are listed.
+const WEBHOOK_DELIVERIES_LIMIT = 100
+
+type webhookRepository struct {
+	read  *gorm.DB
+	write *gorm.DB
+}
+
+func New(connections map[string]*gorm.DB) IWebhookRepository {
+	return &webhookRepository{
+		write: connections["wr"],
+		read:  connections["rd"],
+	}
+}
+
+func (repo *webhookRepository) Create(ctx context.Context, webhook *entity.Webhook) error {
+	if err := repo.write.WithContext(ctx).Create(webhook).Error; err != nil {
+		return fmt.Errorf("error to create webhook: %w", err)
+	}
+
+	return nil
+}
+
+func (repo *webhookRepository) GetAll(ctx context.Context, accountID uint) ([]*entity.Webhook, error) {
+	var webhooks []*entity.Webhook
+
+	if err := repo.read.WithContext(ctx).Where("account_id = ?", accountID).Order("id").Find(&webhooks).Error; err != nil {
+		return nil, fmt.Errorf("error to get all webhooks: %w", err)
+	}
+
+	return webhooks, nil
+}
+
+func (repo *webhookRepository) Get(ctx context.Context, id uint, accountID uint) (*entity.Webhook, error) {
+	var webhook *entity.Webhook
+
+	if err := repo.read.WithContext(ctx).Where("id = ? AND account_id = ?", id, accountID).Find(&webhook).Error; err != nil {
+		return nil, fmt.Errorf("error to get webhook: %w", err)
+	}
+
+	return webhook, nil
+}
+
+func (repo *webhookRepository) Delete(ctx context.Context, webhook *entity.Webhook) error {
+	return repo.write.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("webhook_id = ?", webhook.ID).Delete(&entity.WebhookDelivery{}).Error; err != nil {
+			return fmt.Errorf("error to delete webhook deliveries: %w", err)
+		}
+
+		if err := tx.Delete(webhook).Error; err != nil {
+			return fmt.Errorf("error to delete webhook: %w", err)
+		}
+
+		return nil
+	})
+}
+
+// CreateDeliveries skips events already queued to the same webhook.
+func (repo *webhookRepository) CreateDeliveries(ctx context.Context, deliveries []*entity.WebhookDelivery) error {
+	if err := repo.write.WithContext(ctx).Clauses(clause.OnConflict{DoNothing: true}).Create(deliveries).Error; err != nil {
+		return fmt.Errorf("error to create webhook deliveries: %w", err)
+	}
+
+	return nil
+}
+
+func (repo *webhookRepository) GetDeliveries(ctx context.Context, deliveryInput types.WebhookDeliveryInput) ([]*entity.WebhookDelivery, error) {
+	var deliveries []*entity.WebhookDelivery
+
+	query := repo.read.WithContext(ctx).Where("webhook_id = ?", deliveryInput.WebhookID)
+	if deliveryInput.Status != "" {
+		query = query.Where("status = ?", deliveryInput.Status)
+	}
+
+	if err := query.Order("id DESC").Limit(WEBHOOK_DELIVERIES_LIMIT).Find(&deliveries).Error; err != nil {
+		return nil, fmt.Errorf("error to get webhook deliveries: %w", err)
+	}
+
+	return deliveries, nil
+}
+
+func (repo *webhookRepository) GetDelivery(ctx context.Context, id uint, webhookID uint) (*entity.WebhookDelivery, error) {
+	var delivery *entity.WebhookDelivery
+
+	if err := repo.read.WithContext(ctx).Where("id = ? AND webhook_id = ?", id, webhookID).Find(&delivery).Error; err != nil {
+		return nil, fmt.Errorf("error to get webhook delivery: %w", err)
+	}
+
+	return delivery, nil
+}
+
+// ClaimDeliveries returns the pending deliveries that are due, pushing their
+// next attempt lease away so concurrent workers skip them meanwhile.
+func (repo *webhookRepository) ClaimDeliveries(ctx context.Context, now time.Time, lease time.Duration, limit int) ([]*entity.WebhookDelivery, error) {
+	var deliveries []*entity.WebhookDelivery
+
+	err := repo.write.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+			Where("status = ? AND next_attempt_at <= ?", entity.WEBHOOK_DELIVERY_STATUS_PENDING, now).
+			Order("next_attempt_at").
+			Limit(limit).
+			Find(&deliveries).Error; err != nil {
+			return fmt.Errorf("error to claim webhook deliveries: %w", err)
+		}
+
+		if len(deliveries) == 0 {
+			return nil
+		}
+
+		ids := make([]uint, 0, len(deliveries))
+		for _, delivery := range deliveries {
+			ids = append(ids, delivery.ID)
+		}
+
+		if err := tx.Model(&entity.WebhookDelivery{}).
+			Where("id IN ?", ids).
+			Update("next_attempt_at", now.Add(lease)).Error; err != nil {
+			return fmt.Errorf("error to lease webhook deliveries: %w", err)
+		}
+
+		if err := tx.Preload("Webhook").Where("id IN ?", ids).Order("id").Find(&deliveries).Error; err != nil {
+			return fmt.Errorf("error to get claimed webhook deliveries: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return deliveries, nil
+}
+
+func (repo *webhookRepository) UpdateDelivery(ctx context.Context, delivery *entity.WebhookDelivery) error {
+	if err := repo.write.WithContext(ctx).Model(delivery).Select(
+		"status", "attempts", "next_attempt_at", "response_status", "last_error", "delivered_at",
+	).Updates(delivery).Error; err != nil {
+		return fmt.Errorf("error to update webhook delivery: %w", err)
+	}
+
+	return nil
+}
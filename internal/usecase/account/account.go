@@ -3,26 +3,39 @@ package account
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/fms85/desafio-tecnico-go-stone/internal/domain/common"
 	"github.com/fms85/desafio-tecnico-go-stone/internal/domain/entity"
 	"github.com/fms85/desafio-tecnico-go-stone/internal/domain/types"
+	"github.com/fms85/desafio-tecnico-go-stone/internal/driver/notifier"
+	otelDriver "github.com/fms85/desafio-tecnico-go-stone/internal/driver/otel"
 	accountRepository "github.com/fms85/desafio-tecnico-go-stone/internal/repository/account"
 	"github.com/fms85/desafio-tecnico-go-stone/internal/util"
+	"go.opentelemetry.io/otel"
 )
 
+var tracer = otel.Tracer("internal/usecase/account")
+
 type accountUsecase struct {
 	accountRepository accountRepository.IAccountRepository
+	notifier          notifier.INotifier
+	now               func() time.Time
 }
 
-func New(accountRepository accountRepository.IAccountRepository) IAccountUsecase {
+func New(accountRepository accountRepository.IAccountRepository, notifier notifier.INotifier) IAccountUsecase {
 	return &accountUsecase{
 		accountRepository: accountRepository,
+		notifier:          notifier,
+		now:               time.Now,
 	}
 }
 
-func (usecase *accountUsecase) GetAll(ctx context.Context) ([]*entity.Account, error) {
-	accounts, err := usecase.accountRepository.GetAll(ctx)
+func (usecase *accountUsecase) GetAll(ctx context.Context) (accounts []*entity.Account, err error) {
+	ctx, span := tracer.Start(ctx, "accountUsecase.GetAll")
+	defer func() { otelDriver.End(span, err) }()
+
+	accounts, err = usecase.accountRepository.GetAll(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -30,35 +43,72 @@ func (usecase *accountUsecase) GetAll(ctx context.Context) ([]*entity.Account, e
 	return accounts, nil
 }
 
-func (usecase *accountUsecase) Get(ctx context.Context, accountInput types.AccountInput) (*entity.Account, error) {
-	account, err := usecase.accountRepository.Get(ctx, accountInput)
+func (usecase *accountUsecase) Get(ctx context.Context, accountInput types.AccountInput) (account *entity.Account, err error) {
+	ctx, span := tracer.Start(ctx, "accountUsecase.Get")
+	defer func() { otelDriver.End(span, err) }()
+
+	account, err = usecase.accountRepository.Get(ctx, accountInput)
 	if err != nil {
 		return nil, err
 	}
 
 	if account.ID == 0 {
-		return nil, fmt.Errorf("account %w", &common.ValidationError{Msg: common.NOT_FOUND_ERROR})
+		return nil, fmt.Errorf("account %w", &common.NotFoundError{})
 	}
 
 	return account, nil
 }
 
-func (usecase *accountUsecase) Create(ctx context.Context, accountInput types.AccountInput) (*entity.Account, error) {
-	account, err := usecase.accountRepository.Get(ctx, types.AccountInput{CPF: accountInput.CPF})
+// GetByIDs returns the accounts found among ids, in no particular order.
+func (usecase *accountUsecase) GetByIDs(ctx context.Context, ids []uint) (accounts []*entity.Account, err error) {
+	ctx, span := tracer.Start(ctx, "accountUsecase.GetByIDs")
+	defer func() { otelDriver.End(span, err) }()
+
+	accounts, err = usecase.accountRepository.GetByIDs(ctx, ids)
 	if err != nil {
 		return nil, err
 	}
 
-	if account.ID > 0 {
-		return nil, fmt.Errorf("account %w", &common.ValidationError{Msg: common.FOUND_ERROR})
-	}
+	return accounts, nil
+}
+
+// Create opens an account of the type of accountInput, keeping only the
+// document and names that type takes.
+func (usecase *accountUsecase) Create(ctx context.Context, accountInput types.AccountInput) (account *entity.Account, err error) {
+	ctx, span := tracer.Start(ctx, "accountUsecase.Create")
+	defer func() { otelDriver.End(span, err) }()
 
 	account = &entity.Account{
+		Type:    entity.ACCOUNT_TYPE_PF,
 		Name:    accountInput.Name,
 		CPF:     accountInput.CPF,
 		Secret:  util.GenerateHash(accountInput.Secret),
 		Balance: entity.ENTITY_BALANCE_DEFAULT,
 	}
+	document := types.AccountInput{CPF: accountInput.CPF}
+
+	if accountInput.Type == entity.ACCOUNT_TYPE_PJ {
+		account.Type = entity.ACCOUNT_TYPE_PJ
+		account.CPF = ""
+		account.CNPJ = accountInput.CNPJ
+		account.LegalName = accountInput.LegalName
+		account.TradeName = accountInput.TradeName
+		account.Name = accountInput.TradeName
+		if account.Name == "" {
+			account.Name = accountInput.LegalName
+		}
+
+		document = types.AccountInput{CNPJ: accountInput.CNPJ}
+	}
+
+	existing, err := usecase.accountRepository.Get(ctx, document)
+	if err != nil {
+		return nil, err
+	}
+
+	if existing.ID > 0 {
+		return nil, fmt.Errorf("account %w", &common.ConflictError{})
+	}
 
 	if err := usecase.accountRepository.Save(ctx, account); err != nil {
 		return nil, err
@@ -7,6 +7,7 @@ import (
 
 	"github.com/fms85/desafio-tecnico-go-stone/internal/domain/entity"
 	"github.com/fms85/desafio-tecnico-go-stone/internal/domain/types"
+	"github.com/fms85/desafio-tecnico-go-stone/internal/driver/notifier"
 	accountRepository "github.com/fms85/desafio-tecnico-go-stone/internal/repository/account"
 	"github.com/google/go-cmp/cmp"
 	mock "github.com/stretchr/testify/mock"
@@ -56,7 +57,7 @@ func TestAccountUsecaseGetAll(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			usecase := New(tt.dependencies.accountRepository())
+			usecase := New(tt.dependencies.accountRepository(), &notifier.MemoryNotifier{})
 
 			got, err := usecase.GetAll(context.Background())
 			if (err != nil) != tt.wantErr {
@@ -119,7 +120,7 @@ func TestAccountUsecaseGet(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			usecase := New(tt.dependencies.accountRepository())
+			usecase := New(tt.dependencies.accountRepository(), &notifier.MemoryNotifier{})
 
 			got, err := usecase.Get(context.Background(), types.AccountInput{ID: 1})
 			if (err != nil) != tt.wantErr {
@@ -201,7 +202,7 @@ func TestAccountUsecaseCreate(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			usecase := New(tt.dependencies.accountRepository())
+			usecase := New(tt.dependencies.accountRepository(), &notifier.MemoryNotifier{})
 
 			got, err := usecase.Create(context.Background(), getAccountInputTest())
 			if (err != nil) != tt.wantErr {
@@ -215,9 +216,60 @@ func TestAccountUsecaseCreate(t *testing.T) {
 	}
 }
 
+func TestAccountUsecaseCreateBusiness(t *testing.T) {
+	tests := []struct {
+		name  string
+		input types.AccountInput
+		want  *entity.Account
+	}{
+		{
+			name:  "should_name_the_account_after_its_trade_name",
+			input: types.AccountInput{Type: entity.ACCOUNT_TYPE_PJ, Name: "Ignored", CPF: "25462557035", CNPJ: "12ABC34501DE35", LegalName: "Loren Ipsum Comercio Ltda", TradeName: "Loren Cafe", Secret: "123456"},
+			want: &entity.Account{
+				Type:      entity.ACCOUNT_TYPE_PJ,
+				Name:      "Loren Cafe",
+				CNPJ:      "12ABC34501DE35",
+				LegalName: "Loren Ipsum Comercio Ltda",
+				TradeName: "Loren Cafe",
+				Secret:    "8d969eef6ecad3c29a3a629280e686cf0c3f5d5a86aff3ca12020c923adc6c92",
+				Balance:   100,
+			},
+		},
+		{
+			name:  "should_name_the_account_after_its_legal_name_without_a_trade_name",
+			input: types.AccountInput{Type: entity.ACCOUNT_TYPE_PJ, CNPJ: "11222333000181", LegalName: "Loren Ipsum Comercio Ltda", Secret: "123456"},
+			want: &entity.Account{
+				Type:      entity.ACCOUNT_TYPE_PJ,
+				Name:      "Loren Ipsum Comercio Ltda",
+				CNPJ:      "11222333000181",
+				LegalName: "Loren Ipsum Comercio Ltda",
+				Secret:    "8d969eef6ecad3c29a3a629280e686cf0c3f5d5a86aff3ca12020c923adc6c92",
+				Balance:   100,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &accountRepository.AccountRepositoryMock{}
+			repo.On("Get", mock.Anything, types.AccountInput{CNPJ: tt.input.CNPJ}).Return(&entity.Account{}, nil)
+			repo.On("Save", mock.Anything, mock.Anything).Return(nil)
+
+			got, err := New(repo, &notifier.MemoryNotifier{}).Create(context.Background(), tt.input)
+			if err != nil {
+				t.Fatalf("error = %v", err)
+			}
+
+			if diff := cmp.Diff(got, tt.want); diff != "" {
+				t.Error(diff)
+			}
+		})
+	}
+}
+
 func getAccountTest(id uint) *entity.Account {
 	return &entity.Account{
 		ID:      id,
+		Type:    entity.ACCOUNT_TYPE_PF,
 		Name:    "Loren",
 		CPF:     "25462557035",
 		Secret:  "8d969eef6ecad3c29a3a629280e686cf0c3f5d5a86aff3ca12020c923adc6c92",
@@ -10,5 +10,21 @@ import (
 type IAccountUsecase interface {
 	GetAll(ctx context.Context) ([]*entity.Account, error)
 	Get(ctx context.Context, accountInput types.AccountInput) (*entity.Account, error)
+	GetByIDs(ctx context.Context, ids []uint) ([]*entity.Account, error)
 	Create(ctx context.Context, accountInput types.AccountInput) (*entity.Account, error)
+	UpdateProfile(ctx context.Context, accountID uint, profileInput types.ProfileInput) (*entity.Account, error)
+	GetChanges(ctx context.Context, accountID uint) ([]*entity.AccountChange, error)
+	ChangeSecret(ctx context.Context, accountID uint, secretChangeInput types.SecretChangeInput) (*entity.Account, error)
+	RequestSecretReset(ctx context.Context, secretResetInput types.SecretResetInput) error
+	ResetSecret(ctx context.Context, secretResetConfirmInput types.SecretResetConfirmInput) error
+	ValidateSession(ctx context.Context, accountID uint, tokenVersion uint) error
+	EnrollTOTP(ctx context.Context, accountID uint) (*types.TOTPEnrollment, error)
+	GetTOTPEnrollment(ctx context.Context, accountID uint) (*types.TOTPEnrollment, error)
+	ConfirmTOTP(ctx context.Context, accountID uint, code string) (*types.TOTPRecoveryCodes, error)
+	DisableTOTP(ctx context.Context, accountID uint, code string) error
+	CreateLoginChallenge(ctx context.Context, account *entity.Account) (*types.LoginChallenge, error)
+	CompleteLoginChallenge(ctx context.Context, loginChallengeInput types.LoginChallengeInput) (*entity.Account, error)
+	StepUp(ctx context.Context, accountID uint, code string) error
+	SetPIN(ctx context.Context, accountID uint, pinInput types.PINInput) error
+	VerifyPIN(ctx context.Context, accountID uint, pin string) error
 }
@@ -16,6 +16,84 @@ type AccountUsecaseMock struct {
 	mock.Mock
 }
 
+// ChangeSecret provides a mock function with given fields: ctx, accountID, secretChangeInput
+func (_m *AccountUsecaseMock) ChangeSecret(ctx context.Context, accountID uint, secretChangeInput types.SecretChangeInput) (*entity.Account, error) {
+	ret := _m.Called(ctx, accountID, secretChangeInput)
+
+	var r0 *entity.Account
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint, types.SecretChangeInput) (*entity.Account, error)); ok {
+		return rf(ctx, accountID, secretChangeInput)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint, types.SecretChangeInput) *entity.Account); ok {
+		r0 = rf(ctx, accountID, secretChangeInput)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.Account)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint, types.SecretChangeInput) error); ok {
+		r1 = rf(ctx, accountID, secretChangeInput)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CompleteLoginChallenge provides a mock function with given fields: ctx, loginChallengeInput
+func (_m *AccountUsecaseMock) CompleteLoginChallenge(ctx context.Context, loginChallengeInput types.LoginChallengeInput) (*entity.Account, error) {
+	ret := _m.Called(ctx, loginChallengeInput)
+
+	var r0 *entity.Account
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, types.LoginChallengeInput) (*entity.Account, error)); ok {
+		return rf(ctx, loginChallengeInput)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, types.LoginChallengeInput) *entity.Account); ok {
+		r0 = rf(ctx, loginChallengeInput)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.Account)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, types.LoginChallengeInput) error); ok {
+		r1 = rf(ctx, loginChallengeInput)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ConfirmTOTP provides a mock function with given fields: ctx, accountID, code
+func (_m *AccountUsecaseMock) ConfirmTOTP(ctx context.Context, accountID uint, code string) (*types.TOTPRecoveryCodes, error) {
+	ret := _m.Called(ctx, accountID, code)
+
+	var r0 *types.TOTPRecoveryCodes
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint, string) (*types.TOTPRecoveryCodes, error)); ok {
+		return rf(ctx, accountID, code)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint, string) *types.TOTPRecoveryCodes); ok {
+		r0 = rf(ctx, accountID, code)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*types.TOTPRecoveryCodes)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint, string) error); ok {
+		r1 = rf(ctx, accountID, code)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Create provides a mock function with given fields: ctx, accountInput
 func (_m *AccountUsecaseMock) Create(ctx context.Context, accountInput types.AccountInput) (*entity.Account, error) {
 	ret := _m.Called(ctx, accountInput)
@@ -42,6 +120,72 @@ func (_m *AccountUsecaseMock) Create(ctx context.Context, accountInput types.Acc
 	return r0, r1
 }
 
+// CreateLoginChallenge provides a mock function with given fields: ctx, account
+func (_m *AccountUsecaseMock) CreateLoginChallenge(ctx context.Context, account *entity.Account) (*types.LoginChallenge, error) {
+	ret := _m.Called(ctx, account)
+
+	var r0 *types.LoginChallenge
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *entity.Account) (*types.LoginChallenge, error)); ok {
+		return rf(ctx, account)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *entity.Account) *types.LoginChallenge); ok {
+		r0 = rf(ctx, account)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*types.LoginChallenge)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *entity.Account) error); ok {
+		r1 = rf(ctx, account)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DisableTOTP provides a mock function with given fields: ctx, accountID, code
+func (_m *AccountUsecaseMock) DisableTOTP(ctx context.Context, accountID uint, code string) error {
+	ret := _m.Called(ctx, accountID, code)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint, string) error); ok {
+		r0 = rf(ctx, accountID, code)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// EnrollTOTP provides a mock function with given fields: ctx, accountID
+func (_m *AccountUsecaseMock) EnrollTOTP(ctx context.Context, accountID uint) (*types.TOTPEnrollment, error) {
+	ret := _m.Called(ctx, accountID)
+
+	var r0 *types.TOTPEnrollment
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint) (*types.TOTPEnrollment, error)); ok {
+		return rf(ctx, accountID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint) *types.TOTPEnrollment); ok {
+		r0 = rf(ctx, accountID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*types.TOTPEnrollment)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint) error); ok {
+		r1 = rf(ctx, accountID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Get provides a mock function with given fields: ctx, accountInput
 func (_m *AccountUsecaseMock) Get(ctx context.Context, accountInput types.AccountInput) (*entity.Account, error) {
 	ret := _m.Called(ctx, accountInput)
@@ -94,6 +238,194 @@ func (_m *AccountUsecaseMock) GetAll(ctx context.Context) ([]*entity.Account, er
 	return r0, r1
 }
 
+// GetByIDs provides a mock function with given fields: ctx, ids
+func (_m *AccountUsecaseMock) GetByIDs(ctx context.Context, ids []uint) ([]*entity.Account, error) {
+	ret := _m.Called(ctx, ids)
+
+	var r0 []*entity.Account
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []uint) ([]*entity.Account, error)); ok {
+		return rf(ctx, ids)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []uint) []*entity.Account); ok {
+		r0 = rf(ctx, ids)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*entity.Account)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []uint) error); ok {
+		r1 = rf(ctx, ids)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetChanges provides a mock function with given fields: ctx, accountID
+func (_m *AccountUsecaseMock) GetChanges(ctx context.Context, accountID uint) ([]*entity.AccountChange, error) {
+	ret := _m.Called(ctx, accountID)
+
+	var r0 []*entity.AccountChange
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint) ([]*entity.AccountChange, error)); ok {
+		return rf(ctx, accountID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint) []*entity.AccountChange); ok {
+		r0 = rf(ctx, accountID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*entity.AccountChange)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint) error); ok {
+		r1 = rf(ctx, accountID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetTOTPEnrollment provides a mock function with given fields: ctx, accountID
+func (_m *AccountUsecaseMock) GetTOTPEnrollment(ctx context.Context, accountID uint) (*types.TOTPEnrollment, error) {
+	ret := _m.Called(ctx, accountID)
+
+	var r0 *types.TOTPEnrollment
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint) (*types.TOTPEnrollment, error)); ok {
+		return rf(ctx, accountID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint) *types.TOTPEnrollment); ok {
+		r0 = rf(ctx, accountID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*types.TOTPEnrollment)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint) error); ok {
+		r1 = rf(ctx, accountID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RequestSecretReset provides a mock function with given fields: ctx, secretResetInput
+func (_m *AccountUsecaseMock) RequestSecretReset(ctx context.Context, secretResetInput types.SecretResetInput) error {
+	ret := _m.Called(ctx, secretResetInput)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, types.SecretResetInput) error); ok {
+		r0 = rf(ctx, secretResetInput)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ResetSecret provides a mock function with given fields: ctx, secretResetConfirmInput
+func (_m *AccountUsecaseMock) ResetSecret(ctx context.Context, secretResetConfirmInput types.SecretResetConfirmInput) error {
+	ret := _m.Called(ctx, secretResetConfirmInput)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, types.SecretResetConfirmInput) error); ok {
+		r0 = rf(ctx, secretResetConfirmInput)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SetPIN provides a mock function with given fields: ctx, accountID, pinInput
+func (_m *AccountUsecaseMock) SetPIN(ctx context.Context, accountID uint, pinInput types.PINInput) error {
+	ret := _m.Called(ctx, accountID, pinInput)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint, types.PINInput) error); ok {
+		r0 = rf(ctx, accountID, pinInput)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// StepUp provides a mock function with given fields: ctx, accountID, code
+func (_m *AccountUsecaseMock) StepUp(ctx context.Context, accountID uint, code string) error {
+	ret := _m.Called(ctx, accountID, code)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint, string) error); ok {
+		r0 = rf(ctx, accountID, code)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UpdateProfile provides a mock function with given fields: ctx, accountID, profileInput
+func (_m *AccountUsecaseMock) UpdateProfile(ctx context.Context, accountID uint, profileInput types.ProfileInput) (*entity.Account, error) {
+	ret := _m.Called(ctx, accountID, profileInput)
+
+	var r0 *entity.Account
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint, types.ProfileInput) (*entity.Account, error)); ok {
+		return rf(ctx, accountID, profileInput)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint, types.ProfileInput) *entity.Account); ok {
+		r0 = rf(ctx, accountID, profileInput)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.Account)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint, types.ProfileInput) error); ok {
+		r1 = rf(ctx, accountID, profileInput)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ValidateSession provides a mock function with given fields: ctx, accountID, tokenVersion
+func (_m *AccountUsecaseMock) ValidateSession(ctx context.Context, accountID uint, tokenVersion uint) error {
+	ret := _m.Called(ctx, accountID, tokenVersion)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint, uint) error); ok {
+		r0 = rf(ctx, accountID, tokenVersion)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// VerifyPIN provides a mock function with given fields: ctx, accountID, pin
+func (_m *AccountUsecaseMock) VerifyPIN(ctx context.Context, accountID uint, pin string) error {
+	ret := _m.Called(ctx, accountID, pin)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint, string) error); ok {
+		r0 = rf(ctx, accountID, pin)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // NewAccountUsecaseMock creates a new instance of AccountUsecaseMock. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewAccountUsecaseMock(t interface {
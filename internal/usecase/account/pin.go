@@ -0,0 +1,141 @@
+package account
+
+import (
+	"context"
+	"time"
+
+	"github.com/fms85/desafio-tecnico-go-stone/internal/domain/common"
+	"github.com/fms85/desafio-tecnico-go-stone/internal/domain/entity"
+	"github.com/fms85/desafio-tecnico-go-stone/internal/domain/types"
+	otelDriver "github.com/fms85/desafio-tecnico-go-stone/internal/driver/otel"
+	"github.com/fms85/desafio-tecnico-go-stone/internal/util"
+)
+
+const (
+	PIN_MAX_ATTEMPTS  = 3
+	PIN_LOCK_DURATION = 30 * time.Minute
+)
+
+const (
+	INVALID_PIN_ERROR           = "pin must have 4 to 6 digits"
+	WRONG_PIN_ERROR             = "wrong pin"
+	CURRENT_PIN_REQUIRED_ERROR  = "current pin required"
+	PIN_REQUIRED_ERROR          = "pin required"
+	TOO_MANY_PIN_ATTEMPTS_ERROR = "too many pin attempts"
+)
+
+var pinStateColumns = []string{"pin_attempts", "pin_locked_until"}
+
+// SetPIN sets the transaction PIN of an account. The first PIN takes the
+// secret of the account, so a stolen token alone cannot set one, and a PIN
+// is only changed with the current one, whose failures count towards the
+// PIN lock.
+func (usecase *accountUsecase) SetPIN(ctx context.Context, accountID uint, pinInput types.PINInput) (err error) {
+	ctx, span := tracer.Start(ctx, "accountUsecase.SetPIN")
+	defer func() { otelDriver.End(span, err) }()
+
+	if !util.ValidPIN(pinInput.PIN) {
+		return &common.ValidationError{Msg: INVALID_PIN_ERROR}
+	}
+
+	account, err := usecase.Get(ctx, types.AccountInput{ID: accountID})
+	if err != nil {
+		return err
+	}
+
+	if account.PINHash == "" {
+		if util.GenerateHash(pinInput.Secret) != account.Secret {
+			return &common.ValidationError{Msg: WRONG_SECRET_ERROR}
+		}
+	} else {
+		if pinInput.CurrentPIN == "" {
+			return &common.ValidationError{Msg: CURRENT_PIN_REQUIRED_ERROR}
+		}
+
+		if err := usecase.verifyPIN(ctx, account, pinInput.CurrentPIN); err != nil {
+			return err
+		}
+	}
+
+	hash, err := util.GeneratePINHash(pinInput.PIN)
+	if err != nil {
+		return err
+	}
+
+	account.PINHash = hash
+	account.PINAttempts = 0
+	account.PINLockedUntil = nil
+
+	return usecase.accountRepository.Update(
+		ctx,
+		account,
+		append([]string{"pin_hash"}, pinStateColumns...),
+		[]*entity.AccountChange{{AccountID: account.ID, Field: entity.ACCOUNT_CHANGE_FIELD_PIN}},
+	)
+}
+
+// VerifyPIN checks the transaction PIN of an account moving money. Accounts
+// without a PIN pass, so they keep working until their owners set one.
+func (usecase *accountUsecase) VerifyPIN(ctx context.Context, accountID uint, pin string) (err error) {
+	ctx, span := tracer.Start(ctx, "accountUsecase.VerifyPIN")
+	defer func() { otelDriver.End(span, err) }()
+
+	account, err := usecase.Get(ctx, types.AccountInput{ID: accountID})
+	if err != nil {
+		return err
+	}
+
+	if account.PINHash == "" {
+		return nil
+	}
+
+	if pin == "" {
+		return &common.UnauthorizedError{Msg: PIN_REQUIRED_ERROR}
+	}
+
+	if err := usecase.verifyPIN(ctx, account, pin); err != nil {
+		return err
+	}
+
+	return usecase.accountRepository.Update(ctx, account, pinStateColumns, nil)
+}
+
+// verifyPIN compares a PIN with the hash of the account. Each PIN tried takes
+// an attempt on the primary before it is compared, so parallel guesses cannot
+// get past the limit, and enough of them in a row lock the PIN for a while,
+// independently of the secret and of TOTP codes. Successes clear the
+// attempts, left for the caller to store with pinStateColumns.
+func (usecase *accountUsecase) verifyPIN(ctx context.Context, account *entity.Account, pin string) error {
+	now := usecase.now()
+	if account.PINLockedUntil != nil && now.Before(*account.PINLockedUntil) {
+		return &common.LimitExceededError{Msg: TOO_MANY_PIN_ATTEMPTS_ERROR}
+	}
+
+	claimed, err := usecase.accountRepository.ClaimAttempt(ctx, account.ID, pinAttempt(now))
+	if err != nil {
+		return err
+	}
+
+	if !claimed {
+		return &common.LimitExceededError{Msg: TOO_MANY_PIN_ATTEMPTS_ERROR}
+	}
+
+	if !util.MatchPINHash(account.PINHash, pin) {
+		return &common.ValidationError{Msg: WRONG_PIN_ERROR}
+	}
+
+	account.PINAttempts = 0
+	account.PINLockedUntil = nil
+
+	return nil
+}
+
+func pinAttempt(now time.Time) types.AccountAttempt {
+	return types.AccountAttempt{
+		AttemptsColumn:    "pin_attempts",
+		MaxAttempts:       PIN_MAX_ATTEMPTS,
+		LockedUntilColumn: "pin_locked_until",
+		LockDuration:      PIN_LOCK_DURATION,
+		Now:               now,
+	}
+}
@@ -0,0 +1,250 @@
+package account
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/fms85/desafio-tecnico-go-stone/internal/domain/common"
+	"github.com/fms85/desafio-tecnico-go-stone/internal/domain/entity"
+	"github.com/fms85/desafio-tecnico-go-stone/internal/domain/types"
+	"github.com/fms85/desafio-tecnico-go-stone/internal/driver/notifier"
+	accountRepository "github.com/fms85/desafio-tecnico-go-stone/internal/repository/account"
+	"github.com/fms85/desafio-tecnico-go-stone/internal/util"
+	"github.com/stretchr/testify/mock"
+	"gotest.tools/assert"
+)
+
+func pinAccountTest(t *testing.T) *entity.Account {
+	t.Helper()
+
+	hash, err := util.GeneratePINHash("4321")
+	assert.NilError(t, err)
+
+	return &entity.Account{ID: 3, Secret: util.GenerateHash("123456"), PINHash: hash}
+}
+
+func TestAccountUsecaseSetPIN(t *testing.T) {
+	tests := []struct {
+		name      string
+		account   func(t *testing.T) *entity.Account
+		pinInput  types.PINInput
+		wantErr   error
+		wantPIN   string
+		wantClaim bool
+	}{
+		{
+			name:     "should_set_the_first_pin_with_the_secret",
+			account:  func(t *testing.T) *entity.Account { return &entity.Account{ID: 3, Secret: util.GenerateHash("123456")} },
+			pinInput: types.PINInput{Secret: "123456", PIN: "1234"},
+			wantPIN:  "1234",
+		},
+		{
+			name:     "should_refuse_the_first_pin_with_a_wrong_secret",
+			account:  func(t *testing.T) *entity.Account { return &entity.Account{ID: 3, Secret: util.GenerateHash("123456")} },
+			pinInput: types.PINInput{Secret: "654321", PIN: "1234"},
+			wantErr:  &common.ValidationError{},
+		},
+		{
+			name:      "should_change_the_pin_with_the_current_one",
+			account:   pinAccountTest,
+			pinInput:  types.PINInput{CurrentPIN: "4321", PIN: "987654"},
+			wantPIN:   "987654",
+			wantClaim: true,
+		},
+		{
+			name:     "should_not_change_the_pin_with_the_secret",
+			account:  pinAccountTest,
+			pinInput: types.PINInput{Secret: "123456", PIN: "1234"},
+			wantErr:  &common.ValidationError{},
+			wantPIN:  "4321",
+		},
+		{
+			name:      "should_count_a_wrong_current_pin",
+			account:   pinAccountTest,
+			pinInput:  types.PINInput{CurrentPIN: "1111", PIN: "1234"},
+			wantErr:   &common.ValidationError{},
+			wantPIN:   "4321",
+			wantClaim: true,
+		},
+		{
+			name:     "should_refuse_pins_that_are_not_4_to_6_digits",
+			account:  func(t *testing.T) *entity.Account { return &entity.Account{ID: 3, Secret: util.GenerateHash("123456")} },
+			pinInput: types.PINInput{Secret: "123456", PIN: "12ab"},
+			wantErr:  &common.ValidationError{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			account := tt.account(t)
+			repo := &accountRepository.AccountRepositoryMock{}
+			repo.On("Get", mock.Anything, types.AccountInput{ID: 3}).Return(account, nil)
+			repo.On("ClaimAttempt", mock.Anything, uint(3), pinAttempt(nowTest)).Return(true, nil)
+			repo.On("Update", mock.Anything, account, []string{"pin_hash", "pin_attempts", "pin_locked_until"}, []*entity.AccountChange{{AccountID: 3, Field: entity.ACCOUNT_CHANGE_FIELD_PIN}}).Return(nil)
+
+			err := newUsecaseTest(repo, &notifier.MemoryNotifier{}).SetPIN(context.Background(), 3, tt.pinInput)
+			if tt.wantErr != nil {
+				assertCode(t, err, tt.wantErr)
+				repo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+			} else {
+				assert.NilError(t, err)
+			}
+
+			if tt.wantClaim {
+				repo.AssertCalled(t, "ClaimAttempt", mock.Anything, uint(3), pinAttempt(nowTest))
+			} else {
+				repo.AssertNotCalled(t, "ClaimAttempt", mock.Anything, mock.Anything, mock.Anything)
+			}
+
+			if tt.wantPIN != "" {
+				assert.Assert(t, util.MatchPINHash(account.PINHash, tt.wantPIN))
+			} else {
+				assert.Equal(t, "", account.PINHash)
+			}
+		})
+	}
+}
+
+func TestAccountUsecaseVerifyPIN(t *testing.T) {
+	lockedUntil := nowTest.Add(time.Minute)
+	unlockedAt := nowTest.Add(-time.Minute)
+
+	tests := []struct {
+		name       string
+		account    func(t *testing.T) *entity.Account
+		pin        string
+		exhausted  bool
+		wantErr    error
+		wantClaim  bool
+		wantLocked bool
+	}{
+		{
+			name:    "should_pass_accounts_without_pin",
+			account: func(t *testing.T) *entity.Account { return &entity.Account{ID: 3} },
+		},
+		{
+			name:      "should_accept_the_pin",
+			account:   pinAccountTest,
+			pin:       "4321",
+			wantClaim: true,
+		},
+		{
+			name: "should_clear_attempts_on_the_right_pin",
+			account: func(t *testing.T) *entity.Account {
+				account := pinAccountTest(t)
+				account.PINAttempts = PIN_MAX_ATTEMPTS - 1
+
+				return account
+			},
+			pin:       "4321",
+			wantClaim: true,
+		},
+		{
+			name:    "should_require_the_pin",
+			account: pinAccountTest,
+			wantErr: &common.UnauthorizedError{},
+		},
+		{
+			name:      "should_count_a_wrong_pin",
+			account:   pinAccountTest,
+			pin:       "1234",
+			wantErr:   &common.ValidationError{},
+			wantClaim: true,
+		},
+		{
+			name:      "should_refuse_the_pin_once_out_of_attempts",
+			account:   pinAccountTest,
+			pin:       "4321",
+			exhausted: true,
+			wantErr:   &common.LimitExceededError{},
+			wantClaim: true,
+		},
+		{
+			name: "should_refuse_the_pin_while_locked",
+			account: func(t *testing.T) *entity.Account {
+				account := pinAccountTest(t)
+				account.PINLockedUntil = &lockedUntil
+
+				return account
+			},
+			pin:        "4321",
+			wantErr:    &common.LimitExceededError{},
+			wantLocked: true,
+		},
+		{
+			name: "should_accept_the_pin_once_the_lock_is_over",
+			account: func(t *testing.T) *entity.Account {
+				account := pinAccountTest(t)
+				account.PINLockedUntil = &unlockedAt
+
+				return account
+			},
+			pin:       "4321",
+			wantClaim: true,
+		},
+		{
+			name: "should_not_lock_on_totp_failures",
+			account: func(t *testing.T) *entity.Account {
+				account := pinAccountTest(t)
+				account.TOTPLockedUntil = &lockedUntil
+
+				return account
+			},
+			pin:       "4321",
+			wantClaim: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			account := tt.account(t)
+			repo := &accountRepository.AccountRepositoryMock{}
+			repo.On("Get", mock.Anything, types.AccountInput{ID: 3}).Return(account, nil)
+			repo.On("ClaimAttempt", mock.Anything, uint(3), pinAttempt(nowTest)).Return(!tt.exhausted, nil)
+			repo.On("Update", mock.Anything, account, []string{"pin_attempts", "pin_locked_until"}, []*entity.AccountChange(nil)).Return(nil)
+
+			err := newUsecaseTest(repo, &notifier.MemoryNotifier{}).VerifyPIN(context.Background(), 3, tt.pin)
+			if tt.wantErr != nil {
+				assertCode(t, err, tt.wantErr)
+				repo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+			} else {
+				assert.NilError(t, err)
+			}
+
+			if tt.wantClaim {
+				repo.AssertCalled(t, "ClaimAttempt", mock.Anything, uint(3), pinAttempt(nowTest))
+			} else {
+				repo.AssertNotCalled(t, "ClaimAttempt", mock.Anything, mock.Anything, mock.Anything)
+			}
+			if tt.wantClaim && tt.wantErr == nil {
+				assert.Equal(t, 0, account.PINAttempts)
+				repo.AssertCalled(t, "Update", mock.Anything, account, []string{"pin_attempts", "pin_locked_until"}, []*entity.AccountChange(nil))
+			}
+			assert.Equal(t, tt.wantLocked, account.PINLockedUntil != nil)
+		})
+	}
+}
+
+func TestAccountUsecaseVerifyPINConcurrently(t *testing.T) {
+	requests := 4 * PIN_MAX_ATTEMPTS
+	account := pinAccountTest(t)
+
+	repo := &accountRepository.AccountRepositoryMock{}
+	repo.On("Get", mock.Anything, types.AccountInput{ID: 3}).Return(func(context.Context, types.AccountInput) (*entity.Account, error) {
+		copied := *account
+
+		return &copied, nil
+	})
+	repo.On("ClaimAttempt", mock.Anything, uint(3), pinAttempt(nowTest)).Return(true, nil).Times(PIN_MAX_ATTEMPTS)
+	repo.On("ClaimAttempt", mock.Anything, uint(3), pinAttempt(nowTest)).Return(false, nil)
+
+	usecase := newUsecaseTest(repo, &notifier.MemoryNotifier{})
+	validation, limitExceeded, passed := concurrentlyTest(t, requests, func(i int) error {
+		return usecase.VerifyPIN(context.Background(), 3, fmt.Sprintf("%04d", 5000+i))
+	})
+
+	assert.Equal(t, PIN_MAX_ATTEMPTS, validation)
+	assert.Equal(t, requests-PIN_MAX_ATTEMPTS, limitExceeded)
+	assert.Equal(t, 0, passed)
+	repo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
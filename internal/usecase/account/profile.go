@@ -0,0 +1,264 @@
+package account
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/fms85/desafio-tecnico-go-stone/internal/domain/common"
+	"github.com/fms85/desafio-tecnico-go-stone/internal/domain/entity"
+	"github.com/fms85/desafio-tecnico-go-stone/internal/domain/types"
+	"github.com/fms85/desafio-tecnico-go-stone/internal/driver/notifier"
+	otelDriver "github.com/fms85/desafio-tecnico-go-stone/internal/driver/otel"
+	"github.com/fms85/desafio-tecnico-go-stone/internal/util"
+)
+
+const (
+	SECRET_RESET_CODE_DIGITS       = 6
+	SECRET_RESET_CODE_TTL          = 15 * time.Minute
+	SECRET_RESET_CODE_MAX_ATTEMPTS = 5
+	// SECRET_RESET_COOLDOWN is how long a code has to be pending before
+	// another one is sent, so the attempt limit cannot be worked around by
+	// asking for new codes.
+	SECRET_RESET_COOLDOWN = time.Minute
+)
+
+const (
+	EMPTY_NAME_ERROR              = "name cannot be empty"
+	INVALID_EMAIL_ERROR           = "invalid email"
+	INVALID_PHONE_ERROR           = "invalid phone"
+	WRONG_SECRET_ERROR            = "current secret is wrong"
+	SAME_SECRET_ERROR             = "new secret must differ from the current one"
+	INVALID_RESET_CODE_ERROR      = "invalid reset code"
+	EXPIRED_RESET_CODE_ERROR      = "reset code expired"
+	TOO_MANY_RESET_ATTEMPTS_ERROR = "too many reset attempts"
+	SESSION_REVOKED_ERROR         = "session revoked"
+)
+
+// UpdateProfile changes the name and contact data of an account, recording
+// the fields that actually changed. Business accounts are named after their
+// trade name, so changing the name changes it too.
+func (usecase *accountUsecase) UpdateProfile(ctx context.Context, accountID uint, profileInput types.ProfileInput) (account *entity.Account, err error) {
+	ctx, span := tracer.Start(ctx, "accountUsecase.UpdateProfile")
+	defer func() { otelDriver.End(span, err) }()
+
+	account, err = usecase.Get(ctx, types.AccountInput{ID: accountID})
+	if err != nil {
+		return nil, err
+	}
+
+	var columns []string
+	var changes []*entity.AccountChange
+	change := func(field string, value *string, newValue string) {
+		if *value == newValue {
+			return
+		}
+
+		changes = append(changes, &entity.AccountChange{
+			AccountID: account.ID,
+			Field:     field,
+			OldValue:  *value,
+			NewValue:  newValue,
+		})
+		columns = append(columns, field)
+		*value = newValue
+	}
+
+	if profileInput.Name != nil {
+		if *profileInput.Name == "" {
+			return nil, &common.ValidationError{Msg: EMPTY_NAME_ERROR}
+		}
+
+		change(entity.ACCOUNT_CHANGE_FIELD_NAME, &account.Name, *profileInput.Name)
+		if account.Type == entity.ACCOUNT_TYPE_PJ && account.TradeName != account.Name {
+			account.TradeName = account.Name
+			columns = append(columns, "trade_name")
+		}
+	}
+
+	if profileInput.Email != nil {
+		email, ok := util.NormalizeEmail(*profileInput.Email)
+		if !ok && *profileInput.Email != "" {
+			return nil, &common.ValidationError{Msg: INVALID_EMAIL_ERROR}
+		}
+
+		change(entity.ACCOUNT_CHANGE_FIELD_EMAIL, &account.Email, email)
+	}
+
+	if profileInput.Phone != nil {
+		phone, ok := util.NormalizePhone(*profileInput.Phone)
+		if !ok && *profileInput.Phone != "" {
+			return nil, &common.ValidationError{Msg: INVALID_PHONE_ERROR}
+		}
+
+		change(entity.ACCOUNT_CHANGE_FIELD_PHONE, &account.Phone, phone)
+	}
+
+	if len(columns) == 0 {
+		return account, nil
+	}
+
+	if err := usecase.accountRepository.Update(ctx, account, columns, changes); err != nil {
+		return nil, err
+	}
+
+	return account, nil
+}
+
+func (usecase *accountUsecase) GetChanges(ctx context.Context, accountID uint) (changes []*entity.AccountChange, err error) {
+	ctx, span := tracer.Start(ctx, "accountUsecase.GetChanges")
+	defer func() { otelDriver.End(span, err) }()
+
+	return usecase.accountRepository.GetChanges(ctx, accountID)
+}
+
+// ChangeSecret replaces the secret of an account given the current one and
+// revokes every token issued so far. The account is returned with its new
+// token version, for the caller to get a token of its own.
+func (usecase *accountUsecase) ChangeSecret(ctx context.Context, accountID uint, secretChangeInput types.SecretChangeInput) (account *entity.Account, err error) {
+	ctx, span := tracer.Start(ctx, "accountUsecase.ChangeSecret")
+	defer func() { otelDriver.End(span, err) }()
+
+	account, err = usecase.Get(ctx, types.AccountInput{ID: accountID})
+	if err != nil {
+		return nil, err
+	}
+
+	if util.GenerateHash(secretChangeInput.CurrentSecret) != account.Secret {
+		return nil, &common.ValidationError{Msg: WRONG_SECRET_ERROR}
+	}
+
+	if secretChangeInput.Secret == secretChangeInput.CurrentSecret {
+		return nil, &common.ValidationError{Msg: SAME_SECRET_ERROR}
+	}
+
+	if err := usecase.setSecret(ctx, account, secretChangeInput.Secret, entity.ACCOUNT_CHANGE_FIELD_SECRET); err != nil {
+		return nil, err
+	}
+
+	return account, nil
+}
+
+// RequestSecretReset sends a reset code to the email of the account, or to
+// its phone without one. Nothing tells whether the account exists or has a
+// contact, so the answer cannot be used to find out who has an account.
+func (usecase *accountUsecase) RequestSecretReset(ctx context.Context, secretResetInput types.SecretResetInput) (err error) {
+	ctx, span := tracer.Start(ctx, "accountUsecase.RequestSecretReset")
+	defer func() { otelDriver.End(span, err) }()
+
+	account, err := usecase.accountRepository.Get(ctx, types.AccountInput{CPF: secretResetInput.CPF, CNPJ: secretResetInput.CNPJ})
+	if err != nil {
+		return err
+	}
+
+	to := account.Email
+	if to == "" {
+		to = account.Phone
+	}
+
+	if account.ID == 0 || to == "" {
+		return nil
+	}
+
+	if expiresAt := account.SecretResetExpiresAt; expiresAt != nil && usecase.now().Before(expiresAt.Add(SECRET_RESET_COOLDOWN-SECRET_RESET_CODE_TTL)) {
+		return nil
+	}
+
+	code := util.GenerateNumericCode(SECRET_RESET_CODE_DIGITS)
+	expiresAt := usecase.now().Add(SECRET_RESET_CODE_TTL)
+
+	account.SecretResetHash = secretResetHash(account.ID, code)
+	account.SecretResetExpiresAt = &expiresAt
+	account.SecretResetAttempts = 0
+
+	if err := usecase.accountRepository.Update(ctx, account, secretResetColumns, nil); err != nil {
+		return err
+	}
+
+	return usecase.notifier.Send(ctx, notifier.Message{
+		To:      to,
+		Subject: "Secret reset",
+		Body:    fmt.Sprintf("Your secret reset code is %s. If you did not ask for it, ignore this message.", code),
+	})
+}
+
+// ResetSecret sets a new secret with the code sent by RequestSecretReset and
+// revokes every token issued so far. Unknown accounts and accounts without a
+// pending reset fail like a wrong code. The account is read from the primary
+// and each code tried takes one of the attempts of the reset before it is
+// compared, so parallel guesses cannot get past the limit.
+func (usecase *accountUsecase) ResetSecret(ctx context.Context, secretResetConfirmInput types.SecretResetConfirmInput) (err error) {
+	ctx, span := tracer.Start(ctx, "accountUsecase.ResetSecret")
+	defer func() { otelDriver.End(span, err) }()
+
+	account, err := usecase.accountRepository.GetFromPrimary(ctx, types.AccountInput{CPF: secretResetConfirmInput.CPF, CNPJ: secretResetConfirmInput.CNPJ})
+	if err != nil {
+		return err
+	}
+
+	if account.ID == 0 || account.SecretResetExpiresAt == nil {
+		return &common.ValidationError{Msg: INVALID_RESET_CODE_ERROR}
+	}
+
+	if !usecase.now().Before(*account.SecretResetExpiresAt) {
+		return &common.ValidationError{Msg: EXPIRED_RESET_CODE_ERROR}
+	}
+
+	claimed, err := usecase.accountRepository.ClaimAttempt(ctx, account.ID, secretResetAttempt)
+	if err != nil {
+		return err
+	}
+
+	if !claimed {
+		return &common.LimitExceededError{Msg: TOO_MANY_RESET_ATTEMPTS_ERROR}
+	}
+
+	if secretResetHash(account.ID, secretResetConfirmInput.Code) != account.SecretResetHash {
+		return &common.ValidationError{Msg: INVALID_RESET_CODE_ERROR}
+	}
+
+	return usecase.setSecret(ctx, account, secretResetConfirmInput.Secret, entity.ACCOUNT_CHANGE_FIELD_SECRET_RESET)
+}
+
+// ValidateSession checks that a token was issued after the last secret
+// change of its account.
+func (usecase *accountUsecase) ValidateSession(ctx context.Context, accountID uint, tokenVersion uint) (err error) {
+	ctx, span := tracer.Start(ctx, "accountUsecase.ValidateSession")
+	defer func() { otelDriver.End(span, err) }()
+
+	account, err := usecase.accountRepository.Get(ctx, types.AccountInput{ID: accountID})
+	if err != nil {
+		return err
+	}
+
+	if account.ID == 0 || account.TokenVersion != tokenVersion {
+		return &common.UnauthorizedError{Msg: SESSION_REVOKED_ERROR}
+	}
+
+	return nil
+}
+
+var secretResetColumns = []string{"secret_reset_hash", "secret_reset_expires_at", "secret_reset_attempts"}
+
+var secretResetAttempt = types.AccountAttempt{AttemptsColumn: "secret_reset_attempts", MaxAttempts: SECRET_RESET_CODE_MAX_ATTEMPTS}
+
+// setSecret stores a new secret, drops any pending reset and bumps the token
+// version, recording the change under field.
+func (usecase *accountUsecase) setSecret(ctx context.Context, account *entity.Account, secret string, field string) error {
+	account.Secret = util.GenerateHash(secret)
+	account.TokenVersion++
+	account.SecretResetHash = ""
+	account.SecretResetExpiresAt = nil
+	account.SecretResetAttempts = 0
+
+	return usecase.accountRepository.Update(
+		ctx,
+		account,
+		append([]string{"secret", "token_version"}, secretResetColumns...),
+		[]*entity.AccountChange{{AccountID: account.ID, Field: field}},
+	)
+}
+
+func secretResetHash(accountID uint, code string) string {
+	return util.GenerateHash(fmt.Sprintf("%d:%s", accountID, code))
+}
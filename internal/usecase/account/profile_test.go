@@ -0,0 +1,419 @@
+package account
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/fms85/desafio-tecnico-go-stone/internal/domain/common"
+	"github.com/fms85/desafio-tecnico-go-stone/internal/domain/entity"
+	"github.com/fms85/desafio-tecnico-go-stone/internal/domain/types"
+	"github.com/fms85/desafio-tecnico-go-stone/internal/driver/notifier"
+	accountRepository "github.com/fms85/desafio-tecnico-go-stone/internal/repository/account"
+	"github.com/fms85/desafio-tecnico-go-stone/internal/util"
+	"github.com/stretchr/testify/mock"
+	"gotest.tools/assert"
+)
+
+var nowTest = time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+
+func newUsecaseTest(repo accountRepository.IAccountRepository, messages notifier.INotifier) *accountUsecase {
+	usecase := New(repo, messages).(*accountUsecase)
+	usecase.now = func() time.Time { return nowTest }
+
+	return usecase
+}
+
+func assertCode(t *testing.T, err error, want error) {
+	t.Helper()
+
+	var codedError common.CodedError
+	if !errors.As(err, &codedError) || codedError.Code() != want.(common.CodedError).Code() {
+		t.Fatalf("error = %v, want %v", err, want)
+	}
+}
+
+func stringTest(value string) *string {
+	return &value
+}
+
+func TestAccountUsecaseUpdateProfile(t *testing.T) {
+	tests := []struct {
+		name        string
+		account     *entity.Account
+		input       types.ProfileInput
+		wantColumns []string
+		wantChanges []entity.AccountChange
+		wantErr     error
+	}{
+		{
+			name:        "should_record_the_fields_that_changed",
+			account:     &entity.Account{ID: 3, Type: entity.ACCOUNT_TYPE_PF, Name: "Loren", Phone: "+5561912345678"},
+			input:       types.ProfileInput{Name: stringTest("Loren Ipsum"), Email: stringTest(" Loren@Example.com "), Phone: stringTest("+55 (61) 91234-5678")},
+			wantColumns: []string{"name", "email"},
+			wantChanges: []entity.AccountChange{
+				{AccountID: 3, Field: entity.ACCOUNT_CHANGE_FIELD_NAME, OldValue: "Loren", NewValue: "Loren Ipsum"},
+				{AccountID: 3, Field: entity.ACCOUNT_CHANGE_FIELD_EMAIL, NewValue: "loren@example.com"},
+			},
+		},
+		{
+			name:        "should_remove_a_contact_set_empty",
+			account:     &entity.Account{ID: 3, Type: entity.ACCOUNT_TYPE_PF, Name: "Loren", Phone: "+5561912345678"},
+			input:       types.ProfileInput{Phone: stringTest("")},
+			wantColumns: []string{"phone"},
+			wantChanges: []entity.AccountChange{
+				{AccountID: 3, Field: entity.ACCOUNT_CHANGE_FIELD_PHONE, OldValue: "+5561912345678"},
+			},
+		},
+		{
+			name:        "should_rename_the_trade_name_of_business_accounts",
+			account:     &entity.Account{ID: 3, Type: entity.ACCOUNT_TYPE_PJ, Name: "Loren Cafe", TradeName: "Loren Cafe"},
+			input:       types.ProfileInput{Name: stringTest("Loren Bistro")},
+			wantColumns: []string{"name", "trade_name"},
+			wantChanges: []entity.AccountChange{
+				{AccountID: 3, Field: entity.ACCOUNT_CHANGE_FIELD_NAME, OldValue: "Loren Cafe", NewValue: "Loren Bistro"},
+			},
+		},
+		{
+			name:    "should_not_update_unchanged_fields",
+			account: &entity.Account{ID: 3, Type: entity.ACCOUNT_TYPE_PF, Name: "Loren"},
+			input:   types.ProfileInput{Name: stringTest("Loren")},
+		},
+		{
+			name:    "should_reject_an_empty_name",
+			account: &entity.Account{ID: 3, Type: entity.ACCOUNT_TYPE_PF, Name: "Loren"},
+			input:   types.ProfileInput{Name: stringTest("")},
+			wantErr: &common.ValidationError{},
+		},
+		{
+			name:    "should_reject_an_invalid_email",
+			account: &entity.Account{ID: 3, Type: entity.ACCOUNT_TYPE_PF, Name: "Loren"},
+			input:   types.ProfileInput{Email: stringTest("Loren <loren@example.com>")},
+			wantErr: &common.ValidationError{},
+		},
+		{
+			name:    "should_reject_a_phone_out_of_e164",
+			account: &entity.Account{ID: 3, Type: entity.ACCOUNT_TYPE_PF, Name: "Loren"},
+			input:   types.ProfileInput{Phone: stringTest("61912345678")},
+			wantErr: &common.ValidationError{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &accountRepository.AccountRepositoryMock{}
+			repo.On("Get", mock.Anything, types.AccountInput{ID: 3}).Return(tt.account, nil)
+			repo.On("Update", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+
+			got, err := newUsecaseTest(repo, &notifier.MemoryNotifier{}).UpdateProfile(context.Background(), 3, tt.input)
+			if tt.wantErr != nil {
+				assertCode(t, err, tt.wantErr)
+				repo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+
+				return
+			}
+
+			assert.NilError(t, err)
+			assert.Equal(t, tt.account, got)
+
+			if tt.wantColumns == nil {
+				repo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+
+				return
+			}
+
+			assert.DeepEqual(t, tt.wantColumns, repo.Calls[1].Arguments.Get(2).([]string))
+
+			var changes []entity.AccountChange
+			for _, change := range repo.Calls[1].Arguments.Get(3).([]*entity.AccountChange) {
+				changes = append(changes, *change)
+			}
+			assert.DeepEqual(t, tt.wantChanges, changes)
+		})
+	}
+}
+
+func TestAccountUsecaseChangeSecret(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   types.SecretChangeInput
+		wantErr error
+	}{
+		{
+			name:  "should_change_the_secret_and_revoke_tokens",
+			input: types.SecretChangeInput{CurrentSecret: "123456", Secret: "654321"},
+		},
+		{
+			name:    "should_require_the_current_secret",
+			input:   types.SecretChangeInput{CurrentSecret: "000000", Secret: "654321"},
+			wantErr: &common.ValidationError{},
+		},
+		{
+			name:    "should_require_a_new_secret",
+			input:   types.SecretChangeInput{CurrentSecret: "123456", Secret: "123456"},
+			wantErr: &common.ValidationError{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			account := getAccountTest(3)
+			account.TokenVersion = 2
+
+			repo := &accountRepository.AccountRepositoryMock{}
+			repo.On("Get", mock.Anything, types.AccountInput{ID: 3}).Return(account, nil)
+			repo.On("Update", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+
+			got, err := newUsecaseTest(repo, &notifier.MemoryNotifier{}).ChangeSecret(context.Background(), 3, tt.input)
+			if tt.wantErr != nil {
+				assertCode(t, err, tt.wantErr)
+				repo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+
+				return
+			}
+
+			assert.NilError(t, err)
+			assert.Equal(t, util.GenerateHash("654321"), got.Secret)
+			assert.Equal(t, uint(3), got.TokenVersion)
+			assert.DeepEqual(t, []*entity.AccountChange{{AccountID: 3, Field: entity.ACCOUNT_CHANGE_FIELD_SECRET}}, repo.Calls[1].Arguments.Get(3).([]*entity.AccountChange))
+		})
+	}
+}
+
+func TestAccountUsecaseRequestSecretReset(t *testing.T) {
+	recent := nowTest.Add(SECRET_RESET_CODE_TTL - time.Second)
+	old := nowTest.Add(SECRET_RESET_CODE_TTL - SECRET_RESET_COOLDOWN)
+
+	tests := []struct {
+		name    string
+		account *entity.Account
+		wantTo  string
+	}{
+		{
+			name:    "should_send_a_code_to_the_email",
+			account: &entity.Account{ID: 3, Email: "loren@example.com", Phone: "+5561912345678"},
+			wantTo:  "loren@example.com",
+		},
+		{
+			name:    "should_send_a_code_to_the_phone_without_an_email",
+			account: &entity.Account{ID: 3, Phone: "+5561912345678"},
+			wantTo:  "+5561912345678",
+		},
+		{
+			name:    "should_send_a_new_code_after_the_cooldown",
+			account: &entity.Account{ID: 3, Email: "loren@example.com", SecretResetHash: "hash", SecretResetExpiresAt: &old, SecretResetAttempts: 5},
+			wantTo:  "loren@example.com",
+		},
+		{
+			name:    "should_not_send_codes_too_often",
+			account: &entity.Account{ID: 3, Email: "loren@example.com", SecretResetHash: "hash", SecretResetExpiresAt: &recent},
+		},
+		{
+			name:    "should_do_nothing_for_accounts_without_contact",
+			account: &entity.Account{ID: 3},
+		},
+		{
+			name:    "should_do_nothing_for_unknown_accounts",
+			account: &entity.Account{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &accountRepository.AccountRepositoryMock{}
+			repo.On("Get", mock.Anything, types.AccountInput{CPF: "25462557035"}).Return(tt.account, nil)
+			repo.On("Update", mock.Anything, tt.account, secretResetColumns, []*entity.AccountChange(nil)).Return(nil)
+
+			messages := &notifier.MemoryNotifier{}
+
+			err := newUsecaseTest(repo, messages).RequestSecretReset(context.Background(), types.SecretResetInput{CPF: "25462557035"})
+			assert.NilError(t, err)
+
+			if tt.wantTo == "" {
+				assert.Equal(t, 0, len(messages.Messages()))
+				repo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+
+				return
+			}
+
+			assert.Equal(t, 1, len(messages.Messages()))
+			assert.Equal(t, tt.wantTo, messages.Messages()[0].To)
+
+			code := regexp.MustCompile(`\d{6}`).FindString(messages.Messages()[0].Body)
+			assert.Equal(t, secretResetHash(3, code), tt.account.SecretResetHash)
+			assert.Equal(t, nowTest.Add(SECRET_RESET_CODE_TTL), *tt.account.SecretResetExpiresAt)
+			assert.Equal(t, 0, tt.account.SecretResetAttempts)
+		})
+	}
+}
+
+func TestAccountUsecaseResetSecret(t *testing.T) {
+	valid := nowTest.Add(time.Minute)
+	expired := nowTest.Add(-time.Minute)
+
+	tests := []struct {
+		name      string
+		account   *entity.Account
+		code      string
+		claimed   bool
+		wantClaim bool
+		wantErr   error
+	}{
+		{
+			name:      "should_set_the_secret_and_revoke_tokens",
+			account:   &entity.Account{ID: 3, TokenVersion: 1, SecretResetHash: secretResetHash(3, "123456"), SecretResetExpiresAt: &valid, SecretResetAttempts: 1},
+			code:      "123456",
+			claimed:   true,
+			wantClaim: true,
+		},
+		{
+			name:      "should_count_a_wrong_code",
+			account:   &entity.Account{ID: 3, TokenVersion: 1, SecretResetHash: secretResetHash(3, "123456"), SecretResetExpiresAt: &valid, SecretResetAttempts: 1},
+			code:      "654321",
+			claimed:   true,
+			wantClaim: true,
+			wantErr:   &common.ValidationError{},
+		},
+		{
+			name:    "should_reject_expired_codes",
+			account: &entity.Account{ID: 3, TokenVersion: 1, SecretResetHash: secretResetHash(3, "123456"), SecretResetExpiresAt: &expired},
+			code:    "123456",
+			wantErr: &common.ValidationError{},
+		},
+		{
+			name:      "should_block_after_too_many_attempts",
+			account:   &entity.Account{ID: 3, TokenVersion: 1, SecretResetHash: secretResetHash(3, "123456"), SecretResetExpiresAt: &valid, SecretResetAttempts: SECRET_RESET_CODE_MAX_ATTEMPTS},
+			code:      "123456",
+			wantClaim: true,
+			wantErr:   &common.LimitExceededError{},
+		},
+		{
+			name:    "should_reject_accounts_without_a_pending_reset",
+			account: &entity.Account{ID: 3, TokenVersion: 1},
+			code:    "123456",
+			wantErr: &common.ValidationError{},
+		},
+		{
+			name:    "should_reject_unknown_accounts_like_a_wrong_code",
+			account: &entity.Account{},
+			code:    "123456",
+			wantErr: &common.ValidationError{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &accountRepository.AccountRepositoryMock{}
+			repo.On("GetFromPrimary", mock.Anything, types.AccountInput{CPF: "25462557035"}).Return(tt.account, nil)
+			repo.On("ClaimAttempt", mock.Anything, uint(3), secretResetAttempt).Return(tt.claimed, nil)
+			repo.On("Update", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+
+			err := newUsecaseTest(repo, &notifier.MemoryNotifier{}).ResetSecret(context.Background(), types.SecretResetConfirmInput{CPF: "25462557035", Code: tt.code, Secret: "654321"})
+			if tt.wantClaim {
+				repo.AssertCalled(t, "ClaimAttempt", mock.Anything, uint(3), secretResetAttempt)
+			} else {
+				repo.AssertNotCalled(t, "ClaimAttempt", mock.Anything, mock.Anything, mock.Anything)
+			}
+
+			if tt.wantErr != nil {
+				assertCode(t, err, tt.wantErr)
+				assert.Assert(t, tt.account.Secret == "")
+				repo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+
+				return
+			}
+
+			assert.NilError(t, err)
+			assert.Equal(t, util.GenerateHash("654321"), tt.account.Secret)
+			assert.Equal(t, uint(2), tt.account.TokenVersion)
+			assert.Equal(t, "", tt.account.SecretResetHash)
+			assert.Assert(t, tt.account.SecretResetExpiresAt == nil)
+			assert.DeepEqual(t, []*entity.AccountChange{{AccountID: 3, Field: entity.ACCOUNT_CHANGE_FIELD_SECRET_RESET}}, repo.Calls[2].Arguments.Get(3).([]*entity.AccountChange))
+		})
+	}
+}
+
+func TestAccountUsecaseResetSecretConcurrently(t *testing.T) {
+	valid := nowTest.Add(time.Minute)
+	guesses := 4 * SECRET_RESET_CODE_MAX_ATTEMPTS
+
+	repo := &accountRepository.AccountRepositoryMock{}
+	repo.On("GetFromPrimary", mock.Anything, mock.Anything).Return(func(context.Context, types.AccountInput) (*entity.Account, error) {
+		return &entity.Account{ID: 3, SecretResetHash: secretResetHash(3, "123456"), SecretResetExpiresAt: &valid}, nil
+	})
+	repo.On("ClaimAttempt", mock.Anything, uint(3), secretResetAttempt).Return(true, nil).Times(SECRET_RESET_CODE_MAX_ATTEMPTS)
+	repo.On("ClaimAttempt", mock.Anything, uint(3), secretResetAttempt).Return(false, nil)
+
+	usecase := newUsecaseTest(repo, &notifier.MemoryNotifier{})
+
+	errs := make(chan error, guesses)
+	var wg sync.WaitGroup
+	for i := 0; i < guesses; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			errs <- usecase.ResetSecret(context.Background(), types.SecretResetConfirmInput{CPF: "25462557035", Code: fmt.Sprintf("%06d", 200000+i), Secret: "654321"})
+		}(i)
+	}
+	wg.Wait()
+	close(errs)
+
+	var validationError *common.ValidationError
+	var limitExceededError *common.LimitExceededError
+	compared, refused := 0, 0
+	for err := range errs {
+		switch {
+		case errors.As(err, &validationError):
+			compared++
+		case errors.As(err, &limitExceededError):
+			refused++
+		default:
+			t.Fatalf("unexpected error %v", err)
+		}
+	}
+
+	assert.Equal(t, SECRET_RESET_CODE_MAX_ATTEMPTS, compared)
+	assert.Equal(t, guesses-SECRET_RESET_CODE_MAX_ATTEMPTS, refused)
+	repo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestAccountUsecaseValidateSession(t *testing.T) {
+	tests := []struct {
+		name         string
+		account      *entity.Account
+		tokenVersion uint
+		wantErr      error
+	}{
+		{
+			name:         "should_accept_tokens_of_the_current_version",
+			account:      &entity.Account{ID: 3, TokenVersion: 2},
+			tokenVersion: 2,
+		},
+		{
+			name:         "should_reject_tokens_issued_before_a_secret_change",
+			account:      &entity.Account{ID: 3, TokenVersion: 2},
+			tokenVersion: 1,
+			wantErr:      &common.UnauthorizedError{},
+		},
+		{
+			name:    "should_reject_tokens_of_unknown_accounts",
+			account: &entity.Account{},
+			wantErr: &common.UnauthorizedError{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &accountRepository.AccountRepositoryMock{}
+			repo.On("Get", mock.Anything, types.AccountInput{ID: 3}).Return(tt.account, nil)
+
+			err := newUsecaseTest(repo, &notifier.MemoryNotifier{}).ValidateSession(context.Background(), 3, tt.tokenVersion)
+			if tt.wantErr != nil {
+				assertCode(t, err, tt.wantErr)
+
+				return
+			}
+
+			assert.NilError(t, err)
+		})
+	}
+}
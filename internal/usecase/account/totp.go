@@ -0,0 +1,343 @@
+package account
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/fms85/desafio-tecnico-go-stone/internal/domain/common"
+	"github.com/fms85/desafio-tecnico-go-stone/internal/domain/entity"
+	"github.com/fms85/desafio-tecnico-go-stone/internal/domain/types"
+	otelDriver "github.com/fms85/desafio-tecnico-go-stone/internal/driver/otel"
+	"github.com/fms85/desafio-tecnico-go-stone/internal/util"
+)
+
+const (
+	TOTP_ISSUER              = "Go Stone"
+	TOTP_RECOVERY_CODES      = 10
+	TOTP_MAX_ATTEMPTS        = 5
+	TOTP_LOCK_DURATION       = 15 * time.Minute
+	TOTP_LOGIN_CHALLENGE_TTL = 5 * time.Minute
+)
+
+const (
+	TOTP_ALREADY_ENABLED_ERROR   = "totp is already enabled"
+	TOTP_NOT_ENABLED_ERROR       = "totp is not enabled"
+	TOTP_NOT_PENDING_ERROR       = "totp enrollment is not pending"
+	INVALID_TOTP_CODE_ERROR      = "invalid totp code"
+	TOO_MANY_TOTP_ATTEMPTS_ERROR = "too many totp attempts"
+	TOTP_REQUIRED_ERROR          = "totp code required"
+	INVALID_CHALLENGE_ERROR      = "invalid login challenge"
+	EXPIRED_CHALLENGE_ERROR      = "login challenge expired"
+)
+
+var (
+	totpStateColumns     = []string{"totp_attempts", "totp_locked_until"}
+	totpChallengeColumns = []string{"totp_challenge_hash", "totp_challenge_expires_at"}
+)
+
+// EnrollTOTP starts two-factor authentication with a new secret, which only
+// takes effect once ConfirmTOTP gets a code of it. Enrolling again before
+// confirming replaces the secret.
+func (usecase *accountUsecase) EnrollTOTP(ctx context.Context, accountID uint) (enrollment *types.TOTPEnrollment, err error) {
+	ctx, span := tracer.Start(ctx, "accountUsecase.EnrollTOTP")
+	defer func() { otelDriver.End(span, err) }()
+
+	account, err := usecase.Get(ctx, types.AccountInput{ID: accountID})
+	if err != nil {
+		return nil, err
+	}
+
+	if account.TOTPEnabled {
+		return nil, &common.ValidationError{Msg: TOTP_ALREADY_ENABLED_ERROR}
+	}
+
+	account.TOTPSecret = util.GenerateTOTPSecret()
+	account.TOTPLastStep = 0
+
+	if err := usecase.accountRepository.Update(ctx, account, []string{"totp_secret", "totp_last_step"}, nil); err != nil {
+		return nil, err
+	}
+
+	return totpEnrollment(account), nil
+}
+
+// GetTOTPEnrollment returns the enrollment waiting for confirmation, so its
+// QR code can be rendered again.
+func (usecase *accountUsecase) GetTOTPEnrollment(ctx context.Context, accountID uint) (enrollment *types.TOTPEnrollment, err error) {
+	ctx, span := tracer.Start(ctx, "accountUsecase.GetTOTPEnrollment")
+	defer func() { otelDriver.End(span, err) }()
+
+	account, err := usecase.Get(ctx, types.AccountInput{ID: accountID})
+	if err != nil {
+		return nil, err
+	}
+
+	if account.TOTPEnabled || account.TOTPSecret == "" {
+		return nil, fmt.Errorf("totp enrollment %w", &common.NotFoundError{})
+	}
+
+	return totpEnrollment(account), nil
+}
+
+// ConfirmTOTP enables two-factor authentication with a code of the enrolled
+// secret and returns the recovery codes, which are not shown again.
+func (usecase *accountUsecase) ConfirmTOTP(ctx context.Context, accountID uint, code string) (recoveryCodes *types.TOTPRecoveryCodes, err error) {
+	ctx, span := tracer.Start(ctx, "accountUsecase.ConfirmTOTP")
+	defer func() { otelDriver.End(span, err) }()
+
+	account, err := usecase.Get(ctx, types.AccountInput{ID: accountID})
+	if err != nil {
+		return nil, err
+	}
+
+	if account.TOTPEnabled || account.TOTPSecret == "" {
+		return nil, &common.ValidationError{Msg: TOTP_NOT_PENDING_ERROR}
+	}
+
+	if err := usecase.verifyTOTP(ctx, account, code, false); err != nil {
+		return nil, err
+	}
+
+	recoveryCodes = &types.TOTPRecoveryCodes{RecoveryCodes: make([]string, 0, TOTP_RECOVERY_CODES)}
+	account.TOTPRecoveryCodes = make([]string, 0, TOTP_RECOVERY_CODES)
+	for i := 0; i < TOTP_RECOVERY_CODES; i++ {
+		recoveryCode := util.GenerateRandomHex(5)
+		recoveryCode = recoveryCode[:5] + "-" + recoveryCode[5:]
+
+		recoveryCodes.RecoveryCodes = append(recoveryCodes.RecoveryCodes, recoveryCode)
+		account.TOTPRecoveryCodes = append(account.TOTPRecoveryCodes, recoveryCodeHash(account.ID, recoveryCode))
+	}
+	account.TOTPEnabled = true
+
+	if err := usecase.accountRepository.Update(
+		ctx,
+		account,
+		append([]string{"totp_enabled", "totp_recovery_codes"}, totpStateColumns...),
+		[]*entity.AccountChange{{AccountID: account.ID, Field: entity.ACCOUNT_CHANGE_FIELD_TOTP, NewValue: "enabled"}},
+	); err != nil {
+		return nil, err
+	}
+
+	return recoveryCodes, nil
+}
+
+// DisableTOTP turns two-factor authentication off given a TOTP or recovery
+// code, dropping the secret and the recovery codes left.
+func (usecase *accountUsecase) DisableTOTP(ctx context.Context, accountID uint, code string) (err error) {
+	ctx, span := tracer.Start(ctx, "accountUsecase.DisableTOTP")
+	defer func() { otelDriver.End(span, err) }()
+
+	account, err := usecase.Get(ctx, types.AccountInput{ID: accountID})
+	if err != nil {
+		return err
+	}
+
+	if !account.TOTPEnabled {
+		return &common.ValidationError{Msg: TOTP_NOT_ENABLED_ERROR}
+	}
+
+	if err := usecase.verifyTOTP(ctx, account, code, true); err != nil {
+		return err
+	}
+
+	account.TOTPEnabled = false
+	account.TOTPSecret = ""
+	account.TOTPLastStep = 0
+	account.TOTPRecoveryCodes = nil
+	account.TOTPChallengeHash = ""
+	account.TOTPChallengeExpiresAt = nil
+
+	return usecase.accountRepository.Update(
+		ctx,
+		account,
+		append(append([]string{"totp_enabled", "totp_secret", "totp_last_step", "totp_recovery_codes"}, totpStateColumns...), totpChallengeColumns...),
+		[]*entity.AccountChange{{AccountID: account.ID, Field: entity.ACCOUNT_CHANGE_FIELD_TOTP, OldValue: "enabled"}},
+	)
+}
+
+// CreateLoginChallenge is the first step of the login of an account with
+// two-factor authentication, once its secret was checked. A new challenge
+// replaces the previous one.
+func (usecase *accountUsecase) CreateLoginChallenge(ctx context.Context, account *entity.Account) (challenge *types.LoginChallenge, err error) {
+	ctx, span := tracer.Start(ctx, "accountUsecase.CreateLoginChallenge")
+	defer func() { otelDriver.End(span, err) }()
+
+	value := util.GenerateRandomHex(32)
+	expiresAt := usecase.now().Add(TOTP_LOGIN_CHALLENGE_TTL)
+
+	account.TOTPChallengeHash = util.GenerateHash(value)
+	account.TOTPChallengeExpiresAt = &expiresAt
+
+	if err := usecase.accountRepository.Update(ctx, account, totpChallengeColumns, nil); err != nil {
+		return nil, err
+	}
+
+	return &types.LoginChallenge{
+		Challenge: fmt.Sprintf("%d.%s", account.ID, value),
+		ExpiresAt: expiresAt,
+	}, nil
+}
+
+// CompleteLoginChallenge checks the TOTP or recovery code of a login
+// challenge and returns the account to issue a token to. A challenge is
+// used once.
+func (usecase *accountUsecase) CompleteLoginChallenge(ctx context.Context, loginChallengeInput types.LoginChallengeInput) (account *entity.Account, err error) {
+	ctx, span := tracer.Start(ctx, "accountUsecase.CompleteLoginChallenge")
+	defer func() { otelDriver.End(span, err) }()
+
+	prefix, value, _ := strings.Cut(loginChallengeInput.Challenge, ".")
+	id, parseErr := strconv.ParseUint(prefix, 10, 0)
+	if parseErr != nil || id == 0 || value == "" {
+		return nil, &common.UnauthorizedError{Msg: INVALID_CHALLENGE_ERROR}
+	}
+
+	account, err = usecase.accountRepository.Get(ctx, types.AccountInput{ID: uint(id)})
+	if err != nil {
+		return nil, err
+	}
+
+	if account.ID == 0 || !account.TOTPEnabled || account.TOTPChallengeExpiresAt == nil || util.GenerateHash(value) != account.TOTPChallengeHash {
+		return nil, &common.UnauthorizedError{Msg: INVALID_CHALLENGE_ERROR}
+	}
+
+	if !usecase.now().Before(*account.TOTPChallengeExpiresAt) {
+		return nil, &common.UnauthorizedError{Msg: EXPIRED_CHALLENGE_ERROR}
+	}
+
+	if err := usecase.verifyTOTP(ctx, account, loginChallengeInput.Code, true); err != nil {
+		return nil, err
+	}
+
+	account.TOTPChallengeHash = ""
+	account.TOTPChallengeExpiresAt = nil
+
+	if err := usecase.accountRepository.Update(ctx, account, append(totpStateColumns, totpChallengeColumns...), nil); err != nil {
+		return nil, err
+	}
+
+	return account, nil
+}
+
+// StepUp asks accounts with two-factor authentication for a fresh TOTP code
+// on top of their token. Recovery codes are only good for logging in, and
+// accounts without two-factor authentication pass.
+func (usecase *accountUsecase) StepUp(ctx context.Context, accountID uint, code string) (err error) {
+	ctx, span := tracer.Start(ctx, "accountUsecase.StepUp")
+	defer func() { otelDriver.End(span, err) }()
+
+	account, err := usecase.Get(ctx, types.AccountInput{ID: accountID})
+	if err != nil {
+		return err
+	}
+
+	if !account.TOTPEnabled {
+		return nil
+	}
+
+	if code == "" {
+		return &common.UnauthorizedError{Msg: TOTP_REQUIRED_ERROR}
+	}
+
+	if err := usecase.verifyTOTP(ctx, account, code, false); err != nil {
+		return err
+	}
+
+	return usecase.accountRepository.Update(ctx, account, totpStateColumns, nil)
+}
+
+// verifyTOTP checks a code of the account secret, or one of its recovery
+// codes when allowed, leaving the account updated for the caller to store
+// with totpStateColumns. Each code tried takes an attempt on the primary
+// before it is compared, and enough of them in a row lock the account out of
+// codes for a while. The step of a code is moved forward there too, and a
+// recovery code removed, so parallel requests cannot use a code twice.
+func (usecase *accountUsecase) verifyTOTP(ctx context.Context, account *entity.Account, code string, allowRecovery bool) error {
+	now := usecase.now()
+	if account.TOTPLockedUntil != nil && now.Before(*account.TOTPLockedUntil) {
+		return &common.LimitExceededError{Msg: TOO_MANY_TOTP_ATTEMPTS_ERROR}
+	}
+
+	claimed, err := usecase.accountRepository.ClaimAttempt(ctx, account.ID, totpAttempt(now))
+	if err != nil {
+		return err
+	}
+
+	if !claimed {
+		return &common.LimitExceededError{Msg: TOO_MANY_TOTP_ATTEMPTS_ERROR}
+	}
+
+	if step, ok := util.MatchTOTPCode(account.TOTPSecret, code, now); ok && step > account.TOTPLastStep {
+		advanced, err := usecase.accountRepository.AdvanceTOTPStep(ctx, account.ID, step)
+		if err != nil {
+			return err
+		}
+
+		if !advanced {
+			return &common.ValidationError{Msg: INVALID_TOTP_CODE_ERROR}
+		}
+
+		account.TOTPLastStep = step
+		account.TOTPAttempts = 0
+		account.TOTPLockedUntil = nil
+
+		return nil
+	}
+
+	if allowRecovery {
+		hash := recoveryCodeHash(account.ID, code)
+		for i, recoveryCode := range account.TOTPRecoveryCodes {
+			if recoveryCode != hash {
+				continue
+			}
+
+			consumed, err := usecase.accountRepository.ConsumeRecoveryCode(ctx, account.ID, hash)
+			if err != nil {
+				return err
+			}
+
+			if !consumed {
+				return &common.ValidationError{Msg: INVALID_TOTP_CODE_ERROR}
+			}
+
+			account.TOTPRecoveryCodes = append(account.TOTPRecoveryCodes[:i:i], account.TOTPRecoveryCodes[i+1:]...)
+			account.TOTPAttempts = 0
+			account.TOTPLockedUntil = nil
+
+			return nil
+		}
+	}
+
+	return &common.ValidationError{Msg: INVALID_TOTP_CODE_ERROR}
+}
+
+func totpAttempt(now time.Time) types.AccountAttempt {
+	return types.AccountAttempt{
+		AttemptsColumn:    "totp_attempts",
+		MaxAttempts:       TOTP_MAX_ATTEMPTS,
+		LockedUntilColumn: "totp_locked_until",
+		LockDuration:      TOTP_LOCK_DURATION,
+		Now:               now,
+	}
+}
+
+func totpEnrollment(account *entity.Account) *types.TOTPEnrollment {
+	label := account.CPF
+	if account.Type == entity.ACCOUNT_TYPE_PJ {
+		label = account.CNPJ
+	}
+
+	return &types.TOTPEnrollment{
+		Secret: account.TOTPSecret,
+		URI:    util.TOTPProvisioningURI(TOTP_ISSUER, label, account.TOTPSecret),
+	}
+}
+
+// recoveryCodeHash ignores case and dashes, as codes are typed in by hand.
+func recoveryCodeHash(accountID uint, code string) string {
+	code = strings.ToLower(strings.ReplaceAll(strings.TrimSpace(code), "-", ""))
+
+	return util.GenerateHash(fmt.Sprintf("%d:%s", accountID, code))
+}
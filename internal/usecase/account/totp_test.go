@@ -0,0 +1,457 @@
+package account
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/fms85/desafio-tecnico-go-stone/internal/domain/common"
+	"github.com/fms85/desafio-tecnico-go-stone/internal/domain/entity"
+	"github.com/fms85/desafio-tecnico-go-stone/internal/domain/types"
+	"github.com/fms85/desafio-tecnico-go-stone/internal/driver/notifier"
+	accountRepository "github.com/fms85/desafio-tecnico-go-stone/internal/repository/account"
+	"github.com/fms85/desafio-tecnico-go-stone/internal/util"
+	"github.com/stretchr/testify/mock"
+	"gotest.tools/assert"
+)
+
+const totpSecretTest = "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ"
+
+func totpCodeTest(t *testing.T, at time.Time) string {
+	t.Helper()
+
+	code, err := util.GenerateTOTPCode(totpSecretTest, util.TOTPStep(at))
+	assert.NilError(t, err)
+
+	return code
+}
+
+func totpAccountTest() *entity.Account {
+	return &entity.Account{
+		ID:                3,
+		Type:              entity.ACCOUNT_TYPE_PF,
+		CPF:               "25462557035",
+		TOTPSecret:        totpSecretTest,
+		TOTPEnabled:       true,
+		TOTPRecoveryCodes: []string{recoveryCodeHash(3, "abcde-12345")},
+	}
+}
+
+func TestAccountUsecaseEnrollTOTP(t *testing.T) {
+	t.Run("should_store_a_new_secret_pending_confirmation", func(t *testing.T) {
+		account := &entity.Account{ID: 3, Type: entity.ACCOUNT_TYPE_PF, CPF: "25462557035"}
+		repo := &accountRepository.AccountRepositoryMock{}
+		repo.On("Get", mock.Anything, types.AccountInput{ID: 3}).Return(account, nil)
+		repo.On("Update", mock.Anything, account, []string{"totp_secret", "totp_last_step"}, []*entity.AccountChange(nil)).Return(nil)
+
+		got, err := newUsecaseTest(repo, &notifier.MemoryNotifier{}).EnrollTOTP(context.Background(), 3)
+		assert.NilError(t, err)
+
+		assert.Equal(t, account.TOTPSecret, got.Secret)
+		assert.Assert(t, !account.TOTPEnabled)
+		assert.Assert(t, strings.HasPrefix(got.URI, "otpauth://totp/Go%20Stone:25462557035?"))
+		assert.Assert(t, strings.Contains(got.URI, "secret="+got.Secret))
+	})
+
+	t.Run("should_refuse_accounts_already_enabled", func(t *testing.T) {
+		repo := &accountRepository.AccountRepositoryMock{}
+		repo.On("Get", mock.Anything, types.AccountInput{ID: 3}).Return(totpAccountTest(), nil)
+
+		_, err := newUsecaseTest(repo, &notifier.MemoryNotifier{}).EnrollTOTP(context.Background(), 3)
+		assertCode(t, err, &common.ValidationError{})
+		repo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+func TestAccountUsecaseConfirmTOTP(t *testing.T) {
+	t.Run("should_enable_and_return_recovery_codes", func(t *testing.T) {
+		account := &entity.Account{ID: 3, TOTPSecret: totpSecretTest}
+		repo := &accountRepository.AccountRepositoryMock{}
+		repo.On("Get", mock.Anything, types.AccountInput{ID: 3}).Return(account, nil)
+		repo.On("ClaimAttempt", mock.Anything, uint(3), totpAttempt(nowTest)).Return(true, nil)
+		repo.On("AdvanceTOTPStep", mock.Anything, uint(3), util.TOTPStep(nowTest)).Return(true, nil)
+		repo.On("Update", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+
+		got, err := newUsecaseTest(repo, &notifier.MemoryNotifier{}).ConfirmTOTP(context.Background(), 3, totpCodeTest(t, nowTest))
+		assert.NilError(t, err)
+
+		assert.Assert(t, account.TOTPEnabled)
+		assert.Equal(t, util.TOTPStep(nowTest), account.TOTPLastStep)
+		assert.Equal(t, TOTP_RECOVERY_CODES, len(got.RecoveryCodes))
+		for i, recoveryCode := range got.RecoveryCodes {
+			assert.Equal(t, recoveryCodeHash(3, recoveryCode), account.TOTPRecoveryCodes[i])
+		}
+
+		assert.DeepEqual(t, []string{"totp_enabled", "totp_recovery_codes", "totp_attempts", "totp_locked_until"}, repo.Calls[3].Arguments.Get(2).([]string))
+		changes := repo.Calls[3].Arguments.Get(3).([]*entity.AccountChange)
+		assert.DeepEqual(t, []*entity.AccountChange{{AccountID: 3, Field: entity.ACCOUNT_CHANGE_FIELD_TOTP, NewValue: "enabled"}}, changes)
+	})
+
+	t.Run("should_count_a_wrong_code_as_an_attempt", func(t *testing.T) {
+		account := &entity.Account{ID: 3, TOTPSecret: totpSecretTest}
+		repo := &accountRepository.AccountRepositoryMock{}
+		repo.On("Get", mock.Anything, types.AccountInput{ID: 3}).Return(account, nil)
+		repo.On("ClaimAttempt", mock.Anything, uint(3), totpAttempt(nowTest)).Return(true, nil)
+
+		_, err := newUsecaseTest(repo, &notifier.MemoryNotifier{}).ConfirmTOTP(context.Background(), 3, "000000")
+		assertCode(t, err, &common.ValidationError{})
+
+		assert.Assert(t, !account.TOTPEnabled)
+		repo.AssertCalled(t, "ClaimAttempt", mock.Anything, uint(3), totpAttempt(nowTest))
+		repo.AssertNotCalled(t, "AdvanceTOTPStep", mock.Anything, mock.Anything, mock.Anything)
+		repo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("should_refuse_accounts_without_an_enrollment", func(t *testing.T) {
+		repo := &accountRepository.AccountRepositoryMock{}
+		repo.On("Get", mock.Anything, types.AccountInput{ID: 3}).Return(&entity.Account{ID: 3}, nil)
+
+		_, err := newUsecaseTest(repo, &notifier.MemoryNotifier{}).ConfirmTOTP(context.Background(), 3, "123456")
+		assertCode(t, err, &common.ValidationError{})
+	})
+}
+
+func TestAccountUsecaseDisableTOTP(t *testing.T) {
+	account := totpAccountTest()
+	repo := &accountRepository.AccountRepositoryMock{}
+	repo.On("Get", mock.Anything, types.AccountInput{ID: 3}).Return(account, nil)
+	repo.On("ClaimAttempt", mock.Anything, uint(3), totpAttempt(nowTest)).Return(true, nil)
+	repo.On("ConsumeRecoveryCode", mock.Anything, uint(3), recoveryCodeHash(3, "abcde-12345")).Return(true, nil)
+	repo.On("Update", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+
+	err := newUsecaseTest(repo, &notifier.MemoryNotifier{}).DisableTOTP(context.Background(), 3, "ABCDE12345")
+	assert.NilError(t, err)
+
+	assert.Assert(t, !account.TOTPEnabled)
+	assert.Equal(t, "", account.TOTPSecret)
+	assert.Assert(t, account.TOTPRecoveryCodes == nil)
+
+	assert.DeepEqual(t, []string{"totp_enabled", "totp_secret", "totp_last_step", "totp_recovery_codes", "totp_attempts", "totp_locked_until", "totp_challenge_hash", "totp_challenge_expires_at"}, repo.Calls[3].Arguments.Get(2).([]string))
+	changes := repo.Calls[3].Arguments.Get(3).([]*entity.AccountChange)
+	assert.DeepEqual(t, []*entity.AccountChange{{AccountID: 3, Field: entity.ACCOUNT_CHANGE_FIELD_TOTP, OldValue: "enabled"}}, changes)
+}
+
+func TestAccountUsecaseLoginChallenge(t *testing.T) {
+	t.Run("should_issue_and_complete_a_challenge", func(t *testing.T) {
+		account := totpAccountTest()
+		repo := &accountRepository.AccountRepositoryMock{}
+		repo.On("Get", mock.Anything, types.AccountInput{ID: 3}).Return(account, nil)
+		repo.On("ClaimAttempt", mock.Anything, uint(3), totpAttempt(nowTest)).Return(true, nil)
+		repo.On("AdvanceTOTPStep", mock.Anything, uint(3), util.TOTPStep(nowTest)).Return(true, nil)
+		repo.On("Update", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+
+		usecase := newUsecaseTest(repo, &notifier.MemoryNotifier{})
+
+		challenge, err := usecase.CreateLoginChallenge(context.Background(), account)
+		assert.NilError(t, err)
+		assert.Assert(t, strings.HasPrefix(challenge.Challenge, "3."))
+		assert.Equal(t, nowTest.Add(TOTP_LOGIN_CHALLENGE_TTL), challenge.ExpiresAt)
+
+		got, err := usecase.CompleteLoginChallenge(context.Background(), types.LoginChallengeInput{Challenge: challenge.Challenge, Code: totpCodeTest(t, nowTest)})
+		assert.NilError(t, err)
+		assert.Equal(t, account, got)
+		assert.Equal(t, "", account.TOTPChallengeHash)
+
+		_, err = usecase.CompleteLoginChallenge(context.Background(), types.LoginChallengeInput{Challenge: challenge.Challenge, Code: totpCodeTest(t, nowTest)})
+		assertCode(t, err, &common.UnauthorizedError{})
+	})
+
+	tests := []struct {
+		name      string
+		challenge string
+		expiresAt time.Time
+		lastStep  int64
+		replayed  bool
+		code      string
+		wantErr   error
+		wantCodes int
+	}{
+		{
+			name:      "should_complete_with_a_recovery_code_once",
+			challenge: "3.challenge",
+			expiresAt: nowTest.Add(time.Minute),
+			code:      "abcde-12345",
+			wantCodes: 0,
+		},
+		{
+			name:      "should_refuse_a_recovery_code_used_meanwhile_by_another_request",
+			challenge: "3.challenge",
+			expiresAt: nowTest.Add(time.Minute),
+			replayed:  true,
+			code:      "abcde-12345",
+			wantErr:   &common.ValidationError{},
+			wantCodes: 1,
+		},
+		{
+			name:      "should_refuse_a_code_of_a_step_already_used",
+			challenge: "3.challenge",
+			expiresAt: nowTest.Add(time.Minute),
+			lastStep:  util.TOTPStep(nowTest),
+			code:      "current",
+			wantErr:   &common.ValidationError{},
+			wantCodes: 1,
+		},
+		{
+			name:      "should_refuse_a_code_used_meanwhile_by_another_request",
+			challenge: "3.challenge",
+			expiresAt: nowTest.Add(time.Minute),
+			replayed:  true,
+			code:      "current",
+			wantErr:   &common.ValidationError{},
+			wantCodes: 1,
+		},
+		{
+			name:      "should_refuse_an_expired_challenge",
+			challenge: "3.challenge",
+			expiresAt: nowTest,
+			code:      "current",
+			wantErr:   &common.UnauthorizedError{},
+			wantCodes: 1,
+		},
+		{
+			name:      "should_refuse_a_challenge_of_other_value",
+			challenge: "3.other",
+			expiresAt: nowTest.Add(time.Minute),
+			code:      "current",
+			wantErr:   &common.UnauthorizedError{},
+			wantCodes: 1,
+		},
+		{
+			name:      "should_refuse_a_malformed_challenge",
+			challenge: "challenge",
+			expiresAt: nowTest.Add(time.Minute),
+			code:      "current",
+			wantErr:   &common.UnauthorizedError{},
+			wantCodes: 1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			account := totpAccountTest()
+			account.TOTPChallengeHash = util.GenerateHash("challenge")
+			account.TOTPChallengeExpiresAt = &tt.expiresAt
+			account.TOTPLastStep = tt.lastStep
+
+			repo := &accountRepository.AccountRepositoryMock{}
+			repo.On("Get", mock.Anything, types.AccountInput{ID: 3}).Return(account, nil)
+			repo.On("ClaimAttempt", mock.Anything, uint(3), totpAttempt(nowTest)).Return(true, nil)
+			repo.On("AdvanceTOTPStep", mock.Anything, uint(3), util.TOTPStep(nowTest)).Return(!tt.replayed, nil)
+			repo.On("ConsumeRecoveryCode", mock.Anything, uint(3), recoveryCodeHash(3, "abcde-12345")).Return(!tt.replayed, nil)
+			repo.On("Update", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+
+			code := tt.code
+			if code == "current" {
+				code = totpCodeTest(t, nowTest)
+			}
+
+			_, err := newUsecaseTest(repo, &notifier.MemoryNotifier{}).CompleteLoginChallenge(context.Background(), types.LoginChallengeInput{Challenge: tt.challenge, Code: code})
+			if tt.wantErr != nil {
+				assertCode(t, err, tt.wantErr)
+			} else {
+				assert.NilError(t, err)
+			}
+
+			assert.Equal(t, tt.wantCodes, len(account.TOTPRecoveryCodes))
+			for _, call := range repo.Calls {
+				if call.Method == "Update" {
+					assert.Assert(t, !slices.Contains(call.Arguments.Get(2).([]string), "totp_recovery_codes"))
+				}
+			}
+		})
+	}
+}
+
+func TestAccountUsecaseStepUp(t *testing.T) {
+	lockedUntil := nowTest.Add(time.Minute)
+
+	tests := []struct {
+		name       string
+		account    *entity.Account
+		code       string
+		exhausted  bool
+		wantErr    error
+		wantClaim  bool
+		wantLocked bool
+	}{
+		{
+			name:    "should_pass_accounts_without_totp",
+			account: &entity.Account{ID: 3},
+		},
+		{
+			name:      "should_accept_a_fresh_code",
+			account:   totpAccountTest(),
+			code:      "current",
+			wantClaim: true,
+		},
+		{
+			name:    "should_require_a_code",
+			account: totpAccountTest(),
+			wantErr: &common.UnauthorizedError{},
+		},
+		{
+			name:      "should_refuse_recovery_codes",
+			account:   totpAccountTest(),
+			code:      "abcde-12345",
+			wantErr:   &common.ValidationError{},
+			wantClaim: true,
+		},
+		{
+			name:      "should_refuse_codes_once_out_of_attempts",
+			account:   totpAccountTest(),
+			code:      "current",
+			exhausted: true,
+			wantErr:   &common.LimitExceededError{},
+			wantClaim: true,
+		},
+		{
+			name: "should_refuse_codes_while_locked",
+			account: func() *entity.Account {
+				account := totpAccountTest()
+				account.TOTPLockedUntil = &lockedUntil
+
+				return account
+			}(),
+			code:       "current",
+			wantErr:    &common.LimitExceededError{},
+			wantLocked: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &accountRepository.AccountRepositoryMock{}
+			repo.On("Get", mock.Anything, types.AccountInput{ID: 3}).Return(tt.account, nil)
+			repo.On("ClaimAttempt", mock.Anything, uint(3), totpAttempt(nowTest)).Return(!tt.exhausted, nil)
+			repo.On("AdvanceTOTPStep", mock.Anything, uint(3), util.TOTPStep(nowTest)).Return(true, nil)
+			repo.On("Update", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+
+			code := tt.code
+			if code == "current" {
+				code = totpCodeTest(t, nowTest)
+			}
+
+			err := newUsecaseTest(repo, &notifier.MemoryNotifier{}).StepUp(context.Background(), 3, code)
+			if tt.wantErr != nil {
+				assertCode(t, err, tt.wantErr)
+				repo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+			} else {
+				assert.NilError(t, err)
+			}
+
+			if tt.wantClaim {
+				repo.AssertCalled(t, "ClaimAttempt", mock.Anything, uint(3), totpAttempt(nowTest))
+			} else {
+				repo.AssertNotCalled(t, "ClaimAttempt", mock.Anything, mock.Anything, mock.Anything)
+			}
+			assert.Equal(t, tt.wantLocked, tt.account.TOTPLockedUntil != nil)
+		})
+	}
+}
+
+func TestAccountUsecaseStepUpConcurrently(t *testing.T) {
+	requests := 4 * TOTP_MAX_ATTEMPTS
+
+	t.Run("should_compare_no_more_codes_than_the_attempts_left", func(t *testing.T) {
+		repo := &accountRepository.AccountRepositoryMock{}
+		repo.On("Get", mock.Anything, types.AccountInput{ID: 3}).Return(func(context.Context, types.AccountInput) (*entity.Account, error) {
+			return totpAccountTest(), nil
+		})
+		repo.On("ClaimAttempt", mock.Anything, uint(3), totpAttempt(nowTest)).Return(true, nil).Times(TOTP_MAX_ATTEMPTS)
+		repo.On("ClaimAttempt", mock.Anything, uint(3), totpAttempt(nowTest)).Return(false, nil)
+
+		usecase := newUsecaseTest(repo, &notifier.MemoryNotifier{})
+		validation, limitExceeded, passed := concurrentlyTest(t, requests, func(i int) error {
+			return usecase.StepUp(context.Background(), 3, fmt.Sprintf("%06d", i))
+		})
+
+		assert.Equal(t, TOTP_MAX_ATTEMPTS, validation)
+		assert.Equal(t, requests-TOTP_MAX_ATTEMPTS, limitExceeded)
+		assert.Equal(t, 0, passed)
+		repo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("should_accept_a_code_once", func(t *testing.T) {
+		code := totpCodeTest(t, nowTest)
+
+		repo := &accountRepository.AccountRepositoryMock{}
+		repo.On("Get", mock.Anything, types.AccountInput{ID: 3}).Return(func(context.Context, types.AccountInput) (*entity.Account, error) {
+			return totpAccountTest(), nil
+		})
+		repo.On("ClaimAttempt", mock.Anything, uint(3), totpAttempt(nowTest)).Return(true, nil)
+		repo.On("AdvanceTOTPStep", mock.Anything, uint(3), util.TOTPStep(nowTest)).Return(true, nil).Once()
+		repo.On("AdvanceTOTPStep", mock.Anything, uint(3), util.TOTPStep(nowTest)).Return(false, nil)
+		repo.On("Update", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+
+		usecase := newUsecaseTest(repo, &notifier.MemoryNotifier{})
+		validation, limitExceeded, passed := concurrentlyTest(t, requests, func(int) error {
+			return usecase.StepUp(context.Background(), 3, code)
+		})
+
+		assert.Equal(t, requests-1, validation)
+		assert.Equal(t, 0, limitExceeded)
+		assert.Equal(t, 1, passed)
+	})
+}
+
+func TestAccountUsecaseDisableTOTPConcurrently(t *testing.T) {
+	requests := 4 * TOTP_MAX_ATTEMPTS
+
+	t.Run("should_accept_a_recovery_code_once", func(t *testing.T) {
+		repo := &accountRepository.AccountRepositoryMock{}
+		repo.On("Get", mock.Anything, types.AccountInput{ID: 3}).Return(func(context.Context, types.AccountInput) (*entity.Account, error) {
+			return totpAccountTest(), nil
+		})
+		repo.On("ClaimAttempt", mock.Anything, uint(3), totpAttempt(nowTest)).Return(true, nil)
+		repo.On("ConsumeRecoveryCode", mock.Anything, uint(3), recoveryCodeHash(3, "abcde-12345")).Return(true, nil).Once()
+		repo.On("ConsumeRecoveryCode", mock.Anything, uint(3), recoveryCodeHash(3, "abcde-12345")).Return(false, nil)
+		repo.On("Update", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+
+		usecase := newUsecaseTest(repo, &notifier.MemoryNotifier{})
+		validation, limitExceeded, passed := concurrentlyTest(t, requests, func(int) error {
+			return usecase.DisableTOTP(context.Background(), 3, "abcde-12345")
+		})
+
+		assert.Equal(t, requests-1, validation)
+		assert.Equal(t, 0, limitExceeded)
+		assert.Equal(t, 1, passed)
+		repo.AssertNumberOfCalls(t, "Update", 1)
+	})
+}
+
+// concurrentlyTest makes requests calls at once and counts how they ended.
+func concurrentlyTest(t *testing.T, requests int, call func(i int) error) (validation, limitExceeded, passed int) {
+	t.Helper()
+
+	errs := make(chan error, requests)
+	var wg sync.WaitGroup
+	for i := 0; i < requests; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			errs <- call(i)
+		}(i)
+	}
+	wg.Wait()
+	close(errs)
+
+	var validationError *common.ValidationError
+	var limitExceededError *common.LimitExceededError
+	for err := range errs {
+		switch {
+		case err == nil:
+			passed++
+		case errors.As(err, &validationError):
+			validation++
+		case errors.As(err, &limitExceededError):
+			limitExceeded++
+		default:
+			t.Fatalf("unexpected error %v", err)
+		}
+	}
+
+	return validation, limitExceeded, passed
+}
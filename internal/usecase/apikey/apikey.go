@@ -0,0 +1,181 @@
+package apikey
+
+import (
+	"context"
+	"crypto/subtle"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/fms85/desafio-tecnico-go-stone/internal/domain/common"
+	"github.com/fms85/desafio-tecnico-go-stone/internal/domain/entity"
+	"github.com/fms85/desafio-tecnico-go-stone/internal/domain/types"
+	otelDriver "github.com/fms85/desafio-tecnico-go-stone/internal/driver/otel"
+	apiKeyRepository "github.com/fms85/desafio-tecnico-go-stone/internal/repository/apikey"
+	accountUsecase "github.com/fms85/desafio-tecnico-go-stone/internal/usecase/account"
+	"github.com/fms85/desafio-tecnico-go-stone/internal/util"
+	"go.opentelemetry.io/otel"
+)
+
+const (
+	API_KEY_MAX_PER_ACCOUNT = 10
+	API_KEY_DEFAULT_TTL     = 90 * 24 * time.Hour
+	API_KEY_ID_PREFIX       = "ak_"
+	API_KEY_SECRET_PREFIX   = "sk_"
+	// API_KEY_LAST_USED_PRECISION spares a write per request to busy keys:
+	// their last use is only recorded again once it is this old.
+	API_KEY_LAST_USED_PRECISION = time.Minute
+)
+
+const (
+	API_KEY_LIMIT_ERROR   = "api key limit reached"
+	INVALID_API_KEY_ERROR = "invalid api key"
+	API_KEY_REVOKED_ERROR = "api key revoked"
+	API_KEY_EXPIRED_ERROR = "api key expired"
+)
+
+var tracer = otel.Tracer("internal/usecase/apikey")
+
+type apiKeyUsecase struct {
+	apiKeyRepository apiKeyRepository.IAPIKeyRepository
+	accountUsecase   accountUsecase.IAccountUsecase
+	now              func() time.Time
+}
+
+func New(apiKeyRepository apiKeyRepository.IAPIKeyRepository, accountUsecase accountUsecase.IAccountUsecase) IAPIKeyUsecase {
+	return &apiKeyUsecase{
+		apiKeyRepository: apiKeyRepository,
+		accountUsecase:   accountUsecase,
+		now:              time.Now,
+	}
+}
+
+// Create issues a key to the account. It takes what a login takes, the
+// secret and, with two-factor authentication, a TOTP code, so a stolen token
+// alone cannot mint a credential outliving it.
+func (usecase *apiKeyUsecase) Create(ctx context.Context, apiKeyInput types.APIKeyInput) (credentials *types.APIKeyCredentials, err error) {
+	ctx, span := tracer.Start(ctx, "apiKeyUsecase.Create")
+	defer func() { otelDriver.End(span, err) }()
+
+	account, err := usecase.accountUsecase.Get(ctx, types.AccountInput{ID: apiKeyInput.AccountID})
+	if err != nil {
+		return nil, err
+	}
+
+	if util.GenerateHash(apiKeyInput.Secret) != account.Secret {
+		return nil, &common.ValidationError{Msg: accountUsecase.WRONG_SECRET_ERROR}
+	}
+
+	if err := usecase.accountUsecase.StepUp(ctx, account.ID, apiKeyInput.TOTPCode); err != nil {
+		return nil, err
+	}
+
+	apiKeys, err := usecase.apiKeyRepository.GetAll(ctx, account.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := usecase.now()
+	active := 0
+	for _, apiKey := range apiKeys {
+		if apiKey.RevokedAt == nil && now.Before(apiKey.ExpiresAt) {
+			active++
+		}
+	}
+
+	if active >= API_KEY_MAX_PER_ACCOUNT {
+		return nil, &common.LimitExceededError{Msg: API_KEY_LIMIT_ERROR}
+	}
+
+	ttl := API_KEY_DEFAULT_TTL
+	if apiKeyInput.ExpiresIn > 0 {
+		ttl = time.Duration(apiKeyInput.ExpiresIn) * time.Second
+	}
+
+	scopes := slices.Clone(apiKeyInput.Scopes)
+	slices.Sort(scopes)
+
+	secret := API_KEY_SECRET_PREFIX + util.GenerateRandomHex(24)
+	apiKey := &entity.APIKey{
+		AccountID:  account.ID,
+		KeyID:      API_KEY_ID_PREFIX + util.GenerateRandomHex(8),
+		Name:       apiKeyInput.Name,
+		SecretHash: util.GenerateHash(secret),
+		Scopes:     slices.Compact(scopes),
+		ExpiresAt:  now.Add(ttl),
+	}
+
+	if err := usecase.apiKeyRepository.Create(ctx, apiKey); err != nil {
+		return nil, err
+	}
+
+	return &types.APIKeyCredentials{APIKey: apiKey, Secret: secret}, nil
+}
+
+func (usecase *apiKeyUsecase) GetAll(ctx context.Context, accountID uint) (apiKeys []*entity.APIKey, err error) {
+	ctx, span := tracer.Start(ctx, "apiKeyUsecase.GetAll")
+	defer func() { otelDriver.End(span, err) }()
+
+	return usecase.apiKeyRepository.GetAll(ctx, accountID)
+}
+
+// Revoke stops a key from authenticating from now on. Revoking a revoked key
+// changes nothing.
+func (usecase *apiKeyUsecase) Revoke(ctx context.Context, keyID string, accountID uint) (err error) {
+	ctx, span := tracer.Start(ctx, "apiKeyUsecase.Revoke")
+	defer func() { otelDriver.End(span, err) }()
+
+	apiKey, err := usecase.apiKeyRepository.Get(ctx, keyID)
+	if err != nil {
+		return err
+	}
+
+	if apiKey.ID == 0 || apiKey.AccountID != accountID {
+		return fmt.Errorf("api key %w", &common.NotFoundError{})
+	}
+
+	if apiKey.RevokedAt != nil {
+		return nil
+	}
+
+	now := usecase.now()
+	apiKey.RevokedAt = &now
+
+	return usecase.apiKeyRepository.Update(ctx, apiKey, []string{"revoked_at"})
+}
+
+// Authenticate returns the key of keyID when secret is its own and it is
+// neither revoked nor expired, recording that it was used.
+func (usecase *apiKeyUsecase) Authenticate(ctx context.Context, keyID string, secret string) (apiKey *entity.APIKey, err error) {
+	ctx, span := tracer.Start(ctx, "apiKeyUsecase.Authenticate")
+	defer func() { otelDriver.End(span, err) }()
+
+	apiKey, err = usecase.apiKeyRepository.Get(ctx, keyID)
+	if err != nil {
+		return nil, err
+	}
+
+	if apiKey.ID == 0 || subtle.ConstantTimeCompare([]byte(util.GenerateHash(secret)), []byte(apiKey.SecretHash)) != 1 {
+		return nil, &common.UnauthorizedError{Msg: INVALID_API_KEY_ERROR}
+	}
+
+	if apiKey.RevokedAt != nil {
+		return nil, &common.UnauthorizedError{Msg: API_KEY_REVOKED_ERROR}
+	}
+
+	now := usecase.now()
+	if !now.Before(apiKey.ExpiresAt) {
+		return nil, &common.UnauthorizedError{Msg: API_KEY_EXPIRED_ERROR}
+	}
+
+	if apiKey.LastUsedAt != nil && now.Sub(*apiKey.LastUsedAt) < API_KEY_LAST_USED_PRECISION {
+		return apiKey, nil
+	}
+
+	apiKey.LastUsedAt = &now
+	if err := usecase.apiKeyRepository.Update(ctx, apiKey, []string{"last_used_at"}); err != nil {
+		return nil, err
+	}
+
+	return apiKey, nil
+}
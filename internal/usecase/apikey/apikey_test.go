@@ -0,0 +1,299 @@
+package apikey
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/fms85/desafio-tecnico-go-stone/internal/domain/common"
+	"github.com/fms85/desafio-tecnico-go-stone/internal/domain/entity"
+	"github.com/fms85/desafio-tecnico-go-stone/internal/domain/types"
+	apiKeyRepository "github.com/fms85/desafio-tecnico-go-stone/internal/repository/apikey"
+	accountUsecase "github.com/fms85/desafio-tecnico-go-stone/internal/usecase/account"
+	"github.com/fms85/desafio-tecnico-go-stone/internal/util"
+	"github.com/google/go-cmp/cmp"
+	"github.com/stretchr/testify/mock"
+	"gotest.tools/assert"
+)
+
+var nowTest = time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+
+func newUsecaseTest(repo apiKeyRepository.IAPIKeyRepository, accounts accountUsecase.IAccountUsecase) *apiKeyUsecase {
+	usecase := New(repo, accounts).(*apiKeyUsecase)
+	usecase.now = func() time.Time { return nowTest }
+
+	return usecase
+}
+
+func getAPIKeyTest(secret string) *entity.APIKey {
+	return &entity.APIKey{
+		ID:         1,
+		AccountID:  3,
+		KeyID:      "ak_0123456789abcdef",
+		SecretHash: util.GenerateHash(secret),
+		Scopes:     []string{entity.API_KEY_SCOPE_BALANCE_READ},
+		ExpiresAt:  nowTest.Add(time.Hour),
+	}
+}
+
+func assertCode(t *testing.T, err error, want error) {
+	t.Helper()
+
+	var codedError common.CodedError
+	assert.Assert(t, errors.As(err, &codedError), "error %v is not coded", err)
+	assert.Equal(t, want.(common.CodedError).Code(), codedError.Code())
+}
+
+func TestAPIKeyUsecaseCreate(t *testing.T) {
+	activeKeys := make([]*entity.APIKey, 0, API_KEY_MAX_PER_ACCOUNT)
+	for i := 0; i < API_KEY_MAX_PER_ACCOUNT; i++ {
+		activeKeys = append(activeKeys, getAPIKeyTest("sk_test"))
+	}
+
+	revokedAt := nowTest.Add(-time.Hour)
+	inactiveKeys := []*entity.APIKey{
+		{ExpiresAt: nowTest.Add(time.Hour), RevokedAt: &revokedAt},
+		{ExpiresAt: nowTest},
+	}
+
+	tests := []struct {
+		name          string
+		input         types.APIKeyInput
+		stepUpErr     error
+		apiKeys       []*entity.APIKey
+		wantErr       error
+		wantScopes    []string
+		wantExpiresAt time.Time
+	}{
+		{
+			name:          "should_create_a_key_expiring_by_default",
+			input:         types.APIKeyInput{AccountID: 3, Name: "erp", Scopes: []string{"transfers:write", "balance:read", "transfers:write"}, Secret: "123456"},
+			apiKeys:       inactiveKeys,
+			wantScopes:    []string{"balance:read", "transfers:write"},
+			wantExpiresAt: nowTest.Add(API_KEY_DEFAULT_TTL),
+		},
+		{
+			name:          "should_create_a_key_expiring_in_the_given_seconds",
+			input:         types.APIKeyInput{AccountID: 3, Name: "erp", Scopes: []string{"balance:read"}, ExpiresIn: 3600, Secret: "123456"},
+			wantScopes:    []string{"balance:read"},
+			wantExpiresAt: nowTest.Add(time.Hour),
+		},
+		{
+			name:    "should_refuse_a_wrong_secret",
+			input:   types.APIKeyInput{AccountID: 3, Name: "erp", Scopes: []string{"balance:read"}, Secret: "654321"},
+			wantErr: &common.ValidationError{},
+		},
+		{
+			name:      "should_refuse_a_missing_totp_code",
+			input:     types.APIKeyInput{AccountID: 3, Name: "erp", Scopes: []string{"balance:read"}, Secret: "123456"},
+			stepUpErr: &common.UnauthorizedError{Msg: accountUsecase.TOTP_REQUIRED_ERROR},
+			wantErr:   &common.UnauthorizedError{},
+		},
+		{
+			name:    "should_refuse_more_active_keys_than_the_limit",
+			input:   types.APIKeyInput{AccountID: 3, Name: "erp", Scopes: []string{"balance:read"}, Secret: "123456"},
+			apiKeys: activeKeys,
+			wantErr: &common.LimitExceededError{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			accounts := &accountUsecase.AccountUsecaseMock{}
+			accounts.On("Get", mock.Anything, types.AccountInput{ID: 3}).Return(&entity.Account{ID: 3, Secret: util.GenerateHash("123456")}, nil)
+			accounts.On("StepUp", mock.Anything, uint(3), "").Return(tt.stepUpErr)
+
+			repo := &apiKeyRepository.APIKeyRepositoryMock{}
+			repo.On("GetAll", mock.Anything, uint(3)).Return(tt.apiKeys, nil)
+			repo.On("Create", mock.Anything, mock.Anything).Return(nil)
+
+			got, err := newUsecaseTest(repo, accounts).Create(context.Background(), tt.input)
+			if tt.wantErr != nil {
+				assertCode(t, err, tt.wantErr)
+				repo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+
+				return
+			}
+
+			assert.NilError(t, err)
+			assert.Assert(t, strings.HasPrefix(got.KeyID, API_KEY_ID_PREFIX))
+			assert.Assert(t, strings.HasPrefix(got.Secret, API_KEY_SECRET_PREFIX))
+			assert.Equal(t, util.GenerateHash(got.Secret), got.SecretHash)
+			assert.Equal(t, uint(3), got.AccountID)
+			assert.Equal(t, tt.wantExpiresAt, got.ExpiresAt)
+			if diff := cmp.Diff(tt.wantScopes, got.Scopes); diff != "" {
+				t.Errorf("scopes mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestAPIKeyUsecaseRevoke(t *testing.T) {
+	revokedAt := nowTest.Add(-time.Hour)
+
+	tests := []struct {
+		name          string
+		apiKey        *entity.APIKey
+		accountID     uint
+		wantErr       error
+		wantRevokedAt *time.Time
+		wantUpdate    bool
+	}{
+		{
+			name:          "should_revoke_a_key",
+			apiKey:        getAPIKeyTest("sk_test"),
+			accountID:     3,
+			wantRevokedAt: &nowTest,
+			wantUpdate:    true,
+		},
+		{
+			name: "should_keep_the_revocation_of_a_revoked_key",
+			apiKey: func() *entity.APIKey {
+				apiKey := getAPIKeyTest("sk_test")
+				apiKey.RevokedAt = &revokedAt
+
+				return apiKey
+			}(),
+			accountID:     3,
+			wantRevokedAt: &revokedAt,
+		},
+		{
+			name:      "should_not_find_keys_of_other_accounts",
+			apiKey:    getAPIKeyTest("sk_test"),
+			accountID: 4,
+			wantErr:   &common.NotFoundError{},
+		},
+		{
+			name:      "should_not_find_unknown_keys",
+			apiKey:    &entity.APIKey{},
+			accountID: 3,
+			wantErr:   &common.NotFoundError{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &apiKeyRepository.APIKeyRepositoryMock{}
+			repo.On("Get", mock.Anything, "ak_0123456789abcdef").Return(tt.apiKey, nil)
+			repo.On("Update", mock.Anything, tt.apiKey, []string{"revoked_at"}).Return(nil)
+
+			err := newUsecaseTest(repo, &accountUsecase.AccountUsecaseMock{}).Revoke(context.Background(), "ak_0123456789abcdef", tt.accountID)
+			if tt.wantErr != nil {
+				assertCode(t, err, tt.wantErr)
+
+				return
+			}
+
+			assert.NilError(t, err)
+			assert.Equal(t, *tt.wantRevokedAt, *tt.apiKey.RevokedAt)
+			if tt.wantUpdate {
+				repo.AssertCalled(t, "Update", mock.Anything, tt.apiKey, []string{"revoked_at"})
+			} else {
+				repo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything, mock.Anything)
+			}
+		})
+	}
+}
+
+func TestAPIKeyUsecaseAuthenticate(t *testing.T) {
+	revokedAt := nowTest.Add(-time.Hour)
+	recentlyUsedAt := nowTest.Add(-API_KEY_LAST_USED_PRECISION / 2)
+	usedAt := nowTest.Add(-API_KEY_LAST_USED_PRECISION)
+
+	tests := []struct {
+		name           string
+		apiKey         func() *entity.APIKey
+		secret         string
+		wantErr        error
+		wantErrMsg     string
+		wantLastUsedAt *time.Time
+	}{
+		{
+			name:           "should_authenticate_and_record_the_use",
+			apiKey:         func() *entity.APIKey { return getAPIKeyTest("sk_test") },
+			secret:         "sk_test",
+			wantLastUsedAt: &nowTest,
+		},
+		{
+			name: "should_not_record_a_recent_use_again",
+			apiKey: func() *entity.APIKey {
+				apiKey := getAPIKeyTest("sk_test")
+				apiKey.LastUsedAt = &recentlyUsedAt
+
+				return apiKey
+			},
+			secret:         "sk_test",
+			wantLastUsedAt: &recentlyUsedAt,
+		},
+		{
+			name: "should_record_an_older_use_again",
+			apiKey: func() *entity.APIKey {
+				apiKey := getAPIKeyTest("sk_test")
+				apiKey.LastUsedAt = &usedAt
+
+				return apiKey
+			},
+			secret:         "sk_test",
+			wantLastUsedAt: &nowTest,
+		},
+		{
+			name:       "should_refuse_a_wrong_secret",
+			apiKey:     func() *entity.APIKey { return getAPIKeyTest("sk_test") },
+			secret:     "sk_other",
+			wantErr:    &common.UnauthorizedError{},
+			wantErrMsg: INVALID_API_KEY_ERROR,
+		},
+		{
+			name:       "should_refuse_unknown_keys",
+			apiKey:     func() *entity.APIKey { return &entity.APIKey{} },
+			secret:     "",
+			wantErr:    &common.UnauthorizedError{},
+			wantErrMsg: INVALID_API_KEY_ERROR,
+		},
+		{
+			name: "should_refuse_revoked_keys",
+			apiKey: func() *entity.APIKey {
+				apiKey := getAPIKeyTest("sk_test")
+				apiKey.RevokedAt = &revokedAt
+
+				return apiKey
+			},
+			secret:     "sk_test",
+			wantErr:    &common.UnauthorizedError{},
+			wantErrMsg: API_KEY_REVOKED_ERROR,
+		},
+		{
+			name: "should_refuse_expired_keys",
+			apiKey: func() *entity.APIKey {
+				apiKey := getAPIKeyTest("sk_test")
+				apiKey.ExpiresAt = nowTest
+
+				return apiKey
+			},
+			secret:     "sk_test",
+			wantErr:    &common.UnauthorizedError{},
+			wantErrMsg: API_KEY_EXPIRED_ERROR,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			apiKey := tt.apiKey()
+			repo := &apiKeyRepository.APIKeyRepositoryMock{}
+			repo.On("Get", mock.Anything, "ak_0123456789abcdef").Return(apiKey, nil)
+			repo.On("Update", mock.Anything, apiKey, []string{"last_used_at"}).Return(nil)
+
+			got, err := newUsecaseTest(repo, &accountUsecase.AccountUsecaseMock{}).Authenticate(context.Background(), "ak_0123456789abcdef", tt.secret)
+			if tt.wantErr != nil {
+				assertCode(t, err, tt.wantErr)
+				assert.Equal(t, tt.wantErrMsg, err.Error())
+				repo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything, mock.Anything)
+
+				return
+			}
+
+			assert.NilError(t, err)
+			assert.Equal(t, apiKey, got)
+			assert.Equal(t, *tt.wantLastUsedAt, *got.LastUsedAt)
+		})
+	}
+}
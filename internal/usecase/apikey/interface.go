@@ -0,0 +1,15 @@
+package apikey
+
+import (
+	"context"
+
+	"github.com/fms85/desafio-tecnico-go-stone/internal/domain/entity"
+	"github.com/fms85/desafio-tecnico-go-stone/internal/domain/types"
+)
+
+type IAPIKeyUsecase interface {
+	Create(ctx context.Context, apiKeyInput types.APIKeyInput) (*types.APIKeyCredentials, error)
+	GetAll(ctx context.Context, accountID uint) ([]*entity.APIKey, error)
+	Revoke(ctx context.Context, keyID string, accountID uint) error
+	Authenticate(ctx context.Context, keyID string, secret string) (*entity.APIKey, error)
+}
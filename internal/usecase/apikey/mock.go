@@ -0,0 +1,123 @@
+// Code generated by mockery v2.33.0. DO NOT EDIT.
+
+package apikey
+
+import (
+	context "context"
+
+	entity "github.com/fms85/desafio-tecnico-go-stone/internal/domain/entity"
+	mock "github.com/stretchr/testify/mock"
+
+	types "github.com/fms85/desafio-tecnico-go-stone/internal/domain/types"
+)
+
+// APIKeyUsecaseMock is an autogenerated mock type for the IAPIKeyUsecase type
+type APIKeyUsecaseMock struct {
+	mock.Mock
+}
+
+// Authenticate provides a mock function with given fields: ctx, keyID, secret
+func (_m *APIKeyUsecaseMock) Authenticate(ctx context.Context, keyID string, secret string) (*entity.APIKey, error) {
+	ret := _m.Called(ctx, keyID, secret)
+
+	var r0 *entity.APIKey
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (*entity.APIKey, error)); ok {
+		return rf(ctx, keyID, secret)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) *entity.APIKey); ok {
+		r0 = rf(ctx, keyID, secret)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.APIKey)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, keyID, secret)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Create provides a mock function with given fields: ctx, apiKeyInput
+func (_m *APIKeyUsecaseMock) Create(ctx context.Context, apiKeyInput types.APIKeyInput) (*types.APIKeyCredentials, error) {
+	ret := _m.Called(ctx, apiKeyInput)
+
+	var r0 *types.APIKeyCredentials
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, types.APIKeyInput) (*types.APIKeyCredentials, error)); ok {
+		return rf(ctx, apiKeyInput)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, types.APIKeyInput) *types.APIKeyCredentials); ok {
+		r0 = rf(ctx, apiKeyInput)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*types.APIKeyCredentials)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, types.APIKeyInput) error); ok {
+		r1 = rf(ctx, apiKeyInput)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetAll provides a mock function with given fields: ctx, accountID
+func (_m *APIKeyUsecaseMock) GetAll(ctx context.Context, accountID uint) ([]*entity.APIKey, error) {
+	ret := _m.Called(ctx, accountID)
+
+	var r0 []*entity.APIKey
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint) ([]*entity.APIKey, error)); ok {
+		return rf(ctx, accountID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint) []*entity.APIKey); ok {
+		r0 = rf(ctx, accountID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*entity.APIKey)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint) error); ok {
+		r1 = rf(ctx, accountID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Revoke provides a mock function with given fields: ctx, keyID, accountID
+func (_m *APIKeyUsecaseMock) Revoke(ctx context.Context, keyID string, accountID uint) error {
+	ret := _m.Called(ctx, keyID, accountID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, uint) error); ok {
+		r0 = rf(ctx, keyID, accountID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NewAPIKeyUsecaseMock creates a new instance of APIKeyUsecaseMock. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewAPIKeyUsecaseMock(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *APIKeyUsecaseMock {
+	mock := &APIKeyUsecaseMock{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,221 @@
+package billpayment
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/fms85/desafio-tecnico-go-stone/internal/domain/common"
+	"github.com/fms85/desafio-tecnico-go-stone/internal/domain/entity"
+	"github.com/fms85/desafio-tecnico-go-stone/internal/domain/types"
+	"github.com/fms85/desafio-tecnico-go-stone/internal/driver/billnetwork"
+	otelDriver "github.com/fms85/desafio-tecnico-go-stone/internal/driver/otel"
+	billPaymentRepository "github.com/fms85/desafio-tecnico-go-stone/internal/repository/billpayment"
+	transferUsecase "github.com/fms85/desafio-tecnico-go-stone/internal/usecase/transfer"
+	"github.com/fms85/desafio-tecnico-go-stone/internal/util"
+	"go.opentelemetry.io/otel"
+)
+
+const BOLETO_DATE_FORMAT = "2006-01-02"
+
+const (
+	BILL_PAYMENT_NOT_FOUND   = "bill payment"
+	INVALID_BOLETO_ERROR     = "invalid boleto"
+	BOLETO_OVERDUE_ERROR     = "boleto is overdue"
+	AMOUNT_REQUIRED_ERROR    = "boleto has no amount, inform one"
+	AMOUNT_MISMATCH_ERROR    = "amount differs from the boleto amount"
+	BILL_DECLINED_ERROR      = "bill payment declined by the payment network"
+	SETTLEMENT_NOT_SET_ERROR = "bill settlement account is not configured"
+)
+
+var tracer = otel.Tracer("internal/usecase/billpayment")
+
+type billPaymentUsecase struct {
+	billPaymentRepository billPaymentRepository.IBillPaymentRepository
+	transferUsecase       transferUsecase.ITransferUsecase
+	network               billnetwork.INetwork
+	settlementAccountID   uint
+	now                   func() time.Time
+}
+
+// New pays bills through network. Payments are debited to the account
+// settlementAccountID, which holds them until the network settles them.
+func New(billPaymentRepository billPaymentRepository.IBillPaymentRepository, transferUsecase transferUsecase.ITransferUsecase, network billnetwork.INetwork, settlementAccountID uint) IBillPaymentUsecase {
+	return &billPaymentUsecase{
+		billPaymentRepository: billPaymentRepository,
+		transferUsecase:       transferUsecase,
+		network:               network,
+		settlementAccountID:   settlementAccountID,
+		now:                   time.Now,
+	}
+}
+
+func (usecase *billPaymentUsecase) Parse(ctx context.Context, code string) (boleto *types.Boleto, err error) {
+	_, span := tracer.Start(ctx, "billPaymentUsecase.Parse")
+	defer func() { otelDriver.End(span, err) }()
+
+	return usecase.parse(code)
+}
+
+// Pay debits a boleto to the settlement account and has the network settle
+// it. A declined bill is refunded. Any other network failure leaves the
+// payment pending, as the bill may have been paid all the same. The account
+// authorizes the amount paid as it would a transfer.
+func (usecase *billPaymentUsecase) Pay(ctx context.Context, paymentInput types.BillPaymentInput) (payment *entity.BillPayment, err error) {
+	ctx, span := tracer.Start(ctx, "billPaymentUsecase.Pay")
+	defer func() { otelDriver.End(span, err) }()
+
+	if usecase.settlementAccountID == 0 {
+		return nil, errors.New(SETTLEMENT_NOT_SET_ERROR)
+	}
+
+	boleto, err := usecase.parse(paymentInput.Code)
+	if err != nil {
+		return nil, err
+	}
+
+	if boleto.Overdue {
+		return nil, &common.ValidationError{Msg: BOLETO_OVERDUE_ERROR}
+	}
+
+	amount := boleto.Amount
+	switch {
+	case amount == 0 && paymentInput.Amount == 0:
+		return nil, &common.ValidationError{Msg: AMOUNT_REQUIRED_ERROR}
+	case amount == 0:
+		amount = paymentInput.Amount
+	case paymentInput.Amount != 0 && paymentInput.Amount != amount:
+		return nil, &common.ValidationError{Msg: AMOUNT_MISMATCH_ERROR}
+	}
+
+	existing, err := usecase.billPaymentRepository.GetByBarcode(ctx, boleto.Barcode)
+	if err != nil {
+		return nil, err
+	}
+
+	if existing.ID != 0 {
+		return nil, fmt.Errorf("bill payment %w", &common.ConflictError{})
+	}
+
+	if err := usecase.transferUsecase.Authorize(ctx, paymentInput.AccountID, amount, paymentInput.Authorization); err != nil {
+		return nil, err
+	}
+
+	payment = &entity.BillPayment{
+		AccountID:     paymentInput.AccountID,
+		Type:          boleto.Type,
+		Barcode:       boleto.Barcode,
+		DigitableLine: boleto.DigitableLine,
+		Amount:        amount,
+		DueDate:       boleto.DueDate,
+		Status:        entity.BILL_PAYMENT_STATUS_PENDING,
+	}
+
+	// The payment is created with the debit, so a barcode paid meanwhile
+	// rolls the debit back with the conflict.
+	if _, err := usecase.transferUsecase.CreateWith(ctx, types.TransferInput{
+		AccountOriginID:      paymentInput.AccountID,
+		AccountDestinationID: usecase.settlementAccountID,
+		Amount:               amount,
+	}, func(ctx context.Context, transfer *entity.Transfer) error {
+		payment.TransferID = transfer.ID
+
+		return usecase.billPaymentRepository.Create(ctx, payment)
+	}); err != nil {
+		return nil, err
+	}
+
+	settlement, err := usecase.network.Pay(ctx, billnetwork.Bill{
+		PaymentID: payment.ID,
+		Barcode:   payment.Barcode,
+		Amount:    payment.Amount,
+	})
+	if errors.Is(err, billnetwork.ErrDeclined) {
+		return nil, usecase.refund(ctx, payment)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	payment.Status = entity.BILL_PAYMENT_STATUS_COMPLETED
+	payment.Authentication = settlement.Authentication
+	payment.SettledAt = &settlement.SettledAt
+
+	if err := usecase.billPaymentRepository.Save(ctx, payment); err != nil {
+		return nil, err
+	}
+
+	return payment, nil
+}
+
+func (usecase *billPaymentUsecase) GetAll(ctx context.Context, accountID uint) (payments []*entity.BillPayment, err error) {
+	ctx, span := tracer.Start(ctx, "billPaymentUsecase.GetAll")
+	defer func() { otelDriver.End(span, err) }()
+
+	return usecase.billPaymentRepository.GetAll(ctx, accountID)
+}
+
+func (usecase *billPaymentUsecase) Get(ctx context.Context, paymentID uint, accountID uint) (payment *entity.BillPayment, err error) {
+	ctx, span := tracer.Start(ctx, "billPaymentUsecase.Get")
+	defer func() { otelDriver.End(span, err) }()
+
+	payment, err = usecase.billPaymentRepository.Get(ctx, paymentID)
+	if err != nil {
+		return nil, err
+	}
+
+	if payment.ID == 0 || payment.AccountID != accountID {
+		return nil, fmt.Errorf("%s %w", BILL_PAYMENT_NOT_FOUND, &common.NotFoundError{})
+	}
+
+	return payment, nil
+}
+
+// refund gives a declined payment back to the account and returns the error
+// telling it so.
+func (usecase *billPaymentUsecase) refund(ctx context.Context, payment *entity.BillPayment) error {
+	transfer, err := usecase.transferUsecase.Create(ctx, types.TransferInput{
+		AccountOriginID:      usecase.settlementAccountID,
+		AccountDestinationID: payment.AccountID,
+		Amount:               payment.Amount,
+	})
+	if err != nil {
+		return err
+	}
+
+	payment.Status = entity.BILL_PAYMENT_STATUS_REFUNDED
+	payment.RefundTransferID = &transfer.ID
+
+	if err := usecase.billPaymentRepository.Save(ctx, payment); err != nil {
+		return err
+	}
+
+	return &common.ValidationError{Msg: BILL_DECLINED_ERROR}
+}
+
+func (usecase *billPaymentUsecase) parse(code string) (*types.Boleto, error) {
+	today := usecase.now().In(types.RECEIPT_LOCATION)
+
+	parsed, err := util.ParseBoleto(code, today)
+	if err != nil {
+		return nil, &common.ValidationError{Msg: INVALID_BOLETO_ERROR}
+	}
+
+	boleto := &types.Boleto{
+		Type:          parsed.Type,
+		Barcode:       parsed.Barcode,
+		DigitableLine: parsed.DigitableLine,
+		BankCode:      parsed.BankCode,
+		Segment:       parsed.Segment,
+		Amount:        parsed.Amount,
+	}
+
+	if parsed.DueDate != nil {
+		boleto.DueDate = parsed.DueDate.Format(BOLETO_DATE_FORMAT)
+		boleto.Overdue = boleto.DueDate < today.Format(BOLETO_DATE_FORMAT)
+	}
+
+	return boleto, nil
+}
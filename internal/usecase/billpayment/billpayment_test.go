@@ -0,0 +1,330 @@
+package billpayment
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/fms85/desafio-tecnico-go-stone/internal/domain/common"
+	"github.com/fms85/desafio-tecnico-go-stone/internal/domain/entity"
+	"github.com/fms85/desafio-tecnico-go-stone/internal/domain/types"
+	"github.com/fms85/desafio-tecnico-go-stone/internal/driver/billnetwork"
+	billPaymentRepository "github.com/fms85/desafio-tecnico-go-stone/internal/repository/billpayment"
+	transferUsecase "github.com/fms85/desafio-tecnico-go-stone/internal/usecase/transfer"
+	"github.com/stretchr/testify/mock"
+	"gotest.tools/assert"
+)
+
+// nowTest is 2026-10-19 09:00 in America/Sao_Paulo.
+var nowTest = time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+
+const (
+	// bankBarcodeTest is a bank boleto of 150.00 due 2026-11-10.
+	bankBarcodeTest = "23797162600000150003381260007827139500006330"
+	// overdueBarcodeTest is a bank boleto of 3700.00 due 2018-06-11.
+	overdueBarcodeTest = "23799755200003700003381260007827139500006330"
+	// convenioLineTest is a convenio boleto of 143.59 without due date.
+	convenioLineTest = "84670000001-7 43590024020-9 02405000243-5 84221010811-9"
+	// referenceBarcodeTest is a convenio boleto that does not carry its amount.
+	referenceBarcodeTest = "84770000000000000240200240500024384221010811"
+)
+
+const settlementAccountIDTest = 1
+
+func newUsecaseTest(repo billPaymentRepository.IBillPaymentRepository, transfer transferUsecase.ITransferUsecase, network billnetwork.INetwork) *billPaymentUsecase {
+	usecase := New(repo, transfer, network, settlementAccountIDTest).(*billPaymentUsecase)
+	usecase.now = func() time.Time { return nowTest }
+
+	return usecase
+}
+
+func assertCode(t *testing.T, err error, want error) {
+	t.Helper()
+
+	var codedError common.CodedError
+	if !errors.As(err, &codedError) || codedError.Code() != want.(common.CodedError).Code() {
+		t.Fatalf("error = %v, want %v", err, want)
+	}
+}
+
+func TestBillPaymentUsecaseParse(t *testing.T) {
+	tests := []struct {
+		name    string
+		code    string
+		want    *types.Boleto
+		wantErr error
+	}{
+		{
+			name: "should_parse_a_bank_boleto",
+			code: bankBarcodeTest,
+			want: &types.Boleto{
+				Type:          "bank",
+				Barcode:       bankBarcodeTest,
+				DigitableLine: "23793381286000782713695000063305716260000015000",
+				BankCode:      "237",
+				Amount:        150,
+				DueDate:       "2026-11-10",
+			},
+		},
+		{
+			name: "should_flag_overdue_boletos",
+			code: overdueBarcodeTest,
+			want: &types.Boleto{
+				Type:          "bank",
+				Barcode:       overdueBarcodeTest,
+				DigitableLine: "23793381286000782713695000063305975520000370000",
+				BankCode:      "237",
+				Amount:        3700,
+				DueDate:       "2018-06-11",
+				Overdue:       true,
+			},
+		},
+		{
+			name:    "should_reject_a_wrong_check_digit",
+			code:    "84670000001-7 43590024020-8 02405000243-5 84221010811-9",
+			wantErr: &common.ValidationError{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			usecase := newUsecaseTest(&billPaymentRepository.BillPaymentRepositoryMock{}, &transferUsecase.TransferUsecaseMock{}, &billnetwork.MemoryNetwork{})
+
+			got, err := usecase.Parse(context.Background(), tt.code)
+
+			if tt.wantErr != nil {
+				assertCode(t, err, tt.wantErr)
+				return
+			}
+
+			assert.NilError(t, err)
+			assert.DeepEqual(t, tt.want, got)
+		})
+	}
+}
+
+func TestBillPaymentUsecasePay(t *testing.T) {
+	tests := []struct {
+		name         string
+		input        types.BillPaymentInput
+		existing     *entity.BillPayment
+		authorizeErr error
+		createErr    error
+		networkErr   error
+		wantAmount   float64
+		wantStatus   string
+		wantErr      error
+	}{
+		{
+			name:       "should_pay_the_boleto_amount",
+			input:      types.BillPaymentInput{AccountID: 3, Code: convenioLineTest},
+			wantAmount: 143.59,
+			wantStatus: entity.BILL_PAYMENT_STATUS_COMPLETED,
+		},
+		{
+			name:       "should_accept_the_boleto_amount_informed",
+			input:      types.BillPaymentInput{AccountID: 3, Code: bankBarcodeTest, Amount: 150},
+			wantAmount: 150,
+			wantStatus: entity.BILL_PAYMENT_STATUS_COMPLETED,
+		},
+		{
+			name:       "should_pay_the_informed_amount_of_boletos_without_one",
+			input:      types.BillPaymentInput{AccountID: 3, Code: referenceBarcodeTest, Amount: 80},
+			wantAmount: 80,
+			wantStatus: entity.BILL_PAYMENT_STATUS_COMPLETED,
+		},
+		{
+			name:    "should_require_an_amount_for_boletos_without_one",
+			input:   types.BillPaymentInput{AccountID: 3, Code: referenceBarcodeTest},
+			wantErr: &common.ValidationError{},
+		},
+		{
+			name:    "should_reject_an_amount_other_than_the_boleto_one",
+			input:   types.BillPaymentInput{AccountID: 3, Code: bankBarcodeTest, Amount: 100},
+			wantErr: &common.ValidationError{},
+		},
+		{
+			name:    "should_reject_overdue_boletos",
+			input:   types.BillPaymentInput{AccountID: 3, Code: overdueBarcodeTest},
+			wantErr: &common.ValidationError{},
+		},
+		{
+			name:     "should_reject_boletos_already_paid",
+			input:    types.BillPaymentInput{AccountID: 3, Code: bankBarcodeTest},
+			existing: &entity.BillPayment{ID: 8, Barcode: bankBarcodeTest, Status: entity.BILL_PAYMENT_STATUS_COMPLETED},
+			wantErr:  &common.ConflictError{},
+		},
+		{
+			name:         "should_refuse_a_payment_without_the_pin",
+			input:        types.BillPaymentInput{AccountID: 3, Code: bankBarcodeTest},
+			authorizeErr: &common.UnauthorizedError{},
+			wantErr:      &common.UnauthorizedError{},
+		},
+		{
+			name:         "should_refuse_a_payment_with_a_wrong_pin",
+			input:        types.BillPaymentInput{AccountID: 3, Code: bankBarcodeTest, Authorization: types.TransferAuthorization{PIN: "1234"}},
+			authorizeErr: &common.ValidationError{},
+			wantErr:      &common.ValidationError{},
+		},
+		{
+			name:       "should_roll_back_the_debit_of_boletos_paid_meanwhile",
+			input:      types.BillPaymentInput{AccountID: 3, Code: bankBarcodeTest},
+			createErr:  fmt.Errorf("bill payment %w", &common.ConflictError{}),
+			wantAmount: 150,
+			wantErr:    &common.ConflictError{},
+		},
+		{
+			name:       "should_refund_declined_bills",
+			input:      types.BillPaymentInput{AccountID: 3, Code: bankBarcodeTest},
+			networkErr: billnetwork.ErrDeclined,
+			wantAmount: 150,
+			wantStatus: entity.BILL_PAYMENT_STATUS_REFUNDED,
+			wantErr:    &common.ValidationError{},
+		},
+		{
+			name:       "should_leave_the_payment_pending_when_the_network_fails",
+			input:      types.BillPaymentInput{AccountID: 3, Code: bankBarcodeTest},
+			networkErr: errors.New("timeout"),
+			wantAmount: 150,
+			wantStatus: entity.BILL_PAYMENT_STATUS_PENDING,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			existing := tt.existing
+			if existing == nil {
+				existing = &entity.BillPayment{}
+			}
+
+			var saved *entity.BillPayment
+
+			repo := &billPaymentRepository.BillPaymentRepositoryMock{}
+			repo.On("GetByBarcode", mock.Anything, mock.Anything).Return(existing, nil)
+			repo.On("Create", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+				if tt.createErr == nil {
+					saved = args.Get(1).(*entity.BillPayment)
+					saved.ID = 9
+				}
+			}).Return(tt.createErr)
+			repo.On("Save", mock.Anything, mock.Anything).Return(nil)
+
+			transfer := &transferUsecase.TransferUsecaseMock{}
+			transfer.On("Authorize", mock.Anything, uint(3), mock.Anything, tt.input.Authorization).Return(tt.authorizeErr)
+			transfer.On("CreateWith", mock.Anything, types.TransferInput{AccountOriginID: 3, AccountDestinationID: settlementAccountIDTest, Amount: tt.wantAmount}, mock.Anything).
+				Return(func(ctx context.Context, _ types.TransferInput, record types.TransferRecord) (*entity.Transfer, error) {
+					transfer := &entity.Transfer{ID: 21}
+					if err := record(ctx, transfer); err != nil {
+						return nil, err
+					}
+
+					return transfer, nil
+				})
+			transfer.On("Create", mock.Anything, types.TransferInput{AccountOriginID: settlementAccountIDTest, AccountDestinationID: 3, Amount: tt.wantAmount}).
+				Return(&entity.Transfer{ID: 22}, nil)
+
+			network := &billnetwork.MemoryNetwork{Err: tt.networkErr}
+
+			got, err := newUsecaseTest(repo, transfer, network).Pay(context.Background(), tt.input)
+
+			if tt.wantStatus == "" {
+				assertCode(t, err, tt.wantErr)
+				assert.Assert(t, saved == nil)
+				assert.Equal(t, 0, len(network.Bills()))
+				if tt.createErr == nil {
+					transfer.AssertNotCalled(t, "CreateWith", mock.Anything, mock.Anything, mock.Anything)
+				}
+
+				return
+			}
+
+			assert.Equal(t, tt.wantStatus, saved.Status)
+			assert.Equal(t, tt.wantAmount, saved.Amount)
+			assert.Equal(t, uint(21), saved.TransferID)
+
+			switch tt.wantStatus {
+			case entity.BILL_PAYMENT_STATUS_COMPLETED:
+				assert.NilError(t, err)
+				assert.Equal(t, saved, got)
+				assert.Equal(t, "MEMORY", got.Authentication)
+				assert.DeepEqual(t, []billnetwork.Bill{{PaymentID: 9, Barcode: got.Barcode, Amount: tt.wantAmount}}, network.Bills())
+			case entity.BILL_PAYMENT_STATUS_REFUNDED:
+				assertCode(t, err, tt.wantErr)
+				assert.Equal(t, uint(22), *saved.RefundTransferID)
+			default:
+				assert.ErrorContains(t, err, "timeout")
+				repo.AssertNotCalled(t, "Save", mock.Anything, mock.Anything)
+			}
+		})
+	}
+}
+
+func TestBillPaymentUsecasePayStepsUpTheBoletoAmount(t *testing.T) {
+	authorization := types.TransferAuthorization{PIN: "4321"}
+
+	repo := &billPaymentRepository.BillPaymentRepositoryMock{}
+	repo.On("GetByBarcode", mock.Anything, mock.Anything).Return(&entity.BillPayment{}, nil)
+
+	// Without an amount, the step-up is up to the amount of the boleto.
+	transfer := &transferUsecase.TransferUsecaseMock{}
+	transfer.On("Authorize", mock.Anything, uint(3), 143.59, authorization).Return(&common.UnauthorizedError{Msg: "totp code required"})
+
+	network := &billnetwork.MemoryNetwork{}
+
+	_, err := newUsecaseTest(repo, transfer, network).Pay(context.Background(), types.BillPaymentInput{AccountID: 3, Code: convenioLineTest, Authorization: authorization})
+
+	assertCode(t, err, &common.UnauthorizedError{})
+	transfer.AssertNotCalled(t, "CreateWith", mock.Anything, mock.Anything, mock.Anything)
+	assert.Equal(t, 0, len(network.Bills()))
+}
+
+func TestBillPaymentUsecasePayWithoutSettlementAccount(t *testing.T) {
+	usecase := newUsecaseTest(&billPaymentRepository.BillPaymentRepositoryMock{}, &transferUsecase.TransferUsecaseMock{}, &billnetwork.MemoryNetwork{})
+	usecase.settlementAccountID = 0
+
+	_, err := usecase.Pay(context.Background(), types.BillPaymentInput{AccountID: 3, Code: bankBarcodeTest})
+
+	assert.Error(t, err, SETTLEMENT_NOT_SET_ERROR)
+}
+
+func TestBillPaymentUsecaseGet(t *testing.T) {
+	tests := []struct {
+		name    string
+		payment *entity.BillPayment
+		wantErr error
+	}{
+		{
+			name:    "should_return_a_payment_of_the_account",
+			payment: &entity.BillPayment{ID: 9, AccountID: 3},
+		},
+		{
+			name:    "should_hide_payments_of_other_accounts",
+			payment: &entity.BillPayment{ID: 9, AccountID: 4},
+			wantErr: &common.NotFoundError{},
+		},
+		{
+			name:    "should_return_not_found_for_unknown_ids",
+			payment: &entity.BillPayment{},
+			wantErr: &common.NotFoundError{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &billPaymentRepository.BillPaymentRepositoryMock{}
+			repo.On("Get", mock.Anything, uint(9)).Return(tt.payment, nil)
+
+			got, err := newUsecaseTest(repo, &transferUsecase.TransferUsecaseMock{}, &billnetwork.MemoryNetwork{}).Get(context.Background(), 9, 3)
+
+			if tt.wantErr != nil {
+				assertCode(t, err, tt.wantErr)
+				return
+			}
+
+			assert.NilError(t, err)
+			assert.Equal(t, tt.payment, got)
+		})
+	}
+}
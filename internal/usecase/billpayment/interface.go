@@ -0,0 +1,15 @@
+package billpayment
+
+import (
+	"context"
+
+	"github.com/fms85/desafio-tecnico-go-stone/internal/domain/entity"
+	"github.com/fms85/desafio-tecnico-go-stone/internal/domain/types"
+)
+
+type IBillPaymentUsecase interface {
+	Parse(ctx context.Context, code string) (*types.Boleto, error)
+	Pay(ctx context.Context, paymentInput types.BillPaymentInput) (*entity.BillPayment, error)
+	GetAll(ctx context.Context, accountID uint) ([]*entity.BillPayment, error)
+	Get(ctx context.Context, paymentID uint, accountID uint) (*entity.BillPayment, error)
+}
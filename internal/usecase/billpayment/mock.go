@@ -0,0 +1,135 @@
+// Code generated by mockery v2.33.0. DO NOT EDIT.
+
+package billpayment
+
+import (
+	context "context"
+
+	entity "github.com/fms85/desafio-tecnico-go-stone/internal/domain/entity"
+	mock "github.com/stretchr/testify/mock"
+
+	types "github.com/fms85/desafio-tecnico-go-stone/internal/domain/types"
+)
+
+// BillPaymentUsecaseMock is an autogenerated mock type for the IBillPaymentUsecase type
+type BillPaymentUsecaseMock struct {
+	mock.Mock
+}
+
+// Get provides a mock function with given fields: ctx, paymentID, accountID
+func (_m *BillPaymentUsecaseMock) Get(ctx context.Context, paymentID uint, accountID uint) (*entity.BillPayment, error) {
+	ret := _m.Called(ctx, paymentID, accountID)
+
+	var r0 *entity.BillPayment
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint, uint) (*entity.BillPayment, error)); ok {
+		return rf(ctx, paymentID, accountID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint, uint) *entity.BillPayment); ok {
+		r0 = rf(ctx, paymentID, accountID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.BillPayment)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint, uint) error); ok {
+		r1 = rf(ctx, paymentID, accountID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetAll provides a mock function with given fields: ctx, accountID
+func (_m *BillPaymentUsecaseMock) GetAll(ctx context.Context, accountID uint) ([]*entity.BillPayment, error) {
+	ret := _m.Called(ctx, accountID)
+
+	var r0 []*entity.BillPayment
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint) ([]*entity.BillPayment, error)); ok {
+		return rf(ctx, accountID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint) []*entity.BillPayment); ok {
+		r0 = rf(ctx, accountID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*entity.BillPayment)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint) error); ok {
+		r1 = rf(ctx, accountID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Parse provides a mock function with given fields: ctx, code
+func (_m *BillPaymentUsecaseMock) Parse(ctx context.Context, code string) (*types.Boleto, error) {
+	ret := _m.Called(ctx, code)
+
+	var r0 *types.Boleto
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*types.Boleto, error)); ok {
+		return rf(ctx, code)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *types.Boleto); ok {
+		r0 = rf(ctx, code)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*types.Boleto)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, code)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Pay provides a mock function with given fields: ctx, paymentInput
+func (_m *BillPaymentUsecaseMock) Pay(ctx context.Context, paymentInput types.BillPaymentInput) (*entity.BillPayment, error) {
+	ret := _m.Called(ctx, paymentInput)
+
+	var r0 *entity.BillPayment
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, types.BillPaymentInput) (*entity.BillPayment, error)); ok {
+		return rf(ctx, paymentInput)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, types.BillPaymentInput) *entity.BillPayment); ok {
+		r0 = rf(ctx, paymentInput)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.BillPayment)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, types.BillPaymentInput) error); ok {
+		r1 = rf(ctx, paymentInput)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewBillPaymentUsecaseMock creates a new instance of BillPaymentUsecaseMock. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewBillPaymentUsecaseMock(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *BillPaymentUsecaseMock {
+	mock := &BillPaymentUsecaseMock{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
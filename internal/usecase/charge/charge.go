@@ -0,0 +1,309 @@
+package charge
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/fms85/desafio-tecnico-go-stone/internal/domain/common"
+	"github.com/fms85/desafio-tecnico-go-stone/internal/domain/entity"
+	"github.com/fms85/desafio-tecnico-go-stone/internal/domain/types"
+	otelDriver "github.com/fms85/desafio-tecnico-go-stone/internal/driver/otel"
+	chargeRepository "github.com/fms85/desafio-tecnico-go-stone/internal/repository/charge"
+	accountUsecase "github.com/fms85/desafio-tecnico-go-stone/internal/usecase/account"
+	transferUsecase "github.com/fms85/desafio-tecnico-go-stone/internal/usecase/transfer"
+	"github.com/fms85/desafio-tecnico-go-stone/internal/util"
+	"go.opentelemetry.io/otel"
+)
+
+const CHARGE_DATE_FORMAT = "2006-01-02"
+
+const (
+	CHARGE_NOT_FOUND            = "charge"
+	PAYER_NOT_FOUND             = "payer account"
+	SAME_ACCOUNT_ERROR          = "charger and payer accounts are equal"
+	INVALID_DUE_DATE_ERROR      = "invalid due date"
+	DUE_DATE_PAST_ERROR         = "due date is in the past"
+	MIN_PARTIAL_AMOUNT_ERROR    = "min partial amount is greater than the amount"
+	CHARGE_NOT_OPEN_ERROR       = "charge is not open"
+	CHARGE_EXPIRED_ERROR        = "charge expired"
+	ONLY_PAYER_ERROR            = "only the payer can pay or decline a charge"
+	PARTIAL_NOT_ALLOWED_ERROR   = "charge does not allow partial payments"
+	AMOUNT_OVER_REMAINING_ERROR = "amount is greater than what is left to pay"
+	AMOUNT_UNDER_MIN_ERROR      = "amount is less than the min partial amount"
+)
+
+var (
+	tracer        = otel.Tracer("internal/usecase/charge")
+	notFoundError *common.NotFoundError
+)
+
+type chargeUsecase struct {
+	chargeRepository chargeRepository.IChargeRepository
+	accountUsecase   accountUsecase.IAccountUsecase
+	transferUsecase  transferUsecase.ITransferUsecase
+	now              func() time.Time
+}
+
+func New(chargeRepository chargeRepository.IChargeRepository, accountUsecase accountUsecase.IAccountUsecase, transferUsecase transferUsecase.ITransferUsecase) IChargeUsecase {
+	return &chargeUsecase{
+		chargeRepository: chargeRepository,
+		accountUsecase:   accountUsecase,
+		transferUsecase:  transferUsecase,
+		now:              time.Now,
+	}
+}
+
+func (usecase *chargeUsecase) Create(ctx context.Context, chargeInput types.ChargeInput) (charge *entity.Charge, err error) {
+	ctx, span := tracer.Start(ctx, "chargeUsecase.Create")
+	defer func() { otelDriver.End(span, err) }()
+
+	if chargeInput.ChargerAccountID == chargeInput.PayerAccountID {
+		return nil, &common.ValidationError{Msg: SAME_ACCOUNT_ERROR}
+	}
+
+	dueDate, err := time.ParseInLocation(CHARGE_DATE_FORMAT, chargeInput.DueDate, types.RECEIPT_LOCATION)
+	if err != nil {
+		return nil, &common.ValidationError{Msg: INVALID_DUE_DATE_ERROR}
+	}
+
+	today := usecase.now().In(types.RECEIPT_LOCATION).Format(CHARGE_DATE_FORMAT)
+	if chargeInput.DueDate < today {
+		return nil, &common.ValidationError{Msg: DUE_DATE_PAST_ERROR}
+	}
+
+	if chargeInput.AllowPartial && chargeInput.MinPartialAmount > chargeInput.Amount {
+		return nil, &common.ValidationError{Msg: MIN_PARTIAL_AMOUNT_ERROR}
+	}
+
+	if _, err := usecase.accountUsecase.Get(ctx, types.AccountInput{ID: chargeInput.PayerAccountID}); err != nil {
+		if errors.As(err, &notFoundError) {
+			return nil, fmt.Errorf("%s %w", PAYER_NOT_FOUND, &common.NotFoundError{})
+		}
+
+		return nil, err
+	}
+
+	charge = &entity.Charge{
+		ChargerAccountID: chargeInput.ChargerAccountID,
+		PayerAccountID:   chargeInput.PayerAccountID,
+		Amount:           chargeInput.Amount,
+		Description:      chargeInput.Description,
+		DueDate:          chargeInput.DueDate,
+		ExpiresAt:        dueDate.AddDate(0, 0, chargeInput.GraceDays+1),
+		AllowPartial:     chargeInput.AllowPartial,
+		Status:           entity.CHARGE_STATUS_PENDING,
+	}
+
+	if chargeInput.AllowPartial {
+		charge.MinPartialAmount = chargeInput.MinPartialAmount
+	}
+
+	if err := usecase.chargeRepository.Create(ctx, charge); err != nil {
+		return nil, err
+	}
+
+	return charge, nil
+}
+
+// GetAll lists charges newest first. Open charges past their expiry are
+// reported as expired even before anyone tries to pay them.
+func (usecase *chargeUsecase) GetAll(ctx context.Context, filterInput types.ChargeFilterInput) (charges []*entity.Charge, err error) {
+	ctx, span := tracer.Start(ctx, "chargeUsecase.GetAll")
+	defer func() { otelDriver.End(span, err) }()
+
+	all, err := usecase.chargeRepository.GetAll(ctx, filterInput.AccountID, filterInput.Role)
+	if err != nil {
+		return nil, err
+	}
+
+	charges = []*entity.Charge{}
+	for _, charge := range all {
+		usecase.expire(charge)
+
+		if filterInput.Status == "" || charge.Status == filterInput.Status {
+			charges = append(charges, charge)
+		}
+	}
+
+	return charges, nil
+}
+
+func (usecase *chargeUsecase) Get(ctx context.Context, chargeID uint, accountID uint) (charge *entity.Charge, err error) {
+	ctx, span := tracer.Start(ctx, "chargeUsecase.Get")
+	defer func() { otelDriver.End(span, err) }()
+
+	charge, err = usecase.get(ctx, chargeID, accountID)
+	if err != nil {
+		return nil, err
+	}
+
+	usecase.expire(charge)
+
+	return charge, nil
+}
+
+// Pay sends a transfer from the payer to the charger and links it to the
+// charge, which is paid once nothing is left. The payment is checked again
+// against the charge locked in the transaction of the transfer, so parallel
+// payments, or one checked against a lagging replica, cannot pay more than
+// the charge; the transfer is rolled back when it no longer fits. The payer
+// authorizes the amount paid as it would a transfer.
+func (usecase *chargeUsecase) Pay(ctx context.Context, paymentInput types.ChargePaymentInput) (charge *entity.Charge, err error) {
+	ctx, span := tracer.Start(ctx, "chargeUsecase.Pay")
+	defer func() { otelDriver.End(span, err) }()
+
+	charge, err = usecase.getOpen(ctx, paymentInput.ChargeID, paymentInput.PayerAccountID)
+	if err != nil {
+		return nil, err
+	}
+
+	amount := paymentInput.Amount
+	if amount == 0 {
+		amount = util.Sub(charge.Amount, charge.PaidAmount)
+	}
+
+	if err := checkPayment(charge, amount); err != nil {
+		return nil, err
+	}
+
+	if err := usecase.transferUsecase.Authorize(ctx, charge.PayerAccountID, amount, paymentInput.Authorization); err != nil {
+		return nil, err
+	}
+
+	payment := &entity.ChargePayment{ChargeID: charge.ID, Amount: amount}
+
+	if _, err := usecase.transferUsecase.CreateWith(ctx, types.TransferInput{
+		AccountOriginID:      charge.PayerAccountID,
+		AccountDestinationID: charge.ChargerAccountID,
+		Amount:               amount,
+	}, func(ctx context.Context, transfer *entity.Transfer) error {
+		locked, err := usecase.chargeRepository.GetForUpdate(ctx, charge.ID)
+		if err != nil {
+			return err
+		}
+
+		if err := checkPayment(locked, amount); err != nil {
+			return err
+		}
+
+		payment.TransferID = transfer.ID
+		charge.PaidAmount = util.Sum(locked.PaidAmount, amount)
+		charge.Status = entity.CHARGE_STATUS_PARTIALLY_PAID
+		if charge.PaidAmount >= charge.Amount {
+			charge.Status = entity.CHARGE_STATUS_PAID
+		}
+
+		return usecase.chargeRepository.AddPayment(ctx, charge, payment)
+	}); err != nil {
+		return nil, err
+	}
+
+	charge.Payments = append(charge.Payments, payment)
+
+	return charge, nil
+}
+
+// Decline refuses what is left of a charge. Payments already made stay with
+// the charger.
+func (usecase *chargeUsecase) Decline(ctx context.Context, chargeID uint, accountID uint) (charge *entity.Charge, err error) {
+	ctx, span := tracer.Start(ctx, "chargeUsecase.Decline")
+	defer func() { otelDriver.End(span, err) }()
+
+	charge, err = usecase.getOpen(ctx, chargeID, accountID)
+	if err != nil {
+		return nil, err
+	}
+
+	charge.Status = entity.CHARGE_STATUS_DECLINED
+
+	closed, err := usecase.chargeRepository.Close(ctx, charge)
+	if err != nil {
+		return nil, err
+	}
+
+	if !closed {
+		return nil, &common.ValidationError{Msg: CHARGE_NOT_OPEN_ERROR}
+	}
+
+	return charge, nil
+}
+
+// get returns a charge to either of its parties. Other accounts get a not
+// found error so they cannot probe charge ids.
+func (usecase *chargeUsecase) get(ctx context.Context, chargeID uint, accountID uint) (*entity.Charge, error) {
+	charge, err := usecase.chargeRepository.Get(ctx, chargeID)
+	if err != nil {
+		return nil, err
+	}
+
+	if charge.ID == 0 || (charge.ChargerAccountID != accountID && charge.PayerAccountID != accountID) {
+		return nil, fmt.Errorf("%s %w", CHARGE_NOT_FOUND, &common.NotFoundError{})
+	}
+
+	return charge, nil
+}
+
+// getOpen returns a charge the payer can still act on, recording the expiry
+// of one found past it.
+func (usecase *chargeUsecase) getOpen(ctx context.Context, chargeID uint, payerAccountID uint) (*entity.Charge, error) {
+	charge, err := usecase.get(ctx, chargeID, payerAccountID)
+	if err != nil {
+		return nil, err
+	}
+
+	if charge.PayerAccountID != payerAccountID {
+		return nil, &common.ValidationError{Msg: ONLY_PAYER_ERROR}
+	}
+
+	if usecase.expire(charge) {
+		if _, err := usecase.chargeRepository.Close(ctx, charge); err != nil {
+			return nil, err
+		}
+
+		return nil, &common.ValidationError{Msg: CHARGE_EXPIRED_ERROR}
+	}
+
+	if !isOpen(charge) {
+		return nil, &common.ValidationError{Msg: CHARGE_NOT_OPEN_ERROR}
+	}
+
+	return charge, nil
+}
+
+// expire marks an open charge past its expiry as expired and reports whether
+// it did.
+func (usecase *chargeUsecase) expire(charge *entity.Charge) bool {
+	if !isOpen(charge) || usecase.now().Before(charge.ExpiresAt) {
+		return false
+	}
+
+	charge.Status = entity.CHARGE_STATUS_EXPIRED
+
+	return true
+}
+
+// checkPayment tells whether amount can be paid of charge as it stands.
+func checkPayment(charge *entity.Charge, amount float64) error {
+	if !isOpen(charge) {
+		return &common.ValidationError{Msg: CHARGE_NOT_OPEN_ERROR}
+	}
+
+	remaining := util.Sub(charge.Amount, charge.PaidAmount)
+
+	switch {
+	case amount > remaining:
+		return &common.ValidationError{Msg: AMOUNT_OVER_REMAINING_ERROR}
+	case amount < remaining && !charge.AllowPartial:
+		return &common.ValidationError{Msg: PARTIAL_NOT_ALLOWED_ERROR}
+	case amount < remaining && amount < charge.MinPartialAmount:
+		return &common.ValidationError{Msg: AMOUNT_UNDER_MIN_ERROR}
+	}
+
+	return nil
+}
+
+func isOpen(charge *entity.Charge) bool {
+	return charge.Status == entity.CHARGE_STATUS_PENDING || charge.Status == entity.CHARGE_STATUS_PARTIALLY_PAID
+}
@@ -0,0 +1,541 @@
+package charge
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/fms85/desafio-tecnico-go-stone/internal/domain/common"
+	"github.com/fms85/desafio-tecnico-go-stone/internal/domain/entity"
+	"github.com/fms85/desafio-tecnico-go-stone/internal/domain/types"
+	chargeRepository "github.com/fms85/desafio-tecnico-go-stone/internal/repository/charge"
+	accountUsecase "github.com/fms85/desafio-tecnico-go-stone/internal/usecase/account"
+	transferUsecase "github.com/fms85/desafio-tecnico-go-stone/internal/usecase/transfer"
+	"github.com/stretchr/testify/mock"
+	"gotest.tools/assert"
+)
+
+// nowTest is 2026-10-19 09:00 in America/Sao_Paulo.
+var nowTest = time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+
+func newUsecaseTest(repo chargeRepository.IChargeRepository, account accountUsecase.IAccountUsecase, transfer transferUsecase.ITransferUsecase) *chargeUsecase {
+	usecase := New(repo, account, transfer).(*chargeUsecase)
+	usecase.now = func() time.Time { return nowTest }
+
+	return usecase
+}
+
+func getChargeTest(status string, paidAmount float64, allowPartial bool) *entity.Charge {
+	return &entity.Charge{
+		ID:               11,
+		ChargerAccountID: 4,
+		PayerAccountID:   3,
+		Amount:           100,
+		PaidAmount:       paidAmount,
+		DueDate:          "2026-10-25",
+		ExpiresAt:        time.Date(2026, 10, 26, 0, 0, 0, 0, types.RECEIPT_LOCATION),
+		AllowPartial:     allowPartial,
+		MinPartialAmount: 10,
+		Status:           status,
+	}
+}
+
+func assertCode(t *testing.T, err error, want error) {
+	t.Helper()
+
+	var codedError common.CodedError
+	if !errors.As(err, &codedError) || codedError.Code() != want.(common.CodedError).Code() {
+		t.Fatalf("error = %v, want %v", err, want)
+	}
+}
+
+func TestChargeUsecaseCreate(t *testing.T) {
+	tests := []struct {
+		name          string
+		input         types.ChargeInput
+		accountErr    error
+		wantExpiresAt time.Time
+		wantMin       float64
+		wantErr       error
+	}{
+		{
+			name:          "should_expire_at_the_end_of_the_due_date",
+			input:         types.ChargeInput{ChargerAccountID: 4, PayerAccountID: 3, Amount: 100, DueDate: "2026-10-25", MinPartialAmount: 10},
+			wantExpiresAt: time.Date(2026, 10, 26, 0, 0, 0, 0, types.RECEIPT_LOCATION),
+		},
+		{
+			name:          "should_add_the_grace_days_and_keep_the_min_of_partial_charges",
+			input:         types.ChargeInput{ChargerAccountID: 4, PayerAccountID: 3, Amount: 100, DueDate: "2026-10-19", GraceDays: 5, AllowPartial: true, MinPartialAmount: 10},
+			wantExpiresAt: time.Date(2026, 10, 25, 0, 0, 0, 0, types.RECEIPT_LOCATION),
+			wantMin:       10,
+		},
+		{
+			name:    "should_reject_a_due_date_in_the_past",
+			input:   types.ChargeInput{ChargerAccountID: 4, PayerAccountID: 3, Amount: 100, DueDate: "2026-10-18"},
+			wantErr: &common.ValidationError{},
+		},
+		{
+			name:    "should_reject_charging_the_same_account",
+			input:   types.ChargeInput{ChargerAccountID: 3, PayerAccountID: 3, Amount: 100, DueDate: "2026-10-25"},
+			wantErr: &common.ValidationError{},
+		},
+		{
+			name:    "should_reject_a_min_partial_amount_over_the_amount",
+			input:   types.ChargeInput{ChargerAccountID: 4, PayerAccountID: 3, Amount: 100, DueDate: "2026-10-25", AllowPartial: true, MinPartialAmount: 200},
+			wantErr: &common.ValidationError{},
+		},
+		{
+			name:       "should_return_not_found_for_an_unknown_payer",
+			input:      types.ChargeInput{ChargerAccountID: 4, PayerAccountID: 3, Amount: 100, DueDate: "2026-10-25"},
+			accountErr: fmt.Errorf("account %w", &common.NotFoundError{}),
+			wantErr:    &common.NotFoundError{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &chargeRepository.ChargeRepositoryMock{}
+			repo.On("Create", mock.Anything, mock.Anything).Return(nil)
+
+			account := &accountUsecase.AccountUsecaseMock{}
+			account.On("Get", mock.Anything, types.AccountInput{ID: 3}).Return(&entity.Account{ID: 3}, tt.accountErr)
+
+			got, err := newUsecaseTest(repo, account, nil).Create(context.Background(), tt.input)
+			if tt.wantErr != nil {
+				assertCode(t, err, tt.wantErr)
+
+				repo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+				return
+			}
+
+			assert.NilError(t, err)
+			assert.Equal(t, entity.CHARGE_STATUS_PENDING, got.Status)
+			assert.Assert(t, got.ExpiresAt.Equal(tt.wantExpiresAt), "expires at %v, want %v", got.ExpiresAt, tt.wantExpiresAt)
+			assert.Equal(t, tt.wantMin, got.MinPartialAmount)
+			repo.AssertCalled(t, "Create", mock.Anything, got)
+		})
+	}
+}
+
+func TestChargeUsecaseGetAll(t *testing.T) {
+	expired := getChargeTest(entity.CHARGE_STATUS_PENDING, 0, false)
+	expired.ID = 12
+	expired.ExpiresAt = nowTest
+
+	tests := []struct {
+		name    string
+		status  string
+		wantIDs []uint
+	}{
+		{
+			name:    "should_list_every_charge",
+			wantIDs: []uint{12, 11},
+		},
+		{
+			name:    "should_leave_expired_charges_out_of_pending_ones",
+			status:  entity.CHARGE_STATUS_PENDING,
+			wantIDs: []uint{11},
+		},
+		{
+			name:    "should_list_open_charges_past_expiry_as_expired",
+			status:  entity.CHARGE_STATUS_EXPIRED,
+			wantIDs: []uint{12},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &chargeRepository.ChargeRepositoryMock{}
+			repo.On("GetAll", mock.Anything, uint(3), types.CHARGE_ROLE_PAYER).Return([]*entity.Charge{
+				func() *entity.Charge { charge := *expired; return &charge }(),
+				getChargeTest(entity.CHARGE_STATUS_PENDING, 0, false),
+			}, nil)
+
+			got, err := newUsecaseTest(repo, nil, nil).GetAll(context.Background(), types.ChargeFilterInput{AccountID: 3, Role: types.CHARGE_ROLE_PAYER, Status: tt.status})
+			assert.NilError(t, err)
+
+			ids := []uint{}
+			for _, charge := range got {
+				ids = append(ids, charge.ID)
+			}
+			assert.DeepEqual(t, tt.wantIDs, ids)
+			repo.AssertNotCalled(t, "Save", mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestChargeUsecaseGet(t *testing.T) {
+	tests := []struct {
+		name      string
+		accountID uint
+		found     *entity.Charge
+		wantErr   error
+	}{
+		{
+			name:      "should_return_the_charge_to_the_charger",
+			accountID: 4,
+			found:     getChargeTest(entity.CHARGE_STATUS_PENDING, 0, false),
+		},
+		{
+			name:      "should_return_the_charge_to_the_payer",
+			accountID: 3,
+			found:     getChargeTest(entity.CHARGE_STATUS_PENDING, 0, false),
+		},
+		{
+			name:      "should_return_not_found_to_other_accounts",
+			accountID: 5,
+			found:     getChargeTest(entity.CHARGE_STATUS_PENDING, 0, false),
+			wantErr:   &common.NotFoundError{},
+		},
+		{
+			name:      "should_return_not_found",
+			accountID: 3,
+			found:     &entity.Charge{},
+			wantErr:   &common.NotFoundError{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &chargeRepository.ChargeRepositoryMock{}
+			repo.On("Get", mock.Anything, uint(11)).Return(tt.found, nil)
+
+			got, err := newUsecaseTest(repo, nil, nil).Get(context.Background(), 11, tt.accountID)
+			if tt.wantErr != nil {
+				assertCode(t, err, tt.wantErr)
+				assert.Error(t, err, "charge not found")
+				return
+			}
+
+			assert.NilError(t, err)
+			assert.Equal(t, tt.found, got)
+		})
+	}
+}
+
+// createWithTest is a transfer usecase making transfer 21 and recording it,
+// failing with transferErr instead when set.
+func createWithTest(transferErr error) func(context.Context, types.TransferInput, types.TransferRecord) (*entity.Transfer, error) {
+	return func(ctx context.Context, transferInput types.TransferInput, record types.TransferRecord) (*entity.Transfer, error) {
+		if transferErr != nil {
+			return nil, transferErr
+		}
+
+		transfer := &entity.Transfer{ID: 21, Amount: transferInput.Amount}
+		if err := record(ctx, transfer); err != nil {
+			return nil, err
+		}
+
+		return transfer, nil
+	}
+}
+
+func TestChargeUsecasePay(t *testing.T) {
+	tests := []struct {
+		name           string
+		input          types.ChargePaymentInput
+		charge         *entity.Charge
+		locked         *entity.Charge
+		authorizeErr   error
+		transferErr    error
+		wantTransfer   float64
+		wantPaidAmount float64
+		wantStatus     string
+		wantClosed     bool
+		wantErr        error
+	}{
+		{
+			name:           "should_pay_what_is_left_without_amount",
+			input:          types.ChargePaymentInput{ChargeID: 11, PayerAccountID: 3},
+			charge:         getChargeTest(entity.CHARGE_STATUS_PARTIALLY_PAID, 40, true),
+			wantTransfer:   60,
+			wantPaidAmount: 100,
+			wantStatus:     entity.CHARGE_STATUS_PAID,
+		},
+		{
+			name:           "should_pay_part_of_a_charge_allowing_it",
+			input:          types.ChargePaymentInput{ChargeID: 11, PayerAccountID: 3, Amount: 30.1},
+			charge:         getChargeTest(entity.CHARGE_STATUS_PENDING, 0, true),
+			wantTransfer:   30.1,
+			wantPaidAmount: 30.1,
+			wantStatus:     entity.CHARGE_STATUS_PARTIALLY_PAID,
+		},
+		{
+			name:           "should_settle_a_charge_with_less_than_the_min",
+			input:          types.ChargePaymentInput{ChargeID: 11, PayerAccountID: 3, Amount: 5},
+			charge:         getChargeTest(entity.CHARGE_STATUS_PARTIALLY_PAID, 95, true),
+			wantTransfer:   5,
+			wantPaidAmount: 100,
+			wantStatus:     entity.CHARGE_STATUS_PAID,
+		},
+		{
+			name:           "should_add_to_the_paid_amount_of_the_locked_charge",
+			input:          types.ChargePaymentInput{ChargeID: 11, PayerAccountID: 3, Amount: 30},
+			charge:         getChargeTest(entity.CHARGE_STATUS_PENDING, 0, true),
+			locked:         getChargeTest(entity.CHARGE_STATUS_PARTIALLY_PAID, 40, true),
+			wantTransfer:   30,
+			wantPaidAmount: 70,
+			wantStatus:     entity.CHARGE_STATUS_PARTIALLY_PAID,
+		},
+		{
+			name:         "should_roll_back_a_payment_of_a_charge_paid_meanwhile",
+			input:        types.ChargePaymentInput{ChargeID: 11, PayerAccountID: 3},
+			charge:       getChargeTest(entity.CHARGE_STATUS_PENDING, 0, false),
+			locked:       getChargeTest(entity.CHARGE_STATUS_PAID, 100, false),
+			wantTransfer: 100,
+			wantErr:      &common.ValidationError{},
+		},
+		{
+			name:         "should_roll_back_a_payment_over_what_is_left_meanwhile",
+			input:        types.ChargePaymentInput{ChargeID: 11, PayerAccountID: 3, Amount: 70},
+			charge:       getChargeTest(entity.CHARGE_STATUS_PENDING, 0, true),
+			locked:       getChargeTest(entity.CHARGE_STATUS_PARTIALLY_PAID, 40, true),
+			wantTransfer: 70,
+			wantErr:      &common.ValidationError{},
+		},
+		{
+			name:         "should_refuse_a_payment_without_the_pin",
+			input:        types.ChargePaymentInput{ChargeID: 11, PayerAccountID: 3},
+			charge:       getChargeTest(entity.CHARGE_STATUS_PENDING, 0, false),
+			authorizeErr: &common.UnauthorizedError{},
+			wantErr:      &common.UnauthorizedError{},
+		},
+		{
+			name:         "should_refuse_a_payment_with_a_wrong_pin",
+			input:        types.ChargePaymentInput{ChargeID: 11, PayerAccountID: 3, Authorization: types.TransferAuthorization{PIN: "1234"}},
+			charge:       getChargeTest(entity.CHARGE_STATUS_PENDING, 0, false),
+			authorizeErr: &common.ValidationError{},
+			wantErr:      &common.ValidationError{},
+		},
+		{
+			name:    "should_reject_part_of_a_charge_not_allowing_it",
+			input:   types.ChargePaymentInput{ChargeID: 11, PayerAccountID: 3, Amount: 30},
+			charge:  getChargeTest(entity.CHARGE_STATUS_PENDING, 0, false),
+			wantErr: &common.ValidationError{},
+		},
+		{
+			name:    "should_reject_less_than_the_min_partial_amount",
+			input:   types.ChargePaymentInput{ChargeID: 11, PayerAccountID: 3, Amount: 5},
+			charge:  getChargeTest(entity.CHARGE_STATUS_PENDING, 0, true),
+			wantErr: &common.ValidationError{},
+		},
+		{
+			name:    "should_reject_more_than_is_left",
+			input:   types.ChargePaymentInput{ChargeID: 11, PayerAccountID: 3, Amount: 70},
+			charge:  getChargeTest(entity.CHARGE_STATUS_PARTIALLY_PAID, 40, true),
+			wantErr: &common.ValidationError{},
+		},
+		{
+			name:    "should_only_let_the_payer_pay",
+			input:   types.ChargePaymentInput{ChargeID: 11, PayerAccountID: 4},
+			charge:  getChargeTest(entity.CHARGE_STATUS_PENDING, 0, false),
+			wantErr: &common.ValidationError{},
+		},
+		{
+			name:    "should_reject_a_charge_that_is_not_open",
+			input:   types.ChargePaymentInput{ChargeID: 11, PayerAccountID: 3},
+			charge:  getChargeTest(entity.CHARGE_STATUS_DECLINED, 0, false),
+			wantErr: &common.ValidationError{},
+		},
+		{
+			name:  "should_record_the_expiry_of_a_charge_past_it",
+			input: types.ChargePaymentInput{ChargeID: 11, PayerAccountID: 3},
+			charge: func() *entity.Charge {
+				charge := getChargeTest(entity.CHARGE_STATUS_PENDING, 0, false)
+				charge.ExpiresAt = nowTest
+
+				return charge
+			}(),
+			wantStatus: entity.CHARGE_STATUS_EXPIRED,
+			wantClosed: true,
+			wantErr:    &common.ValidationError{},
+		},
+		{
+			name:         "should_leave_the_charge_open_when_the_transfer_fails",
+			input:        types.ChargePaymentInput{ChargeID: 11, PayerAccountID: 3},
+			charge:       getChargeTest(entity.CHARGE_STATUS_PENDING, 0, false),
+			transferErr:  &common.InsufficientFundsError{},
+			wantTransfer: 100,
+			wantStatus:   entity.CHARGE_STATUS_PENDING,
+			wantErr:      &common.InsufficientFundsError{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			locked := tt.locked
+			if locked == nil {
+				copied := *tt.charge
+				locked = &copied
+			}
+
+			repo := &chargeRepository.ChargeRepositoryMock{}
+			repo.On("Get", mock.Anything, uint(11)).Return(tt.charge, nil)
+			repo.On("Close", mock.Anything, tt.charge).Return(true, nil)
+			repo.On("GetForUpdate", mock.Anything, uint(11)).Return(locked, nil)
+			repo.On("AddPayment", mock.Anything, tt.charge, mock.Anything).Return(nil)
+
+			transfer := &transferUsecase.TransferUsecaseMock{}
+			transfer.On("Authorize", mock.Anything, uint(3), mock.Anything, tt.input.Authorization).Return(tt.authorizeErr)
+			transfer.On("CreateWith", mock.Anything, types.TransferInput{AccountOriginID: 3, AccountDestinationID: 4, Amount: tt.wantTransfer}, mock.Anything).
+				Return(createWithTest(tt.transferErr))
+
+			got, err := newUsecaseTest(repo, nil, transfer).Pay(context.Background(), tt.input)
+			if tt.wantErr != nil {
+				assertCode(t, err, tt.wantErr)
+
+				if tt.wantStatus != "" {
+					assert.Equal(t, tt.wantStatus, tt.charge.Status)
+				}
+				if tt.wantClosed {
+					repo.AssertCalled(t, "Close", mock.Anything, tt.charge)
+				}
+				if tt.wantTransfer == 0 {
+					transfer.AssertNotCalled(t, "CreateWith", mock.Anything, mock.Anything, mock.Anything)
+				}
+				repo.AssertNotCalled(t, "AddPayment", mock.Anything, mock.Anything, mock.Anything)
+				return
+			}
+
+			assert.NilError(t, err)
+			assert.Equal(t, tt.wantPaidAmount, got.PaidAmount)
+			assert.Equal(t, tt.wantStatus, got.Status)
+			assert.DeepEqual(t, []*entity.ChargePayment{{ChargeID: 11, TransferID: 21, Amount: tt.wantTransfer}}, got.Payments)
+			repo.AssertCalled(t, "AddPayment", mock.Anything, got, got.Payments[0])
+		})
+	}
+}
+
+func TestChargeUsecasePayStepsUpWhatIsLeft(t *testing.T) {
+	authorization := types.TransferAuthorization{PIN: "4321"}
+
+	repo := &chargeRepository.ChargeRepositoryMock{}
+	repo.On("Get", mock.Anything, uint(11)).Return(getChargeTest(entity.CHARGE_STATUS_PARTIALLY_PAID, 40, true), nil)
+
+	// Without an amount, the step-up is up to what is left of the charge.
+	transfer := &transferUsecase.TransferUsecaseMock{}
+	transfer.On("Authorize", mock.Anything, uint(3), float64(60), authorization).Return(&common.UnauthorizedError{Msg: "totp code required"})
+
+	_, err := newUsecaseTest(repo, nil, transfer).Pay(context.Background(), types.ChargePaymentInput{ChargeID: 11, PayerAccountID: 3, Authorization: authorization})
+
+	assertCode(t, err, &common.UnauthorizedError{})
+	transfer.AssertNotCalled(t, "CreateWith", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestChargeUsecasePayConcurrently(t *testing.T) {
+	payments := 10
+
+	// stored is the charge as committed, which GetForUpdate hands to one
+	// transaction at a time.
+	var lock sync.Mutex
+	stored := getChargeTest(entity.CHARGE_STATUS_PENDING, 0, false)
+
+	repo := &chargeRepository.ChargeRepositoryMock{}
+	repo.On("Get", mock.Anything, uint(11)).Return(func(context.Context, uint) (*entity.Charge, error) {
+		return getChargeTest(entity.CHARGE_STATUS_PENDING, 0, false), nil
+	})
+	repo.On("GetForUpdate", mock.Anything, uint(11)).Return(func(context.Context, uint) (*entity.Charge, error) {
+		copied := *stored
+
+		return &copied, nil
+	})
+	repo.On("AddPayment", mock.Anything, mock.Anything, mock.Anything).Return(func(_ context.Context, charge *entity.Charge, _ *entity.ChargePayment) error {
+		stored.PaidAmount, stored.Status = charge.PaidAmount, charge.Status
+
+		return nil
+	})
+
+	transfer := &transferUsecase.TransferUsecaseMock{}
+	transfer.On("Authorize", mock.Anything, uint(3), float64(100), types.TransferAuthorization{}).Return(nil)
+	transfer.On("CreateWith", mock.Anything, mock.Anything, mock.Anything).Return(func(ctx context.Context, transferInput types.TransferInput, record types.TransferRecord) (*entity.Transfer, error) {
+		lock.Lock()
+		defer lock.Unlock()
+
+		return createWithTest(nil)(ctx, transferInput, record)
+	})
+
+	usecase := newUsecaseTest(repo, nil, transfer)
+
+	errs := make(chan error, payments)
+	var wg sync.WaitGroup
+	for i := 0; i < payments; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := usecase.Pay(context.Background(), types.ChargePaymentInput{ChargeID: 11, PayerAccountID: 3})
+			errs <- err
+		}()
+	}
+	wg.Wait()
+	close(errs)
+
+	var validationError *common.ValidationError
+	paid, refused := 0, 0
+	for err := range errs {
+		switch {
+		case err == nil:
+			paid++
+		case errors.As(err, &validationError):
+			refused++
+		default:
+			t.Fatalf("unexpected error %v", err)
+		}
+	}
+
+	assert.Equal(t, 1, paid)
+	assert.Equal(t, payments-1, refused)
+	assert.Equal(t, float64(100), stored.PaidAmount)
+	assert.Equal(t, entity.CHARGE_STATUS_PAID, stored.Status)
+	repo.AssertNumberOfCalls(t, "AddPayment", 1)
+}
+
+func TestChargeUsecaseDecline(t *testing.T) {
+	tests := []struct {
+		name      string
+		accountID uint
+		charge    *entity.Charge
+		closed    bool
+		wantErr   error
+	}{
+		{
+			name:      "should_decline_a_partially_paid_charge",
+			accountID: 3,
+			charge:    getChargeTest(entity.CHARGE_STATUS_PARTIALLY_PAID, 40, true),
+			closed:    true,
+		},
+		{
+			name:      "should_reject_a_charge_closed_meanwhile",
+			accountID: 3,
+			charge:    getChargeTest(entity.CHARGE_STATUS_PARTIALLY_PAID, 40, true),
+			wantErr:   &common.ValidationError{},
+		},
+		{
+			name:      "should_only_let_the_payer_decline",
+			accountID: 4,
+			charge:    getChargeTest(entity.CHARGE_STATUS_PENDING, 0, false),
+			wantErr:   &common.ValidationError{},
+		},
+		{
+			name:      "should_reject_a_paid_charge",
+			accountID: 3,
+			charge:    getChargeTest(entity.CHARGE_STATUS_PAID, 100, false),
+			wantErr:   &common.ValidationError{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &chargeRepository.ChargeRepositoryMock{}
+			repo.On("Get", mock.Anything, uint(11)).Return(tt.charge, nil)
+			repo.On("Close", mock.Anything, tt.charge).Return(tt.closed, nil)
+
+			got, err := newUsecaseTest(repo, nil, nil).Decline(context.Background(), 11, tt.accountID)
+			if tt.wantErr != nil {
+				assertCode(t, err, tt.wantErr)
+				return
+			}
+
+			assert.NilError(t, err)
+			assert.Equal(t, entity.CHARGE_STATUS_DECLINED, got.Status)
+			repo.AssertCalled(t, "Close", mock.Anything, got)
+		})
+	}
+}
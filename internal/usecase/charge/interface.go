@@ -0,0 +1,16 @@
+package charge
+
+import (
+	"context"
+
+	"github.com/fms85/desafio-tecnico-go-stone/internal/domain/entity"
+	"github.com/fms85/desafio-tecnico-go-stone/internal/domain/types"
+)
+
+type IChargeUsecase interface {
+	Create(ctx context.Context, chargeInput types.ChargeInput) (*entity.Charge, error)
+	GetAll(ctx context.Context, filterInput types.ChargeFilterInput) ([]*entity.Charge, error)
+	Get(ctx context.Context, chargeID uint, accountID uint) (*entity.Charge, error)
+	Pay(ctx context.Context, paymentInput types.ChargePaymentInput) (*entity.Charge, error)
+	Decline(ctx context.Context, chargeID uint, accountID uint) (*entity.Charge, error)
+}
@@ -0,0 +1,161 @@
+// Code generated by mockery v2.33.0. DO NOT EDIT.
+
+package charge
+
+import (
+	context "context"
+
+	entity "github.com/fms85/desafio-tecnico-go-stone/internal/domain/entity"
+	mock "github.com/stretchr/testify/mock"
+
+	types "github.com/fms85/desafio-tecnico-go-stone/internal/domain/types"
+)
+
+// ChargeUsecaseMock is an autogenerated mock type for the IChargeUsecase type
+type ChargeUsecaseMock struct {
+	mock.Mock
+}
+
+// Create provides a mock function with given fields: ctx, chargeInput
+func (_m *ChargeUsecaseMock) Create(ctx context.Context, chargeInput types.ChargeInput) (*entity.Charge, error) {
+	ret := _m.Called(ctx, chargeInput)
+
+	var r0 *entity.Charge
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, types.ChargeInput) (*entity.Charge, error)); ok {
+		return rf(ctx, chargeInput)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, types.ChargeInput) *entity.Charge); ok {
+		r0 = rf(ctx, chargeInput)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.Charge)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, types.ChargeInput) error); ok {
+		r1 = rf(ctx, chargeInput)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Decline provides a mock function with given fields: ctx, chargeID, accountID
+func (_m *ChargeUsecaseMock) Decline(ctx context.Context, chargeID uint, accountID uint) (*entity.Charge, error) {
+	ret := _m.Called(ctx, chargeID, accountID)
+
+	var r0 *entity.Charge
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint, uint) (*entity.Charge, error)); ok {
+		return rf(ctx, chargeID, accountID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint, uint) *entity.Charge); ok {
+		r0 = rf(ctx, chargeID, accountID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.Charge)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint, uint) error); ok {
+		r1 = rf(ctx, chargeID, accountID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Get provides a mock function with given fields: ctx, chargeID, accountID
+func (_m *ChargeUsecaseMock) Get(ctx context.Context, chargeID uint, accountID uint) (*entity.Charge, error) {
+	ret := _m.Called(ctx, chargeID, accountID)
+
+	var r0 *entity.Charge
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint, uint) (*entity.Charge, error)); ok {
+		return rf(ctx, chargeID, accountID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint, uint) *entity.Charge); ok {
+		r0 = rf(ctx, chargeID, accountID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.Charge)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint, uint) error); ok {
+		r1 = rf(ctx, chargeID, accountID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetAll provides a mock function with given fields: ctx, filterInput
+func (_m *ChargeUsecaseMock) GetAll(ctx context.Context, filterInput types.ChargeFilterInput) ([]*entity.Charge, error) {
+	ret := _m.Called(ctx, filterInput)
+
+	var r0 []*entity.Charge
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, types.ChargeFilterInput) ([]*entity.Charge, error)); ok {
+		return rf(ctx, filterInput)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, types.ChargeFilterInput) []*entity.Charge); ok {
+		r0 = rf(ctx, filterInput)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*entity.Charge)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, types.ChargeFilterInput) error); ok {
+		r1 = rf(ctx, filterInput)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Pay provides a mock function with given fields: ctx, paymentInput
+func (_m *ChargeUsecaseMock) Pay(ctx context.Context, paymentInput types.ChargePaymentInput) (*entity.Charge, error) {
+	ret := _m.Called(ctx, paymentInput)
+
+	var r0 *entity.Charge
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, types.ChargePaymentInput) (*entity.Charge, error)); ok {
+		return rf(ctx, paymentInput)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, types.ChargePaymentInput) *entity.Charge); ok {
+		r0 = rf(ctx, paymentInput)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.Charge)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, types.ChargePaymentInput) error); ok {
+		r1 = rf(ctx, paymentInput)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewChargeUsecaseMock creates a new instance of ChargeUsecaseMock. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewChargeUsecaseMock(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *ChargeUsecaseMock {
+	mock := &ChargeUsecaseMock{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
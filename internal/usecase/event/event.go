@@ -0,0 +1,64 @@
+package event
+
+import (
+	"context"
+	"time"
+
+	"github.com/fms85/desafio-tecnico-go-stone/internal/domain/types"
+	"github.com/fms85/desafio-tecnico-go-stone/internal/driver/eventbus"
+	otelDriver "github.com/fms85/desafio-tecnico-go-stone/internal/driver/otel"
+	ledgerRepository "github.com/fms85/desafio-tecnico-go-stone/internal/repository/ledger"
+	"go.opentelemetry.io/otel"
+)
+
+// EVENT_BATCH_SIZE bounds how many ledger entries After reads at once.
+const EVENT_BATCH_SIZE = 500
+
+var tracer = otel.Tracer("internal/usecase/event")
+
+// eventUsecase builds the account event stream from the ledger. The bus only
+// says when to look, so resuming and live updates read the same history.
+type eventUsecase struct {
+	ledgerRepository ledgerRepository.ILedgerRepository
+	bus              eventbus.IBus
+	now              func() time.Time
+}
+
+func New(ledgerRepository ledgerRepository.ILedgerRepository, bus eventbus.IBus) IEventUsecase {
+	return &eventUsecase{
+		ledgerRepository: ledgerRepository,
+		bus:              bus,
+		now:              time.Now,
+	}
+}
+
+// Snapshot is the first event of a stream that is not resuming: the current
+// balance, with the id of the last entry behind it.
+func (usecase *eventUsecase) Snapshot(ctx context.Context, accountID uint) (event *types.AccountEvent, err error) {
+	ctx, span := tracer.Start(ctx, "eventUsecase.Snapshot")
+	defer func() { otelDriver.End(span, err) }()
+
+	lastEntryID, balance, err := usecase.ledgerRepository.Balance(ctx, accountID)
+	if err != nil {
+		return nil, err
+	}
+
+	return types.CreateBalanceEvent(lastEntryID, balance, usecase.now()), nil
+}
+
+// After returns the next events following afterID, at most EVENT_BATCH_SIZE.
+func (usecase *eventUsecase) After(ctx context.Context, accountID uint, afterID uint) (events []*types.AccountEvent, err error) {
+	ctx, span := tracer.Start(ctx, "eventUsecase.After")
+	defer func() { otelDriver.End(span, err) }()
+
+	opening, entries, err := usecase.ledgerRepository.After(ctx, accountID, afterID, EVENT_BATCH_SIZE)
+	if err != nil {
+		return nil, err
+	}
+
+	return types.CreateAccountEvents(opening, entries), nil
+}
+
+func (usecase *eventUsecase) Subscribe(accountID uint) (<-chan struct{}, func()) {
+	return usecase.bus.Subscribe(accountID)
+}
@@ -0,0 +1,178 @@
+package event
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/fms85/desafio-tecnico-go-stone/internal/domain/entity"
+	"github.com/fms85/desafio-tecnico-go-stone/internal/domain/types"
+	"github.com/fms85/desafio-tecnico-go-stone/internal/driver/eventbus"
+	ledgerRepository "github.com/fms85/desafio-tecnico-go-stone/internal/repository/ledger"
+	"github.com/google/go-cmp/cmp"
+	mock "github.com/stretchr/testify/mock"
+)
+
+var nowTest = time.Date(2026, 10, 19, 15, 0, 0, 0, time.UTC)
+
+func getLedgerEntryTest(id uint, kind string, amount float64, transferID *uint) *entity.LedgerEntry {
+	return &entity.LedgerEntry{
+		ID:         id,
+		AccountID:  3,
+		TransferID: transferID,
+		Kind:       kind,
+		Amount:     amount,
+		CreatedAt:  nowTest,
+	}
+}
+
+func TestEventUsecaseSnapshot(t *testing.T) {
+	tests := []struct {
+		name             string
+		ledgerRepository func() *ledgerRepository.LedgerRepositoryMock
+		want             *types.AccountEvent
+		wantErr          bool
+	}{
+		{
+			name: "should_return_balance_with_last_entry_id",
+			ledgerRepository: func() *ledgerRepository.LedgerRepositoryMock {
+				repo := &ledgerRepository.LedgerRepositoryMock{}
+				repo.On("Balance", mock.Anything, uint(3)).Return(uint(42), 100.5, nil)
+
+				return repo
+			},
+			want: &types.AccountEvent{
+				ID:        42,
+				Type:      types.ACCOUNT_EVENT_BALANCE,
+				Balance:   100.5,
+				CreatedAt: nowTest.In(types.RECEIPT_LOCATION),
+			},
+		},
+		{
+			name: "should_return_error_when_balance_fails",
+			ledgerRepository: func() *ledgerRepository.LedgerRepositoryMock {
+				repo := &ledgerRepository.LedgerRepositoryMock{}
+				repo.On("Balance", mock.Anything, uint(3)).Return(uint(0), float64(0), errors.New("error"))
+
+				return repo
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			usecase := &eventUsecase{
+				ledgerRepository: tt.ledgerRepository(),
+				bus:              eventbus.New(),
+				now:              func() time.Time { return nowTest },
+			}
+
+			got, err := usecase.Snapshot(context.Background(), 3)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("eventUsecase.Snapshot() error = %v, wantErr %v", err, tt.wantErr)
+
+				return
+			}
+
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestEventUsecaseAfter(t *testing.T) {
+	transferID := uint(9)
+
+	tests := []struct {
+		name             string
+		ledgerRepository func() *ledgerRepository.LedgerRepositoryMock
+		want             []*types.AccountEvent
+		wantErr          bool
+	}{
+		{
+			name: "should_carry_running_balance_and_flag_incoming_transfers",
+			ledgerRepository: func() *ledgerRepository.LedgerRepositoryMock {
+				repo := &ledgerRepository.LedgerRepositoryMock{}
+				repo.On("After", mock.Anything, uint(3), uint(5), EVENT_BATCH_SIZE).Return(100.0, []*entity.LedgerEntry{
+					getLedgerEntryTest(6, entity.LEDGER_ENTRY_KIND_TRANSFER_IN, 25.3, &transferID),
+					getLedgerEntryTest(7, entity.LEDGER_ENTRY_KIND_TRANSFER_OUT, -10.1, nil),
+				}, nil)
+
+				return repo
+			},
+			want: []*types.AccountEvent{
+				{
+					ID:         6,
+					Type:       types.ACCOUNT_EVENT_TRANSFER_RECEIVED,
+					Balance:    125.3,
+					Amount:     25.3,
+					Kind:       entity.LEDGER_ENTRY_KIND_TRANSFER_IN,
+					TransferID: &transferID,
+					CreatedAt:  nowTest.In(types.RECEIPT_LOCATION),
+				},
+				{
+					ID:        7,
+					Type:      types.ACCOUNT_EVENT_BALANCE_CHANGED,
+					Balance:   115.2,
+					Amount:    -10.1,
+					Kind:      entity.LEDGER_ENTRY_KIND_TRANSFER_OUT,
+					CreatedAt: nowTest.In(types.RECEIPT_LOCATION),
+				},
+			},
+		},
+		{
+			name: "should_return_no_events_when_up_to_date",
+			ledgerRepository: func() *ledgerRepository.LedgerRepositoryMock {
+				repo := &ledgerRepository.LedgerRepositoryMock{}
+				repo.On("After", mock.Anything, uint(3), uint(5), EVENT_BATCH_SIZE).Return(100.0, []*entity.LedgerEntry{}, nil)
+
+				return repo
+			},
+			want: []*types.AccountEvent{},
+		},
+		{
+			name: "should_return_error_when_ledger_fails",
+			ledgerRepository: func() *ledgerRepository.LedgerRepositoryMock {
+				repo := &ledgerRepository.LedgerRepositoryMock{}
+				repo.On("After", mock.Anything, uint(3), uint(5), EVENT_BATCH_SIZE).Return(float64(0), nil, errors.New("error"))
+
+				return repo
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			usecase := New(tt.ledgerRepository(), eventbus.New())
+
+			got, err := usecase.After(context.Background(), 3, 5)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("eventUsecase.After() error = %v, wantErr %v", err, tt.wantErr)
+
+				return
+			}
+
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestEventUsecaseSubscribe(t *testing.T) {
+	bus := eventbus.New()
+	usecase := New(&ledgerRepository.LedgerRepositoryMock{}, bus)
+
+	notifications, unsubscribe := usecase.Subscribe(3)
+	defer unsubscribe()
+
+	bus.Publish(3)
+
+	select {
+	case <-notifications:
+	default:
+		t.Error("subscriber was not notified")
+	}
+}
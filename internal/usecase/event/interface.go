@@ -0,0 +1,13 @@
+package event
+
+import (
+	"context"
+
+	"github.com/fms85/desafio-tecnico-go-stone/internal/domain/types"
+)
+
+type IEventUsecase interface {
+	Snapshot(ctx context.Context, accountID uint) (*types.AccountEvent, error)
+	After(ctx context.Context, accountID uint, afterID uint) ([]*types.AccountEvent, error)
+	Subscribe(accountID uint) (<-chan struct{}, func())
+}
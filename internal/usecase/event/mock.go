@@ -0,0 +1,109 @@
+// Code generated by mockery v2.33.0. DO NOT EDIT.
+
+package event
+
+import (
+	context "context"
+
+	types "github.com/fms85/desafio-tecnico-go-stone/internal/domain/types"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// EventUsecaseMock is an autogenerated mock type for the IEventUsecase type
+type EventUsecaseMock struct {
+	mock.Mock
+}
+
+// After provides a mock function with given fields: ctx, accountID, afterID
+func (_m *EventUsecaseMock) After(ctx context.Context, accountID uint, afterID uint) ([]*types.AccountEvent, error) {
+	ret := _m.Called(ctx, accountID, afterID)
+
+	var r0 []*types.AccountEvent
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint, uint) ([]*types.AccountEvent, error)); ok {
+		return rf(ctx, accountID, afterID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint, uint) []*types.AccountEvent); ok {
+		r0 = rf(ctx, accountID, afterID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*types.AccountEvent)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint, uint) error); ok {
+		r1 = rf(ctx, accountID, afterID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Snapshot provides a mock function with given fields: ctx, accountID
+func (_m *EventUsecaseMock) Snapshot(ctx context.Context, accountID uint) (*types.AccountEvent, error) {
+	ret := _m.Called(ctx, accountID)
+
+	var r0 *types.AccountEvent
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint) (*types.AccountEvent, error)); ok {
+		return rf(ctx, accountID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint) *types.AccountEvent); ok {
+		r0 = rf(ctx, accountID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*types.AccountEvent)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint) error); ok {
+		r1 = rf(ctx, accountID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Subscribe provides a mock function with given fields: accountID
+func (_m *EventUsecaseMock) Subscribe(accountID uint) (<-chan struct{}, func()) {
+	ret := _m.Called(accountID)
+
+	var r0 <-chan struct{}
+	var r1 func()
+	if rf, ok := ret.Get(0).(func(uint) (<-chan struct{}, func())); ok {
+		return rf(accountID)
+	}
+	if rf, ok := ret.Get(0).(func(uint) <-chan struct{}); ok {
+		r0 = rf(accountID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(<-chan struct{})
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(uint) func()); ok {
+		r1 = rf(accountID)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(func())
+		}
+	}
+
+	return r0, r1
+}
+
+// NewEventUsecaseMock creates a new instance of EventUsecaseMock. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewEventUsecaseMock(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *EventUsecaseMock {
+	mock := &EventUsecaseMock{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,23 @@
+package outbox
+
+import (
+	"context"
+
+	"github.com/fms85/desafio-tecnico-go-stone/internal/domain/types"
+)
+
+type IOutboxUsecase interface {
+	Relay(ctx context.Context) (int, error)
+}
+
+// ISink is where the relay publishes events. Publishing may be retried, so
+// sinks and their consumers must tolerate the same event ID twice.
+type ISink interface {
+	Publish(ctx context.Context, event types.Event) error
+}
+
+// IProducer is the part of a message broker client BrokerSink needs. Keys
+// select the partition, keeping each account's events in order.
+type IProducer interface {
+	Produce(ctx context.Context, topic string, key []byte, value []byte) error
+}
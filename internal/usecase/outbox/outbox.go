@@ -0,0 +1,116 @@
+package outbox
+
+import (
+	"context"
+	"time"
+
+	"github.com/fms85/desafio-tecnico-go-stone/internal/domain/entity"
+	"github.com/fms85/desafio-tecnico-go-stone/internal/domain/types"
+	"github.com/fms85/desafio-tecnico-go-stone/internal/driver/logger"
+	otelDriver "github.com/fms85/desafio-tecnico-go-stone/internal/driver/otel"
+	outboxRepository "github.com/fms85/desafio-tecnico-go-stone/internal/repository/outbox"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+const (
+	OUTBOX_BATCH_SIZE   = 100
+	OUTBOX_LEASE        = time.Minute
+	OUTBOX_BACKOFF_BASE = time.Second
+	OUTBOX_BACKOFF_MAX  = 5 * time.Minute
+)
+
+var tracer = otel.Tracer("internal/usecase/outbox")
+
+type outboxUsecase struct {
+	outboxRepository outboxRepository.IOutboxRepository
+	sink             ISink
+	now              func() time.Time
+}
+
+func New(outboxRepository outboxRepository.IOutboxRepository, sink ISink) IOutboxUsecase {
+	return &outboxUsecase{
+		outboxRepository: outboxRepository,
+		sink:             sink,
+		now:              time.Now,
+	}
+}
+
+// Relay publishes one batch of committed events and returns its size. Events
+// are marked as published only after the sink accepts them, so a crash in
+// between publishes them again. When an event fails, the later events of its
+// account wait for it, keeping each account's events in order.
+func (usecase *outboxUsecase) Relay(ctx context.Context) (count int, err error) {
+	ctx, span := tracer.Start(ctx, "outboxUsecase.Relay")
+	defer func() { otelDriver.End(span, err, attribute.Int("outbox.events", count)) }()
+
+	events, err := usecase.outboxRepository.Claim(ctx, usecase.now(), OUTBOX_LEASE, OUTBOX_BATCH_SIZE)
+	if err != nil {
+		return 0, err
+	}
+
+	blocked := map[uint]bool{}
+	var skipped []*entity.OutboxEvent
+
+	for _, event := range events {
+		if blocked[event.AccountID] {
+			skipped = append(skipped, event)
+			continue
+		}
+
+		if err := usecase.publish(ctx, event); err != nil {
+			return len(events), err
+		}
+
+		if event.PublishedAt == nil {
+			blocked[event.AccountID] = true
+		}
+	}
+
+	if len(skipped) > 0 {
+		if err := usecase.outboxRepository.Release(ctx, skipped); err != nil {
+			return len(events), err
+		}
+	}
+
+	return len(events), nil
+}
+
+func (usecase *outboxUsecase) publish(ctx context.Context, event *entity.OutboxEvent) error {
+	event.Attempts++
+
+	now := usecase.now()
+	if err := usecase.sink.Publish(ctx, types.CreateEvent(event)); err != nil {
+		lockedUntil := now.Add(backoff(event.Attempts))
+		event.LastError = err.Error()
+		event.LockedUntil = &lockedUntil
+
+		logger.FromContext(ctx).Warn("outbox event not published",
+			"event_id", event.ID,
+			"event", event.Type,
+			"account_id", event.AccountID,
+			"attempts", event.Attempts,
+			"error", err,
+		)
+
+		return usecase.outboxRepository.MarkFailed(ctx, event)
+	}
+
+	event.PublishedAt = &now
+	event.LockedUntil = nil
+	event.LastError = ""
+
+	return usecase.outboxRepository.MarkPublished(ctx, event)
+}
+
+// backoff is the wait before publishing a failed event again:
+// OUTBOX_BACKOFF_BASE doubled after every attempt, up to OUTBOX_BACKOFF_MAX.
+// Events are never dropped.
+func backoff(attempts int) time.Duration {
+	wait := OUTBOX_BACKOFF_BASE
+	for i := 1; i < attempts && wait < OUTBOX_BACKOFF_MAX; i++ {
+		wait *= 2
+	}
+
+	return min(wait, OUTBOX_BACKOFF_MAX)
+}
@@ -0,0 +1,155 @@
+package outbox
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/fms85/desafio-tecnico-go-stone/internal/domain/entity"
+	"github.com/fms85/desafio-tecnico-go-stone/internal/domain/types"
+	outboxRepository "github.com/fms85/desafio-tecnico-go-stone/internal/repository/outbox"
+	"github.com/google/go-cmp/cmp"
+	"github.com/stretchr/testify/mock"
+	"gotest.tools/assert"
+)
+
+var nowTest = time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+
+// failingSink fails the events of some accounts and records the others.
+type failingSink struct {
+	MemorySink
+	failing map[uint]bool
+}
+
+func (sink *failingSink) Publish(ctx context.Context, event types.Event) error {
+	if sink.failing[event.AccountID] {
+		return errors.New("sink unavailable")
+	}
+
+	return sink.MemorySink.Publish(ctx, event)
+}
+
+func getOutboxEventTest(id uint, accountID uint) *entity.OutboxEvent {
+	return &entity.OutboxEvent{
+		ID:        id,
+		AccountID: accountID,
+		Type:      entity.EVENT_TRANSFER_CREATED,
+		Payload:   `{"id":1}`,
+		CreatedAt: nowTest,
+	}
+}
+
+func TestOutboxUsecaseRelay(t *testing.T) {
+	tests := []struct {
+		name          string
+		events        []*entity.OutboxEvent
+		failing       map[uint]bool
+		wantPublished []string
+		wantFailed    []uint
+		wantReleased  []uint
+	}{
+		{
+			name:          "should_publish_events_in_order",
+			events:        []*entity.OutboxEvent{getOutboxEventTest(1, 3), getOutboxEventTest(2, 4), getOutboxEventTest(3, 3)},
+			wantPublished: []string{"1", "2", "3"},
+		},
+		{
+			name:          "should_hold_later_events_of_an_account_behind_a_failure",
+			events:        []*entity.OutboxEvent{getOutboxEventTest(1, 3), getOutboxEventTest(2, 4), getOutboxEventTest(3, 3), getOutboxEventTest(4, 4)},
+			failing:       map[uint]bool{3: true},
+			wantPublished: []string{"2", "4"},
+			wantFailed:    []uint{1},
+			wantReleased:  []uint{3},
+		},
+		{
+			name:   "should_do_nothing_without_events",
+			events: []*entity.OutboxEvent{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var failed, released []uint
+
+			repo := outboxRepository.NewOutboxRepositoryMock(t)
+			repo.On("Claim", mock.Anything, nowTest, OUTBOX_LEASE, OUTBOX_BATCH_SIZE).Return(tt.events, nil)
+			if len(tt.wantPublished) > 0 {
+				repo.On("MarkPublished", mock.Anything, mock.Anything).Return(nil)
+			}
+			if len(tt.wantFailed) > 0 {
+				repo.On("MarkFailed", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+					event := args.Get(1).(*entity.OutboxEvent)
+					failed = append(failed, event.ID)
+
+					assert.Equal(t, 1, event.Attempts)
+					assert.Equal(t, "sink unavailable", event.LastError)
+					assert.Equal(t, nowTest.Add(OUTBOX_BACKOFF_BASE), *event.LockedUntil)
+				}).Return(nil)
+			}
+			if len(tt.wantReleased) > 0 {
+				repo.On("Release", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+					for _, event := range args.Get(1).([]*entity.OutboxEvent) {
+						released = append(released, event.ID)
+					}
+				}).Return(nil)
+			}
+
+			sink := &failingSink{failing: tt.failing}
+			usecase := New(repo, sink).(*outboxUsecase)
+			usecase.now = func() time.Time { return nowTest }
+
+			count, err := usecase.Relay(context.Background())
+			assert.NilError(t, err)
+			assert.Equal(t, len(tt.events), count)
+
+			var published []string
+			for _, event := range sink.Events() {
+				published = append(published, event.ID)
+			}
+
+			if diff := cmp.Diff(published, tt.wantPublished); diff != "" {
+				t.Error(diff)
+			}
+
+			if diff := cmp.Diff(failed, tt.wantFailed); diff != "" {
+				t.Error(diff)
+			}
+
+			if diff := cmp.Diff(released, tt.wantReleased); diff != "" {
+				t.Error(diff)
+			}
+
+			for _, event := range tt.events {
+				if event.PublishedAt != nil {
+					assert.Equal(t, nowTest, *event.PublishedAt)
+					assert.Assert(t, event.LockedUntil == nil)
+				}
+			}
+		})
+	}
+}
+
+func TestOutboxUsecaseRelayClaimError(t *testing.T) {
+	repo := outboxRepository.NewOutboxRepositoryMock(t)
+	repo.On("Claim", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil, errors.New(""))
+
+	count, err := New(repo, NewMemorySink()).Relay(context.Background())
+	assert.Assert(t, err != nil)
+	assert.Equal(t, 0, count)
+}
+
+func TestBackoff(t *testing.T) {
+	tests := []struct {
+		attempts int
+		want     time.Duration
+	}{
+		{attempts: 1, want: time.Second},
+		{attempts: 4, want: 8 * time.Second},
+		{attempts: 9, want: 256 * time.Second},
+		{attempts: 10, want: 5 * time.Minute},
+		{attempts: 100, want: 5 * time.Minute},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, backoff(tt.attempts))
+	}
+}
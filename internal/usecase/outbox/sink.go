@@ -0,0 +1,111 @@
+package outbox
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"strconv"
+	"sync"
+
+	"github.com/fms85/desafio-tecnico-go-stone/internal/domain/types"
+	webhookUsecase "github.com/fms85/desafio-tecnico-go-stone/internal/usecase/webhook"
+)
+
+const (
+	SINK_WEBHOOK = "webhook"
+	SINK_LOG     = "log"
+	SINK_MEMORY  = "memory"
+)
+
+// MemorySink keeps published events, for tests and local runs.
+type MemorySink struct {
+	mu     sync.Mutex
+	events []types.Event
+}
+
+func NewMemorySink() *MemorySink {
+	return &MemorySink{}
+}
+
+func (sink *MemorySink) Publish(_ context.Context, event types.Event) error {
+	sink.mu.Lock()
+	defer sink.mu.Unlock()
+
+	sink.events = append(sink.events, event)
+
+	return nil
+}
+
+func (sink *MemorySink) Events() []types.Event {
+	sink.mu.Lock()
+	defer sink.mu.Unlock()
+
+	return append([]types.Event(nil), sink.events...)
+}
+
+// LogSink writes events to the log. Payloads are left out, they may carry
+// personal data.
+type LogSink struct {
+	log *slog.Logger
+}
+
+func NewLogSink(log *slog.Logger) *LogSink {
+	return &LogSink{log: log}
+}
+
+func (sink *LogSink) Publish(ctx context.Context, event types.Event) error {
+	sink.log.InfoContext(ctx, "event published",
+		"event_id", event.ID,
+		"event", event.Type,
+		"account_id", event.AccountID,
+	)
+
+	return nil
+}
+
+// WebhookSink queues events to the webhooks subscribed to them.
+type WebhookSink struct {
+	webhookUsecase webhookUsecase.IWebhookUsecase
+}
+
+func NewWebhookSink(webhookUsecase webhookUsecase.IWebhookUsecase) *WebhookSink {
+	return &WebhookSink{webhookUsecase: webhookUsecase}
+}
+
+func (sink *WebhookSink) Publish(ctx context.Context, event types.Event) error {
+	return sink.webhookUsecase.Publish(ctx, event)
+}
+
+// BrokerSink produces events as JSON to a topic, keyed by account.
+type BrokerSink struct {
+	producer IProducer
+	topic    string
+}
+
+func NewBrokerSink(producer IProducer, topic string) *BrokerSink {
+	return &BrokerSink{producer: producer, topic: topic}
+}
+
+func (sink *BrokerSink) Publish(ctx context.Context, event types.Event) error {
+	value, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("error to encode event: %w", err)
+	}
+
+	return sink.producer.Produce(ctx, sink.topic, []byte(strconv.FormatUint(uint64(event.AccountID), 10)), value)
+}
+
+// MultiSink publishes to every sink in turn. A failure fails the event, which
+// is then published again to all of them.
+type MultiSink []ISink
+
+func (sinks MultiSink) Publish(ctx context.Context, event types.Event) error {
+	for _, sink := range sinks {
+		if err := sink.Publish(ctx, event); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,82 @@
+package outbox
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"testing"
+	"time"
+
+	"github.com/fms85/desafio-tecnico-go-stone/internal/domain/types"
+	webhookUsecase "github.com/fms85/desafio-tecnico-go-stone/internal/usecase/webhook"
+	"gotest.tools/assert"
+)
+
+type producerTest struct {
+	topic string
+	key   string
+	value string
+	err   error
+}
+
+func (producer *producerTest) Produce(_ context.Context, topic string, key []byte, value []byte) error {
+	producer.topic, producer.key, producer.value = topic, string(key), string(value)
+
+	return producer.err
+}
+
+func getEventTest() types.Event {
+	return types.Event{
+		ID:        "7",
+		Type:      "transfer.received",
+		AccountID: 4,
+		CreatedAt: time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC),
+		Data:      json.RawMessage(`{"id":1,"amount":10}`),
+	}
+}
+
+func TestSinks(t *testing.T) {
+	ctx := context.Background()
+	event := getEventTest()
+
+	t.Run("should_keep_events_in_memory", func(t *testing.T) {
+		sink := NewMemorySink()
+		assert.NilError(t, sink.Publish(ctx, event))
+		assert.DeepEqual(t, []types.Event{event}, sink.Events())
+	})
+
+	t.Run("should_log_events_without_payload", func(t *testing.T) {
+		var buffer bytes.Buffer
+		sink := NewLogSink(slog.New(slog.NewTextHandler(&buffer, nil)))
+
+		assert.NilError(t, sink.Publish(ctx, event))
+		assert.Assert(t, bytes.Contains(buffer.Bytes(), []byte(`msg="event published" event_id=7 event=transfer.received account_id=4`)))
+		assert.Assert(t, !bytes.Contains(buffer.Bytes(), []byte("amount")))
+	})
+
+	t.Run("should_queue_events_to_webhooks", func(t *testing.T) {
+		usecase := webhookUsecase.NewWebhookUsecaseMock(t)
+		usecase.On("Publish", ctx, event).Return(nil)
+
+		assert.NilError(t, NewWebhookSink(usecase).Publish(ctx, event))
+	})
+
+	t.Run("should_produce_events_keyed_by_account", func(t *testing.T) {
+		producer := &producerTest{}
+
+		assert.NilError(t, NewBrokerSink(producer, "bank.events").Publish(ctx, event))
+		assert.Equal(t, "bank.events", producer.topic)
+		assert.Equal(t, "4", producer.key)
+		assert.Equal(t, `{"id":"7","type":"transfer.received","account_id":4,"created_at":"2026-10-19T12:00:00Z","data":{"id":1,"amount":10}}`, producer.value)
+	})
+
+	t.Run("should_fail_when_any_sink_fails", func(t *testing.T) {
+		memory := NewMemorySink()
+		sinks := MultiSink{memory, NewBrokerSink(&producerTest{err: errors.New("")}, "bank.events")}
+
+		assert.Assert(t, sinks.Publish(ctx, event) != nil)
+		assert.Equal(t, 1, len(memory.Events()))
+	})
+}
@@ -0,0 +1,16 @@
+package paymentrequest
+
+import (
+	"context"
+
+	"github.com/fms85/desafio-tecnico-go-stone/internal/domain/entity"
+	"github.com/fms85/desafio-tecnico-go-stone/internal/domain/types"
+)
+
+type IPaymentRequestUsecase interface {
+	Create(ctx context.Context, paymentRequestInput types.PaymentRequestInput) (*entity.PaymentRequest, error)
+	GetAll(ctx context.Context, accountID uint) ([]*entity.PaymentRequest, error)
+	Get(ctx context.Context, requestID uint, accountID uint) (*entity.PaymentRequest, error)
+	Location(ctx context.Context, txID string) (*types.PaymentRequestLocation, error)
+	Parse(ctx context.Context, payload string) (*types.BRCodeQuote, error)
+}
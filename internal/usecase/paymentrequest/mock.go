@@ -0,0 +1,161 @@
+// Code generated by mockery v2.33.0. DO NOT EDIT.
+
+package paymentrequest
+
+import (
+	context "context"
+
+	entity "github.com/fms85/desafio-tecnico-go-stone/internal/domain/entity"
+	mock "github.com/stretchr/testify/mock"
+
+	types "github.com/fms85/desafio-tecnico-go-stone/internal/domain/types"
+)
+
+// PaymentRequestUsecaseMock is an autogenerated mock type for the IPaymentRequestUsecase type
+type PaymentRequestUsecaseMock struct {
+	mock.Mock
+}
+
+// Create provides a mock function with given fields: ctx, paymentRequestInput
+func (_m *PaymentRequestUsecaseMock) Create(ctx context.Context, paymentRequestInput types.PaymentRequestInput) (*entity.PaymentRequest, error) {
+	ret := _m.Called(ctx, paymentRequestInput)
+
+	var r0 *entity.PaymentRequest
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, types.PaymentRequestInput) (*entity.PaymentRequest, error)); ok {
+		return rf(ctx, paymentRequestInput)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, types.PaymentRequestInput) *entity.PaymentRequest); ok {
+		r0 = rf(ctx, paymentRequestInput)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.PaymentRequest)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, types.PaymentRequestInput) error); ok {
+		r1 = rf(ctx, paymentRequestInput)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Get provides a mock function with given fields: ctx, requestID, accountID
+func (_m *PaymentRequestUsecaseMock) Get(ctx context.Context, requestID uint, accountID uint) (*entity.PaymentRequest, error) {
+	ret := _m.Called(ctx, requestID, accountID)
+
+	var r0 *entity.PaymentRequest
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint, uint) (*entity.PaymentRequest, error)); ok {
+		return rf(ctx, requestID, accountID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint, uint) *entity.PaymentRequest); ok {
+		r0 = rf(ctx, requestID, accountID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.PaymentRequest)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint, uint) error); ok {
+		r1 = rf(ctx, requestID, accountID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetAll provides a mock function with given fields: ctx, accountID
+func (_m *PaymentRequestUsecaseMock) GetAll(ctx context.Context, accountID uint) ([]*entity.PaymentRequest, error) {
+	ret := _m.Called(ctx, accountID)
+
+	var r0 []*entity.PaymentRequest
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint) ([]*entity.PaymentRequest, error)); ok {
+		return rf(ctx, accountID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint) []*entity.PaymentRequest); ok {
+		r0 = rf(ctx, accountID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*entity.PaymentRequest)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint) error); ok {
+		r1 = rf(ctx, accountID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Location provides a mock function with given fields: ctx, txID
+func (_m *PaymentRequestUsecaseMock) Location(ctx context.Context, txID string) (*types.PaymentRequestLocation, error) {
+	ret := _m.Called(ctx, txID)
+
+	var r0 *types.PaymentRequestLocation
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*types.PaymentRequestLocation, error)); ok {
+		return rf(ctx, txID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *types.PaymentRequestLocation); ok {
+		r0 = rf(ctx, txID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*types.PaymentRequestLocation)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, txID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Parse provides a mock function with given fields: ctx, payload
+func (_m *PaymentRequestUsecaseMock) Parse(ctx context.Context, payload string) (*types.BRCodeQuote, error) {
+	ret := _m.Called(ctx, payload)
+
+	var r0 *types.BRCodeQuote
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*types.BRCodeQuote, error)); ok {
+		return rf(ctx, payload)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *types.BRCodeQuote); ok {
+		r0 = rf(ctx, payload)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*types.BRCodeQuote)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, payload)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewPaymentRequestUsecaseMock creates a new instance of PaymentRequestUsecaseMock. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewPaymentRequestUsecaseMock(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *PaymentRequestUsecaseMock {
+	mock := &PaymentRequestUsecaseMock{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
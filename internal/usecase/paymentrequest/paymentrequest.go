@@ -0,0 +1,244 @@
+package paymentrequest
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/fms85/desafio-tecnico-go-stone/internal/domain/common"
+	"github.com/fms85/desafio-tecnico-go-stone/internal/domain/entity"
+	"github.com/fms85/desafio-tecnico-go-stone/internal/domain/types"
+	otelDriver "github.com/fms85/desafio-tecnico-go-stone/internal/driver/otel"
+	paymentRequestRepository "github.com/fms85/desafio-tecnico-go-stone/internal/repository/paymentrequest"
+	accountUsecase "github.com/fms85/desafio-tecnico-go-stone/internal/usecase/account"
+	pixKeyUsecase "github.com/fms85/desafio-tecnico-go-stone/internal/usecase/pixkey"
+	"github.com/fms85/desafio-tecnico-go-stone/internal/util"
+	"go.opentelemetry.io/otel"
+)
+
+const (
+	PAYMENT_REQUEST_TTL                   = time.Hour
+	PAYMENT_REQUEST_CITY_DEFAULT          = "SAO PAULO"
+	PAYMENT_REQUEST_LOCATION_HOST_DEFAULT = "localhost:8080"
+	PAYMENT_REQUEST_LOCATION_PATH         = "/v1/pix/qr"
+)
+
+const (
+	FOREIGN_KEY_ERROR         = "pix key does not belong to the account"
+	BRCODE_TOO_LONG_ERROR     = "pix key and description do not fit in a br code"
+	INVALID_BRCODE_ERROR      = "invalid br code"
+	FOREIGN_BRCODE_ERROR      = "br codes of other institutions are not supported"
+	PAYMENT_REQUEST_EXPIRED   = "payment request expired"
+	PAYMENT_REQUEST_NOT_FOUND = "payment request"
+)
+
+var tracer = otel.Tracer("internal/usecase/paymentrequest")
+
+type paymentRequestUsecase struct {
+	paymentRequestRepository paymentRequestRepository.IPaymentRequestRepository
+	accountUsecase           accountUsecase.IAccountUsecase
+	pixKeyUsecase            pixKeyUsecase.IPixKeyUsecase
+	merchantCity             string
+	locationURL              string
+	now                      func() time.Time
+}
+
+// New takes the city written in the codes and the host dynamic codes point
+// to, like api.example.com, where PAYMENT_REQUEST_LOCATION_PATH is served.
+func New(paymentRequestRepository paymentRequestRepository.IPaymentRequestRepository, accountUsecase accountUsecase.IAccountUsecase, pixKeyUsecase pixKeyUsecase.IPixKeyUsecase, merchantCity string, locationHost string) IPaymentRequestUsecase {
+	if merchantCity == "" {
+		merchantCity = PAYMENT_REQUEST_CITY_DEFAULT
+	}
+
+	if locationHost == "" {
+		locationHost = PAYMENT_REQUEST_LOCATION_HOST_DEFAULT
+	}
+
+	return &paymentRequestUsecase{
+		paymentRequestRepository: paymentRequestRepository,
+		accountUsecase:           accountUsecase,
+		pixKeyUsecase:            pixKeyUsecase,
+		merchantCity:             merchantCity,
+		locationURL:              strings.TrimSuffix(locationHost, "/") + PAYMENT_REQUEST_LOCATION_PATH + "/",
+		now:                      time.Now,
+	}
+}
+
+func (usecase *paymentRequestUsecase) Create(ctx context.Context, paymentRequestInput types.PaymentRequestInput) (paymentRequest *entity.PaymentRequest, err error) {
+	ctx, span := tracer.Start(ctx, "paymentRequestUsecase.Create")
+	defer func() { otelDriver.End(span, err) }()
+
+	account, err := usecase.accountUsecase.Get(ctx, types.AccountInput{ID: paymentRequestInput.AccountID})
+	if err != nil {
+		return nil, err
+	}
+
+	paymentRequest = &entity.PaymentRequest{
+		AccountID:   paymentRequestInput.AccountID,
+		Type:        paymentRequestInput.Type,
+		Amount:      paymentRequestInput.Amount,
+		Description: paymentRequestInput.Description,
+	}
+
+	code := util.BRCode{
+		Amount:       paymentRequestInput.Amount,
+		MerchantName: account.Name,
+		MerchantCity: usecase.merchantCity,
+	}
+
+	if paymentRequestInput.Type == entity.PAYMENT_REQUEST_TYPE_STATIC {
+		lookup, err := usecase.pixKeyUsecase.Lookup(ctx, paymentRequestInput.Key)
+		if err != nil {
+			return nil, err
+		}
+
+		if lookup.AccountID != paymentRequestInput.AccountID {
+			return nil, &common.ValidationError{Msg: FOREIGN_KEY_ERROR}
+		}
+
+		paymentRequest.Key = types.NormalizePixKey(paymentRequestInput.Key)
+		paymentRequest.TxID = util.GenerateRandomHex(12)
+
+		code.Initiation = util.BRCODE_STATIC
+		code.Key = paymentRequest.Key
+		code.Description = paymentRequest.Description
+		code.TxID = paymentRequest.TxID
+	} else {
+		ttl := PAYMENT_REQUEST_TTL
+		if paymentRequestInput.ExpiresIn > 0 {
+			ttl = time.Duration(paymentRequestInput.ExpiresIn) * time.Second
+		}
+
+		expiresAt := usecase.now().Add(ttl)
+		paymentRequest.TxID = util.GenerateRandomHex(16)
+		paymentRequest.ExpiresAt = &expiresAt
+
+		code.Initiation = util.BRCODE_DYNAMIC
+		code.URL = usecase.locationURL + paymentRequest.TxID
+	}
+
+	paymentRequest.Payload, err = util.EncodeBRCode(code)
+	if err != nil {
+		return nil, &common.ValidationError{Msg: BRCODE_TOO_LONG_ERROR}
+	}
+
+	if err := usecase.paymentRequestRepository.Create(ctx, paymentRequest); err != nil {
+		return nil, err
+	}
+
+	return paymentRequest, nil
+}
+
+func (usecase *paymentRequestUsecase) GetAll(ctx context.Context, accountID uint) (paymentRequests []*entity.PaymentRequest, err error) {
+	ctx, span := tracer.Start(ctx, "paymentRequestUsecase.GetAll")
+	defer func() { otelDriver.End(span, err) }()
+
+	return usecase.paymentRequestRepository.GetAll(ctx, accountID)
+}
+
+func (usecase *paymentRequestUsecase) Get(ctx context.Context, requestID uint, accountID uint) (paymentRequest *entity.PaymentRequest, err error) {
+	ctx, span := tracer.Start(ctx, "paymentRequestUsecase.Get")
+	defer func() { otelDriver.End(span, err) }()
+
+	paymentRequest, err = usecase.paymentRequestRepository.Get(ctx, requestID, accountID)
+	if err != nil {
+		return nil, err
+	}
+
+	if paymentRequest.ID == 0 {
+		return nil, fmt.Errorf("%s %w", PAYMENT_REQUEST_NOT_FOUND, &common.NotFoundError{})
+	}
+
+	return paymentRequest, nil
+}
+
+// Location returns what the URL of a dynamic code resolves to.
+func (usecase *paymentRequestUsecase) Location(ctx context.Context, txID string) (location *types.PaymentRequestLocation, err error) {
+	ctx, span := tracer.Start(ctx, "paymentRequestUsecase.Location")
+	defer func() { otelDriver.End(span, err) }()
+
+	paymentRequest, account, err := usecase.dynamic(ctx, txID)
+	if err != nil {
+		return nil, err
+	}
+
+	return types.CreatePaymentRequestLocation(paymentRequest, account), nil
+}
+
+// Parse reads a pasted BR Code and fills in the transfer that pays it. Static
+// codes are paid to their Pix key; dynamic codes must point to a payment
+// request of this bank that has not expired.
+func (usecase *paymentRequestUsecase) Parse(ctx context.Context, payload string) (quote *types.BRCodeQuote, err error) {
+	ctx, span := tracer.Start(ctx, "paymentRequestUsecase.Parse")
+	defer func() { otelDriver.End(span, err) }()
+
+	code, err := util.ParseBRCode(payload)
+	if err != nil {
+		return nil, &common.ValidationError{Msg: INVALID_BRCODE_ERROR}
+	}
+
+	quote = &types.BRCodeQuote{
+		Type:         entity.PAYMENT_REQUEST_TYPE_STATIC,
+		TxID:         code.TxID,
+		MerchantName: code.MerchantName,
+		MerchantCity: code.MerchantCity,
+		Description:  code.Description,
+		Transfer:     types.TransferQuote{Amount: code.Amount},
+	}
+
+	if code.URL == "" {
+		lookup, err := usecase.pixKeyUsecase.Lookup(ctx, code.Key)
+		if err != nil {
+			return nil, err
+		}
+
+		quote.Receiver = types.ReceiptParty{Name: lookup.Name, CPF: lookup.CPF, CNPJ: lookup.CNPJ}
+		quote.Transfer.DestinationKey = code.Key
+
+		return quote, nil
+	}
+
+	txID, found := strings.CutPrefix(code.URL, usecase.locationURL)
+	if !found {
+		return nil, &common.ValidationError{Msg: FOREIGN_BRCODE_ERROR}
+	}
+
+	paymentRequest, account, err := usecase.dynamic(ctx, txID)
+	if err != nil {
+		return nil, err
+	}
+
+	location := types.CreatePaymentRequestLocation(paymentRequest, account)
+
+	quote.Type = entity.PAYMENT_REQUEST_TYPE_DYNAMIC
+	quote.TxID = location.TxID
+	quote.Description = location.Description
+	quote.Receiver = location.Receiver
+	quote.ExpiresAt = location.ExpiresAt
+	quote.Transfer = types.TransferQuote{AccountDestinationID: account.ID, Amount: location.Amount}
+
+	return quote, nil
+}
+
+func (usecase *paymentRequestUsecase) dynamic(ctx context.Context, txID string) (*entity.PaymentRequest, *entity.Account, error) {
+	paymentRequest, err := usecase.paymentRequestRepository.GetByTxID(ctx, txID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if paymentRequest.ID == 0 || paymentRequest.Type != entity.PAYMENT_REQUEST_TYPE_DYNAMIC {
+		return nil, nil, fmt.Errorf("%s %w", PAYMENT_REQUEST_NOT_FOUND, &common.NotFoundError{})
+	}
+
+	if !usecase.now().Before(*paymentRequest.ExpiresAt) {
+		return nil, nil, &common.ValidationError{Msg: PAYMENT_REQUEST_EXPIRED}
+	}
+
+	account, err := usecase.accountUsecase.Get(ctx, types.AccountInput{ID: paymentRequest.AccountID})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return paymentRequest, account, nil
+}
@@ -0,0 +1,331 @@
+package paymentrequest
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/fms85/desafio-tecnico-go-stone/internal/domain/common"
+	"github.com/fms85/desafio-tecnico-go-stone/internal/domain/entity"
+	"github.com/fms85/desafio-tecnico-go-stone/internal/domain/types"
+	paymentRequestRepository "github.com/fms85/desafio-tecnico-go-stone/internal/repository/paymentrequest"
+	accountUsecase "github.com/fms85/desafio-tecnico-go-stone/internal/usecase/account"
+	pixKeyUsecase "github.com/fms85/desafio-tecnico-go-stone/internal/usecase/pixkey"
+	"github.com/fms85/desafio-tecnico-go-stone/internal/util"
+	"github.com/google/go-cmp/cmp"
+	"github.com/stretchr/testify/mock"
+	"gotest.tools/assert"
+)
+
+var nowTest = time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+
+func newUsecaseTest(repo paymentRequestRepository.IPaymentRequestRepository, account accountUsecase.IAccountUsecase, pixKey pixKeyUsecase.IPixKeyUsecase) *paymentRequestUsecase {
+	usecase := New(repo, account, pixKey, "", "pix.example.com").(*paymentRequestUsecase)
+	usecase.now = func() time.Time { return nowTest }
+
+	return usecase
+}
+
+func getAccountTest() *entity.Account {
+	return &entity.Account{ID: 3, Name: "Loren Ipsum", CPF: "64537643060"}
+}
+
+func assertCode(t *testing.T, err error, want error) {
+	t.Helper()
+
+	var codedError common.CodedError
+	if !errors.As(err, &codedError) || codedError.Code() != want.(common.CodedError).Code() {
+		t.Fatalf("error = %v, want %v", err, want)
+	}
+}
+
+func TestPaymentRequestUsecaseCreate(t *testing.T) {
+	tests := []struct {
+		name          string
+		input         types.PaymentRequestInput
+		lookup        *types.PixKeyLookup
+		lookupErr     error
+		wantKey       string
+		wantURL       string
+		wantExpiresAt *time.Time
+		wantErr       error
+	}{
+		{
+			name:    "should_create_a_static_code_for_a_key_of_the_account",
+			input:   types.PaymentRequestInput{AccountID: 3, Type: entity.PAYMENT_REQUEST_TYPE_STATIC, Key: " Loren@Example.com ", Amount: 10.5, Description: "Coffee"},
+			lookup:  &types.PixKeyLookup{Key: "loren@example.com", AccountID: 3},
+			wantKey: "loren@example.com",
+		},
+		{
+			name:          "should_create_a_dynamic_code_expiring_after_the_default_ttl",
+			input:         types.PaymentRequestInput{AccountID: 3, Type: entity.PAYMENT_REQUEST_TYPE_DYNAMIC, Amount: 25},
+			wantURL:       "pix.example.com/v1/pix/qr/",
+			wantExpiresAt: func() *time.Time { expiresAt := nowTest.Add(PAYMENT_REQUEST_TTL); return &expiresAt }(),
+		},
+		{
+			name:          "should_create_a_dynamic_code_expiring_after_the_given_time",
+			input:         types.PaymentRequestInput{AccountID: 3, Type: entity.PAYMENT_REQUEST_TYPE_DYNAMIC, ExpiresIn: 300},
+			wantURL:       "pix.example.com/v1/pix/qr/",
+			wantExpiresAt: func() *time.Time { expiresAt := nowTest.Add(5 * time.Minute); return &expiresAt }(),
+		},
+		{
+			name:    "should_reject_a_key_of_another_account",
+			input:   types.PaymentRequestInput{AccountID: 3, Type: entity.PAYMENT_REQUEST_TYPE_STATIC, Key: "ipsum@example.com"},
+			lookup:  &types.PixKeyLookup{Key: "ipsum@example.com", AccountID: 4},
+			wantErr: &common.ValidationError{},
+		},
+		{
+			name:      "should_return_not_found_for_an_unknown_key",
+			input:     types.PaymentRequestInput{AccountID: 3, Type: entity.PAYMENT_REQUEST_TYPE_STATIC, Key: "ipsum@example.com"},
+			lookupErr: &common.NotFoundError{},
+			wantErr:   &common.NotFoundError{},
+		},
+		{
+			name:    "should_reject_a_key_and_description_that_do_not_fit",
+			input:   types.PaymentRequestInput{AccountID: 3, Type: entity.PAYMENT_REQUEST_TYPE_STATIC, Key: "loren.ipsum.dolor.sit.amet@example.com", Description: strings.Repeat("a", 72)},
+			lookup:  &types.PixKeyLookup{Key: "loren.ipsum.dolor.sit.amet@example.com", AccountID: 3},
+			wantErr: &common.ValidationError{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &paymentRequestRepository.PaymentRequestRepositoryMock{}
+			repo.On("Create", mock.Anything, mock.Anything).Return(nil)
+
+			account := &accountUsecase.AccountUsecaseMock{}
+			account.On("Get", mock.Anything, types.AccountInput{ID: 3}).Return(getAccountTest(), nil)
+
+			pixKey := &pixKeyUsecase.PixKeyUsecaseMock{}
+			pixKey.On("Lookup", mock.Anything, tt.input.Key).Return(tt.lookup, tt.lookupErr)
+
+			got, err := newUsecaseTest(repo, account, pixKey).Create(context.Background(), tt.input)
+			if tt.wantErr != nil {
+				assertCode(t, err, tt.wantErr)
+
+				repo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+				return
+			}
+
+			assert.NilError(t, err)
+			assert.Equal(t, tt.wantKey, got.Key)
+			assert.DeepEqual(t, tt.wantExpiresAt, got.ExpiresAt)
+
+			code, err := util.ParseBRCode(got.Payload)
+			assert.NilError(t, err)
+			assert.Equal(t, "Loren Ipsum", code.MerchantName)
+			assert.Equal(t, PAYMENT_REQUEST_CITY_DEFAULT, code.MerchantCity)
+
+			if tt.wantURL != "" {
+				assert.Equal(t, util.BRCODE_DYNAMIC, code.Initiation)
+				assert.Equal(t, tt.wantURL+got.TxID, code.URL)
+				assert.Equal(t, "", code.TxID)
+			} else {
+				assert.Equal(t, util.BRCODE_STATIC, code.Initiation)
+				assert.Equal(t, tt.wantKey, code.Key)
+				assert.Equal(t, got.TxID, code.TxID)
+				assert.Equal(t, tt.input.Amount, code.Amount)
+				assert.Equal(t, tt.input.Description, code.Description)
+			}
+
+			repo.AssertCalled(t, "Create", mock.Anything, got)
+		})
+	}
+}
+
+func TestPaymentRequestUsecaseGet(t *testing.T) {
+	tests := []struct {
+		name    string
+		found   *entity.PaymentRequest
+		wantErr error
+	}{
+		{
+			name:  "should_return_the_payment_request",
+			found: &entity.PaymentRequest{ID: 5, AccountID: 3},
+		},
+		{
+			name:    "should_return_not_found",
+			found:   &entity.PaymentRequest{},
+			wantErr: &common.NotFoundError{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &paymentRequestRepository.PaymentRequestRepositoryMock{}
+			repo.On("Get", mock.Anything, uint(5), uint(3)).Return(tt.found, nil)
+
+			got, err := newUsecaseTest(repo, nil, nil).Get(context.Background(), 5, 3)
+			if tt.wantErr != nil {
+				assertCode(t, err, tt.wantErr)
+				assert.Error(t, err, "payment request not found")
+				return
+			}
+
+			assert.NilError(t, err)
+			assert.Equal(t, tt.found, got)
+		})
+	}
+}
+
+func TestPaymentRequestUsecaseLocation(t *testing.T) {
+	valid := nowTest.Add(time.Minute)
+	expired := nowTest
+
+	tests := []struct {
+		name    string
+		found   *entity.PaymentRequest
+		want    *types.PaymentRequestLocation
+		wantErr error
+	}{
+		{
+			name:  "should_return_the_masked_receiver",
+			found: &entity.PaymentRequest{ID: 5, AccountID: 3, Type: entity.PAYMENT_REQUEST_TYPE_DYNAMIC, TxID: "9d36b84f", Amount: 25, ExpiresAt: &valid},
+			want: &types.PaymentRequestLocation{
+				TxID:      "9d36b84f",
+				Amount:    25,
+				Receiver:  types.ReceiptParty{Name: util.MaskName("Loren Ipsum"), CPF: util.MaskCPF("64537643060")},
+				ExpiresAt: &valid,
+			},
+		},
+		{
+			name:    "should_reject_an_expired_request",
+			found:   &entity.PaymentRequest{ID: 5, AccountID: 3, Type: entity.PAYMENT_REQUEST_TYPE_DYNAMIC, TxID: "9d36b84f", ExpiresAt: &expired},
+			wantErr: &common.ValidationError{},
+		},
+		{
+			name:    "should_not_serve_static_requests",
+			found:   &entity.PaymentRequest{ID: 5, AccountID: 3, Type: entity.PAYMENT_REQUEST_TYPE_STATIC, TxID: "9d36b84f"},
+			wantErr: &common.NotFoundError{},
+		},
+		{
+			name:    "should_return_not_found",
+			found:   &entity.PaymentRequest{},
+			wantErr: &common.NotFoundError{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &paymentRequestRepository.PaymentRequestRepositoryMock{}
+			repo.On("GetByTxID", mock.Anything, "9d36b84f").Return(tt.found, nil)
+
+			account := &accountUsecase.AccountUsecaseMock{}
+			account.On("Get", mock.Anything, types.AccountInput{ID: 3}).Return(getAccountTest(), nil)
+
+			got, err := newUsecaseTest(repo, account, nil).Location(context.Background(), "9d36b84f")
+			if tt.wantErr != nil {
+				assertCode(t, err, tt.wantErr)
+				return
+			}
+
+			assert.NilError(t, err)
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("Location() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestPaymentRequestUsecaseParse(t *testing.T) {
+	valid := nowTest.Add(time.Minute)
+
+	static, _ := util.EncodeBRCode(util.BRCode{
+		Initiation:   util.BRCODE_STATIC,
+		Key:          "loren@example.com",
+		Description:  "Coffee",
+		Amount:       10.5,
+		MerchantName: "Loren Ipsum",
+		MerchantCity: "SAO PAULO",
+		TxID:         "abc123",
+	})
+	dynamic, _ := util.EncodeBRCode(util.BRCode{
+		Initiation:   util.BRCODE_DYNAMIC,
+		URL:          "pix.example.com/v1/pix/qr/9d36b84f",
+		MerchantName: "Loren Ipsum",
+		MerchantCity: "SAO PAULO",
+	})
+	foreign, _ := util.EncodeBRCode(util.BRCode{
+		Initiation:   util.BRCODE_DYNAMIC,
+		URL:          "pix.other.com/qr/9d36b84f",
+		MerchantName: "Loren Ipsum",
+		MerchantCity: "SAO PAULO",
+	})
+
+	receiver := types.ReceiptParty{Name: util.MaskName("Loren Ipsum"), CPF: util.MaskCPF("64537643060")}
+
+	tests := []struct {
+		name    string
+		payload string
+		want    *types.BRCodeQuote
+		wantErr error
+	}{
+		{
+			name:    "should_pay_a_static_code_to_its_key",
+			payload: static,
+			want: &types.BRCodeQuote{
+				Type:         entity.PAYMENT_REQUEST_TYPE_STATIC,
+				TxID:         "abc123",
+				MerchantName: "Loren Ipsum",
+				MerchantCity: "SAO PAULO",
+				Description:  "Coffee",
+				Receiver:     receiver,
+				Transfer:     types.TransferQuote{DestinationKey: "loren@example.com", Amount: 10.5},
+			},
+		},
+		{
+			name:    "should_pay_a_dynamic_code_to_its_account",
+			payload: dynamic,
+			want: &types.BRCodeQuote{
+				Type:         entity.PAYMENT_REQUEST_TYPE_DYNAMIC,
+				TxID:         "9d36b84f",
+				MerchantName: "Loren Ipsum",
+				MerchantCity: "SAO PAULO",
+				Description:  "Lunch",
+				Receiver:     receiver,
+				ExpiresAt:    &valid,
+				Transfer:     types.TransferQuote{AccountDestinationID: 3, Amount: 25},
+			},
+		},
+		{
+			name:    "should_reject_codes_of_other_institutions",
+			payload: foreign,
+			wantErr: &common.ValidationError{},
+		},
+		{
+			name:    "should_reject_an_invalid_code",
+			payload: static[:len(static)-4] + "XXXX",
+			wantErr: &common.ValidationError{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &paymentRequestRepository.PaymentRequestRepositoryMock{}
+			repo.On("GetByTxID", mock.Anything, "9d36b84f").Return(&entity.PaymentRequest{
+				ID:          5,
+				AccountID:   3,
+				Type:        entity.PAYMENT_REQUEST_TYPE_DYNAMIC,
+				TxID:        "9d36b84f",
+				Amount:      25,
+				Description: "Lunch",
+				ExpiresAt:   &valid,
+			}, nil)
+
+			account := &accountUsecase.AccountUsecaseMock{}
+			account.On("Get", mock.Anything, types.AccountInput{ID: 3}).Return(getAccountTest(), nil)
+
+			pixKey := &pixKeyUsecase.PixKeyUsecaseMock{}
+			pixKey.On("Lookup", mock.Anything, "loren@example.com").Return(&types.PixKeyLookup{Key: "loren@example.com", AccountID: 3, Name: receiver.Name, CPF: receiver.CPF}, nil)
+
+			got, err := newUsecaseTest(repo, account, pixKey).Parse(context.Background(), tt.payload)
+			if tt.wantErr != nil {
+				assertCode(t, err, tt.wantErr)
+				return
+			}
+
+			assert.NilError(t, err)
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("Parse() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
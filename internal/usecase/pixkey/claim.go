@@ -0,0 +1,207 @@
+package pixkey
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/fms85/desafio-tecnico-go-stone/internal/domain/common"
+	"github.com/fms85/desafio-tecnico-go-stone/internal/domain/entity"
+	"github.com/fms85/desafio-tecnico-go-stone/internal/domain/types"
+	"github.com/fms85/desafio-tecnico-go-stone/internal/driver/notifier"
+	otelDriver "github.com/fms85/desafio-tecnico-go-stone/internal/driver/otel"
+)
+
+// CreateClaim asks for an email or phone key registered to another account.
+// The owner is told at the key address and has until ResolveBy to answer.
+func (usecase *pixKeyUsecase) CreateClaim(ctx context.Context, claimInput types.PixClaimInput) (claim *entity.PixKeyClaim, err error) {
+	ctx, span := tracer.Start(ctx, "pixKeyUsecase.CreateClaim")
+	defer func() { otelDriver.End(span, err) }()
+
+	pixKey, err := usecase.pixKeyRepository.Get(ctx, types.NormalizePixKey(claimInput.Key))
+	if err != nil {
+		return nil, err
+	}
+
+	if pixKey.ID == 0 || pixKey.Status != entity.PIX_KEY_STATUS_ACTIVE {
+		return nil, fmt.Errorf("pix key %w", &common.NotFoundError{})
+	}
+
+	if pixKey.Type != entity.PIX_KEY_TYPE_EMAIL && pixKey.Type != entity.PIX_KEY_TYPE_PHONE {
+		return nil, &common.ValidationError{Msg: UNCLAIMABLE_KEY_ERROR}
+	}
+
+	if pixKey.AccountID == claimInput.ClaimerAccountID {
+		return nil, &common.ValidationError{Msg: OWN_KEY_CLAIM_ERROR}
+	}
+
+	if err := usecase.checkLimit(ctx, claimInput.ClaimerAccountID); err != nil {
+		return nil, err
+	}
+
+	openClaim, err := usecase.pixKeyRepository.GetOpenClaim(ctx, pixKey.Key)
+	if err != nil {
+		return nil, err
+	}
+
+	if openClaim.ID > 0 {
+		return nil, fmt.Errorf("pix key claim %w", &common.ConflictError{})
+	}
+
+	claimType := claimInput.Type
+	if claimType == "" {
+		claimType = entity.PIX_CLAIM_TYPE_OWNERSHIP
+	}
+
+	claim = &entity.PixKeyClaim{
+		KeyID:            pixKey.ID,
+		Key:              pixKey.Key,
+		Type:             claimType,
+		Status:           entity.PIX_CLAIM_STATUS_OPEN,
+		ClaimerAccountID: claimInput.ClaimerAccountID,
+		OwnerAccountID:   pixKey.AccountID,
+		ResolveBy:        usecase.now().Add(PIX_CLAIM_RESOLUTION_PERIOD),
+	}
+
+	if err := usecase.pixKeyRepository.CreateClaim(ctx, claim); err != nil {
+		return nil, err
+	}
+
+	if err := usecase.notifier.Send(ctx, notifier.Message{
+		To:      pixKey.Key,
+		Subject: "Pix key claim",
+		Body:    fmt.Sprintf("Another account claimed your pix key. Confirm or cancel the claim %d by %s.", claim.ID, claim.ResolveBy.Format("2006-01-02")),
+	}); err != nil {
+		return nil, err
+	}
+
+	return claim, nil
+}
+
+func (usecase *pixKeyUsecase) GetClaims(ctx context.Context, accountID uint) (claims []*entity.PixKeyClaim, err error) {
+	ctx, span := tracer.Start(ctx, "pixKeyUsecase.GetClaims")
+	defer func() { otelDriver.End(span, err) }()
+
+	return usecase.pixKeyRepository.GetClaims(ctx, accountID)
+}
+
+// ConfirmClaim is the owner giving the key up.
+func (usecase *pixKeyUsecase) ConfirmClaim(ctx context.Context, claimID uint, accountID uint) (claim *entity.PixKeyClaim, err error) {
+	ctx, span := tracer.Start(ctx, "pixKeyUsecase.ConfirmClaim")
+	defer func() { otelDriver.End(span, err) }()
+
+	claim, err = usecase.getOpenClaim(ctx, claimID, accountID)
+	if err != nil {
+		return nil, err
+	}
+
+	if claim.OwnerAccountID != accountID {
+		return nil, &common.ValidationError{Msg: CLAIM_OWNER_ONLY_ERROR}
+	}
+
+	if err := usecase.complete(ctx, claim); err != nil {
+		return nil, err
+	}
+
+	return claim, nil
+}
+
+// CancelClaim closes a claim without moving the key, by either party.
+func (usecase *pixKeyUsecase) CancelClaim(ctx context.Context, claimID uint, accountID uint) (claim *entity.PixKeyClaim, err error) {
+	ctx, span := tracer.Start(ctx, "pixKeyUsecase.CancelClaim")
+	defer func() { otelDriver.End(span, err) }()
+
+	claim, err = usecase.getOpenClaim(ctx, claimID, accountID)
+	if err != nil {
+		return nil, err
+	}
+
+	claim.Status = entity.PIX_CLAIM_STATUS_CANCELLED
+
+	if err := usecase.pixKeyRepository.SaveClaim(ctx, claim); err != nil {
+		return nil, err
+	}
+
+	return claim, nil
+}
+
+// CompleteClaim lets the claimer take a key whose owner did not answer an
+// ownership claim in time. Portability always needs the owner to confirm.
+func (usecase *pixKeyUsecase) CompleteClaim(ctx context.Context, claimID uint, accountID uint) (claim *entity.PixKeyClaim, err error) {
+	ctx, span := tracer.Start(ctx, "pixKeyUsecase.CompleteClaim")
+	defer func() { otelDriver.End(span, err) }()
+
+	claim, err = usecase.getOpenClaim(ctx, claimID, accountID)
+	if err != nil {
+		return nil, err
+	}
+
+	if claim.ClaimerAccountID != accountID {
+		return nil, &common.ValidationError{Msg: CLAIM_CLAIMER_ONLY_ERROR}
+	}
+
+	if claim.Type != entity.PIX_CLAIM_TYPE_OWNERSHIP {
+		return nil, &common.ValidationError{Msg: CLAIM_PORTABILITY_ERROR}
+	}
+
+	if usecase.now().Before(claim.ResolveBy) {
+		return nil, &common.ValidationError{Msg: CLAIM_RESOLUTION_OPEN_ERROR}
+	}
+
+	if err := usecase.complete(ctx, claim); err != nil {
+		return nil, err
+	}
+
+	return claim, nil
+}
+
+// getOpenClaim returns a claim to either of its parties. Other accounts get a
+// not found error.
+func (usecase *pixKeyUsecase) getOpenClaim(ctx context.Context, claimID uint, accountID uint) (*entity.PixKeyClaim, error) {
+	claim, err := usecase.pixKeyRepository.GetClaim(ctx, claimID)
+	if err != nil {
+		return nil, err
+	}
+
+	if claim.ID == 0 || (claim.ClaimerAccountID != accountID && claim.OwnerAccountID != accountID) {
+		return nil, fmt.Errorf("pix key claim %w", &common.NotFoundError{})
+	}
+
+	if claim.Status != entity.PIX_CLAIM_STATUS_OPEN {
+		return nil, &common.ValidationError{Msg: CLAIM_NOT_OPEN_ERROR}
+	}
+
+	return claim, nil
+}
+
+// complete moves the key to the claimer. A key taken through portability
+// was handed over by its owner and stays active; one taken through an
+// ownership claim has to be verified by the claimer first.
+func (usecase *pixKeyUsecase) complete(ctx context.Context, claim *entity.PixKeyClaim) error {
+	pixKey, err := usecase.pixKeyRepository.GetByID(ctx, claim.KeyID)
+	if err != nil {
+		return err
+	}
+
+	if pixKey.ID == 0 {
+		return fmt.Errorf("pix key %w", &common.NotFoundError{})
+	}
+
+	pixKey.AccountID = claim.ClaimerAccountID
+
+	var code string
+	if claim.Type == entity.PIX_CLAIM_TYPE_OWNERSHIP {
+		code = usecase.resetVerification(pixKey)
+	}
+
+	claim.Status = entity.PIX_CLAIM_STATUS_COMPLETED
+
+	if err := usecase.pixKeyRepository.CompleteClaim(ctx, claim, pixKey); err != nil {
+		return err
+	}
+
+	if code != "" {
+		return usecase.sendCode(ctx, pixKey, code)
+	}
+
+	return nil
+}
@@ -0,0 +1,235 @@
+package pixkey
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/fms85/desafio-tecnico-go-stone/internal/domain/common"
+	"github.com/fms85/desafio-tecnico-go-stone/internal/domain/entity"
+	"github.com/fms85/desafio-tecnico-go-stone/internal/domain/types"
+	"github.com/fms85/desafio-tecnico-go-stone/internal/driver/notifier"
+	pixKeyRepository "github.com/fms85/desafio-tecnico-go-stone/internal/repository/pixkey"
+	accountUsecase "github.com/fms85/desafio-tecnico-go-stone/internal/usecase/account"
+	"github.com/stretchr/testify/mock"
+	"gotest.tools/assert"
+)
+
+func getActiveKeyTest(keyType string, key string) *entity.PixKey {
+	return &entity.PixKey{ID: 7, AccountID: 4, Type: keyType, Key: key, Status: entity.PIX_KEY_STATUS_ACTIVE}
+}
+
+func getClaimTest(claimType string) *entity.PixKeyClaim {
+	return &entity.PixKeyClaim{
+		ID:               11,
+		KeyID:            7,
+		Key:              "loren@example.com",
+		Type:             claimType,
+		Status:           entity.PIX_CLAIM_STATUS_OPEN,
+		ClaimerAccountID: 3,
+		OwnerAccountID:   4,
+		ResolveBy:        nowTest,
+	}
+}
+
+func assertCode(t *testing.T, err error, want error) {
+	t.Helper()
+
+	var codedError common.CodedError
+	if !errors.As(err, &codedError) || codedError.Code() != want.(common.CodedError).Code() {
+		t.Fatalf("error = %v, want %v", err, want)
+	}
+}
+
+func TestPixKeyUsecaseCreateClaim(t *testing.T) {
+	tests := []struct {
+		name      string
+		input     types.PixClaimInput
+		key       *entity.PixKey
+		openClaim *entity.PixKeyClaim
+		wantType  string
+		wantErr   error
+	}{
+		{
+			name:      "should_open_an_ownership_claim_by_default",
+			input:     types.PixClaimInput{ClaimerAccountID: 3, Key: "loren@example.com"},
+			key:       getActiveKeyTest(entity.PIX_KEY_TYPE_EMAIL, "loren@example.com"),
+			openClaim: &entity.PixKeyClaim{},
+			wantType:  entity.PIX_CLAIM_TYPE_OWNERSHIP,
+		},
+		{
+			name:      "should_open_a_portability_claim",
+			input:     types.PixClaimInput{ClaimerAccountID: 3, Key: "+5561912345678", Type: entity.PIX_CLAIM_TYPE_PORTABILITY},
+			key:       getActiveKeyTest(entity.PIX_KEY_TYPE_PHONE, "+5561912345678"),
+			openClaim: &entity.PixKeyClaim{},
+			wantType:  entity.PIX_CLAIM_TYPE_PORTABILITY,
+		},
+		{
+			name:    "should_reject_random_keys",
+			input:   types.PixClaimInput{ClaimerAccountID: 3, Key: "0f9b5a8e-8f3c-4c1e-9a4e-6f2b1d3c5e7a"},
+			key:     getActiveKeyTest(entity.PIX_KEY_TYPE_EVP, "0f9b5a8e-8f3c-4c1e-9a4e-6f2b1d3c5e7a"),
+			wantErr: &common.ValidationError{},
+		},
+		{
+			name:    "should_reject_keys_of_the_claimer",
+			input:   types.PixClaimInput{ClaimerAccountID: 4, Key: "loren@example.com"},
+			key:     getActiveKeyTest(entity.PIX_KEY_TYPE_EMAIL, "loren@example.com"),
+			wantErr: &common.ValidationError{},
+		},
+		{
+			name:    "should_not_find_unknown_keys",
+			input:   types.PixClaimInput{ClaimerAccountID: 3, Key: "loren@example.com"},
+			key:     &entity.PixKey{},
+			wantErr: &common.NotFoundError{},
+		},
+		{
+			name:      "should_return_conflict_when_a_claim_is_open",
+			input:     types.PixClaimInput{ClaimerAccountID: 3, Key: "loren@example.com"},
+			key:       getActiveKeyTest(entity.PIX_KEY_TYPE_EMAIL, "loren@example.com"),
+			openClaim: getClaimTest(entity.PIX_CLAIM_TYPE_OWNERSHIP),
+			wantErr:   &common.ConflictError{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &pixKeyRepository.PixKeyRepositoryMock{}
+			repo.On("Get", mock.Anything, tt.input.Key).Return(tt.key, nil)
+			repo.On("GetAll", mock.Anything, uint(3)).Return([]*entity.PixKey{}, nil)
+			repo.On("GetOpenClaim", mock.Anything, tt.key.Key).Return(tt.openClaim, nil)
+			repo.On("CreateClaim", mock.Anything, mock.Anything).Return(nil)
+
+			messages := &notifier.MemoryNotifier{}
+
+			got, err := newUsecaseTest(repo, &accountUsecase.AccountUsecaseMock{}, messages).CreateClaim(context.Background(), tt.input)
+			if tt.wantErr != nil {
+				assertCode(t, err, tt.wantErr)
+				repo.AssertNotCalled(t, "CreateClaim", mock.Anything, mock.Anything)
+				return
+			}
+
+			assert.NilError(t, err)
+			assert.Equal(t, tt.wantType, got.Type)
+			assert.Equal(t, entity.PIX_CLAIM_STATUS_OPEN, got.Status)
+			assert.Equal(t, uint(4), got.OwnerAccountID)
+			assert.Equal(t, nowTest.Add(PIX_CLAIM_RESOLUTION_PERIOD), got.ResolveBy)
+
+			sent := messages.Messages()
+			assert.Equal(t, 1, len(sent))
+			assert.Equal(t, tt.key.Key, sent[0].To)
+		})
+	}
+}
+
+func TestPixKeyUsecaseResolveClaim(t *testing.T) {
+	tests := []struct {
+		name          string
+		resolve       func(usecase *pixKeyUsecase, ctx context.Context, claimID uint, accountID uint) (*entity.PixKeyClaim, error)
+		claim         *entity.PixKeyClaim
+		accountID     uint
+		now           func() time.Time
+		wantStatus    string
+		wantKeyStatus string
+		wantErr       error
+	}{
+		{
+			name:          "should_hand_a_key_over_when_the_owner_confirms_portability",
+			resolve:       (*pixKeyUsecase).ConfirmClaim,
+			claim:         getClaimTest(entity.PIX_CLAIM_TYPE_PORTABILITY),
+			accountID:     4,
+			wantStatus:    entity.PIX_CLAIM_STATUS_COMPLETED,
+			wantKeyStatus: entity.PIX_KEY_STATUS_ACTIVE,
+		},
+		{
+			name:          "should_require_verification_when_the_owner_confirms_ownership",
+			resolve:       (*pixKeyUsecase).ConfirmClaim,
+			claim:         getClaimTest(entity.PIX_CLAIM_TYPE_OWNERSHIP),
+			accountID:     4,
+			wantStatus:    entity.PIX_CLAIM_STATUS_COMPLETED,
+			wantKeyStatus: entity.PIX_KEY_STATUS_PENDING,
+		},
+		{
+			name:      "should_not_let_the_claimer_confirm",
+			resolve:   (*pixKeyUsecase).ConfirmClaim,
+			claim:     getClaimTest(entity.PIX_CLAIM_TYPE_OWNERSHIP),
+			accountID: 3,
+			wantErr:   &common.ValidationError{},
+		},
+		{
+			name:       "should_let_either_party_cancel",
+			resolve:    (*pixKeyUsecase).CancelClaim,
+			claim:      getClaimTest(entity.PIX_CLAIM_TYPE_OWNERSHIP),
+			accountID:  3,
+			wantStatus: entity.PIX_CLAIM_STATUS_CANCELLED,
+		},
+		{
+			name:      "should_not_find_claims_of_other_accounts",
+			resolve:   (*pixKeyUsecase).CancelClaim,
+			claim:     getClaimTest(entity.PIX_CLAIM_TYPE_OWNERSHIP),
+			accountID: 5,
+			wantErr:   &common.NotFoundError{},
+		},
+		{
+			name:          "should_complete_an_ownership_claim_after_the_period",
+			resolve:       (*pixKeyUsecase).CompleteClaim,
+			claim:         getClaimTest(entity.PIX_CLAIM_TYPE_OWNERSHIP),
+			accountID:     3,
+			wantStatus:    entity.PIX_CLAIM_STATUS_COMPLETED,
+			wantKeyStatus: entity.PIX_KEY_STATUS_PENDING,
+		},
+		{
+			name:      "should_not_complete_before_the_period_ends",
+			resolve:   (*pixKeyUsecase).CompleteClaim,
+			claim:     getClaimTest(entity.PIX_CLAIM_TYPE_OWNERSHIP),
+			accountID: 3,
+			now:       func() time.Time { return nowTest.Add(-time.Second) },
+			wantErr:   &common.ValidationError{},
+		},
+		{
+			name:      "should_not_complete_portability_claims",
+			resolve:   (*pixKeyUsecase).CompleteClaim,
+			claim:     getClaimTest(entity.PIX_CLAIM_TYPE_PORTABILITY),
+			accountID: 3,
+			wantErr:   &common.ValidationError{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			key := getActiveKeyTest(entity.PIX_KEY_TYPE_EMAIL, "loren@example.com")
+
+			repo := &pixKeyRepository.PixKeyRepositoryMock{}
+			repo.On("GetClaim", mock.Anything, uint(11)).Return(tt.claim, nil)
+			repo.On("GetByID", mock.Anything, uint(7)).Return(key, nil)
+			repo.On("SaveClaim", mock.Anything, tt.claim).Return(nil)
+			repo.On("CompleteClaim", mock.Anything, tt.claim, key).Return(nil)
+
+			messages := &notifier.MemoryNotifier{}
+
+			usecase := newUsecaseTest(repo, &accountUsecase.AccountUsecaseMock{}, messages)
+			if tt.now != nil {
+				usecase.now = tt.now
+			}
+
+			got, err := tt.resolve(usecase, context.Background(), 11, tt.accountID)
+			if tt.wantErr != nil {
+				assertCode(t, err, tt.wantErr)
+				assert.Equal(t, entity.PIX_CLAIM_STATUS_OPEN, tt.claim.Status)
+				return
+			}
+
+			assert.NilError(t, err)
+			assert.Equal(t, tt.wantStatus, got.Status)
+
+			if tt.wantKeyStatus == "" {
+				repo.AssertNotCalled(t, "CompleteClaim", mock.Anything, mock.Anything, mock.Anything)
+				assert.Equal(t, uint(4), key.AccountID)
+				return
+			}
+
+			repo.AssertCalled(t, "CompleteClaim", mock.Anything, tt.claim, key)
+			assert.Equal(t, uint(3), key.AccountID)
+			assert.Equal(t, tt.wantKeyStatus, key.Status)
+			assert.Equal(t, tt.wantKeyStatus == entity.PIX_KEY_STATUS_PENDING, len(messages.Messages()) == 1)
+		})
+	}
+}
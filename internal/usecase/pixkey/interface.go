@@ -0,0 +1,21 @@
+package pixkey
+
+import (
+	"context"
+
+	"github.com/fms85/desafio-tecnico-go-stone/internal/domain/entity"
+	"github.com/fms85/desafio-tecnico-go-stone/internal/domain/types"
+)
+
+type IPixKeyUsecase interface {
+	Create(ctx context.Context, pixKeyInput types.PixKeyInput) (*entity.PixKey, error)
+	GetAll(ctx context.Context, accountID uint) ([]*entity.PixKey, error)
+	Verify(ctx context.Context, keyID uint, accountID uint, code string) (*entity.PixKey, error)
+	Delete(ctx context.Context, keyID uint, accountID uint) error
+	Lookup(ctx context.Context, key string) (*types.PixKeyLookup, error)
+	CreateClaim(ctx context.Context, claimInput types.PixClaimInput) (*entity.PixKeyClaim, error)
+	GetClaims(ctx context.Context, accountID uint) ([]*entity.PixKeyClaim, error)
+	ConfirmClaim(ctx context.Context, claimID uint, accountID uint) (*entity.PixKeyClaim, error)
+	CancelClaim(ctx context.Context, claimID uint, accountID uint) (*entity.PixKeyClaim, error)
+	CompleteClaim(ctx context.Context, claimID uint, accountID uint) (*entity.PixKeyClaim, error)
+}
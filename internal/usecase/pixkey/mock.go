@@ -0,0 +1,279 @@
+// Code generated by mockery v2.33.0. DO NOT EDIT.
+
+package pixkey
+
+import (
+	context "context"
+
+	entity "github.com/fms85/desafio-tecnico-go-stone/internal/domain/entity"
+	mock "github.com/stretchr/testify/mock"
+
+	types "github.com/fms85/desafio-tecnico-go-stone/internal/domain/types"
+)
+
+// PixKeyUsecaseMock is an autogenerated mock type for the IPixKeyUsecase type
+type PixKeyUsecaseMock struct {
+	mock.Mock
+}
+
+// CancelClaim provides a mock function with given fields: ctx, claimID, accountID
+func (_m *PixKeyUsecaseMock) CancelClaim(ctx context.Context, claimID uint, accountID uint) (*entity.PixKeyClaim, error) {
+	ret := _m.Called(ctx, claimID, accountID)
+
+	var r0 *entity.PixKeyClaim
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint, uint) (*entity.PixKeyClaim, error)); ok {
+		return rf(ctx, claimID, accountID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint, uint) *entity.PixKeyClaim); ok {
+		r0 = rf(ctx, claimID, accountID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.PixKeyClaim)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint, uint) error); ok {
+		r1 = rf(ctx, claimID, accountID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CompleteClaim provides a mock function with given fields: ctx, claimID, accountID
+func (_m *PixKeyUsecaseMock) CompleteClaim(ctx context.Context, claimID uint, accountID uint) (*entity.PixKeyClaim, error) {
+	ret := _m.Called(ctx, claimID, accountID)
+
+	var r0 *entity.PixKeyClaim
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint, uint) (*entity.PixKeyClaim, error)); ok {
+		return rf(ctx, claimID, accountID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint, uint) *entity.PixKeyClaim); ok {
+		r0 = rf(ctx, claimID, accountID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.PixKeyClaim)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint, uint) error); ok {
+		r1 = rf(ctx, claimID, accountID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ConfirmClaim provides a mock function with given fields: ctx, claimID, accountID
+func (_m *PixKeyUsecaseMock) ConfirmClaim(ctx context.Context, claimID uint, accountID uint) (*entity.PixKeyClaim, error) {
+	ret := _m.Called(ctx, claimID, accountID)
+
+	var r0 *entity.PixKeyClaim
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint, uint) (*entity.PixKeyClaim, error)); ok {
+		return rf(ctx, claimID, accountID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint, uint) *entity.PixKeyClaim); ok {
+		r0 = rf(ctx, claimID, accountID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.PixKeyClaim)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint, uint) error); ok {
+		r1 = rf(ctx, claimID, accountID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Create provides a mock function with given fields: ctx, pixKeyInput
+func (_m *PixKeyUsecaseMock) Create(ctx context.Context, pixKeyInput types.PixKeyInput) (*entity.PixKey, error) {
+	ret := _m.Called(ctx, pixKeyInput)
+
+	var r0 *entity.PixKey
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, types.PixKeyInput) (*entity.PixKey, error)); ok {
+		return rf(ctx, pixKeyInput)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, types.PixKeyInput) *entity.PixKey); ok {
+		r0 = rf(ctx, pixKeyInput)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.PixKey)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, types.PixKeyInput) error); ok {
+		r1 = rf(ctx, pixKeyInput)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CreateClaim provides a mock function with given fields: ctx, claimInput
+func (_m *PixKeyUsecaseMock) CreateClaim(ctx context.Context, claimInput types.PixClaimInput) (*entity.PixKeyClaim, error) {
+	ret := _m.Called(ctx, claimInput)
+
+	var r0 *entity.PixKeyClaim
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, types.PixClaimInput) (*entity.PixKeyClaim, error)); ok {
+		return rf(ctx, claimInput)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, types.PixClaimInput) *entity.PixKeyClaim); ok {
+		r0 = rf(ctx, claimInput)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.PixKeyClaim)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, types.PixClaimInput) error); ok {
+		r1 = rf(ctx, claimInput)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Delete provides a mock function with given fields: ctx, keyID, accountID
+func (_m *PixKeyUsecaseMock) Delete(ctx context.Context, keyID uint, accountID uint) error {
+	ret := _m.Called(ctx, keyID, accountID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint, uint) error); ok {
+		r0 = rf(ctx, keyID, accountID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GetAll provides a mock function with given fields: ctx, accountID
+func (_m *PixKeyUsecaseMock) GetAll(ctx context.Context, accountID uint) ([]*entity.PixKey, error) {
+	ret := _m.Called(ctx, accountID)
+
+	var r0 []*entity.PixKey
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint) ([]*entity.PixKey, error)); ok {
+		return rf(ctx, accountID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint) []*entity.PixKey); ok {
+		r0 = rf(ctx, accountID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*entity.PixKey)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint) error); ok {
+		r1 = rf(ctx, accountID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetClaims provides a mock function with given fields: ctx, accountID
+func (_m *PixKeyUsecaseMock) GetClaims(ctx context.Context, accountID uint) ([]*entity.PixKeyClaim, error) {
+	ret := _m.Called(ctx, accountID)
+
+	var r0 []*entity.PixKeyClaim
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint) ([]*entity.PixKeyClaim, error)); ok {
+		return rf(ctx, accountID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint) []*entity.PixKeyClaim); ok {
+		r0 = rf(ctx, accountID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*entity.PixKeyClaim)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint) error); ok {
+		r1 = rf(ctx, accountID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Lookup provides a mock function with given fields: ctx, key
+func (_m *PixKeyUsecaseMock) Lookup(ctx context.Context, key string) (*types.PixKeyLookup, error) {
+	ret := _m.Called(ctx, key)
+
+	var r0 *types.PixKeyLookup
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*types.PixKeyLookup, error)); ok {
+		return rf(ctx, key)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *types.PixKeyLookup); ok {
+		r0 = rf(ctx, key)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*types.PixKeyLookup)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, key)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Verify provides a mock function with given fields: ctx, keyID, accountID, code
+func (_m *PixKeyUsecaseMock) Verify(ctx context.Context, keyID uint, accountID uint, code string) (*entity.PixKey, error) {
+	ret := _m.Called(ctx, keyID, accountID, code)
+
+	var r0 *entity.PixKey
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint, uint, string) (*entity.PixKey, error)); ok {
+		return rf(ctx, keyID, accountID, code)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint, uint, string) *entity.PixKey); ok {
+		r0 = rf(ctx, keyID, accountID, code)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.PixKey)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint, uint, string) error); ok {
+		r1 = rf(ctx, keyID, accountID, code)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewPixKeyUsecaseMock creates a new instance of PixKeyUsecaseMock. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewPixKeyUsecaseMock(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *PixKeyUsecaseMock {
+	mock := &PixKeyUsecaseMock{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
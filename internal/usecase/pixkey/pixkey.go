@@ -0,0 +1,300 @@
+package pixkey
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/fms85/desafio-tecnico-go-stone/internal/domain/common"
+	"github.com/fms85/desafio-tecnico-go-stone/internal/domain/entity"
+	"github.com/fms85/desafio-tecnico-go-stone/internal/domain/types"
+	"github.com/fms85/desafio-tecnico-go-stone/internal/driver/notifier"
+	otelDriver "github.com/fms85/desafio-tecnico-go-stone/internal/driver/otel"
+	pixKeyRepository "github.com/fms85/desafio-tecnico-go-stone/internal/repository/pixkey"
+	accountUsecase "github.com/fms85/desafio-tecnico-go-stone/internal/usecase/account"
+	"github.com/fms85/desafio-tecnico-go-stone/internal/util"
+	"go.opentelemetry.io/otel"
+)
+
+const (
+	PIX_KEY_MAX_PER_ACCOUNT     = 5
+	PIX_KEY_CODE_DIGITS         = 6
+	PIX_KEY_CODE_TTL            = 30 * time.Minute
+	PIX_KEY_CODE_MAX_ATTEMPTS   = 5
+	PIX_CLAIM_RESOLUTION_PERIOD = 7 * 24 * time.Hour
+)
+
+const (
+	PIX_KEY_LIMIT_ERROR         = "pix key limit reached"
+	INVALID_EMAIL_KEY_ERROR     = "invalid email key"
+	INVALID_PHONE_KEY_ERROR     = "invalid phone key"
+	NO_CPF_KEY_ERROR            = "account has no cpf to register as a key"
+	PIX_KEY_NOT_PENDING_ERROR   = "pix key is not pending verification"
+	INVALID_CODE_ERROR          = "invalid verification code"
+	EXPIRED_CODE_ERROR          = "verification code expired"
+	TOO_MANY_ATTEMPTS_ERROR     = "too many verification attempts"
+	UNCLAIMABLE_KEY_ERROR       = "only email and phone keys can be claimed"
+	OWN_KEY_CLAIM_ERROR         = "pix key already belongs to the account"
+	CLAIM_NOT_OPEN_ERROR        = "pix key claim is not open"
+	CLAIM_OWNER_ONLY_ERROR      = "only the key owner can confirm a claim"
+	CLAIM_CLAIMER_ONLY_ERROR    = "only the claimer can complete a claim"
+	CLAIM_PORTABILITY_ERROR     = "portability claims must be confirmed by the key owner"
+	CLAIM_RESOLUTION_OPEN_ERROR = "pix key claim resolution period has not ended"
+)
+
+var pixKeyVerificationColumns = []string{"status", "verification_hash", "verification_expires_at", "verification_attempts"}
+
+var tracer = otel.Tracer("internal/usecase/pixkey")
+
+type pixKeyUsecase struct {
+	pixKeyRepository pixKeyRepository.IPixKeyRepository
+	accountUsecase   accountUsecase.IAccountUsecase
+	notifier         notifier.INotifier
+	now              func() time.Time
+}
+
+func New(pixKeyRepository pixKeyRepository.IPixKeyRepository, accountUsecase accountUsecase.IAccountUsecase, notifier notifier.INotifier) IPixKeyUsecase {
+	return &pixKeyUsecase{
+		pixKeyRepository: pixKeyRepository,
+		accountUsecase:   accountUsecase,
+		notifier:         notifier,
+		now:              time.Now,
+	}
+}
+
+// Create registers a key to an account. CPF and random keys are active at
+// once; email and phone keys stay pending until the code sent to them is
+// verified.
+func (usecase *pixKeyUsecase) Create(ctx context.Context, pixKeyInput types.PixKeyInput) (pixKey *entity.PixKey, err error) {
+	ctx, span := tracer.Start(ctx, "pixKeyUsecase.Create")
+	defer func() { otelDriver.End(span, err) }()
+
+	pixKey = &entity.PixKey{
+		AccountID: pixKeyInput.AccountID,
+		Type:      pixKeyInput.Type,
+		Status:    entity.PIX_KEY_STATUS_ACTIVE,
+	}
+
+	switch pixKeyInput.Type {
+	case entity.PIX_KEY_TYPE_CPF:
+		account, err := usecase.accountUsecase.Get(ctx, types.AccountInput{ID: pixKeyInput.AccountID})
+		if err != nil {
+			return nil, err
+		}
+
+		if account.CPF == "" {
+			return nil, &common.ValidationError{Msg: NO_CPF_KEY_ERROR}
+		}
+
+		pixKey.Key = account.CPF
+	case entity.PIX_KEY_TYPE_EVP:
+		pixKey.Key = util.GenerateUUID()
+	case entity.PIX_KEY_TYPE_EMAIL:
+		email, ok := util.NormalizeEmail(pixKeyInput.Key)
+		if !ok {
+			return nil, &common.ValidationError{Msg: INVALID_EMAIL_KEY_ERROR}
+		}
+
+		pixKey.Key = email
+	case entity.PIX_KEY_TYPE_PHONE:
+		phone, ok := util.NormalizePhone(pixKeyInput.Key)
+		if !ok {
+			return nil, &common.ValidationError{Msg: INVALID_PHONE_KEY_ERROR}
+		}
+
+		pixKey.Key = phone
+	}
+
+	if err := usecase.checkLimit(ctx, pixKeyInput.AccountID); err != nil {
+		return nil, err
+	}
+
+	if err := usecase.release(ctx, pixKey.Key); err != nil {
+		return nil, err
+	}
+
+	var code string
+	if pixKey.Type == entity.PIX_KEY_TYPE_EMAIL || pixKey.Type == entity.PIX_KEY_TYPE_PHONE {
+		code = usecase.resetVerification(pixKey)
+	}
+
+	if err := usecase.pixKeyRepository.Create(ctx, pixKey); err != nil {
+		return nil, err
+	}
+
+	if code != "" {
+		if err := usecase.sendCode(ctx, pixKey, code); err != nil {
+			return nil, err
+		}
+	}
+
+	return pixKey, nil
+}
+
+func (usecase *pixKeyUsecase) GetAll(ctx context.Context, accountID uint) (pixKeys []*entity.PixKey, err error) {
+	ctx, span := tracer.Start(ctx, "pixKeyUsecase.GetAll")
+	defer func() { otelDriver.End(span, err) }()
+
+	return usecase.pixKeyRepository.GetAll(ctx, accountID)
+}
+
+// Verify activates a pending key with the code sent to it. Every try takes
+// one of the attempts of the code on the primary before it is compared, so
+// parallel guesses cannot get past the limit, after which a new code has to
+// be requested by registering the key again once it expires.
+func (usecase *pixKeyUsecase) Verify(ctx context.Context, keyID uint, accountID uint, code string) (pixKey *entity.PixKey, err error) {
+	ctx, span := tracer.Start(ctx, "pixKeyUsecase.Verify")
+	defer func() { otelDriver.End(span, err) }()
+
+	pixKey, err = usecase.get(ctx, keyID, accountID)
+	if err != nil {
+		return nil, err
+	}
+
+	if pixKey.Status != entity.PIX_KEY_STATUS_PENDING {
+		return nil, &common.ValidationError{Msg: PIX_KEY_NOT_PENDING_ERROR}
+	}
+
+	if pixKey.VerificationAttempts >= PIX_KEY_CODE_MAX_ATTEMPTS {
+		return nil, &common.LimitExceededError{Msg: TOO_MANY_ATTEMPTS_ERROR}
+	}
+
+	if usecase.expired(pixKey) {
+		return nil, &common.ValidationError{Msg: EXPIRED_CODE_ERROR}
+	}
+
+	claimed, err := usecase.pixKeyRepository.ClaimVerificationAttempt(ctx, pixKey.ID, PIX_KEY_CODE_MAX_ATTEMPTS)
+	if err != nil {
+		return nil, err
+	}
+
+	if !claimed {
+		return nil, &common.LimitExceededError{Msg: TOO_MANY_ATTEMPTS_ERROR}
+	}
+
+	if verificationHash(pixKey.Key, code) != pixKey.VerificationHash {
+		return nil, &common.ValidationError{Msg: INVALID_CODE_ERROR}
+	}
+
+	pixKey.Status = entity.PIX_KEY_STATUS_ACTIVE
+	pixKey.VerificationHash = ""
+	pixKey.VerificationExpiresAt = nil
+	pixKey.VerificationAttempts = 0
+
+	if err := usecase.pixKeyRepository.Update(ctx, pixKey, pixKeyVerificationColumns); err != nil {
+		return nil, err
+	}
+
+	return pixKey, nil
+}
+
+func (usecase *pixKeyUsecase) Delete(ctx context.Context, keyID uint, accountID uint) (err error) {
+	ctx, span := tracer.Start(ctx, "pixKeyUsecase.Delete")
+	defer func() { otelDriver.End(span, err) }()
+
+	pixKey, err := usecase.get(ctx, keyID, accountID)
+	if err != nil {
+		return err
+	}
+
+	return usecase.pixKeyRepository.Delete(ctx, pixKey)
+}
+
+// Lookup resolves an active key to its account. Pending keys are not found,
+// so a key cannot be paid to before it is verified.
+func (usecase *pixKeyUsecase) Lookup(ctx context.Context, key string) (lookup *types.PixKeyLookup, err error) {
+	ctx, span := tracer.Start(ctx, "pixKeyUsecase.Lookup")
+	defer func() { otelDriver.End(span, err) }()
+
+	pixKey, err := usecase.pixKeyRepository.Get(ctx, types.NormalizePixKey(key))
+	if err != nil {
+		return nil, err
+	}
+
+	if pixKey.ID == 0 || pixKey.Status != entity.PIX_KEY_STATUS_ACTIVE {
+		return nil, fmt.Errorf("pix key %w", &common.NotFoundError{})
+	}
+
+	account, err := usecase.accountUsecase.Get(ctx, types.AccountInput{ID: pixKey.AccountID})
+	if err != nil {
+		return nil, err
+	}
+
+	return types.CreatePixKeyLookup(pixKey, account), nil
+}
+
+func (usecase *pixKeyUsecase) get(ctx context.Context, keyID uint, accountID uint) (*entity.PixKey, error) {
+	pixKey, err := usecase.pixKeyRepository.GetByID(ctx, keyID)
+	if err != nil {
+		return nil, err
+	}
+
+	if pixKey.ID == 0 || pixKey.AccountID != accountID {
+		return nil, fmt.Errorf("pix key %w", &common.NotFoundError{})
+	}
+
+	return pixKey, nil
+}
+
+func (usecase *pixKeyUsecase) checkLimit(ctx context.Context, accountID uint) error {
+	pixKeys, err := usecase.pixKeyRepository.GetAll(ctx, accountID)
+	if err != nil {
+		return err
+	}
+
+	if len(pixKeys) >= PIX_KEY_MAX_PER_ACCOUNT {
+		return &common.LimitExceededError{Msg: PIX_KEY_LIMIT_ERROR}
+	}
+
+	return nil
+}
+
+// release frees a key left pending past its code expiry, so an address
+// nobody verified cannot be held forever. Any other registration is a
+// conflict.
+func (usecase *pixKeyUsecase) release(ctx context.Context, key string) error {
+	pixKey, err := usecase.pixKeyRepository.Get(ctx, key)
+	if err != nil {
+		return err
+	}
+
+	if pixKey.ID == 0 {
+		return nil
+	}
+
+	if pixKey.Status != entity.PIX_KEY_STATUS_PENDING || !usecase.expired(pixKey) {
+		return fmt.Errorf("pix key %w", &common.ConflictError{})
+	}
+
+	return usecase.pixKeyRepository.Delete(ctx, pixKey)
+}
+
+func (usecase *pixKeyUsecase) expired(pixKey *entity.PixKey) bool {
+	return pixKey.VerificationExpiresAt != nil && !usecase.now().Before(*pixKey.VerificationExpiresAt)
+}
+
+// resetVerification makes a key pending with a new code, which is returned
+// to be sent; only its hash is kept.
+func (usecase *pixKeyUsecase) resetVerification(pixKey *entity.PixKey) string {
+	code := util.GenerateNumericCode(PIX_KEY_CODE_DIGITS)
+	expiresAt := usecase.now().Add(PIX_KEY_CODE_TTL)
+
+	pixKey.Status = entity.PIX_KEY_STATUS_PENDING
+	pixKey.VerificationHash = verificationHash(pixKey.Key, code)
+	pixKey.VerificationExpiresAt = &expiresAt
+	pixKey.VerificationAttempts = 0
+
+	return code
+}
+
+func (usecase *pixKeyUsecase) sendCode(ctx context.Context, pixKey *entity.PixKey, code string) error {
+	return usecase.notifier.Send(ctx, notifier.Message{
+		To:      pixKey.Key,
+		Subject: "Pix key verification",
+		Body:    fmt.Sprintf("Your pix key verification code is %s", code),
+	})
+}
+
+func verificationHash(key string, code string) string {
+	return util.GenerateHash(key + ":" + code)
+}
@@ -0,0 +1,383 @@
+package pixkey
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/fms85/desafio-tecnico-go-stone/internal/domain/common"
+	"github.com/fms85/desafio-tecnico-go-stone/internal/domain/entity"
+	"github.com/fms85/desafio-tecnico-go-stone/internal/domain/types"
+	"github.com/fms85/desafio-tecnico-go-stone/internal/driver/notifier"
+	pixKeyRepository "github.com/fms85/desafio-tecnico-go-stone/internal/repository/pixkey"
+	accountUsecase "github.com/fms85/desafio-tecnico-go-stone/internal/usecase/account"
+	"github.com/fms85/desafio-tecnico-go-stone/internal/util"
+	"github.com/google/go-cmp/cmp"
+	"github.com/stretchr/testify/mock"
+	"gotest.tools/assert"
+)
+
+var nowTest = time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+
+func newUsecaseTest(repo pixKeyRepository.IPixKeyRepository, account accountUsecase.IAccountUsecase, messages *notifier.MemoryNotifier) *pixKeyUsecase {
+	usecase := New(repo, account, messages).(*pixKeyUsecase)
+	usecase.now = func() time.Time { return nowTest }
+
+	return usecase
+}
+
+func getAccountTest() *entity.Account {
+	return &entity.Account{ID: 3, Name: "Loren Ipsum", CPF: "64537643060"}
+}
+
+func getPendingKeyTest(code string, expiresAt time.Time, attempts int) *entity.PixKey {
+	return &entity.PixKey{
+		ID:                    7,
+		AccountID:             3,
+		Type:                  entity.PIX_KEY_TYPE_EMAIL,
+		Key:                   "loren@example.com",
+		Status:                entity.PIX_KEY_STATUS_PENDING,
+		VerificationHash:      verificationHash("loren@example.com", code),
+		VerificationExpiresAt: &expiresAt,
+		VerificationAttempts:  attempts,
+	}
+}
+
+func TestPixKeyUsecaseCreate(t *testing.T) {
+	expired := nowTest.Add(-time.Minute)
+
+	tests := []struct {
+		name        string
+		input       types.PixKeyInput
+		keys        []*entity.PixKey
+		existing    *entity.PixKey
+		wantKey     string
+		wantStatus  string
+		wantDeleted bool
+		wantNotice  bool
+		wantErr     error
+	}{
+		{
+			name:       "should_register_the_cpf_of_the_account",
+			input:      types.PixKeyInput{AccountID: 3, Type: entity.PIX_KEY_TYPE_CPF},
+			existing:   &entity.PixKey{},
+			wantKey:    "64537643060",
+			wantStatus: entity.PIX_KEY_STATUS_ACTIVE,
+		},
+		{
+			name:       "should_register_an_email_pending_verification",
+			input:      types.PixKeyInput{AccountID: 3, Type: entity.PIX_KEY_TYPE_EMAIL, Key: " Loren@Example.com "},
+			existing:   &entity.PixKey{},
+			wantKey:    "loren@example.com",
+			wantStatus: entity.PIX_KEY_STATUS_PENDING,
+			wantNotice: true,
+		},
+		{
+			name:       "should_normalize_a_phone",
+			input:      types.PixKeyInput{AccountID: 3, Type: entity.PIX_KEY_TYPE_PHONE, Key: "+55 (61) 91234-5678"},
+			existing:   &entity.PixKey{},
+			wantKey:    "+5561912345678",
+			wantStatus: entity.PIX_KEY_STATUS_PENDING,
+			wantNotice: true,
+		},
+		{
+			name:        "should_take_over_a_key_left_pending_past_expiry",
+			input:       types.PixKeyInput{AccountID: 3, Type: entity.PIX_KEY_TYPE_EMAIL, Key: "loren@example.com"},
+			existing:    &entity.PixKey{ID: 9, AccountID: 4, Key: "loren@example.com", Status: entity.PIX_KEY_STATUS_PENDING, VerificationExpiresAt: &expired},
+			wantKey:     "loren@example.com",
+			wantStatus:  entity.PIX_KEY_STATUS_PENDING,
+			wantDeleted: true,
+			wantNotice:  true,
+		},
+		{
+			name:     "should_return_conflict_when_the_key_is_registered",
+			input:    types.PixKeyInput{AccountID: 3, Type: entity.PIX_KEY_TYPE_CPF},
+			existing: &entity.PixKey{ID: 9, AccountID: 3, Key: "64537643060", Status: entity.PIX_KEY_STATUS_ACTIVE},
+			wantErr:  &common.ConflictError{},
+		},
+		{
+			name:    "should_reject_an_invalid_phone",
+			input:   types.PixKeyInput{AccountID: 3, Type: entity.PIX_KEY_TYPE_PHONE, Key: "61912345678"},
+			wantErr: &common.ValidationError{},
+		},
+		{
+			name:    "should_reject_more_keys_than_the_limit",
+			input:   types.PixKeyInput{AccountID: 3, Type: entity.PIX_KEY_TYPE_EVP},
+			keys:    make([]*entity.PixKey, PIX_KEY_MAX_PER_ACCOUNT),
+			wantErr: &common.LimitExceededError{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &pixKeyRepository.PixKeyRepositoryMock{}
+			repo.On("GetAll", mock.Anything, uint(3)).Return(tt.keys, nil)
+			repo.On("Get", mock.Anything, mock.Anything).Return(tt.existing, nil)
+			repo.On("Delete", mock.Anything, tt.existing).Return(nil)
+			repo.On("Create", mock.Anything, mock.Anything).Return(nil)
+
+			account := &accountUsecase.AccountUsecaseMock{}
+			account.On("Get", mock.Anything, types.AccountInput{ID: 3}).Return(getAccountTest(), nil)
+
+			messages := &notifier.MemoryNotifier{}
+
+			got, err := newUsecaseTest(repo, account, messages).Create(context.Background(), tt.input)
+			if tt.wantErr != nil {
+				assertCode(t, err, tt.wantErr)
+
+				repo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+				return
+			}
+
+			assert.NilError(t, err)
+			assert.Equal(t, tt.wantKey, got.Key)
+			assert.Equal(t, tt.wantStatus, got.Status)
+
+			if tt.wantDeleted {
+				repo.AssertCalled(t, "Delete", mock.Anything, tt.existing)
+			} else {
+				repo.AssertNotCalled(t, "Delete", mock.Anything, mock.Anything)
+			}
+
+			sent := messages.Messages()
+			if !tt.wantNotice {
+				assert.Equal(t, 0, len(sent))
+				return
+			}
+
+			assert.Equal(t, 1, len(sent))
+			assert.Equal(t, tt.wantKey, sent[0].To)
+
+			code := sent[0].Body[len(sent[0].Body)-PIX_KEY_CODE_DIGITS:]
+			assert.Equal(t, verificationHash(tt.wantKey, code), got.VerificationHash)
+			assert.Equal(t, nowTest.Add(PIX_KEY_CODE_TTL), *got.VerificationExpiresAt)
+		})
+	}
+}
+
+func TestPixKeyUsecaseCreateEvp(t *testing.T) {
+	repo := &pixKeyRepository.PixKeyRepositoryMock{}
+	repo.On("GetAll", mock.Anything, uint(3)).Return([]*entity.PixKey{}, nil)
+	repo.On("Get", mock.Anything, mock.Anything).Return(&entity.PixKey{}, nil)
+	repo.On("Create", mock.Anything, mock.Anything).Return(nil)
+
+	got, err := newUsecaseTest(repo, &accountUsecase.AccountUsecaseMock{}, &notifier.MemoryNotifier{}).
+		Create(context.Background(), types.PixKeyInput{AccountID: 3, Type: entity.PIX_KEY_TYPE_EVP})
+
+	assert.NilError(t, err)
+	assert.Assert(t, util.IsUUID(got.Key), got.Key)
+	assert.Equal(t, entity.PIX_KEY_STATUS_ACTIVE, got.Status)
+}
+
+func TestPixKeyUsecaseCreateCpfOfBusiness(t *testing.T) {
+	repo := &pixKeyRepository.PixKeyRepositoryMock{}
+	repo.On("GetAll", mock.Anything, uint(3)).Return([]*entity.PixKey{}, nil)
+
+	account := &accountUsecase.AccountUsecaseMock{}
+	account.On("Get", mock.Anything, types.AccountInput{ID: 3}).Return(&entity.Account{ID: 3, Type: entity.ACCOUNT_TYPE_PJ, CNPJ: "11222333000181"}, nil)
+
+	_, err := newUsecaseTest(repo, account, &notifier.MemoryNotifier{}).
+		Create(context.Background(), types.PixKeyInput{AccountID: 3, Type: entity.PIX_KEY_TYPE_CPF})
+
+	assertCode(t, err, &common.ValidationError{})
+	repo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+}
+
+func TestPixKeyUsecaseVerify(t *testing.T) {
+	valid := nowTest.Add(time.Minute)
+
+	tests := []struct {
+		name        string
+		key         *entity.PixKey
+		code        string
+		exhausted   bool
+		wantStatus  string
+		wantClaim   bool
+		wantUpdated bool
+		wantErr     error
+	}{
+		{
+			name:        "should_activate_the_key",
+			key:         getPendingKeyTest("123456", valid, 0),
+			code:        "123456",
+			wantStatus:  entity.PIX_KEY_STATUS_ACTIVE,
+			wantClaim:   true,
+			wantUpdated: true,
+		},
+		{
+			name:       "should_count_a_wrong_code",
+			key:        getPendingKeyTest("123456", valid, 1),
+			code:       "654321",
+			wantStatus: entity.PIX_KEY_STATUS_PENDING,
+			wantClaim:  true,
+			wantErr:    &common.ValidationError{},
+		},
+		{
+			name:       "should_reject_an_expired_code",
+			key:        getPendingKeyTest("123456", nowTest, 0),
+			code:       "123456",
+			wantStatus: entity.PIX_KEY_STATUS_PENDING,
+			wantErr:    &common.ValidationError{},
+		},
+		{
+			name:       "should_reject_after_too_many_attempts",
+			key:        getPendingKeyTest("123456", valid, PIX_KEY_CODE_MAX_ATTEMPTS),
+			code:       "123456",
+			wantStatus: entity.PIX_KEY_STATUS_PENDING,
+			wantErr:    &common.LimitExceededError{},
+		},
+		{
+			name:       "should_reject_once_the_attempts_were_taken_meanwhile",
+			key:        getPendingKeyTest("123456", valid, 1),
+			code:       "123456",
+			exhausted:  true,
+			wantStatus: entity.PIX_KEY_STATUS_PENDING,
+			wantClaim:  true,
+			wantErr:    &common.LimitExceededError{},
+		},
+		{
+			name:    "should_return_not_found_for_keys_of_other_accounts",
+			key:     &entity.PixKey{ID: 7, AccountID: 4, Status: entity.PIX_KEY_STATUS_PENDING},
+			code:    "123456",
+			wantErr: &common.NotFoundError{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &pixKeyRepository.PixKeyRepositoryMock{}
+			repo.On("GetByID", mock.Anything, uint(7)).Return(tt.key, nil)
+			repo.On("ClaimVerificationAttempt", mock.Anything, uint(7), PIX_KEY_CODE_MAX_ATTEMPTS).Return(!tt.exhausted, nil)
+			repo.On("Update", mock.Anything, tt.key, pixKeyVerificationColumns).Return(nil)
+
+			got, err := newUsecaseTest(repo, &accountUsecase.AccountUsecaseMock{}, &notifier.MemoryNotifier{}).
+				Verify(context.Background(), 7, 3, tt.code)
+			if tt.wantErr != nil {
+				assertCode(t, err, tt.wantErr)
+			} else {
+				assert.NilError(t, err)
+				assert.Equal(t, tt.key, got)
+			}
+
+			if tt.wantStatus != "" {
+				assert.Equal(t, tt.wantStatus, tt.key.Status)
+			}
+
+			if tt.wantClaim {
+				repo.AssertCalled(t, "ClaimVerificationAttempt", mock.Anything, uint(7), PIX_KEY_CODE_MAX_ATTEMPTS)
+			} else {
+				repo.AssertNotCalled(t, "ClaimVerificationAttempt", mock.Anything, mock.Anything, mock.Anything)
+			}
+
+			if tt.wantUpdated {
+				repo.AssertCalled(t, "Update", mock.Anything, tt.key, pixKeyVerificationColumns)
+			} else {
+				repo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything, mock.Anything)
+			}
+		})
+	}
+}
+
+func TestPixKeyUsecaseVerifyConcurrently(t *testing.T) {
+	valid := nowTest.Add(time.Minute)
+	guesses := 4 * PIX_KEY_CODE_MAX_ATTEMPTS
+
+	repo := &pixKeyRepository.PixKeyRepositoryMock{}
+	repo.On("GetByID", mock.Anything, uint(7)).Return(func(context.Context, uint) (*entity.PixKey, error) {
+		return getPendingKeyTest("123456", valid, 0), nil
+	})
+	repo.On("ClaimVerificationAttempt", mock.Anything, uint(7), PIX_KEY_CODE_MAX_ATTEMPTS).Return(true, nil).Times(PIX_KEY_CODE_MAX_ATTEMPTS)
+	repo.On("ClaimVerificationAttempt", mock.Anything, uint(7), PIX_KEY_CODE_MAX_ATTEMPTS).Return(false, nil)
+
+	usecase := newUsecaseTest(repo, &accountUsecase.AccountUsecaseMock{}, &notifier.MemoryNotifier{})
+
+	errs := make(chan error, guesses)
+	var wg sync.WaitGroup
+	for i := 0; i < guesses; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			_, err := usecase.Verify(context.Background(), 7, 3, fmt.Sprintf("%06d", 200000+i))
+			errs <- err
+		}(i)
+	}
+	wg.Wait()
+	close(errs)
+
+	var validationError *common.ValidationError
+	var limitExceededError *common.LimitExceededError
+	compared, refused := 0, 0
+	for err := range errs {
+		switch {
+		case errors.As(err, &validationError):
+			compared++
+		case errors.As(err, &limitExceededError):
+			refused++
+		default:
+			t.Fatalf("unexpected error %v", err)
+		}
+	}
+
+	assert.Equal(t, PIX_KEY_CODE_MAX_ATTEMPTS, compared)
+	assert.Equal(t, guesses-PIX_KEY_CODE_MAX_ATTEMPTS, refused)
+	repo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestPixKeyUsecaseLookup(t *testing.T) {
+	tests := []struct {
+		name    string
+		key     string
+		stored  *entity.PixKey
+		want    *types.PixKeyLookup
+		wantErr bool
+	}{
+		{
+			name:   "should_return_the_masked_owner",
+			key:    "Loren@Example.com",
+			stored: &entity.PixKey{ID: 7, AccountID: 3, Type: entity.PIX_KEY_TYPE_EMAIL, Key: "loren@example.com", Status: entity.PIX_KEY_STATUS_ACTIVE},
+			want: &types.PixKeyLookup{
+				Key:       "l****@example.com",
+				Type:      entity.PIX_KEY_TYPE_EMAIL,
+				AccountID: 3,
+				Name:      util.MaskName("Loren Ipsum"),
+				CPF:       util.MaskCPF("64537643060"),
+			},
+		},
+		{
+			name:    "should_not_find_pending_keys",
+			key:     "loren@example.com",
+			stored:  &entity.PixKey{ID: 7, AccountID: 3, Key: "loren@example.com", Status: entity.PIX_KEY_STATUS_PENDING},
+			wantErr: true,
+		},
+		{
+			name:    "should_not_find_unknown_keys",
+			key:     "645.376.430-60",
+			stored:  &entity.PixKey{},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &pixKeyRepository.PixKeyRepositoryMock{}
+			repo.On("Get", mock.Anything, types.NormalizePixKey(tt.key)).Return(tt.stored, nil)
+
+			account := &accountUsecase.AccountUsecaseMock{}
+			account.On("Get", mock.Anything, types.AccountInput{ID: 3}).Return(getAccountTest(), nil)
+
+			got, err := newUsecaseTest(repo, account, &notifier.MemoryNotifier{}).Lookup(context.Background(), tt.key)
+			if tt.wantErr {
+				var notFoundError *common.NotFoundError
+				assert.Assert(t, errors.As(err, &notFoundError), err)
+				assert.Assert(t, strings.HasPrefix(err.Error(), "pix key"))
+				return
+			}
+
+			assert.NilError(t, err)
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("Lookup() mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,12 @@
+package statement
+
+import (
+	"context"
+
+	"github.com/fms85/desafio-tecnico-go-stone/internal/domain/types"
+)
+
+type IStatementUsecase interface {
+	Get(ctx context.Context, statementInput types.StatementInput) (*types.Statement, error)
+	Export(ctx context.Context, statementInput types.StatementInput, writer types.StatementWriter) error
+}
@@ -0,0 +1,69 @@
+// Code generated by mockery v2.33.0. DO NOT EDIT.
+
+package statement
+
+import (
+	context "context"
+
+	types "github.com/fms85/desafio-tecnico-go-stone/internal/domain/types"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// StatementUsecaseMock is an autogenerated mock type for the IStatementUsecase type
+type StatementUsecaseMock struct {
+	mock.Mock
+}
+
+// Export provides a mock function with given fields: ctx, statementInput, writer
+func (_m *StatementUsecaseMock) Export(ctx context.Context, statementInput types.StatementInput, writer types.StatementWriter) error {
+	ret := _m.Called(ctx, statementInput, writer)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, types.StatementInput, types.StatementWriter) error); ok {
+		r0 = rf(ctx, statementInput, writer)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Get provides a mock function with given fields: ctx, statementInput
+func (_m *StatementUsecaseMock) Get(ctx context.Context, statementInput types.StatementInput) (*types.Statement, error) {
+	ret := _m.Called(ctx, statementInput)
+
+	var r0 *types.Statement
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, types.StatementInput) (*types.Statement, error)); ok {
+		return rf(ctx, statementInput)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, types.StatementInput) *types.Statement); ok {
+		r0 = rf(ctx, statementInput)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*types.Statement)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, types.StatementInput) error); ok {
+		r1 = rf(ctx, statementInput)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewStatementUsecaseMock creates a new instance of StatementUsecaseMock. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewStatementUsecaseMock(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *StatementUsecaseMock {
+	mock := &StatementUsecaseMock{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
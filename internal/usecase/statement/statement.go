@@ -0,0 +1,99 @@
+package statement
+
+import (
+	"context"
+
+	"github.com/fms85/desafio-tecnico-go-stone/internal/domain/common"
+	"github.com/fms85/desafio-tecnico-go-stone/internal/domain/entity"
+	"github.com/fms85/desafio-tecnico-go-stone/internal/domain/types"
+	otelDriver "github.com/fms85/desafio-tecnico-go-stone/internal/driver/otel"
+	ledgerRepository "github.com/fms85/desafio-tecnico-go-stone/internal/repository/ledger"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// STATEMENT_MAX_DAYS caps the days a statement covers, both ends included.
+const STATEMENT_MAX_DAYS = 366
+
+const STATEMENT_RANGE_ERROR = "statement range over 366 days"
+
+var tracer = otel.Tracer("internal/usecase/statement")
+
+type statementUsecase struct {
+	ledgerRepository ledgerRepository.ILedgerRepository
+}
+
+func New(ledgerRepository ledgerRepository.ILedgerRepository) IStatementUsecase {
+	return &statementUsecase{
+		ledgerRepository: ledgerRepository,
+	}
+}
+
+// Get builds the statement from the ledger instead of the balance column, so
+// it reflects the history of the account at the time of the snapshot.
+func (usecase *statementUsecase) Get(ctx context.Context, statementInput types.StatementInput) (*types.Statement, error) {
+	collector := &collector{}
+	if err := usecase.Export(ctx, statementInput, collector); err != nil {
+		return nil, err
+	}
+
+	return collector.statement, nil
+}
+
+// Export streams the statement to writer while the ledger is read, for up to
+// STATEMENT_MAX_DAYS.
+func (usecase *statementUsecase) Export(ctx context.Context, statementInput types.StatementInput, writer types.StatementWriter) (err error) {
+	ctx, span := tracer.Start(ctx, "statementUsecase.Export", trace.WithAttributes(
+		attribute.Int64("statement.account_id", int64(statementInput.AccountID)),
+		attribute.String("statement.format", statementInput.Format),
+	))
+	defer func() { otelDriver.End(span, err) }()
+
+	if statementInput.To.After(statementInput.From.AddDate(0, 0, STATEMENT_MAX_DAYS-1)) {
+		return &common.ValidationError{Msg: STATEMENT_RANGE_ERROR}
+	}
+
+	var statement *types.Statement
+
+	err = usecase.ledgerRepository.Statement(
+		ctx,
+		statementInput.AccountID,
+		statementInput.From,
+		statementInput.To.AddDate(0, 0, 1),
+		func(balance float64) error {
+			statement = types.CreateStatement(statementInput, balance)
+
+			return writer.WriteHeader(statement)
+		},
+		func(entry *entity.LedgerEntry) error {
+			return writer.WriteEntry(statement, statement.Add(entry))
+		},
+	)
+	if err != nil {
+		return err
+	}
+
+	return writer.WriteFooter(statement)
+}
+
+// collector is the StatementWriter behind Get: it keeps every line.
+type collector struct {
+	statement *types.Statement
+}
+
+func (c *collector) WriteHeader(statement *types.Statement) error {
+	c.statement = statement
+
+	return nil
+}
+
+func (c *collector) WriteEntry(statement *types.Statement, entry types.StatementEntry) error {
+	statement.Entries = append(statement.Entries, entry)
+
+	return nil
+}
+
+func (c *collector) WriteFooter(statement *types.Statement) error {
+	return nil
+}
@@ -0,0 +1,246 @@
+package statement
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/fms85/desafio-tecnico-go-stone/internal/domain/common"
+	"github.com/fms85/desafio-tecnico-go-stone/internal/domain/entity"
+	"github.com/fms85/desafio-tecnico-go-stone/internal/domain/types"
+	ledgerRepository "github.com/fms85/desafio-tecnico-go-stone/internal/repository/ledger"
+	"github.com/google/go-cmp/cmp"
+	mock "github.com/stretchr/testify/mock"
+)
+
+func TestStatementUsecaseGet(t *testing.T) {
+	from := time.Date(2026, 10, 1, 0, 0, 0, 0, types.RECEIPT_LOCATION)
+	to := time.Date(2026, 10, 19, 0, 0, 0, 0, types.RECEIPT_LOCATION)
+	transferID := uint(9)
+
+	tests := []struct {
+		name             string
+		ledgerRepository func() *ledgerRepository.LedgerRepositoryMock
+		want             *types.Statement
+		wantErr          bool
+	}{
+		{
+			name: "should_compute_running_and_closing_balances",
+			ledgerRepository: func() *ledgerRepository.LedgerRepositoryMock {
+				repo := &ledgerRepository.LedgerRepositoryMock{}
+				repo.On("Statement", mock.Anything, uint(3), from, to.AddDate(0, 0, 1), mock.Anything, mock.Anything).
+					Return(readLedgerTest(100,
+						getLedgerEntryTest(1, entity.LEDGER_ENTRY_KIND_TRANSFER_OUT, -10.1, &transferID),
+						getLedgerEntryTest(2, entity.LEDGER_ENTRY_KIND_FEE, -0.2, &transferID),
+						getLedgerEntryTest(3, entity.LEDGER_ENTRY_KIND_TRANSFER_IN, 25.3, nil),
+					))
+
+				return repo
+			},
+			want: &types.Statement{
+				AccountID:      3,
+				From:           from,
+				To:             to,
+				OpeningBalance: 100,
+				TotalCredits:   25.3,
+				TotalDebits:    10.3,
+				ClosingBalance: 115,
+				Entries: []types.StatementEntry{
+					getStatementEntryTest(1, entity.LEDGER_ENTRY_KIND_TRANSFER_OUT, -10.1, 89.9, &transferID),
+					getStatementEntryTest(2, entity.LEDGER_ENTRY_KIND_FEE, -0.2, 89.7, &transferID),
+					getStatementEntryTest(3, entity.LEDGER_ENTRY_KIND_TRANSFER_IN, 25.3, 115, nil),
+				},
+			},
+		},
+		{
+			name: "should_keep_opening_balance_when_there_are_no_entries",
+			ledgerRepository: func() *ledgerRepository.LedgerRepositoryMock {
+				repo := &ledgerRepository.LedgerRepositoryMock{}
+				repo.On("Statement", mock.Anything, uint(3), from, to.AddDate(0, 0, 1), mock.Anything, mock.Anything).
+					Return(readLedgerTest(42))
+
+				return repo
+			},
+			want: &types.Statement{
+				AccountID:      3,
+				From:           from,
+				To:             to,
+				OpeningBalance: 42,
+				ClosingBalance: 42,
+				Entries:        []types.StatementEntry{},
+			},
+		},
+		{
+			name: "should_return_an_error_when_repository_fails",
+			ledgerRepository: func() *ledgerRepository.LedgerRepositoryMock {
+				repo := &ledgerRepository.LedgerRepositoryMock{}
+				repo.On("Statement", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(errors.New(""))
+
+				return repo
+			},
+			want:    nil,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			usecase := New(tt.ledgerRepository())
+
+			got, err := usecase.Get(context.Background(), types.StatementInput{AccountID: 3, From: from, To: to})
+			if (err != nil) != tt.wantErr {
+				t.Errorf("error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if diff := cmp.Diff(got, tt.want); diff != "" {
+				t.Error(diff)
+			}
+		})
+	}
+}
+
+func TestStatementUsecaseExport(t *testing.T) {
+	from := time.Date(2026, 10, 1, 0, 0, 0, 0, types.RECEIPT_LOCATION)
+	to := time.Date(2026, 10, 19, 0, 0, 0, 0, types.RECEIPT_LOCATION)
+
+	tests := []struct {
+		name    string
+		writer  *recorderTest
+		want    []string
+		wantErr bool
+	}{
+		{
+			name:   "should_stream_header_entries_and_footer",
+			writer: &recorderTest{},
+			want:   []string{"header 100", "entry 1 90", "entry 2 95", "footer 95"},
+		},
+		{
+			name:    "should_stop_when_writer_fails",
+			writer:  &recorderTest{failOn: "entry 1 90"},
+			want:    []string{"header 100", "entry 1 90"},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &ledgerRepository.LedgerRepositoryMock{}
+			repo.On("Statement", mock.Anything, uint(3), from, to.AddDate(0, 0, 1), mock.Anything, mock.Anything).
+				Return(readLedgerTest(100,
+					getLedgerEntryTest(1, entity.LEDGER_ENTRY_KIND_TRANSFER_OUT, -10, nil),
+					getLedgerEntryTest(2, entity.LEDGER_ENTRY_KIND_TRANSFER_IN, 5, nil),
+				))
+
+			err := New(repo).Export(context.Background(), types.StatementInput{AccountID: 3, From: from, To: to}, tt.writer)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if diff := cmp.Diff(tt.writer.calls, tt.want); diff != "" {
+				t.Error(diff)
+			}
+		})
+	}
+}
+
+type recorderTest struct {
+	calls  []string
+	failOn string
+}
+
+func (r *recorderTest) record(call string) error {
+	r.calls = append(r.calls, call)
+	if call == r.failOn {
+		return errors.New("client went away")
+	}
+
+	return nil
+}
+
+func (r *recorderTest) WriteHeader(statement *types.Statement) error {
+	return r.record(fmt.Sprintf("header %v", statement.OpeningBalance))
+}
+
+func (r *recorderTest) WriteEntry(statement *types.Statement, entry types.StatementEntry) error {
+	return r.record(fmt.Sprintf("entry %d %v", entry.ID, entry.Balance))
+}
+
+func (r *recorderTest) WriteFooter(statement *types.Statement) error {
+	return r.record(fmt.Sprintf("footer %v", statement.ClosingBalance))
+}
+
+// readLedgerTest plays the repository callbacks over the given ledger,
+// stopping at the first error like the real row loop.
+func readLedgerTest(opening float64, entries ...*entity.LedgerEntry) func(context.Context, uint, time.Time, time.Time, func(float64) error, func(*entity.LedgerEntry) error) error {
+	return func(_ context.Context, _ uint, _ time.Time, _ time.Time, openingFn func(float64) error, entryFn func(*entity.LedgerEntry) error) error {
+		if err := openingFn(opening); err != nil {
+			return err
+		}
+
+		for _, entry := range entries {
+			if err := entryFn(entry); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}
+}
+
+func getLedgerEntryTest(id uint, kind string, amount float64, transferID *uint) *entity.LedgerEntry {
+	return &entity.LedgerEntry{
+		ID:         id,
+		AccountID:  3,
+		Kind:       kind,
+		Amount:     amount,
+		TransferID: transferID,
+		CreatedAt:  time.Date(2026, 10, 10, 12, 0, int(id), 0, time.UTC),
+	}
+}
+
+func getStatementEntryTest(id uint, kind string, amount float64, balance float64, transferID *uint) types.StatementEntry {
+	return types.StatementEntry{
+		ID:         id,
+		Kind:       kind,
+		Amount:     amount,
+		Balance:    balance,
+		TransferID: transferID,
+		CreatedAt:  time.Date(2026, 10, 10, 12, 0, int(id), 0, time.UTC).In(types.RECEIPT_LOCATION),
+	}
+}
+
+func TestStatementUsecaseExportRange(t *testing.T) {
+	from := time.Date(2026, 1, 1, 0, 0, 0, 0, types.RECEIPT_LOCATION)
+
+	tests := []struct {
+		name    string
+		to      time.Time
+		wantErr error
+	}{
+		{
+			name: "should_export_up_to_the_maximum_of_days",
+			to:   from.AddDate(0, 0, STATEMENT_MAX_DAYS-1),
+		},
+		{
+			name:    "should_refuse_ranges_over_the_maximum_of_days",
+			to:      from.AddDate(0, 0, STATEMENT_MAX_DAYS),
+			wantErr: &common.ValidationError{Msg: STATEMENT_RANGE_ERROR},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &ledgerRepository.LedgerRepositoryMock{}
+			repo.On("Statement", mock.Anything, uint(3), from, tt.to.AddDate(0, 0, 1), mock.Anything, mock.Anything).
+				Return(readLedgerTest(100))
+
+			err := New(repo).Export(context.Background(), types.StatementInput{AccountID: 3, From: from, To: tt.to}, &recorderTest{})
+			if diff := cmp.Diff(tt.wantErr, err); diff != "" {
+				t.Error(diff)
+			}
+
+			if tt.wantErr != nil {
+				repo.AssertNotCalled(t, "Statement", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+			}
+		})
+	}
+}
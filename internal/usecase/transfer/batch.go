@@ -0,0 +1,174 @@
+package transfer
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/fms85/desafio-tecnico-go-stone/internal/domain/common"
+	"github.com/fms85/desafio-tecnico-go-stone/internal/domain/entity"
+	"github.com/fms85/desafio-tecnico-go-stone/internal/domain/types"
+	otelDriver "github.com/fms85/desafio-tecnico-go-stone/internal/driver/otel"
+	"github.com/fms85/desafio-tecnico-go-stone/internal/driver/prometheus"
+	"github.com/fms85/desafio-tecnico-go-stone/internal/util"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	DESTINATION_NOT_FOUND_ERROR = "account destination not found"
+	INVALID_DESTINATIONS_ERROR  = "invalid transfer batch destinations"
+)
+
+// CreateBatch checks every destination before moving any money, then runs the
+// items in order against the origin balance. In atomic mode an item the
+// balance cannot cover fails the whole batch; in best effort mode only that
+// item fails and the next ones are still tried, also when the balance drops
+// before the batch is committed.
+func (usecase *transferUsecase) CreateBatch(ctx context.Context, batchInput types.TransferBatchInput) (batch *entity.TransferBatch, err error) {
+	ctx, span := tracer.Start(ctx, "transferUsecase.CreateBatch", trace.WithAttributes(
+		attribute.Int64("transfer.account_origin_id", int64(batchInput.AccountOriginID)),
+		attribute.Int("transfer_batch.items", len(batchInput.Items)),
+	))
+	defer func() { otelDriver.End(span, err) }()
+
+	if batchInput.Mode == "" {
+		batchInput.Mode = entity.TRANSFER_BATCH_MODE_ATOMIC
+	}
+
+	accounts, err := usecase.batchAccounts(ctx, batchInput)
+	if err != nil {
+		return nil, err
+	}
+
+	accountOrigin := accounts[batchInput.AccountOriginID]
+
+	batch = &entity.TransferBatch{
+		AccountID: batchInput.AccountOriginID,
+		Mode:      batchInput.Mode,
+		Items:     make([]*entity.TransferBatchItem, 0, len(batchInput.Items)),
+	}
+	batchAggregation := &types.TransferBatchAggregation{
+		Batch:     batch,
+		Transfers: make([]*types.TransferAggregation, 0, len(batchInput.Items)),
+	}
+
+	for i, item := range batchInput.Items {
+		transferAggregation := types.CreateTransferAggregation(types.TransferInput{
+			AccountOriginID:      batchInput.AccountOriginID,
+			AccountDestinationID: item.AccountDestinationID,
+			Amount:               item.Amount,
+		}, accountOrigin, accounts[item.AccountDestinationID])
+
+		batchItem := &entity.TransferBatchItem{
+			Position:             i,
+			AccountDestinationID: item.AccountDestinationID,
+			Amount:               item.Amount,
+			Status:               entity.TRANSFER_BATCH_ITEM_STATUS_COMPLETED,
+		}
+
+		batch.Items = append(batch.Items, batchItem)
+		batchAggregation.Transfers = append(batchAggregation.Transfers, transferAggregation)
+		batch.Total = util.Sum(batch.Total, item.Amount)
+		batch.Completed++
+
+		if err := usecase.setAmount(transferAggregation); err != nil {
+			if batch.Mode == entity.TRANSFER_BATCH_MODE_ATOMIC {
+				return nil, err
+			}
+
+			batchAggregation.FailItem(i, err)
+		}
+	}
+
+	batchAggregation.SetStatus()
+
+	if err := usecase.transferRepository.CreateBatch(ctx, batchAggregation); err != nil {
+		return nil, err
+	}
+
+	usecase.bus.Publish(batchInput.AccountOriginID)
+	for _, item := range batch.Items {
+		outcome := prometheus.TRANSFER_OUTCOME_INSUFFICIENT_FUNDS
+		if item.ErrorCode == common.LIMIT_EXCEEDED_CODE {
+			outcome = prometheus.TRANSFER_OUTCOME_LIMIT_EXCEEDED
+		}
+
+		if item.Status == entity.TRANSFER_BATCH_ITEM_STATUS_COMPLETED {
+			outcome = prometheus.TRANSFER_OUTCOME_SUCCESS
+			usecase.bus.Publish(item.AccountDestinationID)
+		}
+
+		prometheus.ObserveTransfer(outcome, item.Amount)
+	}
+
+	return batch, nil
+}
+
+// GetBatch returns a batch to the account that sent it. Other accounts get a
+// not found error.
+func (usecase *transferUsecase) GetBatch(ctx context.Context, batchID uint, accountID uint) (batch *entity.TransferBatch, err error) {
+	ctx, span := tracer.Start(ctx, "transferUsecase.GetBatch", trace.WithAttributes(
+		attribute.Int64("transfer_batch.id", int64(batchID)),
+	))
+	defer func() { otelDriver.End(span, err) }()
+
+	batch, err = usecase.transferRepository.GetBatch(ctx, batchID)
+	if err != nil {
+		return nil, err
+	}
+
+	if batch.ID == 0 || batch.AccountID != accountID {
+		return nil, fmt.Errorf("transfer batch %w", &common.NotFoundError{})
+	}
+
+	return batch, nil
+}
+
+// batchAccounts loads the origin and every destination at once, by id. All
+// invalid destinations are reported together so the whole file can be fixed
+// in one go.
+func (usecase *transferUsecase) batchAccounts(ctx context.Context, batchInput types.TransferBatchInput) (map[uint]*entity.Account, error) {
+	ids := make([]uint, 0, len(batchInput.Items)+1)
+	ids = append(ids, batchInput.AccountOriginID)
+	for _, item := range batchInput.Items {
+		ids = append(ids, item.AccountDestinationID)
+	}
+
+	found, err := usecase.accountUsecase.GetByIDs(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	accounts := make(map[uint]*entity.Account, len(found))
+	for _, account := range found {
+		accounts[account.ID] = account
+	}
+
+	if accounts[batchInput.AccountOriginID] == nil {
+		return nil, fmt.Errorf("account origin %w", &common.NotFoundError{})
+	}
+
+	var fields []common.FieldError
+	for i, item := range batchInput.Items {
+		field := common.FieldError{Field: fmt.Sprintf("items[%d].account_destination_id", i)}
+
+		switch {
+		case item.AccountDestinationID == batchInput.AccountOriginID:
+			field.Code = "ne"
+			field.Message = SAME_ACCOUNT_ERROR
+		case accounts[item.AccountDestinationID] == nil:
+			field.Code = "exists"
+			field.Message = DESTINATION_NOT_FOUND_ERROR
+		default:
+			continue
+		}
+
+		fields = append(fields, field)
+	}
+
+	if len(fields) > 0 {
+		return nil, &common.ValidationError{Msg: INVALID_DESTINATIONS_ERROR, Fields: fields}
+	}
+
+	return accounts, nil
+}
@@ -0,0 +1,435 @@
+package transfer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/fms85/desafio-tecnico-go-stone/internal/domain/common"
+	"github.com/fms85/desafio-tecnico-go-stone/internal/domain/entity"
+	"github.com/fms85/desafio-tecnico-go-stone/internal/domain/types"
+	"github.com/fms85/desafio-tecnico-go-stone/internal/driver/eventbus"
+	transferRepository "github.com/fms85/desafio-tecnico-go-stone/internal/repository/transfer"
+	accountUsecase "github.com/fms85/desafio-tecnico-go-stone/internal/usecase/account"
+	pixKeyUsecase "github.com/fms85/desafio-tecnico-go-stone/internal/usecase/pixkey"
+	"github.com/google/go-cmp/cmp"
+	mock "github.com/stretchr/testify/mock"
+)
+
+func getBatchInputTest(mode string, items ...types.TransferBatchItemInput) types.TransferBatchInput {
+	return types.TransferBatchInput{AccountOriginID: 1, Mode: mode, Items: items}
+}
+
+func getBatchAccountsTest(originBalance float64) func() *accountUsecase.AccountUsecaseMock {
+	return func() *accountUsecase.AccountUsecaseMock {
+		usecase := &accountUsecase.AccountUsecaseMock{}
+		usecase.On("GetByIDs", mock.Anything, mock.Anything).Return([]*entity.Account{
+			getAccountTest(1, originBalance),
+			getAccountTest(2, 100),
+			getAccountTest(4, 100),
+		}, nil)
+
+		return usecase
+	}
+}
+
+func completedItemTest(position int, destination uint, amount float64) *entity.TransferBatchItem {
+	return &entity.TransferBatchItem{
+		Position:             position,
+		AccountDestinationID: destination,
+		Amount:               amount,
+		Status:               entity.TRANSFER_BATCH_ITEM_STATUS_COMPLETED,
+	}
+}
+
+func failedItemTest(position int, destination uint, amount float64) *entity.TransferBatchItem {
+	return &entity.TransferBatchItem{
+		Position:             position,
+		AccountDestinationID: destination,
+		Amount:               amount,
+		Status:               entity.TRANSFER_BATCH_ITEM_STATUS_FAILED,
+		ErrorCode:            common.INSUFFICIENT_FUNDS_CODE,
+		Error:                common.INSUFFICIENT_FUNDS_ERROR,
+	}
+}
+
+func TestTransferUsecaseCreateBatch(t *testing.T) {
+	tests := []struct {
+		name               string
+		dependencies       dependencies
+		batchInput         types.TransferBatchInput
+		want               *entity.TransferBatch
+		wantTransfers      []bool
+		wantBalances       map[uint]float64
+		wantErr            error
+		wantNotifications  []uint
+		wantNoNotification []uint
+	}{
+		{
+			name: "should_create_atomic_batch_when_balance_covers_all_items",
+			dependencies: dependencies{
+				accountUsecase: getBatchAccountsTest(100),
+				transferRepository: func() *transferRepository.TransferRepositoryMock {
+					repo := &transferRepository.TransferRepositoryMock{}
+					repo.On("CreateBatch", mock.Anything, mock.Anything).Return(nil)
+
+					return repo
+				},
+			},
+			batchInput: getBatchInputTest("",
+				types.TransferBatchItemInput{AccountDestinationID: 2, Amount: 30},
+				types.TransferBatchItemInput{AccountDestinationID: 4, Amount: 20.5},
+			),
+			want: &entity.TransferBatch{
+				AccountID: 1,
+				Mode:      entity.TRANSFER_BATCH_MODE_ATOMIC,
+				Status:    entity.TRANSFER_BATCH_STATUS_COMPLETED,
+				Total:     50.5,
+				Completed: 2,
+				Items: []*entity.TransferBatchItem{
+					completedItemTest(0, 2, 30),
+					completedItemTest(1, 4, 20.5),
+				},
+			},
+			wantTransfers:     []bool{true, true},
+			wantBalances:      map[uint]float64{1: 49.5, 2: 130, 4: 120.5},
+			wantNotifications: []uint{1, 2, 4},
+		},
+		{
+			name: "should_reject_atomic_batch_when_balance_runs_out",
+			dependencies: dependencies{
+				accountUsecase: getBatchAccountsTest(100),
+				transferRepository: func() *transferRepository.TransferRepositoryMock {
+					return &transferRepository.TransferRepositoryMock{}
+				},
+			},
+			batchInput: getBatchInputTest(entity.TRANSFER_BATCH_MODE_ATOMIC,
+				types.TransferBatchItemInput{AccountDestinationID: 2, Amount: 60},
+				types.TransferBatchItemInput{AccountDestinationID: 4, Amount: 60},
+			),
+			wantErr:            &common.InsufficientFundsError{},
+			wantNoNotification: []uint{1, 2, 4},
+		},
+		{
+			name: "should_skip_items_the_balance_cannot_cover_in_best_effort",
+			dependencies: dependencies{
+				accountUsecase: getBatchAccountsTest(100),
+				transferRepository: func() *transferRepository.TransferRepositoryMock {
+					repo := &transferRepository.TransferRepositoryMock{}
+					repo.On("CreateBatch", mock.Anything, mock.Anything).Return(nil)
+
+					return repo
+				},
+			},
+			batchInput: getBatchInputTest(entity.TRANSFER_BATCH_MODE_BEST_EFFORT,
+				types.TransferBatchItemInput{AccountDestinationID: 2, Amount: 60},
+				types.TransferBatchItemInput{AccountDestinationID: 4, Amount: 60},
+				types.TransferBatchItemInput{AccountDestinationID: 2, Amount: 40},
+			),
+			want: &entity.TransferBatch{
+				AccountID: 1,
+				Mode:      entity.TRANSFER_BATCH_MODE_BEST_EFFORT,
+				Status:    entity.TRANSFER_BATCH_STATUS_PARTIAL,
+				Total:     100,
+				Completed: 2,
+				Failed:    1,
+				Items: []*entity.TransferBatchItem{
+					completedItemTest(0, 2, 60),
+					failedItemTest(1, 4, 60),
+					completedItemTest(2, 2, 40),
+				},
+			},
+			wantTransfers:      []bool{true, false, true},
+			wantBalances:       map[uint]float64{1: 0, 2: 200},
+			wantNotifications:  []uint{1, 2},
+			wantNoNotification: []uint{4},
+		},
+		{
+			name: "should_record_failed_best_effort_batch_when_no_item_fits",
+			dependencies: dependencies{
+				accountUsecase: getBatchAccountsTest(5),
+				transferRepository: func() *transferRepository.TransferRepositoryMock {
+					repo := &transferRepository.TransferRepositoryMock{}
+					repo.On("CreateBatch", mock.Anything, mock.Anything).Return(nil)
+
+					return repo
+				},
+			},
+			batchInput: getBatchInputTest(entity.TRANSFER_BATCH_MODE_BEST_EFFORT,
+				types.TransferBatchItemInput{AccountDestinationID: 2, Amount: 10},
+			),
+			want: &entity.TransferBatch{
+				AccountID: 1,
+				Mode:      entity.TRANSFER_BATCH_MODE_BEST_EFFORT,
+				Status:    entity.TRANSFER_BATCH_STATUS_FAILED,
+				Failed:    1,
+				Items: []*entity.TransferBatchItem{
+					failedItemTest(0, 2, 10),
+				},
+			},
+			wantTransfers:      []bool{false},
+			wantNoNotification: []uint{2},
+		},
+		{
+			name: "should_report_items_the_locked_balance_no_longer_covers_in_best_effort",
+			dependencies: dependencies{
+				accountUsecase: getBatchAccountsTest(100),
+				transferRepository: func() *transferRepository.TransferRepositoryMock {
+					repo := &transferRepository.TransferRepositoryMock{}
+					repo.On("CreateBatch", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+						batchAggregation := args.Get(1).(*types.TransferBatchAggregation)
+						batchAggregation.FailItem(1, &common.InsufficientFundsError{})
+						batchAggregation.SetStatus()
+					}).Return(nil)
+
+					return repo
+				},
+			},
+			batchInput: getBatchInputTest(entity.TRANSFER_BATCH_MODE_BEST_EFFORT,
+				types.TransferBatchItemInput{AccountDestinationID: 2, Amount: 30},
+				types.TransferBatchItemInput{AccountDestinationID: 4, Amount: 50},
+			),
+			want: &entity.TransferBatch{
+				AccountID: 1,
+				Mode:      entity.TRANSFER_BATCH_MODE_BEST_EFFORT,
+				Status:    entity.TRANSFER_BATCH_STATUS_PARTIAL,
+				Total:     30,
+				Completed: 1,
+				Failed:    1,
+				Items: []*entity.TransferBatchItem{
+					completedItemTest(0, 2, 30),
+					failedItemTest(1, 4, 50),
+				},
+			},
+			wantTransfers:      []bool{true, false},
+			wantNotifications:  []uint{1, 2},
+			wantNoNotification: []uint{4},
+		},
+		{
+			name: "should_skip_items_over_the_transfer_limit_in_best_effort",
+			dependencies: dependencies{
+				accountUsecase: getBatchAccountsTest(10000),
+				transferRepository: func() *transferRepository.TransferRepositoryMock {
+					repo := &transferRepository.TransferRepositoryMock{}
+					repo.On("CreateBatch", mock.Anything, mock.Anything).Return(nil)
+
+					return repo
+				},
+			},
+			batchInput: getBatchInputTest(entity.TRANSFER_BATCH_MODE_BEST_EFFORT,
+				types.TransferBatchItemInput{AccountDestinationID: 2, Amount: 6000},
+				types.TransferBatchItemInput{AccountDestinationID: 4, Amount: 10},
+			),
+			want: &entity.TransferBatch{
+				AccountID: 1,
+				Mode:      entity.TRANSFER_BATCH_MODE_BEST_EFFORT,
+				Status:    entity.TRANSFER_BATCH_STATUS_PARTIAL,
+				Total:     10,
+				Completed: 1,
+				Failed:    1,
+				Items: []*entity.TransferBatchItem{
+					{
+						Position:             0,
+						AccountDestinationID: 2,
+						Amount:               6000,
+						Status:               entity.TRANSFER_BATCH_ITEM_STATUS_FAILED,
+						ErrorCode:            common.LIMIT_EXCEEDED_CODE,
+						Error:                TRANSFER_LIMIT_ERROR,
+					},
+					completedItemTest(1, 4, 10),
+				},
+			},
+			wantTransfers:      []bool{false, true},
+			wantBalances:       map[uint]float64{1: 9990, 4: 110},
+			wantNotifications:  []uint{1, 4},
+			wantNoNotification: []uint{2},
+		},
+		{
+			name: "should_report_every_invalid_destination_before_transferring",
+			dependencies: dependencies{
+				accountUsecase: getBatchAccountsTest(100),
+				transferRepository: func() *transferRepository.TransferRepositoryMock {
+					return &transferRepository.TransferRepositoryMock{}
+				},
+			},
+			batchInput: getBatchInputTest(entity.TRANSFER_BATCH_MODE_BEST_EFFORT,
+				types.TransferBatchItemInput{AccountDestinationID: 2, Amount: 10},
+				types.TransferBatchItemInput{AccountDestinationID: 1, Amount: 10},
+				types.TransferBatchItemInput{AccountDestinationID: 99, Amount: 10},
+			),
+			wantErr: &common.ValidationError{
+				Msg: INVALID_DESTINATIONS_ERROR,
+				Fields: []common.FieldError{
+					{Field: "items[1].account_destination_id", Code: "ne", Message: SAME_ACCOUNT_ERROR},
+					{Field: "items[2].account_destination_id", Code: "exists", Message: DESTINATION_NOT_FOUND_ERROR},
+				},
+			},
+			wantNoNotification: []uint{1, 2},
+		},
+		{
+			name: "should_return_not_found_when_origin_does_not_exist",
+			dependencies: dependencies{
+				accountUsecase: func() *accountUsecase.AccountUsecaseMock {
+					usecase := &accountUsecase.AccountUsecaseMock{}
+					usecase.On("GetByIDs", mock.Anything, []uint{1, 2}).Return([]*entity.Account{getAccountTest(2, 100)}, nil)
+
+					return usecase
+				},
+				transferRepository: func() *transferRepository.TransferRepositoryMock {
+					return &transferRepository.TransferRepositoryMock{}
+				},
+			},
+			batchInput: getBatchInputTest("",
+				types.TransferBatchItemInput{AccountDestinationID: 2, Amount: 10},
+			),
+			wantErr: fmt.Errorf("account origin %w", &common.NotFoundError{}),
+		},
+		{
+			name: "should_return_error_when_repository_fails",
+			dependencies: dependencies{
+				accountUsecase: getBatchAccountsTest(100),
+				transferRepository: func() *transferRepository.TransferRepositoryMock {
+					repo := &transferRepository.TransferRepositoryMock{}
+					repo.On("CreateBatch", mock.Anything, mock.Anything).Return(errors.New("connection refused"))
+
+					return repo
+				},
+			},
+			batchInput: getBatchInputTest("",
+				types.TransferBatchItemInput{AccountDestinationID: 2, Amount: 10},
+			),
+			wantErr:            errors.New("connection refused"),
+			wantNoNotification: []uint{1, 2},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			bus := eventbus.New()
+			notifications := map[uint]<-chan struct{}{}
+			for _, id := range append(append([]uint{}, tt.wantNotifications...), tt.wantNoNotification...) {
+				ch, unsubscribe := bus.Subscribe(id)
+				defer unsubscribe()
+				notifications[id] = ch
+			}
+
+			repo := tt.dependencies.transferRepository()
+			usecase := New(repo, tt.dependencies.accountUsecase(), &pixKeyUsecase.PixKeyUsecaseMock{}, bus, receiptSecretTest, 0)
+
+			got, err := usecase.CreateBatch(context.Background(), tt.batchInput)
+			if tt.wantErr != nil {
+				if err == nil || err.Error() != tt.wantErr.Error() {
+					t.Fatalf("transferUsecase.CreateBatch() error = %v, wantErr %v", err, tt.wantErr)
+				}
+
+				var validationError *common.ValidationError
+				if errors.As(err, &validationError) {
+					if diff := cmp.Diff(tt.wantErr, validationError); diff != "" {
+						t.Errorf("mismatch (-want +got):\n%s", diff)
+					}
+				}
+			} else if err != nil {
+				t.Fatalf("transferUsecase.CreateBatch() error = %v", err)
+			}
+
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("mismatch (-want +got):\n%s", diff)
+			}
+
+			if tt.wantTransfers != nil {
+				batchAggregation := repo.Calls[0].Arguments.Get(1).(*types.TransferBatchAggregation)
+
+				created := make([]bool, 0, len(batchAggregation.Transfers))
+				balances := map[uint]float64{}
+				for _, transferAggregation := range batchAggregation.Transfers {
+					created = append(created, transferAggregation != nil)
+					if transferAggregation != nil {
+						balances[transferAggregation.AccountOrigin.ID] = transferAggregation.AccountOrigin.Balance
+						balances[transferAggregation.AccountDestination.ID] = transferAggregation.AccountDestination.Balance
+					}
+				}
+
+				if diff := cmp.Diff(tt.wantTransfers, created); diff != "" {
+					t.Errorf("transfers mismatch (-want +got):\n%s", diff)
+				}
+
+				for id, balance := range tt.wantBalances {
+					if balances[id] != balance {
+						t.Errorf("balance of %d = %v, want %v", id, balances[id], balance)
+					}
+				}
+			}
+
+			for _, id := range tt.wantNotifications {
+				if len(notifications[id]) != 1 {
+					t.Errorf("account %d was not notified", id)
+				}
+			}
+
+			for _, id := range tt.wantNoNotification {
+				if len(notifications[id]) != 0 {
+					t.Errorf("account %d was notified", id)
+				}
+			}
+		})
+	}
+}
+
+func TestTransferUsecaseGetBatch(t *testing.T) {
+	tests := []struct {
+		name               string
+		transferRepository func() *transferRepository.TransferRepositoryMock
+		want               *entity.TransferBatch
+		wantErr            bool
+	}{
+		{
+			name: "should_return_batch_of_the_account",
+			transferRepository: func() *transferRepository.TransferRepositoryMock {
+				repo := &transferRepository.TransferRepositoryMock{}
+				repo.On("GetBatch", mock.Anything, uint(1)).Return(&entity.TransferBatch{ID: 1, AccountID: 3}, nil)
+
+				return repo
+			},
+			want: &entity.TransferBatch{ID: 1, AccountID: 3},
+		},
+		{
+			name: "should_return_not_found_for_batch_of_other_account",
+			transferRepository: func() *transferRepository.TransferRepositoryMock {
+				repo := &transferRepository.TransferRepositoryMock{}
+				repo.On("GetBatch", mock.Anything, uint(1)).Return(&entity.TransferBatch{ID: 1, AccountID: 4}, nil)
+
+				return repo
+			},
+			wantErr: true,
+		},
+		{
+			name: "should_return_not_found_for_missing_batch",
+			transferRepository: func() *transferRepository.TransferRepositoryMock {
+				repo := &transferRepository.TransferRepositoryMock{}
+				repo.On("GetBatch", mock.Anything, uint(1)).Return(&entity.TransferBatch{}, nil)
+
+				return repo
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			usecase := New(tt.transferRepository(), &accountUsecase.AccountUsecaseMock{}, &pixKeyUsecase.PixKeyUsecaseMock{}, eventbus.New(), receiptSecretTest, 0)
+
+			got, err := usecase.GetBatch(context.Background(), 1, 3)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("transferUsecase.GetBatch() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			var notFoundError *common.NotFoundError
+			if tt.wantErr && !errors.As(err, &notFoundError) {
+				t.Errorf("transferUsecase.GetBatch() error = %v, want not found", err)
+			}
+
+			if diff := cmp.Diff(tt.want, got); diff != "" {
+				t.Errorf("mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -9,5 +9,11 @@ import (
 
 type ITransferUsecase interface {
 	GetAll(ctx context.Context, transferInput types.TransferInput) ([]*entity.Transfer, error)
-	Create(ctx context.Context, transferInput types.TransferInput) error
+	Create(ctx context.Context, transferInput types.TransferInput) (*entity.Transfer, error)
+	CreateWith(ctx context.Context, transferInput types.TransferInput, record types.TransferRecord) (*entity.Transfer, error)
+	Authorize(ctx context.Context, accountID uint, amount float64, authorization types.TransferAuthorization) error
+	CreateBatch(ctx context.Context, batchInput types.TransferBatchInput) (*entity.TransferBatch, error)
+	GetBatch(ctx context.Context, batchID uint, accountID uint) (*entity.TransferBatch, error)
+	Receipt(ctx context.Context, transferID uint, accountID uint) (*types.TransferReceipt, error)
+	VerifyReceipt(ctx context.Context, code string) (*types.TransferReceipt, error)
 }
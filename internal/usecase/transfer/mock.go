@@ -16,13 +16,13 @@ type TransferUsecaseMock struct {
 	mock.Mock
 }
 
-// Create provides a mock function with given fields: ctx, transferInput
-func (_m *TransferUsecaseMock) Create(ctx context.Context, transferInput types.TransferInput) error {
-	ret := _m.Called(ctx, transferInput)
+// Authorize provides a mock function with given fields: ctx, accountID, amount, authorization
+func (_m *TransferUsecaseMock) Authorize(ctx context.Context, accountID uint, amount float64, authorization types.TransferAuthorization) error {
+	ret := _m.Called(ctx, accountID, amount, authorization)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, types.TransferInput) error); ok {
-		r0 = rf(ctx, transferInput)
+	if rf, ok := ret.Get(0).(func(context.Context, uint, float64, types.TransferAuthorization) error); ok {
+		r0 = rf(ctx, accountID, amount, authorization)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -30,6 +30,84 @@ func (_m *TransferUsecaseMock) Create(ctx context.Context, transferInput types.T
 	return r0
 }
 
+// Create provides a mock function with given fields: ctx, transferInput
+func (_m *TransferUsecaseMock) Create(ctx context.Context, transferInput types.TransferInput) (*entity.Transfer, error) {
+	ret := _m.Called(ctx, transferInput)
+
+	var r0 *entity.Transfer
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, types.TransferInput) (*entity.Transfer, error)); ok {
+		return rf(ctx, transferInput)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, types.TransferInput) *entity.Transfer); ok {
+		r0 = rf(ctx, transferInput)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.Transfer)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, types.TransferInput) error); ok {
+		r1 = rf(ctx, transferInput)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CreateBatch provides a mock function with given fields: ctx, batchInput
+func (_m *TransferUsecaseMock) CreateBatch(ctx context.Context, batchInput types.TransferBatchInput) (*entity.TransferBatch, error) {
+	ret := _m.Called(ctx, batchInput)
+
+	var r0 *entity.TransferBatch
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, types.TransferBatchInput) (*entity.TransferBatch, error)); ok {
+		return rf(ctx, batchInput)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, types.TransferBatchInput) *entity.TransferBatch); ok {
+		r0 = rf(ctx, batchInput)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.TransferBatch)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, types.TransferBatchInput) error); ok {
+		r1 = rf(ctx, batchInput)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CreateWith provides a mock function with given fields: ctx, transferInput, record
+func (_m *TransferUsecaseMock) CreateWith(ctx context.Context, transferInput types.TransferInput, record types.TransferRecord) (*entity.Transfer, error) {
+	ret := _m.Called(ctx, transferInput, record)
+
+	var r0 *entity.Transfer
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, types.TransferInput, types.TransferRecord) (*entity.Transfer, error)); ok {
+		return rf(ctx, transferInput, record)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, types.TransferInput, types.TransferRecord) *entity.Transfer); ok {
+		r0 = rf(ctx, transferInput, record)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.Transfer)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, types.TransferInput, types.TransferRecord) error); ok {
+		r1 = rf(ctx, transferInput, record)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetAll provides a mock function with given fields: ctx, transferInput
 func (_m *TransferUsecaseMock) GetAll(ctx context.Context, transferInput types.TransferInput) ([]*entity.Transfer, error) {
 	ret := _m.Called(ctx, transferInput)
@@ -56,6 +134,84 @@ func (_m *TransferUsecaseMock) GetAll(ctx context.Context, transferInput types.T
 	return r0, r1
 }
 
+// GetBatch provides a mock function with given fields: ctx, batchID, accountID
+func (_m *TransferUsecaseMock) GetBatch(ctx context.Context, batchID uint, accountID uint) (*entity.TransferBatch, error) {
+	ret := _m.Called(ctx, batchID, accountID)
+
+	var r0 *entity.TransferBatch
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint, uint) (*entity.TransferBatch, error)); ok {
+		return rf(ctx, batchID, accountID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint, uint) *entity.TransferBatch); ok {
+		r0 = rf(ctx, batchID, accountID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.TransferBatch)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint, uint) error); ok {
+		r1 = rf(ctx, batchID, accountID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Receipt provides a mock function with given fields: ctx, transferID, accountID
+func (_m *TransferUsecaseMock) Receipt(ctx context.Context, transferID uint, accountID uint) (*types.TransferReceipt, error) {
+	ret := _m.Called(ctx, transferID, accountID)
+
+	var r0 *types.TransferReceipt
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint, uint) (*types.TransferReceipt, error)); ok {
+		return rf(ctx, transferID, accountID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint, uint) *types.TransferReceipt); ok {
+		r0 = rf(ctx, transferID, accountID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*types.TransferReceipt)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint, uint) error); ok {
+		r1 = rf(ctx, transferID, accountID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// VerifyReceipt provides a mock function with given fields: ctx, code
+func (_m *TransferUsecaseMock) VerifyReceipt(ctx context.Context, code string) (*types.TransferReceipt, error) {
+	ret := _m.Called(ctx, code)
+
+	var r0 *types.TransferReceipt
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*types.TransferReceipt, error)); ok {
+		return rf(ctx, code)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *types.TransferReceipt); ok {
+		r0 = rf(ctx, code)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*types.TransferReceipt)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, code)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // NewTransferUsecaseMock creates a new instance of TransferUsecaseMock. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewTransferUsecaseMock(t interface {
@@ -2,30 +2,63 @@ package transfer
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"strings"
 
 	"github.com/fms85/desafio-tecnico-go-stone/internal/domain/common"
 	"github.com/fms85/desafio-tecnico-go-stone/internal/domain/entity"
 	"github.com/fms85/desafio-tecnico-go-stone/internal/domain/types"
+	"github.com/fms85/desafio-tecnico-go-stone/internal/driver/eventbus"
+	otelDriver "github.com/fms85/desafio-tecnico-go-stone/internal/driver/otel"
+	"github.com/fms85/desafio-tecnico-go-stone/internal/driver/prometheus"
 	transferRepository "github.com/fms85/desafio-tecnico-go-stone/internal/repository/transfer"
 	accountUsecase "github.com/fms85/desafio-tecnico-go-stone/internal/usecase/account"
+	pixKeyUsecase "github.com/fms85/desafio-tecnico-go-stone/internal/usecase/pixkey"
 	"github.com/fms85/desafio-tecnico-go-stone/internal/util"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	SAME_ACCOUNT_ERROR   = "origin and destination accounts are equal"
+	TRANSFER_LIMIT_ERROR = "amount is over the transfer limit of the account"
+)
+
+var (
+	tracer                 = otel.Tracer("internal/usecase/transfer")
+	notFoundError          *common.NotFoundError
+	insufficientFundsError *common.InsufficientFundsError
 )
 
 type transferUsecase struct {
 	transferRepository transferRepository.ITransferRepository
 	accountUsecase     accountUsecase.IAccountUsecase
+	pixKeyUsecase      pixKeyUsecase.IPixKeyUsecase
+	bus                eventbus.IBus
+	receiptSecret      string
+	stepUpAmount       float64
 }
 
-func New(transferRepository transferRepository.ITransferRepository, accountUsecase accountUsecase.IAccountUsecase) ITransferUsecase {
+// New takes the amount over which transfers ask for a TOTP code; zero turns
+// step-up authentication off.
+func New(transferRepository transferRepository.ITransferRepository, accountUsecase accountUsecase.IAccountUsecase, pixKeyUsecase pixKeyUsecase.IPixKeyUsecase, bus eventbus.IBus, receiptSecret string, stepUpAmount float64) ITransferUsecase {
 	return &transferUsecase{
 		transferRepository: transferRepository,
 		accountUsecase:     accountUsecase,
+		pixKeyUsecase:      pixKeyUsecase,
+		bus:                bus,
+		receiptSecret:      receiptSecret,
+		stepUpAmount:       stepUpAmount,
 	}
 }
 
-func (usecase *transferUsecase) GetAll(ctx context.Context, transferInput types.TransferInput) ([]*entity.Transfer, error) {
-	transfers, err := usecase.transferRepository.Get(ctx, transferInput)
+func (usecase *transferUsecase) GetAll(ctx context.Context, transferInput types.TransferInput) (transfers []*entity.Transfer, err error) {
+	ctx, span := tracer.Start(ctx, "transferUsecase.GetAll")
+	defer func() { otelDriver.End(span, err) }()
+
+	transfers, err = usecase.transferRepository.Get(ctx, transferInput)
 	if err != nil {
 		return nil, err
 	}
@@ -33,41 +66,190 @@ func (usecase *transferUsecase) GetAll(ctx context.Context, transferInput types.
 	return transfers, nil
 }
 
-func (usecase *transferUsecase) Create(ctx context.Context, transferInput types.TransferInput) error {
+func (usecase *transferUsecase) Create(ctx context.Context, transferInput types.TransferInput) (*entity.Transfer, error) {
+	return usecase.CreateWith(ctx, transferInput, nil)
+}
+
+// CreateWith creates a transfer and has record write what it pays for in the
+// same transaction, so neither is kept without the other.
+func (usecase *transferUsecase) CreateWith(ctx context.Context, transferInput types.TransferInput, record types.TransferRecord) (transfer *entity.Transfer, err error) {
+	ctx, span := tracer.Start(ctx, "transferUsecase.Create", trace.WithAttributes(
+		attribute.Int64("transfer.account_origin_id", int64(transferInput.AccountOriginID)),
+		attribute.Int64("transfer.account_destination_id", int64(transferInput.AccountDestinationID)),
+	))
+
+	outcome := prometheus.TRANSFER_OUTCOME_ERROR
+	defer func() {
+		prometheus.ObserveTransfer(outcome, transferInput.Amount)
+		otelDriver.End(span, err, attribute.String("transfer.outcome", outcome))
+	}()
+
+	if transferInput.DestinationKey != "" {
+		lookup, err := usecase.pixKeyUsecase.Lookup(ctx, transferInput.DestinationKey)
+		if err != nil {
+			if errors.As(err, &notFoundError) {
+				outcome = prometheus.TRANSFER_OUTCOME_NOT_FOUND
+			}
+
+			return nil, err
+		}
+
+		transferInput.AccountDestinationID = lookup.AccountID
+		span.SetAttributes(attribute.Int64("transfer.account_destination_id", int64(lookup.AccountID)))
+	}
+
 	if transferInput.AccountOriginID == transferInput.AccountDestinationID {
-		return &common.ValidationError{Msg: "origin and destination accounts are equal"}
+		outcome = prometheus.TRANSFER_OUTCOME_INVALID
+		return nil, &common.ValidationError{Msg: SAME_ACCOUNT_ERROR}
 	}
 
 	accountOrigin, err := usecase.accountUsecase.Get(ctx, types.AccountInput{ID: transferInput.AccountOriginID})
 	if err != nil {
-		return fmt.Errorf("account origin %w", &common.ValidationError{Msg: common.NOT_FOUND_ERROR})
+		if errors.As(err, &notFoundError) {
+			outcome = prometheus.TRANSFER_OUTCOME_NOT_FOUND
+			return nil, fmt.Errorf("account origin %w", &common.NotFoundError{})
+		}
+
+		return nil, err
 	}
 
 	accountDestination, err := usecase.accountUsecase.Get(ctx, types.AccountInput{ID: transferInput.AccountDestinationID})
 	if err != nil {
-		return fmt.Errorf("account destination %w", &common.ValidationError{Msg: common.NOT_FOUND_ERROR})
+		if errors.As(err, &notFoundError) {
+			outcome = prometheus.TRANSFER_OUTCOME_NOT_FOUND
+			return nil, fmt.Errorf("account destination %w", &common.NotFoundError{})
+		}
+
+		return nil, err
 	}
 
 	transferAggregation := types.CreateTransferAggregation(transferInput, accountOrigin, accountDestination)
+	transferAggregation.Record = record
 	if err := usecase.setAmount(transferAggregation); err != nil {
-		return err
+		outcome = amountOutcome(err)
+		return nil, err
 	}
 
 	if err := usecase.transferRepository.Create(ctx, transferAggregation); err != nil {
+		if errors.As(err, &insufficientFundsError) {
+			outcome = prometheus.TRANSFER_OUTCOME_INSUFFICIENT_FUNDS
+		}
+
+		return nil, err
+	}
+
+	outcome = prometheus.TRANSFER_OUTCOME_SUCCESS
+
+	usecase.bus.Publish(transferInput.AccountOriginID)
+	usecase.bus.Publish(transferInput.AccountDestinationID)
+
+	return transferAggregation.Transfer, nil
+}
+
+// Authorize checks what the owner of the origin account sends to move money:
+// its transaction PIN and, for more than the step-up amount, a fresh TOTP
+// code, a batch counting the sum of its items. It is left to the callers
+// moving money on behalf of the account owner: charge and bill payments call
+// it with the amount they debit, once they know it. API keys pass: they took
+// the secret and TOTP code of the owner when created, and their transfers
+// scope is what authorizes them.
+func (usecase *transferUsecase) Authorize(ctx context.Context, accountID uint, amount float64, authorization types.TransferAuthorization) (err error) {
+	ctx, span := tracer.Start(ctx, "transferUsecase.Authorize")
+	defer func() { otelDriver.End(span, err) }()
+
+	if authorization.APIKeyID != "" {
+		return nil
+	}
+
+	if err := usecase.accountUsecase.VerifyPIN(ctx, accountID, authorization.PIN); err != nil {
 		return err
 	}
 
-	return nil
+	if usecase.stepUpAmount <= 0 || amount <= usecase.stepUpAmount {
+		return nil
+	}
+
+	return usecase.accountUsecase.StepUp(ctx, accountID, authorization.TOTPCode)
 }
 
+// Receipt returns the receipt of a transfer to either of its parties. Other
+// accounts get a not found error so they cannot probe transfer ids.
+func (usecase *transferUsecase) Receipt(ctx context.Context, transferID uint, accountID uint) (receipt *types.TransferReceipt, err error) {
+	ctx, span := tracer.Start(ctx, "transferUsecase.Receipt", trace.WithAttributes(
+		attribute.Int64("transfer.id", int64(transferID)),
+	))
+	defer func() { otelDriver.End(span, err) }()
+
+	transfer, err := usecase.transferRepository.GetByID(ctx, transferID)
+	if err != nil {
+		return nil, err
+	}
+
+	if transfer.ID == 0 || (transfer.AccountOriginID != accountID && transfer.AccountDestinationID != accountID) {
+		return nil, fmt.Errorf("transfer %w", &common.NotFoundError{})
+	}
+
+	code := util.GenerateVerificationCode(transfer.ID, receiptPayload(transfer), usecase.receiptSecret)
+
+	return types.CreateTransferReceipt(transfer, code), nil
+}
+
+// VerifyReceipt returns the receipt a verification code was issued for, as
+// long as the transfer still matches it.
+func (usecase *transferUsecase) VerifyReceipt(ctx context.Context, code string) (receipt *types.TransferReceipt, err error) {
+	ctx, span := tracer.Start(ctx, "transferUsecase.VerifyReceipt")
+	defer func() { otelDriver.End(span, err) }()
+
+	transferID, err := util.ParseVerificationCode(code)
+	if err != nil {
+		return nil, fmt.Errorf("receipt %w", &common.NotFoundError{})
+	}
+
+	transfer, err := usecase.transferRepository.GetByID(ctx, transferID)
+	if err != nil {
+		return nil, err
+	}
+
+	if transfer.ID == 0 || !util.CheckVerificationCode(code, transfer.ID, receiptPayload(transfer), usecase.receiptSecret) {
+		return nil, fmt.Errorf("receipt %w", &common.NotFoundError{})
+	}
+
+	return types.CreateTransferReceipt(transfer, strings.ToUpper(code)), nil
+}
+
+func receiptPayload(transfer *entity.Transfer) string {
+	return fmt.Sprintf("%d|%d|%d|%s|%s|%s|%d",
+		transfer.ID,
+		transfer.AccountOriginID,
+		transfer.AccountDestinationID,
+		util.ConvertToDecimal(transfer.Amount).String(),
+		util.ConvertToDecimal(transfer.Fee).String(),
+		transfer.Status,
+		transfer.CreatedAt.UnixMicro(),
+	)
+}
+
+// setAmount moves the amount between the balances of a transfer, charging
+// the origin the fee of its account type on top. Amounts over the transfer
+// limit of that type are refused before the balance is looked at. The
+// balances come from a replica and only let transfers they cannot cover fail
+// early: the repository moves the amount again over the locked accounts.
 func (usecase *transferUsecase) setAmount(transferAggregation *types.TransferAggregation) error {
-	if transferAggregation.Transfer.Amount > transferAggregation.AccountOrigin.Balance {
-		return &common.ValidationError{Msg: "insufficient funds"}
+	limits := types.GetAccountLimits(transferAggregation.AccountOrigin.Type)
+	if transferAggregation.Transfer.Amount > limits.TransferLimit {
+		return &common.LimitExceededError{Msg: TRANSFER_LIMIT_ERROR}
+	}
+
+	transferAggregation.Transfer.Fee = limits.TransferFee
+	total := util.Sum(transferAggregation.Transfer.Amount, transferAggregation.Transfer.Fee)
+
+	if total > transferAggregation.AccountOrigin.Balance {
+		return &common.InsufficientFundsError{}
 	}
 
 	transferAggregation.AccountOrigin.Balance = util.Sub(
 		transferAggregation.AccountOrigin.Balance,
-		transferAggregation.Transfer.Amount,
+		total,
 	)
 
 	transferAggregation.AccountDestination.Balance = util.Sum(
@@ -77,3 +259,13 @@ func (usecase *transferUsecase) setAmount(transferAggregation *types.TransferAgg
 
 	return nil
 }
+
+// amountOutcome is the metrics outcome of a transfer setAmount refused.
+func amountOutcome(err error) string {
+	var limitExceededError *common.LimitExceededError
+	if errors.As(err, &limitExceededError) {
+		return prometheus.TRANSFER_OUTCOME_LIMIT_EXCEEDED
+	}
+
+	return prometheus.TRANSFER_OUTCOME_INSUFFICIENT_FUNDS
+}
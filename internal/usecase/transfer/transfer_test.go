@@ -3,16 +3,28 @@ package transfer
 import (
 	"context"
 	"errors"
+	"fmt"
+	"strings"
 	"testing"
+	"time"
 
+	"github.com/fms85/desafio-tecnico-go-stone/internal/domain/common"
 	"github.com/fms85/desafio-tecnico-go-stone/internal/domain/entity"
 	"github.com/fms85/desafio-tecnico-go-stone/internal/domain/types"
+	"github.com/fms85/desafio-tecnico-go-stone/internal/driver/eventbus"
+	"github.com/fms85/desafio-tecnico-go-stone/internal/driver/prometheus"
 	transferRepository "github.com/fms85/desafio-tecnico-go-stone/internal/repository/transfer"
 	accountUsecase "github.com/fms85/desafio-tecnico-go-stone/internal/usecase/account"
+	pixKeyUsecase "github.com/fms85/desafio-tecnico-go-stone/internal/usecase/pixkey"
+	"github.com/fms85/desafio-tecnico-go-stone/internal/util"
 	"github.com/google/go-cmp/cmp"
+	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/prometheus/client_golang/prometheus/testutil"
 	mock "github.com/stretchr/testify/mock"
 )
 
+const receiptSecretTest = "receipt-secret"
+
 type dependencies struct {
 	transferRepository func() *transferRepository.TransferRepositoryMock
 	accountUsecase     func() *accountUsecase.AccountUsecaseMock
@@ -58,7 +70,7 @@ func TestTransferUsecaseGet(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			usecase := New(tt.dependencies.transferRepository(), &accountUsecase.AccountUsecaseMock{})
+			usecase := New(tt.dependencies.transferRepository(), &accountUsecase.AccountUsecaseMock{}, &pixKeyUsecase.PixKeyUsecaseMock{}, eventbus.New(), receiptSecretTest, 0)
 
 			got, err := usecase.GetAll(context.Background(), types.TransferInput{})
 			if (err != nil) != tt.wantErr {
@@ -82,6 +94,7 @@ func TestTransferUsecaseCreate(t *testing.T) {
 		dependencies dependencies
 		params       params
 		wantErr      bool
+		wantOutcome  string
 	}{
 		{
 			name: "should_retrieve_transfers_successfully",
@@ -104,7 +117,8 @@ func TestTransferUsecaseCreate(t *testing.T) {
 			params: params{
 				transferInput: getTransferInputTest(1, 2),
 			},
-			wantErr: false,
+			wantErr:     false,
+			wantOutcome: prometheus.TRANSFER_OUTCOME_SUCCESS,
 		},
 		{
 			name: "should_return_an_error_when_origin_and_destination_accounts_are_equal",
@@ -120,7 +134,8 @@ func TestTransferUsecaseCreate(t *testing.T) {
 			params: params{
 				transferInput: getTransferInputTest(1, 1),
 			},
-			wantErr: true,
+			wantErr:     true,
+			wantOutcome: prometheus.TRANSFER_OUTCOME_INVALID,
 		},
 		{
 			name: "should_return_an_error_when_insufficient_funds",
@@ -140,7 +155,8 @@ func TestTransferUsecaseCreate(t *testing.T) {
 			params: params{
 				transferInput: getTransferInputTest(1, 2),
 			},
-			wantErr: true,
+			wantErr:     true,
+			wantOutcome: prometheus.TRANSFER_OUTCOME_INSUFFICIENT_FUNDS,
 		},
 		{
 			name: "should_return_an_error_when_usecase_origin_get_retrieval",
@@ -158,7 +174,8 @@ func TestTransferUsecaseCreate(t *testing.T) {
 			params: params{
 				transferInput: getTransferInputTest(1, 2),
 			},
-			wantErr: true,
+			wantErr:     true,
+			wantOutcome: prometheus.TRANSFER_OUTCOME_ERROR,
 		},
 		{
 			name: "should_return_an_error_when_usecase_destination_get_retrieval",
@@ -177,7 +194,47 @@ func TestTransferUsecaseCreate(t *testing.T) {
 			params: params{
 				transferInput: getTransferInputTest(1, 2),
 			},
-			wantErr: true,
+			wantErr:     true,
+			wantOutcome: prometheus.TRANSFER_OUTCOME_ERROR,
+		},
+		{
+			name: "should_return_not_found_when_origin_account_does_not_exist",
+			dependencies: dependencies{
+				accountUsecase: func() *accountUsecase.AccountUsecaseMock {
+					usecase := &accountUsecase.AccountUsecaseMock{}
+					usecase.On("Get", mock.Anything, mock.Anything).Return(nil, fmt.Errorf("account %w", &common.NotFoundError{})).Once()
+
+					return usecase
+				},
+				transferRepository: func() *transferRepository.TransferRepositoryMock {
+					return &transferRepository.TransferRepositoryMock{}
+				},
+			},
+			params: params{
+				transferInput: getTransferInputTest(1, 2),
+			},
+			wantErr:     true,
+			wantOutcome: prometheus.TRANSFER_OUTCOME_NOT_FOUND,
+		},
+		{
+			name: "should_return_not_found_when_destination_account_does_not_exist",
+			dependencies: dependencies{
+				accountUsecase: func() *accountUsecase.AccountUsecaseMock {
+					usecase := &accountUsecase.AccountUsecaseMock{}
+					usecase.On("Get", mock.Anything, mock.Anything).Return(getAccountTest(1, 100), nil).Once()
+					usecase.On("Get", mock.Anything, mock.Anything).Return(nil, fmt.Errorf("account %w", &common.NotFoundError{})).Once()
+
+					return usecase
+				},
+				transferRepository: func() *transferRepository.TransferRepositoryMock {
+					return &transferRepository.TransferRepositoryMock{}
+				},
+			},
+			params: params{
+				transferInput: getTransferInputTest(1, 2),
+			},
+			wantErr:     true,
+			wantOutcome: prometheus.TRANSFER_OUTCOME_NOT_FOUND,
 		},
 		{
 			name: "should_return_an_error_when_repository_get_retrieval",
@@ -199,17 +256,315 @@ func TestTransferUsecaseCreate(t *testing.T) {
 			params: params{
 				transferInput: getTransferInputTest(1, 2),
 			},
-			wantErr: true,
+			wantErr:     true,
+			wantOutcome: prometheus.TRANSFER_OUTCOME_ERROR,
+		},
+		{
+			name: "should_return_an_error_when_the_locked_balance_no_longer_covers_the_transfer",
+			dependencies: dependencies{
+				accountUsecase: func() *accountUsecase.AccountUsecaseMock {
+					usecase := &accountUsecase.AccountUsecaseMock{}
+					usecase.On("Get", mock.Anything, mock.Anything).Return(getAccountTest(1, 100), nil).Once()
+					usecase.On("Get", mock.Anything, mock.Anything).Return(getAccountTest(2, 100), nil).Once()
+
+					return usecase
+				},
+				transferRepository: func() *transferRepository.TransferRepositoryMock {
+					repo := &transferRepository.TransferRepositoryMock{}
+					repo.On("Create", mock.Anything, mock.Anything).Return(&common.InsufficientFundsError{})
+
+					return repo
+				},
+			},
+			params: params{
+				transferInput: getTransferInputTest(1, 2),
+			},
+			wantErr:     true,
+			wantOutcome: prometheus.TRANSFER_OUTCOME_INSUFFICIENT_FUNDS,
 		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			usecase := New(tt.dependencies.transferRepository(), tt.dependencies.accountUsecase())
+			bus := eventbus.New()
+			origin, unsubscribeOrigin := bus.Subscribe(tt.params.transferInput.AccountOriginID)
+			defer unsubscribeOrigin()
+			destination, unsubscribeDestination := bus.Subscribe(tt.params.transferInput.AccountDestinationID)
+			defer unsubscribeDestination()
+
+			usecase := New(tt.dependencies.transferRepository(), tt.dependencies.accountUsecase(), &pixKeyUsecase.PixKeyUsecaseMock{}, bus, receiptSecretTest, 0)
+			counter := prometheus.TransfersTotal.WithLabelValues(tt.wantOutcome)
+			before := testutil.ToFloat64(counter)
 
-			err := usecase.Create(context.Background(), tt.params.transferInput)
+			transfer, err := usecase.Create(context.Background(), tt.params.transferInput)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("error = %v, wantErr %v", err, tt.wantErr)
 			}
+
+			if (transfer != nil) == tt.wantErr {
+				t.Errorf("transfer = %v, wantErr %v", transfer, tt.wantErr)
+			}
+
+			if got := testutil.ToFloat64(counter); got != before+1 {
+				t.Errorf("outcome %s = %v, want %v", tt.wantOutcome, got, before+1)
+			}
+
+			if notified := len(origin) == 1 && len(destination) == 1; notified == tt.wantErr {
+				t.Errorf("accounts notified = %v, want %v", notified, !tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestTransferUsecaseCreateByKey(t *testing.T) {
+	tests := []struct {
+		name        string
+		lookup      *types.PixKeyLookup
+		lookupErr   error
+		wantErr     error
+		wantOutcome string
+	}{
+		{
+			name:        "should_transfer_to_the_account_of_the_key",
+			lookup:      &types.PixKeyLookup{AccountID: 2},
+			wantOutcome: prometheus.TRANSFER_OUTCOME_SUCCESS,
+		},
+		{
+			name:        "should_reject_a_key_of_the_origin_account",
+			lookup:      &types.PixKeyLookup{AccountID: 1},
+			wantErr:     &common.ValidationError{},
+			wantOutcome: prometheus.TRANSFER_OUTCOME_INVALID,
+		},
+		{
+			name:        "should_return_not_found_for_unknown_keys",
+			lookupErr:   fmt.Errorf("pix key %w", &common.NotFoundError{}),
+			wantErr:     &common.NotFoundError{},
+			wantOutcome: prometheus.TRANSFER_OUTCOME_NOT_FOUND,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			account := &accountUsecase.AccountUsecaseMock{}
+			account.On("Get", mock.Anything, types.AccountInput{ID: 1}).Return(getAccountTest(1, 100), nil)
+			account.On("Get", mock.Anything, types.AccountInput{ID: 2}).Return(getAccountTest(2, 100), nil)
+
+			pixKey := &pixKeyUsecase.PixKeyUsecaseMock{}
+			pixKey.On("Lookup", mock.Anything, "loren@example.com").Return(tt.lookup, tt.lookupErr)
+
+			repo := &transferRepository.TransferRepositoryMock{}
+			repo.On("Create", mock.Anything, mock.Anything).Return(nil)
+
+			usecase := New(repo, account, pixKey, eventbus.New(), receiptSecretTest, 0)
+			counter := prometheus.TransfersTotal.WithLabelValues(tt.wantOutcome)
+			before := testutil.ToFloat64(counter)
+
+			transfer, err := usecase.Create(context.Background(), types.TransferInput{AccountOriginID: 1, DestinationKey: "loren@example.com", Amount: 10})
+			if tt.wantErr != nil {
+				var codedError common.CodedError
+				if !errors.As(err, &codedError) || codedError.Code() != tt.wantErr.(common.CodedError).Code() {
+					t.Fatalf("error = %v, want %v", err, tt.wantErr)
+				}
+
+				repo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+			} else if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			} else {
+				aggregation := repo.Calls[0].Arguments.Get(1).(*types.TransferAggregation)
+				if transfer != aggregation.Transfer {
+					t.Errorf("transfer = %v, want the created one", transfer)
+				}
+				if aggregation.Transfer.AccountDestinationID != 2 {
+					t.Errorf("destination = %d, want 2", aggregation.Transfer.AccountDestinationID)
+				}
+			}
+
+			if got := testutil.ToFloat64(counter); got != before+1 {
+				t.Errorf("outcome %s = %v, want %v", tt.wantOutcome, got, before+1)
+			}
+		})
+	}
+}
+
+func TestTransferUsecaseCreateLimits(t *testing.T) {
+	tests := []struct {
+		name        string
+		accountType string
+		balance     float64
+		amount      float64
+		wantFee     float64
+		wantBalance float64
+		wantErr     error
+		wantOutcome string
+	}{
+		{
+			name:        "should_not_charge_personal_accounts",
+			accountType: entity.ACCOUNT_TYPE_PF,
+			balance:     100,
+			amount:      10,
+			wantBalance: 90,
+			wantOutcome: prometheus.TRANSFER_OUTCOME_SUCCESS,
+		},
+		{
+			name:        "should_charge_the_fee_of_business_accounts",
+			accountType: entity.ACCOUNT_TYPE_PJ,
+			balance:     100,
+			amount:      10,
+			wantFee:     1.5,
+			wantBalance: 88.5,
+			wantOutcome: prometheus.TRANSFER_OUTCOME_SUCCESS,
+		},
+		{
+			name:        "should_reject_amounts_over_the_personal_limit",
+			accountType: entity.ACCOUNT_TYPE_PF,
+			balance:     10000,
+			amount:      5000.01,
+			wantErr:     &common.LimitExceededError{},
+			wantOutcome: prometheus.TRANSFER_OUTCOME_LIMIT_EXCEEDED,
+		},
+		{
+			name:        "should_accept_amounts_over_the_personal_limit_for_business_accounts",
+			accountType: entity.ACCOUNT_TYPE_PJ,
+			balance:     10000,
+			amount:      5000.01,
+			wantFee:     1.5,
+			wantBalance: 4998.49,
+			wantOutcome: prometheus.TRANSFER_OUTCOME_SUCCESS,
+		},
+		{
+			name:        "should_require_funds_for_the_fee",
+			accountType: entity.ACCOUNT_TYPE_PJ,
+			balance:     10,
+			amount:      10,
+			wantErr:     &common.InsufficientFundsError{},
+			wantOutcome: prometheus.TRANSFER_OUTCOME_INSUFFICIENT_FUNDS,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			origin := getAccountTest(1, tt.balance)
+			origin.Type = tt.accountType
+
+			account := &accountUsecase.AccountUsecaseMock{}
+			account.On("Get", mock.Anything, types.AccountInput{ID: 1}).Return(origin, nil)
+			account.On("Get", mock.Anything, types.AccountInput{ID: 2}).Return(getAccountTest(2, 0), nil)
+
+			repo := &transferRepository.TransferRepositoryMock{}
+			repo.On("Create", mock.Anything, mock.Anything).Return(nil)
+
+			usecase := New(repo, account, &pixKeyUsecase.PixKeyUsecaseMock{}, eventbus.New(), receiptSecretTest, 0)
+			counter := prometheus.TransfersTotal.WithLabelValues(tt.wantOutcome)
+			before := testutil.ToFloat64(counter)
+
+			_, err := usecase.Create(context.Background(), types.TransferInput{AccountOriginID: 1, AccountDestinationID: 2, Amount: tt.amount})
+			if tt.wantErr != nil {
+				var codedError common.CodedError
+				if !errors.As(err, &codedError) || codedError.Code() != tt.wantErr.(common.CodedError).Code() {
+					t.Fatalf("error = %v, want %v", err, tt.wantErr)
+				}
+
+				repo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+			} else if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			} else {
+				aggregation := repo.Calls[0].Arguments.Get(1).(*types.TransferAggregation)
+				if aggregation.Transfer.Fee != tt.wantFee {
+					t.Errorf("fee = %v, want %v", aggregation.Transfer.Fee, tt.wantFee)
+				}
+				if aggregation.AccountOrigin.Balance != tt.wantBalance {
+					t.Errorf("origin balance = %v, want %v", aggregation.AccountOrigin.Balance, tt.wantBalance)
+				}
+				if aggregation.AccountDestination.Balance != tt.amount {
+					t.Errorf("destination balance = %v, want %v", aggregation.AccountDestination.Balance, tt.amount)
+				}
+			}
+
+			if got := testutil.ToFloat64(counter); got != before+1 {
+				t.Errorf("outcome %s = %v, want %v", tt.wantOutcome, got, before+1)
+			}
+		})
+	}
+}
+
+func TestTransferUsecaseAuthorize(t *testing.T) {
+	authorization := types.TransferAuthorization{PIN: "4321", TOTPCode: "123456"}
+
+	tests := []struct {
+		name         string
+		stepUpAmount float64
+		amount       float64
+		apiKeyID     string
+		pinErr       error
+		wantStepUp   bool
+		stepUpErr    error
+		wantErr      error
+	}{
+		{
+			name:         "should_not_ask_for_a_code_up_to_the_step_up_amount",
+			stepUpAmount: 1000,
+			amount:       1000,
+		},
+		{
+			name:         "should_ask_for_a_code_over_the_step_up_amount",
+			stepUpAmount: 1000,
+			amount:       1000.01,
+			wantStepUp:   true,
+		},
+		{
+			name:         "should_return_the_step_up_error",
+			stepUpAmount: 1000,
+			amount:       2000,
+			wantStepUp:   true,
+			stepUpErr:    &common.UnauthorizedError{Msg: "totp code required"},
+			wantErr:      &common.UnauthorizedError{},
+		},
+		{
+			name:   "should_not_ask_for_a_code_without_a_step_up_amount",
+			amount: 100000,
+		},
+		{
+			name:         "should_return_the_pin_error_before_asking_for_a_code",
+			stepUpAmount: 1000,
+			amount:       2000,
+			pinErr:       &common.ValidationError{Msg: "wrong pin"},
+			wantErr:      &common.ValidationError{},
+		},
+		{
+			name:         "should_pass_api_keys_without_pin_or_code",
+			stepUpAmount: 1000,
+			amount:       2000,
+			apiKeyID:     "ak_0123456789abcdef",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			account := &accountUsecase.AccountUsecaseMock{}
+			account.On("VerifyPIN", mock.Anything, uint(1), "4321").Return(tt.pinErr)
+			account.On("StepUp", mock.Anything, uint(1), "123456").Return(tt.stepUpErr)
+
+			usecase := New(&transferRepository.TransferRepositoryMock{}, account, &pixKeyUsecase.PixKeyUsecaseMock{}, eventbus.New(), receiptSecretTest, tt.stepUpAmount)
+
+			authorization := authorization
+			authorization.APIKeyID = tt.apiKeyID
+
+			err := usecase.Authorize(context.Background(), 1, tt.amount, authorization)
+			if tt.wantErr != nil {
+				var codedError common.CodedError
+				if !errors.As(err, &codedError) || codedError.Code() != tt.wantErr.(common.CodedError).Code() {
+					t.Fatalf("error = %v, want %v", err, tt.wantErr)
+				}
+			} else if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if tt.apiKeyID != "" {
+				account.AssertNotCalled(t, "VerifyPIN", mock.Anything, mock.Anything, mock.Anything)
+			} else {
+				account.AssertCalled(t, "VerifyPIN", mock.Anything, uint(1), "4321")
+			}
+			if tt.wantStepUp {
+				account.AssertCalled(t, "StepUp", mock.Anything, uint(1), "123456")
+			} else {
+				account.AssertNotCalled(t, "StepUp", mock.Anything, mock.Anything, mock.Anything)
+			}
 		})
 	}
 }
@@ -224,6 +579,150 @@ func getAccountTest(id uint, balance float64) *entity.Account {
 	}
 }
 
+func TestTransferUsecaseReceipt(t *testing.T) {
+	tests := []struct {
+		name      string
+		accountID uint
+		transfer  *entity.Transfer
+		wantErr   string
+	}{
+		{
+			name:      "should_return_receipt_to_the_origin_account",
+			accountID: 1,
+			transfer:  getTransferReceiptTest(7),
+		},
+		{
+			name:      "should_return_receipt_to_the_destination_account",
+			accountID: 2,
+			transfer:  getTransferReceiptTest(7),
+		},
+		{
+			name:      "should_hide_transfer_from_other_accounts",
+			accountID: 3,
+			transfer:  getTransferReceiptTest(7),
+			wantErr:   "transfer not found",
+		},
+		{
+			name:      "should_return_not_found_when_transfer_does_not_exist",
+			accountID: 1,
+			transfer:  &entity.Transfer{},
+			wantErr:   "transfer not found",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &transferRepository.TransferRepositoryMock{}
+			repo.On("GetByID", mock.Anything, uint(7)).Return(tt.transfer, nil)
+			usecase := New(repo, &accountUsecase.AccountUsecaseMock{}, &pixKeyUsecase.PixKeyUsecaseMock{}, eventbus.New(), receiptSecretTest, 0)
+
+			got, err := usecase.Receipt(context.Background(), 7, tt.accountID)
+			if tt.wantErr != "" {
+				if err == nil || err.Error() != tt.wantErr {
+					t.Errorf("error = %v, wantErr %v", err, tt.wantErr)
+				}
+
+				return
+			}
+
+			want := &types.TransferReceipt{
+				ID:          7,
+				Status:      entity.TRANSFER_STATUS_COMPLETED,
+				Amount:      10,
+				Fee:         0,
+				Total:       10,
+				Origin:      types.ReceiptParty{Name: "Loren I.", CPF: "***.625.570-**"},
+				Destination: types.ReceiptParty{Name: "Dolor", CPF: "***.744.080-**"},
+				CreatedAt:   time.Date(2026, 10, 19, 10, 30, 0, 0, types.RECEIPT_LOCATION),
+			}
+			if diff := cmp.Diff(want, got, cmpopts.IgnoreFields(types.TransferReceipt{}, "VerificationCode")); diff != "" {
+				t.Error(diff)
+			}
+			if got.CreatedAt.Format(time.RFC3339) != "2026-10-19T10:30:00-03:00" {
+				t.Errorf("created_at = %s, want Sao Paulo time", got.CreatedAt.Format(time.RFC3339))
+			}
+		})
+	}
+}
+
+func TestTransferUsecaseVerifyReceipt(t *testing.T) {
+	transfer := getTransferReceiptTest(7)
+	code := util.GenerateVerificationCode(7, receiptPayload(transfer), receiptSecretTest)
+
+	tampered := getTransferReceiptTest(7)
+	tampered.Amount = 1000
+
+	tests := []struct {
+		name     string
+		code     string
+		transfer *entity.Transfer
+		wantErr  bool
+	}{
+		{
+			name:     "should_verify_a_valid_code",
+			code:     code,
+			transfer: transfer,
+		},
+		{
+			name:     "should_verify_a_lowercase_code",
+			code:     strings.ToLower(code),
+			transfer: transfer,
+		},
+		{
+			name:     "should_reject_code_of_a_changed_transfer",
+			code:     code,
+			transfer: tampered,
+			wantErr:  true,
+		},
+		{
+			name:     "should_reject_code_of_a_missing_transfer",
+			code:     code,
+			transfer: &entity.Transfer{},
+			wantErr:  true,
+		},
+		{
+			name:    "should_reject_malformed_code",
+			code:    "ABC",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &transferRepository.TransferRepositoryMock{}
+			repo.On("GetByID", mock.Anything, uint(7)).Return(tt.transfer, nil)
+			usecase := New(repo, &accountUsecase.AccountUsecaseMock{}, &pixKeyUsecase.PixKeyUsecaseMock{}, eventbus.New(), receiptSecretTest, 0)
+
+			got, err := usecase.VerifyReceipt(context.Background(), tt.code)
+			if tt.wantErr {
+				if err == nil || err.Error() != "receipt not found" {
+					t.Errorf("error = %v, want receipt not found", err)
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got.ID != 7 || got.VerificationCode != code {
+				t.Errorf("got receipt %d with code %s", got.ID, got.VerificationCode)
+			}
+		})
+	}
+}
+
+func getTransferReceiptTest(id uint) *entity.Transfer {
+	return &entity.Transfer{
+		ID:                   id,
+		AccountOriginID:      1,
+		AccountDestinationID: 2,
+		Amount:               10,
+		Status:               entity.TRANSFER_STATUS_COMPLETED,
+		CreatedAt:            time.Date(2026, 10, 19, 13, 30, 0, 0, time.UTC),
+		AccountOrigin:        &entity.Account{ID: 1, Name: "Loren Ipsum", CPF: "25462557035"},
+		AccountDestination:   &entity.Account{ID: 2, Name: "Dolor", CPF: "31974408035"},
+	}
+}
+
 func getTransferTest(id uint) *entity.Transfer {
 	return &entity.Transfer{
 		ID:                   id,
@@ -0,0 +1,66 @@
+package webhook
+
+import (
+	"errors"
+	"net"
+	"net/http"
+	"net/netip"
+	"strings"
+	"syscall"
+)
+
+const WEBHOOK_ADDRESS_ERROR = "webhook url must point to a public address"
+
+var errAddressNotAllowed = errors.New("webhook address not allowed")
+
+// NewClient returns the client webhooks are sent with. It refuses to connect
+// to loopback, private, link-local and unspecified addresses, checked on the
+// address actually dialed, so neither redirects nor DNS answers changed
+// after the url was accepted reach internal services. Proxies from the
+// environment are ignored for the same reason.
+func NewClient() *http.Client {
+	dialer := &net.Dialer{
+		Timeout: WEBHOOK_TIMEOUT,
+		Control: func(network string, address string, _ syscall.RawConn) error {
+			addrPort, err := netip.ParseAddrPort(address)
+			if err != nil || !publicAddr(addrPort.Addr()) {
+				return errAddressNotAllowed
+			}
+
+			return nil
+		},
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = nil
+	transport.DialContext = dialer.DialContext
+
+	return &http.Client{Timeout: WEBHOOK_TIMEOUT, Transport: transport}
+}
+
+// publicHost reports whether host may be the host of a webhook url. Names
+// other than localhost are left to the dialer, which sees what they resolve
+// to when the webhook is sent.
+func publicHost(host string) bool {
+	host = strings.TrimSuffix(strings.ToLower(host), ".")
+	if host == "localhost" || strings.HasSuffix(host, ".localhost") {
+		return false
+	}
+
+	addr, err := netip.ParseAddr(host)
+	if err != nil {
+		return true
+	}
+
+	return publicAddr(addr)
+}
+
+func publicAddr(addr netip.Addr) bool {
+	addr = addr.Unmap()
+
+	return !addr.IsLoopback() &&
+		!addr.IsPrivate() &&
+		!addr.IsLinkLocalUnicast() &&
+		!addr.IsLinkLocalMulticast() &&
+		!addr.IsUnspecified()
+}
@@ -0,0 +1,18 @@
+package webhook
+
+import (
+	"context"
+
+	"github.com/fms85/desafio-tecnico-go-stone/internal/domain/entity"
+	"github.com/fms85/desafio-tecnico-go-stone/internal/domain/types"
+)
+
+type IWebhookUsecase interface {
+	Create(ctx context.Context, webhookInput types.WebhookInput) (*types.WebhookSubscription, error)
+	GetAll(ctx context.Context, accountID uint) ([]*entity.Webhook, error)
+	Delete(ctx context.Context, webhookID uint, accountID uint) error
+	GetDeliveries(ctx context.Context, deliveryInput types.WebhookDeliveryInput) ([]*entity.WebhookDelivery, error)
+	Redeliver(ctx context.Context, deliveryID uint, webhookID uint, accountID uint) (*entity.WebhookDelivery, error)
+	Publish(ctx context.Context, event types.Event) error
+	Dispatch(ctx context.Context) (int, error)
+}
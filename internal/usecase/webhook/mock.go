@@ -0,0 +1,187 @@
+// Code generated by mockery v2.33.0. DO NOT EDIT.
+
+package webhook
+
+import (
+	context "context"
+
+	entity "github.com/fms85/desafio-tecnico-go-stone/internal/domain/entity"
+	mock "github.com/stretchr/testify/mock"
+
+	types "github.com/fms85/desafio-tecnico-go-stone/internal/domain/types"
+)
+
+// WebhookUsecaseMock is an autogenerated mock type for the IWebhookUsecase type
+type WebhookUsecaseMock struct {
+	mock.Mock
+}
+
+// Create provides a mock function with given fields: ctx, webhookInput
+func (_m *WebhookUsecaseMock) Create(ctx context.Context, webhookInput types.WebhookInput) (*types.WebhookSubscription, error) {
+	ret := _m.Called(ctx, webhookInput)
+
+	var r0 *types.WebhookSubscription
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, types.WebhookInput) (*types.WebhookSubscription, error)); ok {
+		return rf(ctx, webhookInput)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, types.WebhookInput) *types.WebhookSubscription); ok {
+		r0 = rf(ctx, webhookInput)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*types.WebhookSubscription)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, types.WebhookInput) error); ok {
+		r1 = rf(ctx, webhookInput)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Delete provides a mock function with given fields: ctx, webhookID, accountID
+func (_m *WebhookUsecaseMock) Delete(ctx context.Context, webhookID uint, accountID uint) error {
+	ret := _m.Called(ctx, webhookID, accountID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint, uint) error); ok {
+		r0 = rf(ctx, webhookID, accountID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Dispatch provides a mock function with given fields: ctx
+func (_m *WebhookUsecaseMock) Dispatch(ctx context.Context) (int, error) {
+	ret := _m.Called(ctx)
+
+	var r0 int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) (int, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) int); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetAll provides a mock function with given fields: ctx, accountID
+func (_m *WebhookUsecaseMock) GetAll(ctx context.Context, accountID uint) ([]*entity.Webhook, error) {
+	ret := _m.Called(ctx, accountID)
+
+	var r0 []*entity.Webhook
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint) ([]*entity.Webhook, error)); ok {
+		return rf(ctx, accountID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint) []*entity.Webhook); ok {
+		r0 = rf(ctx, accountID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*entity.Webhook)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint) error); ok {
+		r1 = rf(ctx, accountID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetDeliveries provides a mock function with given fields: ctx, deliveryInput
+func (_m *WebhookUsecaseMock) GetDeliveries(ctx context.Context, deliveryInput types.WebhookDeliveryInput) ([]*entity.WebhookDelivery, error) {
+	ret := _m.Called(ctx, deliveryInput)
+
+	var r0 []*entity.WebhookDelivery
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, types.WebhookDeliveryInput) ([]*entity.WebhookDelivery, error)); ok {
+		return rf(ctx, deliveryInput)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, types.WebhookDeliveryInput) []*entity.WebhookDelivery); ok {
+		r0 = rf(ctx, deliveryInput)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*entity.WebhookDelivery)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, types.WebhookDeliveryInput) error); ok {
+		r1 = rf(ctx, deliveryInput)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Publish provides a mock function with given fields: ctx, event
+func (_m *WebhookUsecaseMock) Publish(ctx context.Context, event types.Event) error {
+	ret := _m.Called(ctx, event)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, types.Event) error); ok {
+		r0 = rf(ctx, event)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Redeliver provides a mock function with given fields: ctx, deliveryID, webhookID, accountID
+func (_m *WebhookUsecaseMock) Redeliver(ctx context.Context, deliveryID uint, webhookID uint, accountID uint) (*entity.WebhookDelivery, error) {
+	ret := _m.Called(ctx, deliveryID, webhookID, accountID)
+
+	var r0 *entity.WebhookDelivery
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uint, uint, uint) (*entity.WebhookDelivery, error)); ok {
+		return rf(ctx, deliveryID, webhookID, accountID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uint, uint, uint) *entity.WebhookDelivery); ok {
+		r0 = rf(ctx, deliveryID, webhookID, accountID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*entity.WebhookDelivery)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uint, uint, uint) error); ok {
+		r1 = rf(ctx, deliveryID, webhookID, accountID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewWebhookUsecaseMock creates a new instance of WebhookUsecaseMock. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewWebhookUsecaseMock(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *WebhookUsecaseMock {
+	mock := &WebhookUsecaseMock{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,318 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/fms85/desafio-tecnico-go-stone/internal/domain/common"
+	"github.com/fms85/desafio-tecnico-go-stone/internal/domain/entity"
+	"github.com/fms85/desafio-tecnico-go-stone/internal/domain/types"
+	"github.com/fms85/desafio-tecnico-go-stone/internal/driver/logger"
+	otelDriver "github.com/fms85/desafio-tecnico-go-stone/internal/driver/otel"
+	webhookRepository "github.com/fms85/desafio-tecnico-go-stone/internal/repository/webhook"
+	"github.com/fms85/desafio-tecnico-go-stone/internal/util"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	WEBHOOK_MAX_ATTEMPTS      = 8
+	WEBHOOK_BACKOFF_BASE      = 30 * time.Second
+	WEBHOOK_BACKOFF_MAX       = time.Hour
+	WEBHOOK_BATCH_SIZE        = 20
+	WEBHOOK_LEASE             = 5 * time.Minute
+	WEBHOOK_TIMEOUT           = 10 * time.Second
+	WEBHOOK_RESPONSE_MAX_SIZE = 64 << 10
+	WEBHOOK_SECRET_PREFIX     = "whsec_"
+	WEBHOOK_USER_AGENT        = "desafio-tecnico-go-stone-webhooks/1"
+)
+
+var tracer = otel.Tracer("internal/usecase/webhook")
+
+type webhookUsecase struct {
+	webhookRepository webhookRepository.IWebhookRepository
+	client            *http.Client
+	now               func() time.Time
+}
+
+func New(webhookRepository webhookRepository.IWebhookRepository, client *http.Client) IWebhookUsecase {
+	return &webhookUsecase{
+		webhookRepository: webhookRepository,
+		client:            client,
+		now:               time.Now,
+	}
+}
+
+func (usecase *webhookUsecase) Create(ctx context.Context, webhookInput types.WebhookInput) (subscription *types.WebhookSubscription, err error) {
+	ctx, span := tracer.Start(ctx, "webhookUsecase.Create")
+	defer func() { otelDriver.End(span, err) }()
+
+	target, err := url.Parse(webhookInput.URL)
+	if err != nil || (target.Scheme != "http" && target.Scheme != "https") || target.Host == "" {
+		return nil, &common.ValidationError{Msg: "webhook url must use http or https"}
+	}
+
+	if !publicHost(target.Hostname()) {
+		return nil, &common.ValidationError{Msg: WEBHOOK_ADDRESS_ERROR}
+	}
+
+	secret := webhookInput.Secret
+	if secret == "" {
+		secret = WEBHOOK_SECRET_PREFIX + util.GenerateRandomHex(24)
+	}
+
+	events := slices.Clone(webhookInput.Events)
+	slices.Sort(events)
+
+	webhook := &entity.Webhook{
+		AccountID: webhookInput.AccountID,
+		URL:       webhookInput.URL,
+		Events:    slices.Compact(events),
+		Secret:    secret,
+	}
+
+	if err := usecase.webhookRepository.Create(ctx, webhook); err != nil {
+		return nil, err
+	}
+
+	return &types.WebhookSubscription{Webhook: webhook, Secret: secret}, nil
+}
+
+func (usecase *webhookUsecase) GetAll(ctx context.Context, accountID uint) (webhooks []*entity.Webhook, err error) {
+	ctx, span := tracer.Start(ctx, "webhookUsecase.GetAll")
+	defer func() { otelDriver.End(span, err) }()
+
+	return usecase.webhookRepository.GetAll(ctx, accountID)
+}
+
+func (usecase *webhookUsecase) Delete(ctx context.Context, webhookID uint, accountID uint) (err error) {
+	ctx, span := tracer.Start(ctx, "webhookUsecase.Delete")
+	defer func() { otelDriver.End(span, err) }()
+
+	webhook, err := usecase.get(ctx, webhookID, accountID)
+	if err != nil {
+		return err
+	}
+
+	return usecase.webhookRepository.Delete(ctx, webhook)
+}
+
+func (usecase *webhookUsecase) GetDeliveries(ctx context.Context, deliveryInput types.WebhookDeliveryInput) (deliveries []*entity.WebhookDelivery, err error) {
+	ctx, span := tracer.Start(ctx, "webhookUsecase.GetDeliveries")
+	defer func() { otelDriver.End(span, err) }()
+
+	if _, err := usecase.get(ctx, deliveryInput.WebhookID, deliveryInput.AccountID); err != nil {
+		return nil, err
+	}
+
+	return usecase.webhookRepository.GetDeliveries(ctx, deliveryInput)
+}
+
+// Redeliver schedules a delivery again with a fresh set of attempts, whatever
+// its status. It is how dead letters are replayed once the receiver is fixed.
+func (usecase *webhookUsecase) Redeliver(ctx context.Context, deliveryID uint, webhookID uint, accountID uint) (delivery *entity.WebhookDelivery, err error) {
+	ctx, span := tracer.Start(ctx, "webhookUsecase.Redeliver")
+	defer func() { otelDriver.End(span, err) }()
+
+	if _, err := usecase.get(ctx, webhookID, accountID); err != nil {
+		return nil, err
+	}
+
+	delivery, err = usecase.webhookRepository.GetDelivery(ctx, deliveryID, webhookID)
+	if err != nil {
+		return nil, err
+	}
+
+	if delivery.ID == 0 {
+		return nil, fmt.Errorf("webhook delivery %w", &common.NotFoundError{})
+	}
+
+	delivery.Status = entity.WEBHOOK_DELIVERY_STATUS_PENDING
+	delivery.Attempts = 0
+	delivery.NextAttemptAt = usecase.now()
+	delivery.ResponseStatus = 0
+	delivery.LastError = ""
+	delivery.DeliveredAt = nil
+
+	if err := usecase.webhookRepository.UpdateDelivery(ctx, delivery); err != nil {
+		return nil, err
+	}
+
+	return delivery, nil
+}
+
+// Publish queues one delivery of the event per webhook of the account
+// subscribed to it. Sending happens later, in Dispatch. Publishing an event
+// again does not queue it twice.
+func (usecase *webhookUsecase) Publish(ctx context.Context, event types.Event) (err error) {
+	ctx, span := tracer.Start(ctx, "webhookUsecase.Publish", trace.WithAttributes(
+		attribute.Int64("webhook.account_id", int64(event.AccountID)),
+		attribute.String("webhook.event", event.Type),
+	))
+	defer func() { otelDriver.End(span, err) }()
+
+	webhooks, err := usecase.webhookRepository.GetAll(ctx, event.AccountID)
+	if err != nil {
+		return err
+	}
+
+	var deliveries []*entity.WebhookDelivery
+	var payload []byte
+
+	now := usecase.now()
+	for _, webhook := range webhooks {
+		if !slices.Contains(webhook.Events, event.Type) {
+			continue
+		}
+
+		if payload == nil {
+			if payload, err = json.Marshal(event); err != nil {
+				return fmt.Errorf("error to encode webhook event: %w", err)
+			}
+		}
+
+		deliveries = append(deliveries, &entity.WebhookDelivery{
+			WebhookID:     webhook.ID,
+			EventID:       event.ID,
+			Event:         event.Type,
+			Payload:       string(payload),
+			Status:        entity.WEBHOOK_DELIVERY_STATUS_PENDING,
+			NextAttemptAt: now,
+		})
+	}
+
+	if len(deliveries) == 0 {
+		return nil
+	}
+
+	return usecase.webhookRepository.CreateDeliveries(ctx, deliveries)
+}
+
+// Dispatch sends one batch of due deliveries and returns its size. Failed
+// attempts are retried with exponential backoff until WEBHOOK_MAX_ATTEMPTS,
+// after which the delivery is dead.
+func (usecase *webhookUsecase) Dispatch(ctx context.Context) (count int, err error) {
+	ctx, span := tracer.Start(ctx, "webhookUsecase.Dispatch")
+	defer func() { otelDriver.End(span, err, attribute.Int("webhook.deliveries", count)) }()
+
+	deliveries, err := usecase.webhookRepository.ClaimDeliveries(ctx, usecase.now(), WEBHOOK_LEASE, WEBHOOK_BATCH_SIZE)
+	if err != nil {
+		return 0, err
+	}
+
+	for _, delivery := range deliveries {
+		usecase.deliver(ctx, delivery)
+
+		if err := usecase.webhookRepository.UpdateDelivery(ctx, delivery); err != nil {
+			return len(deliveries), err
+		}
+	}
+
+	return len(deliveries), nil
+}
+
+func (usecase *webhookUsecase) deliver(ctx context.Context, delivery *entity.WebhookDelivery) {
+	var err error
+
+	ctx, span := tracer.Start(ctx, "webhookUsecase.deliver", trace.WithAttributes(
+		attribute.Int64("webhook.delivery_id", int64(delivery.ID)),
+		attribute.String("webhook.event", delivery.Event),
+	))
+	defer func() { otelDriver.End(span, err) }()
+
+	now := usecase.now()
+	delivery.Attempts++
+
+	status, err := usecase.send(ctx, delivery, now)
+	delivery.ResponseStatus = status
+	if err == nil {
+		delivery.Status = entity.WEBHOOK_DELIVERY_STATUS_DELIVERED
+		delivery.LastError = ""
+		delivery.DeliveredAt = &now
+
+		return
+	}
+
+	delivery.LastError = err.Error()
+	if delivery.Attempts >= WEBHOOK_MAX_ATTEMPTS {
+		delivery.Status = entity.WEBHOOK_DELIVERY_STATUS_DEAD
+	} else {
+		delivery.NextAttemptAt = now.Add(backoff(delivery.Attempts))
+	}
+
+	logger.FromContext(ctx).Warn("webhook delivery failed",
+		"delivery_id", delivery.ID,
+		"webhook_id", delivery.WebhookID,
+		"attempts", delivery.Attempts,
+		"status", delivery.Status,
+		"error", err,
+	)
+}
+
+// send posts a delivery and reports the status it got. The body of the
+// answer is drained and dropped, never stored: it is only ever seen by the
+// owner of the receiver.
+func (usecase *webhookUsecase) send(ctx context.Context, delivery *entity.WebhookDelivery, now time.Time) (int, error) {
+	body := []byte(delivery.Payload)
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, delivery.Webhook.URL, bytes.NewReader(body))
+	if err != nil {
+		return 0, err
+	}
+
+	request.Header.Set("Content-Type", "application/json")
+	request.Header.Set("User-Agent", WEBHOOK_USER_AGENT)
+	request.Header.Set("X-Webhook-Id", delivery.EventID)
+	request.Header.Set("X-Webhook-Event", delivery.Event)
+	request.Header.Set("X-Webhook-Delivery", strconv.FormatUint(uint64(delivery.ID), 10))
+	request.Header.Set("X-Webhook-Signature", util.SignPayload(delivery.Webhook.Secret, now, body))
+	otel.GetTextMapPropagator().Inject(ctx, propagation.HeaderCarrier(request.Header))
+
+	response, err := usecase.client.Do(request)
+	if err != nil {
+		return 0, err
+	}
+	defer response.Body.Close()
+
+	_, _ = io.Copy(io.Discard, io.LimitReader(response.Body, WEBHOOK_RESPONSE_MAX_SIZE))
+
+	if response.StatusCode < 200 || response.StatusCode > 299 {
+		return response.StatusCode, fmt.Errorf("unexpected status %d", response.StatusCode)
+	}
+
+	return response.StatusCode, nil
+}
+
+func (usecase *webhookUsecase) get(ctx context.Context, webhookID uint, accountID uint) (*entity.Webhook, error) {
+	webhook, err := usecase.webhookRepository.Get(ctx, webhookID, accountID)
+	if err != nil {
+		return nil, err
+	}
+
+	if webhook.ID == 0 {
+		return nil, fmt.Errorf("webhook %w", &common.NotFoundError{})
+	}
+
+	return webhook, nil
+}
+
+// backoff is the wait before the next attempt: WEBHOOK_BACKOFF_BASE doubled
+// after every failed attempt, up to WEBHOOK_BACKOFF_MAX.
+func backoff(attempts int) time.Duration {
+	wait := WEBHOOK_BACKOFF_BASE
+	for i := 1; i < attempts && wait < WEBHOOK_BACKOFF_MAX; i++ {
+		wait *= 2
+	}
+
+	return min(wait, WEBHOOK_BACKOFF_MAX)
+}
@@ -0,0 +1,418 @@
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/fms85/desafio-tecnico-go-stone/internal/domain/common"
+	"github.com/fms85/desafio-tecnico-go-stone/internal/domain/entity"
+	"github.com/fms85/desafio-tecnico-go-stone/internal/domain/types"
+	webhookRepository "github.com/fms85/desafio-tecnico-go-stone/internal/repository/webhook"
+	"github.com/fms85/desafio-tecnico-go-stone/internal/util"
+	"github.com/google/go-cmp/cmp"
+	"github.com/stretchr/testify/mock"
+	"gotest.tools/assert"
+)
+
+var nowTest = time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+
+func newUsecaseTest(repo webhookRepository.IWebhookRepository) *webhookUsecase {
+	usecase := New(repo, http.DefaultClient).(*webhookUsecase)
+	usecase.now = func() time.Time { return nowTest }
+
+	return usecase
+}
+
+func getWebhookTest(id uint, url string, events ...string) *entity.Webhook {
+	return &entity.Webhook{ID: id, AccountID: 3, URL: url, Events: events, Secret: "whsec_test_secret_value"}
+}
+
+func TestWebhookUsecaseCreate(t *testing.T) {
+	tests := []struct {
+		name       string
+		input      types.WebhookInput
+		repoErr    error
+		wantEvents []string
+		wantSecret string
+		wantErr    bool
+	}{
+		{
+			name:       "should_keep_the_given_secret",
+			input:      types.WebhookInput{AccountID: 3, URL: "https://example.com/hook", Events: []string{"transfer.created"}, Secret: "my-own-secret-value"},
+			wantEvents: []string{"transfer.created"},
+			wantSecret: "my-own-secret-value",
+		},
+		{
+			name:       "should_generate_a_secret_and_deduplicate_events",
+			input:      types.WebhookInput{AccountID: 3, URL: "http://hooks.example.com:9000", Events: []string{"transfer.received", "transfer.created", "transfer.received"}},
+			wantEvents: []string{"transfer.created", "transfer.received"},
+		},
+		{
+			name:    "should_reject_urls_other_than_http",
+			input:   types.WebhookInput{AccountID: 3, URL: "ftp://example.com/hook", Events: []string{"transfer.created"}},
+			wantErr: true,
+		},
+		{
+			name:    "should_reject_localhost",
+			input:   types.WebhookInput{AccountID: 3, URL: "http://localhost:9000", Events: []string{"transfer.created"}},
+			wantErr: true,
+		},
+		{
+			name:    "should_reject_link_local_addresses",
+			input:   types.WebhookInput{AccountID: 3, URL: "http://169.254.169.254/latest/meta-data", Events: []string{"transfer.created"}},
+			wantErr: true,
+		},
+		{
+			name:    "should_reject_private_addresses",
+			input:   types.WebhookInput{AccountID: 3, URL: "https://10.0.0.7/hook", Events: []string{"transfer.created"}},
+			wantErr: true,
+		},
+		{
+			name:    "should_reject_mapped_loopback_addresses",
+			input:   types.WebhookInput{AccountID: 3, URL: "http://[::ffff:127.0.0.1]/hook", Events: []string{"transfer.created"}},
+			wantErr: true,
+		},
+		{
+			name:    "should_return_an_error_when_repository_fails",
+			input:   types.WebhookInput{AccountID: 3, URL: "https://example.com/hook", Events: []string{"transfer.created"}},
+			repoErr: errors.New(""),
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &webhookRepository.WebhookRepositoryMock{}
+			repo.On("Create", mock.Anything, mock.Anything).Return(tt.repoErr)
+
+			got, err := newUsecaseTest(repo).Create(context.Background(), tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if tt.wantErr {
+				return
+			}
+
+			if diff := cmp.Diff(got.Events, tt.wantEvents); diff != "" {
+				t.Error(diff)
+			}
+
+			assert.Equal(t, got.Webhook.Secret, got.Secret)
+			if tt.wantSecret != "" {
+				assert.Equal(t, tt.wantSecret, got.Secret)
+			} else {
+				assert.Assert(t, strings.HasPrefix(got.Secret, WEBHOOK_SECRET_PREFIX))
+				assert.Equal(t, len(WEBHOOK_SECRET_PREFIX)+48, len(got.Secret))
+			}
+		})
+	}
+}
+
+func TestWebhookUsecasePublish(t *testing.T) {
+	tests := []struct {
+		name           string
+		webhooks       []*entity.Webhook
+		wantDeliveries []uint
+		wantErr        bool
+	}{
+		{
+			name: "should_queue_a_delivery_per_subscribed_webhook",
+			webhooks: []*entity.Webhook{
+				getWebhookTest(1, "https://a.example.com", "transfer.created"),
+				getWebhookTest(2, "https://b.example.com", "transfer.received"),
+				getWebhookTest(3, "https://c.example.com", "transfer.created", "transfer.received"),
+			},
+			wantDeliveries: []uint{1, 3},
+		},
+		{
+			name: "should_not_queue_anything_without_subscribers",
+			webhooks: []*entity.Webhook{
+				getWebhookTest(2, "https://b.example.com", "transfer.received"),
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []*entity.WebhookDelivery
+
+			repo := webhookRepository.NewWebhookRepositoryMock(t)
+			repo.On("GetAll", mock.Anything, uint(3)).Return(tt.webhooks, nil)
+			if tt.wantDeliveries != nil {
+				repo.On("CreateDeliveries", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+					got = args.Get(1).([]*entity.WebhookDelivery)
+				}).Return(nil)
+			}
+
+			err := newUsecaseTest(repo).Publish(context.Background(), types.Event{
+				ID:        "42",
+				Type:      entity.EVENT_TRANSFER_CREATED,
+				AccountID: 3,
+				CreatedAt: nowTest,
+				Data:      json.RawMessage(`{"id":9}`),
+			})
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			assert.Equal(t, len(tt.wantDeliveries), len(got))
+			for i, delivery := range got {
+				assert.Equal(t, tt.wantDeliveries[i], delivery.WebhookID)
+				assert.Equal(t, entity.WEBHOOK_DELIVERY_STATUS_PENDING, delivery.Status)
+				assert.Equal(t, nowTest, delivery.NextAttemptAt)
+				assert.Equal(t, "42", delivery.EventID)
+				assert.Equal(t, entity.EVENT_TRANSFER_CREATED, delivery.Event)
+				assert.Equal(t, `{"id":"42","type":"transfer.created","account_id":3,"created_at":"2026-10-19T12:00:00Z","data":{"id":9}}`, delivery.Payload)
+			}
+		})
+	}
+}
+
+func TestWebhookUsecaseDispatch(t *testing.T) {
+	type received struct {
+		header http.Header
+		body   string
+	}
+
+	tests := []struct {
+		name         string
+		attempts     int
+		status       int
+		wantStatus   string
+		wantAttempts int
+		wantNext     time.Time
+		wantError    string
+	}{
+		{
+			name:         "should_deliver_signed_payload",
+			status:       http.StatusNoContent,
+			wantStatus:   entity.WEBHOOK_DELIVERY_STATUS_DELIVERED,
+			wantAttempts: 1,
+			wantNext:     nowTest,
+		},
+		{
+			name:         "should_retry_with_backoff_when_receiver_fails",
+			status:       http.StatusInternalServerError,
+			wantStatus:   entity.WEBHOOK_DELIVERY_STATUS_PENDING,
+			wantAttempts: 1,
+			wantNext:     nowTest.Add(30 * time.Second),
+			wantError:    "unexpected status 500",
+		},
+		{
+			name:         "should_double_the_wait_after_each_failure",
+			attempts:     3,
+			status:       http.StatusBadGateway,
+			wantStatus:   entity.WEBHOOK_DELIVERY_STATUS_PENDING,
+			wantAttempts: 4,
+			wantNext:     nowTest.Add(4 * time.Minute),
+			wantError:    "unexpected status 502",
+		},
+		{
+			name:         "should_dead_letter_after_the_last_attempt",
+			attempts:     WEBHOOK_MAX_ATTEMPTS - 1,
+			status:       http.StatusGone,
+			wantStatus:   entity.WEBHOOK_DELIVERY_STATUS_DEAD,
+			wantAttempts: WEBHOOK_MAX_ATTEMPTS,
+			wantNext:     nowTest,
+			wantError:    "unexpected status 410",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			requests := make(chan received, 1)
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				body, _ := io.ReadAll(r.Body)
+				requests <- received{header: r.Header, body: string(body)}
+
+				w.WriteHeader(tt.status)
+				_, _ = w.Write([]byte("boom\n"))
+			}))
+			defer server.Close()
+
+			webhook := getWebhookTest(1, server.URL+"/hook", "transfer.created")
+			delivery := &entity.WebhookDelivery{
+				ID:            10,
+				WebhookID:     webhook.ID,
+				EventID:       "evt",
+				Event:         entity.EVENT_TRANSFER_CREATED,
+				Payload:       `{"id":"evt","type":"transfer.created"}`,
+				Status:        entity.WEBHOOK_DELIVERY_STATUS_PENDING,
+				Attempts:      tt.attempts,
+				NextAttemptAt: nowTest,
+				Webhook:       webhook,
+			}
+
+			repo := webhookRepository.NewWebhookRepositoryMock(t)
+			repo.On("ClaimDeliveries", mock.Anything, nowTest, WEBHOOK_LEASE, WEBHOOK_BATCH_SIZE).Return([]*entity.WebhookDelivery{delivery}, nil)
+			repo.On("UpdateDelivery", mock.Anything, delivery).Return(nil)
+
+			count, err := newUsecaseTest(repo).Dispatch(context.Background())
+			assert.NilError(t, err)
+			assert.Equal(t, 1, count)
+
+			request := <-requests
+			assert.Equal(t, delivery.Payload, request.body)
+			assert.Equal(t, "application/json", request.header.Get("Content-Type"))
+			assert.Equal(t, "evt", request.header.Get("X-Webhook-Id"))
+			assert.Equal(t, "transfer.created", request.header.Get("X-Webhook-Event"))
+			assert.Equal(t, "10", request.header.Get("X-Webhook-Delivery"))
+			assert.Assert(t, util.CheckPayloadSignature(webhook.Secret, request.header.Get("X-Webhook-Signature"), []byte(request.body), time.Minute, nowTest))
+
+			assert.Equal(t, tt.wantStatus, delivery.Status)
+			assert.Equal(t, tt.wantAttempts, delivery.Attempts)
+			assert.Equal(t, tt.wantNext, delivery.NextAttemptAt)
+			assert.Equal(t, tt.status, delivery.ResponseStatus)
+			assert.Equal(t, tt.wantError, delivery.LastError)
+			assert.Equal(t, tt.wantStatus == entity.WEBHOOK_DELIVERY_STATUS_DELIVERED, delivery.DeliveredAt != nil)
+		})
+	}
+}
+
+func TestWebhookUsecaseDispatchUnreachable(t *testing.T) {
+	server := httptest.NewServer(http.NotFoundHandler())
+	server.Close()
+
+	delivery := &entity.WebhookDelivery{
+		ID:      10,
+		Payload: `{}`,
+		Status:  entity.WEBHOOK_DELIVERY_STATUS_PENDING,
+		Webhook: getWebhookTest(1, server.URL, "transfer.created"),
+	}
+
+	repo := webhookRepository.NewWebhookRepositoryMock(t)
+	repo.On("ClaimDeliveries", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return([]*entity.WebhookDelivery{delivery}, nil)
+	repo.On("UpdateDelivery", mock.Anything, delivery).Return(nil)
+
+	_, err := newUsecaseTest(repo).Dispatch(context.Background())
+	assert.NilError(t, err)
+
+	assert.Equal(t, entity.WEBHOOK_DELIVERY_STATUS_PENDING, delivery.Status)
+	assert.Equal(t, 1, delivery.Attempts)
+	assert.Equal(t, 0, delivery.ResponseStatus)
+	assert.Assert(t, delivery.LastError != "")
+}
+
+func TestWebhookUsecaseRedeliver(t *testing.T) {
+	tests := []struct {
+		name     string
+		webhook  *entity.Webhook
+		delivery *entity.WebhookDelivery
+		wantErr  string
+	}{
+		{
+			name:    "should_schedule_a_dead_delivery_again",
+			webhook: getWebhookTest(1, "https://example.com", "transfer.created"),
+			delivery: &entity.WebhookDelivery{
+				ID:             10,
+				WebhookID:      1,
+				Status:         entity.WEBHOOK_DELIVERY_STATUS_DEAD,
+				Attempts:       WEBHOOK_MAX_ATTEMPTS,
+				ResponseStatus: http.StatusGone,
+				LastError:      "unexpected status 410",
+			},
+		},
+		{
+			name:    "should_not_find_webhooks_of_other_accounts",
+			webhook: &entity.Webhook{},
+			wantErr: "webhook not found",
+		},
+		{
+			name:     "should_not_find_deliveries_of_other_webhooks",
+			webhook:  getWebhookTest(1, "https://example.com", "transfer.created"),
+			delivery: &entity.WebhookDelivery{},
+			wantErr:  "webhook delivery not found",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := webhookRepository.NewWebhookRepositoryMock(t)
+			repo.On("Get", mock.Anything, uint(1), uint(3)).Return(tt.webhook, nil)
+			if tt.delivery != nil {
+				repo.On("GetDelivery", mock.Anything, uint(10), uint(1)).Return(tt.delivery, nil)
+			}
+			if tt.wantErr == "" {
+				repo.On("UpdateDelivery", mock.Anything, tt.delivery).Return(nil)
+			}
+
+			got, err := newUsecaseTest(repo).Redeliver(context.Background(), 10, 1, 3)
+			if tt.wantErr != "" {
+				var notFoundError *common.NotFoundError
+				assert.Assert(t, errors.As(err, &notFoundError))
+				assert.Error(t, err, tt.wantErr)
+
+				return
+			}
+
+			assert.NilError(t, err)
+			assert.Equal(t, entity.WEBHOOK_DELIVERY_STATUS_PENDING, got.Status)
+			assert.Equal(t, 0, got.Attempts)
+			assert.Equal(t, nowTest, got.NextAttemptAt)
+			assert.Equal(t, "", got.LastError)
+		})
+	}
+}
+
+func TestBackoff(t *testing.T) {
+	tests := []struct {
+		attempts int
+		want     time.Duration
+	}{
+		{attempts: 1, want: 30 * time.Second},
+		{attempts: 2, want: time.Minute},
+		{attempts: 7, want: 32 * time.Minute},
+		{attempts: 8, want: time.Hour},
+		{attempts: 50, want: time.Hour},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, backoff(tt.attempts))
+	}
+}
+
+func TestNewClient(t *testing.T) {
+	received := false
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received = true
+	}))
+	defer server.Close()
+
+	response, err := NewClient().Post(server.URL, "application/json", strings.NewReader("{}"))
+	if response != nil {
+		response.Body.Close()
+	}
+
+	assert.Assert(t, errors.Is(err, errAddressNotAllowed), "error = %v", err)
+
+	assert.Assert(t, !received)
+}
+
+func TestPublicAddr(t *testing.T) {
+	tests := []struct {
+		addr string
+		want bool
+	}{
+		{addr: "8.8.8.8", want: true},
+		{addr: "2001:4860:4860::8888", want: true},
+		{addr: "127.0.0.1"},
+		{addr: "::1"},
+		{addr: "10.1.2.3"},
+		{addr: "172.16.0.1"},
+		{addr: "192.168.0.1"},
+		{addr: "169.254.169.254"},
+		{addr: "fe80::1"},
+		{addr: "fd00::1"},
+		{addr: "0.0.0.0"},
+		{addr: "::ffff:10.0.0.1"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.addr, func(t *testing.T) {
+			assert.Equal(t, tt.want, publicAddr(netip.MustParseAddr(tt.addr)))
+		})
+	}
+}
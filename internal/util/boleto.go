@@ -0,0 +1,230 @@
+package util
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Boletos are paid by their 44 digit barcode or by the digitable line typed
+// from it: 47 digits for bank boletos and 48 for convenio ones (utility
+// bills, taxes), which start with 8.
+const (
+	BOLETO_TYPE_BANK     = "bank"
+	BOLETO_TYPE_CONVENIO = "convenio"
+	BOLETO_BARCODE_SIZE  = 44
+	BOLETO_BANK_LINE     = 47
+	BOLETO_CONVENIO_LINE = 48
+)
+
+// The due date factor counts days from a base date and only has 4 digits, so
+// it went back to 1000 on 2025-02-22, 9999 days after it started.
+var (
+	boletoFactorBase      = time.Date(1997, 10, 7, 0, 0, 0, 0, time.UTC)
+	boletoFactorBaseReset = time.Date(2022, 5, 29, 0, 0, 0, 0, time.UTC)
+)
+
+var ErrInvalidBoleto = errors.New("invalid boleto")
+
+// Boleto holds what the barcode says. Amount is zero when the payer chooses
+// it, and DueDate is nil for convenio boletos and bank boletos without one.
+// Segment is only set for convenio boletos and BankCode for bank ones.
+type Boleto struct {
+	Type          string
+	Barcode       string
+	DigitableLine string
+	BankCode      string
+	Segment       string
+	Amount        float64
+	DueDate       *time.Time
+}
+
+// ParseBoleto reads a barcode or digitable line, ignoring dots, dashes and
+// spaces, and checks its verification digits. The due date is resolved to the
+// factor cycle closest to reference. Errors wrap ErrInvalidBoleto.
+func ParseBoleto(code string, reference time.Time) (*Boleto, error) {
+	digits := strings.Map(func(r rune) rune {
+		switch {
+		case r >= '0' && r <= '9':
+			return r
+		case r == '.' || r == '-' || r == ' ':
+			return -1
+		default:
+			return 'x'
+		}
+	}, strings.TrimSpace(code))
+
+	if strings.Contains(digits, "x") {
+		return nil, fmt.Errorf("%w: only digits are allowed", ErrInvalidBoleto)
+	}
+
+	switch {
+	case len(digits) == BOLETO_BARCODE_SIZE && digits[0] == '8':
+		return parseConvenio(digits, "")
+	case len(digits) == BOLETO_BARCODE_SIZE:
+		return parseBank(digits, "", reference)
+	case len(digits) == BOLETO_CONVENIO_LINE && digits[0] == '8':
+		barcode := ""
+		for block := 0; block < 4; block++ {
+			field := digits[block*12 : block*12+12]
+			if convenioDigit(digits[2], field[:11]) != field[11] {
+				return nil, fmt.Errorf("%w: wrong verification digit in block %d", ErrInvalidBoleto, block+1)
+			}
+
+			barcode += field[:11]
+		}
+
+		return parseConvenio(barcode, digits)
+	case len(digits) == BOLETO_BANK_LINE:
+		for i, field := range []string{digits[0:10], digits[10:21], digits[21:32]} {
+			if Mod10(field[:len(field)-1]) != field[len(field)-1] {
+				return nil, fmt.Errorf("%w: wrong verification digit in field %d", ErrInvalidBoleto, i+1)
+			}
+		}
+
+		barcode := digits[0:4] + digits[32:47] + digits[4:9] + digits[10:20] + digits[21:31]
+
+		return parseBank(barcode, digits, reference)
+	default:
+		return nil, fmt.Errorf("%w: expected 44, 47 or 48 digits", ErrInvalidBoleto)
+	}
+}
+
+func parseBank(barcode string, line string, reference time.Time) (*Boleto, error) {
+	if bankDigit(barcode[:4]+barcode[5:]) != barcode[4] {
+		return nil, fmt.Errorf("%w: wrong barcode verification digit", ErrInvalidBoleto)
+	}
+
+	if barcode[3] != '9' {
+		return nil, fmt.Errorf("%w: currency is not BRL", ErrInvalidBoleto)
+	}
+
+	if line == "" {
+		free := barcode[19:]
+		first := barcode[0:4] + free[0:5]
+		second := free[5:15]
+		third := free[15:25]
+		line = first + string(Mod10(first)) + second + string(Mod10(second)) + third + string(Mod10(third)) + barcode[4:19]
+	}
+
+	boleto := &Boleto{
+		Type:          BOLETO_TYPE_BANK,
+		Barcode:       barcode,
+		DigitableLine: line,
+		BankCode:      barcode[0:3],
+		Amount:        boletoAmount(barcode[9:19]),
+	}
+
+	if factor, _ := strconv.Atoi(barcode[5:9]); factor > 0 {
+		dueDate := boletoDueDate(factor, reference)
+		boleto.DueDate = &dueDate
+	}
+
+	return boleto, nil
+}
+
+func parseConvenio(barcode string, line string) (*Boleto, error) {
+	valueID := barcode[2]
+	if valueID < '6' || valueID > '9' {
+		return nil, fmt.Errorf("%w: unknown value identifier", ErrInvalidBoleto)
+	}
+
+	if convenioDigit(valueID, barcode[:3]+barcode[4:]) != barcode[3] {
+		return nil, fmt.Errorf("%w: wrong barcode verification digit", ErrInvalidBoleto)
+	}
+
+	if line == "" {
+		for block := 0; block < 4; block++ {
+			field := barcode[block*11 : block*11+11]
+			line += field + string(convenioDigit(valueID, field))
+		}
+	}
+
+	boleto := &Boleto{
+		Type:          BOLETO_TYPE_CONVENIO,
+		Barcode:       barcode,
+		DigitableLine: line,
+		Segment:       barcode[1:2],
+	}
+
+	// 6 and 8 mean the amount field holds the amount; 7 and 9 a reference.
+	if valueID == '6' || valueID == '8' {
+		boleto.Amount = boletoAmount(barcode[4:15])
+	}
+
+	return boleto, nil
+}
+
+func boletoAmount(digits string) float64 {
+	cents, _ := strconv.ParseInt(digits, 10, 64)
+
+	return float64(cents) / 100
+}
+
+func boletoDueDate(factor int, reference time.Time) time.Time {
+	dueDate := boletoFactorBase.AddDate(0, 0, factor)
+	if factor < 1000 {
+		return dueDate
+	}
+
+	reset := boletoFactorBaseReset.AddDate(0, 0, factor)
+	if reference.Sub(dueDate).Abs() > reference.Sub(reset).Abs() {
+		return reset
+	}
+
+	return dueDate
+}
+
+// Mod10 is the check digit of digitable line fields: digits are weighted 2
+// and 1 from the right, products over 9 add their digits, and the digit is
+// what takes the sum to the next multiple of 10.
+func Mod10(digits string) byte {
+	sum := 0
+	weight := 2
+	for i := len(digits) - 1; i >= 0; i-- {
+		product := int(digits[i]-'0') * weight
+		sum += product/10 + product%10
+		weight = 3 - weight
+	}
+
+	return byte('0' + (10-sum%10)%10)
+}
+
+// Mod11 weights digits 2 to 9 from the right and returns the remainder of the
+// sum by 11, which each boleto type turns into a digit its own way.
+func Mod11(digits string) int {
+	sum := 0
+	weight := 2
+	for i := len(digits) - 1; i >= 0; i-- {
+		sum += int(digits[i]-'0') * weight
+		if weight++; weight > 9 {
+			weight = 2
+		}
+	}
+
+	return sum % 11
+}
+
+func bankDigit(digits string) byte {
+	digit := 11 - Mod11(digits)
+	if digit == 0 || digit == 10 || digit == 11 {
+		digit = 1
+	}
+
+	return byte('0' + digit)
+}
+
+func convenioDigit(valueID byte, digits string) byte {
+	if valueID == '6' || valueID == '7' {
+		return Mod10(digits)
+	}
+
+	remainder := Mod11(digits)
+	if remainder == 0 || remainder == 1 {
+		return '0'
+	}
+
+	return byte('0' + 11 - remainder)
+}
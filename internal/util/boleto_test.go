@@ -0,0 +1,143 @@
+package util
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseBoleto(t *testing.T) {
+	reference := time.Date(2018, 7, 1, 0, 0, 0, 0, time.UTC)
+	dueDate := time.Date(2018, 6, 11, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name    string
+		code    string
+		want    *Boleto
+		wantErr string
+	}{
+		{
+			name: "should_parse_a_bank_digitable_line",
+			code: "23793.38128 60007.827136 95000.063305 9 75520000370000",
+			want: &Boleto{
+				Type:          BOLETO_TYPE_BANK,
+				Barcode:       "23799755200003700003381260007827139500006330",
+				DigitableLine: "23793381286000782713695000063305975520000370000",
+				BankCode:      "237",
+				Amount:        3700,
+				DueDate:       &dueDate,
+			},
+		},
+		{
+			name: "should_build_the_digitable_line_of_a_bank_barcode",
+			code: "23799755200003700003381260007827139500006330",
+			want: &Boleto{
+				Type:          BOLETO_TYPE_BANK,
+				Barcode:       "23799755200003700003381260007827139500006330",
+				DigitableLine: "23793381286000782713695000063305975520000370000",
+				BankCode:      "237",
+				Amount:        3700,
+				DueDate:       &dueDate,
+			},
+		},
+		{
+			name: "should_parse_a_convenio_digitable_line_with_mod10",
+			code: "84670000001-7 43590024020-9 02405000243-5 84221010811-9",
+			want: &Boleto{
+				Type:          BOLETO_TYPE_CONVENIO,
+				Barcode:       "84670000001435900240200240500024384221010811",
+				DigitableLine: "846700000017435900240209024050002435842210108119",
+				Segment:       "4",
+				Amount:        143.59,
+			},
+		},
+		{
+			name: "should_build_the_digitable_line_of_a_convenio_barcode",
+			code: "85890000460524601791606075930508683148300001",
+			want: &Boleto{
+				Type:          BOLETO_TYPE_CONVENIO,
+				Barcode:       "85890000460524601791606075930508683148300001",
+				DigitableLine: "858900004609524601791605607593050865831483000010",
+				Segment:       "5",
+				Amount:        46052.46,
+			},
+		},
+		{
+			name:    "should_reject_a_wrong_field_digit",
+			code:    "23793.38127 60007.827136 95000.063305 9 75520000370000",
+			wantErr: "invalid boleto: wrong verification digit in field 1",
+		},
+		{
+			name:    "should_reject_a_wrong_general_digit",
+			code:    "23793.38128 60007.827136 95000.063305 8 75520000370000",
+			wantErr: "invalid boleto: wrong barcode verification digit",
+		},
+		{
+			name:    "should_reject_a_wrong_block_digit",
+			code:    "84670000001-7 43590024020-8 02405000243-5 84221010811-9",
+			wantErr: "invalid boleto: wrong verification digit in block 2",
+		},
+		{
+			name:    "should_reject_letters",
+			code:    "23793.38128 60007.82713A",
+			wantErr: "invalid boleto: only digits are allowed",
+		},
+		{
+			name:    "should_reject_an_unknown_size",
+			code:    "2379338128",
+			wantErr: "invalid boleto: expected 44, 47 or 48 digits",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseBoleto(tt.code, reference)
+
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+				assert.True(t, errors.Is(err, ErrInvalidBoleto))
+
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestBoletoDueDate(t *testing.T) {
+	tests := []struct {
+		name      string
+		factor    int
+		reference time.Time
+		want      time.Time
+	}{
+		{
+			name:      "should_count_from_the_first_base",
+			factor:    7552,
+			reference: time.Date(2018, 7, 1, 0, 0, 0, 0, time.UTC),
+			want:      time.Date(2018, 6, 11, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:      "should_count_from_the_reset_after_it",
+			factor:    1000,
+			reference: time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC),
+			want:      time.Date(2025, 2, 22, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name:      "should_keep_the_last_factors_of_the_first_cycle",
+			factor:    9999,
+			reference: time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC),
+			want:      time.Date(2025, 2, 21, 0, 0, 0, 0, time.UTC),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, boletoDueDate(tt.factor, tt.reference))
+		})
+	}
+}
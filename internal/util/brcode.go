@@ -0,0 +1,256 @@
+package util
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"golang.org/x/text/runes"
+	"golang.org/x/text/transform"
+	"golang.org/x/text/unicode/norm"
+)
+
+// BR Code is the EMV Merchant Presented Mode QR payload used by Pix. Every
+// field is an ID, a two digit length and the value; the last one is a
+// CRC16/CCITT-FALSE checksum of the whole payload up to its own length.
+const (
+	BRCODE_GUI       = "br.gov.bcb.pix"
+	BRCODE_STATIC    = "11"
+	BRCODE_DYNAMIC   = "12"
+	BRCODE_NO_TXID   = "***"
+	BRCODE_NAME_MAX  = 25
+	BRCODE_CITY_MAX  = 15
+	BRCODE_TXID_MAX  = 25
+	BRCODE_FIELD_MAX = 99
+)
+
+const (
+	brCodeFormat         = "00"
+	brCodeInitiation     = "01"
+	brCodeAccount        = "26"
+	brCodeAccountGUI     = "00"
+	brCodeAccountKey     = "01"
+	brCodeAccountInfo    = "02"
+	brCodeAccountURL     = "25"
+	brCodeCategory       = "52"
+	brCodeCurrency       = "53"
+	brCodeAmount         = "54"
+	brCodeCountry        = "58"
+	brCodeName           = "59"
+	brCodeCity           = "60"
+	brCodeAdditional     = "62"
+	brCodeAdditionalTxID = "05"
+	brCodeCRC            = "63"
+)
+
+var ErrInvalidBRCode = errors.New("invalid br code")
+
+// BRCode holds the fields of a Pix payload. Static codes carry the key and
+// may be paid many times; dynamic codes carry the URL, without scheme, of a
+// payment request that is fetched when paying.
+type BRCode struct {
+	Initiation   string
+	Key          string
+	Description  string
+	URL          string
+	Amount       float64
+	MerchantName string
+	MerchantCity string
+	TxID         string
+}
+
+// EncodeBRCode builds the payload of a code, cutting name and city to their
+// maximum lengths and dropping accents, which not every reader accepts. It
+// fails when key, description and URL do not fit in their 99 characters.
+func EncodeBRCode(code BRCode) (string, error) {
+	account := emvField(brCodeAccountGUI, BRCODE_GUI)
+	if code.Key != "" {
+		account += emvField(brCodeAccountKey, code.Key)
+	}
+	if code.Description != "" {
+		account += emvField(brCodeAccountInfo, code.Description)
+	}
+	if code.URL != "" {
+		account += emvField(brCodeAccountURL, code.URL)
+	}
+
+	if len(account) > BRCODE_FIELD_MAX {
+		return "", fmt.Errorf("%w: merchant account information is too long", ErrInvalidBRCode)
+	}
+
+	txID := code.TxID
+	if txID == "" {
+		txID = BRCODE_NO_TXID
+	}
+
+	var payload strings.Builder
+	payload.WriteString(emvField(brCodeFormat, "01"))
+	if code.Initiation != "" {
+		payload.WriteString(emvField(brCodeInitiation, code.Initiation))
+	}
+	payload.WriteString(emvField(brCodeAccount, account))
+	payload.WriteString(emvField(brCodeCategory, "0000"))
+	payload.WriteString(emvField(brCodeCurrency, "986"))
+	if code.Amount > 0 {
+		payload.WriteString(emvField(brCodeAmount, strconv.FormatFloat(code.Amount, 'f', 2, 64)))
+	}
+	payload.WriteString(emvField(brCodeCountry, "BR"))
+	payload.WriteString(emvField(brCodeName, brCodeText(code.MerchantName, BRCODE_NAME_MAX)))
+	payload.WriteString(emvField(brCodeCity, brCodeText(code.MerchantCity, BRCODE_CITY_MAX)))
+	payload.WriteString(emvField(brCodeAdditional, emvField(brCodeAdditionalTxID, txID)))
+	payload.WriteString(brCodeCRC + "04")
+
+	return payload.String() + fmt.Sprintf("%04X", CRC16(payload.String())), nil
+}
+
+// ParseBRCode reads a Pix payload, checking its checksum and the fields every
+// code must have. Errors wrap ErrInvalidBRCode and say what is wrong.
+func ParseBRCode(payload string) (*BRCode, error) {
+	payload = strings.TrimSpace(payload)
+
+	if len(payload) < 8 || payload[len(payload)-8:len(payload)-4] != brCodeCRC+"04" {
+		return nil, fmt.Errorf("%w: missing checksum", ErrInvalidBRCode)
+	}
+
+	checksum, err := strconv.ParseUint(payload[len(payload)-4:], 16, 16)
+	if err != nil || uint16(checksum) != CRC16(payload[:len(payload)-4]) {
+		return nil, fmt.Errorf("%w: checksum mismatch", ErrInvalidBRCode)
+	}
+
+	fields, err := emvFields(payload[:len(payload)-8])
+	if err != nil {
+		return nil, err
+	}
+
+	if fields[brCodeFormat] != "01" {
+		return nil, fmt.Errorf("%w: unsupported payload format", ErrInvalidBRCode)
+	}
+
+	if fields[brCodeCurrency] != "986" {
+		return nil, fmt.Errorf("%w: currency is not BRL", ErrInvalidBRCode)
+	}
+
+	account, err := emvFields(fields[brCodeAccount])
+	if err != nil {
+		return nil, err
+	}
+
+	if !strings.EqualFold(account[brCodeAccountGUI], BRCODE_GUI) {
+		return nil, fmt.Errorf("%w: not a pix code", ErrInvalidBRCode)
+	}
+
+	code := &BRCode{
+		Initiation:   fields[brCodeInitiation],
+		Key:          account[brCodeAccountKey],
+		Description:  account[brCodeAccountInfo],
+		URL:          account[brCodeAccountURL],
+		MerchantName: fields[brCodeName],
+		MerchantCity: fields[brCodeCity],
+	}
+
+	if code.Key == "" && code.URL == "" {
+		return nil, fmt.Errorf("%w: missing pix key", ErrInvalidBRCode)
+	}
+
+	if code.MerchantName == "" || code.MerchantCity == "" {
+		return nil, fmt.Errorf("%w: missing merchant", ErrInvalidBRCode)
+	}
+
+	if amount, ok := fields[brCodeAmount]; ok {
+		code.Amount, err = strconv.ParseFloat(amount, 64)
+		if err != nil || code.Amount <= 0 {
+			return nil, fmt.Errorf("%w: invalid amount", ErrInvalidBRCode)
+		}
+	}
+
+	if additional, ok := fields[brCodeAdditional]; ok {
+		additionalFields, err := emvFields(additional)
+		if err != nil {
+			return nil, err
+		}
+
+		if txID := additionalFields[brCodeAdditionalTxID]; txID != BRCODE_NO_TXID {
+			code.TxID = txID
+		}
+	}
+
+	return code, nil
+}
+
+// CRC16 is the CRC16/CCITT-FALSE checksum (polynomial 0x1021, initial value
+// 0xFFFF) BR Codes end with.
+func CRC16(data string) uint16 {
+	crc := uint16(0xFFFF)
+	for i := 0; i < len(data); i++ {
+		crc ^= uint16(data[i]) << 8
+		for bit := 0; bit < 8; bit++ {
+			if crc&0x8000 != 0 {
+				crc = crc<<1 ^ 0x1021
+			} else {
+				crc <<= 1
+			}
+		}
+	}
+
+	return crc
+}
+
+func emvField(id string, value string) string {
+	return fmt.Sprintf("%s%02d%s", id, len(value), value)
+}
+
+func emvFields(data string) (map[string]string, error) {
+	fields := map[string]string{}
+	for len(data) > 0 {
+		if len(data) < 4 {
+			return nil, fmt.Errorf("%w: truncated field", ErrInvalidBRCode)
+		}
+
+		if !isDigits(data[2:4]) {
+			return nil, fmt.Errorf("%w: invalid length of field %s", ErrInvalidBRCode, data[:2])
+		}
+
+		size, _ := strconv.Atoi(data[2:4])
+		if len(data) < 4+size {
+			return nil, fmt.Errorf("%w: invalid length of field %s", ErrInvalidBRCode, data[:2])
+		}
+
+		fields[data[:2]] = data[4 : 4+size]
+		data = data[4+size:]
+	}
+
+	return fields, nil
+}
+
+// isDigits tells whether value has only ASCII digits, which strconv.Atoi
+// alone does not, as it takes signs.
+func isDigits(value string) bool {
+	for i := 0; i < len(value); i++ {
+		if value[i] < '0' || value[i] > '9' {
+			return false
+		}
+	}
+
+	return true
+}
+
+func brCodeText(text string, max int) string {
+	stripAccents := transform.Chain(norm.NFD, runes.Remove(runes.In(unicode.Mn)), norm.NFC)
+	text, _, _ = transform.String(stripAccents, strings.TrimSpace(text))
+
+	text = strings.Map(func(r rune) rune {
+		if r > unicode.MaxASCII {
+			return -1
+		}
+
+		return r
+	}, text)
+
+	if len(text) > max {
+		text = strings.TrimSpace(text[:max])
+	}
+
+	return text
+}
@@ -0,0 +1,177 @@
+package util
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// brCodeManualExample is the static code of the BR Code manual of the
+// Central Bank of Brazil.
+const brCodeManualExample = "00020126580014br.gov.bcb.pix0136123e4567-e12b-12d1-a456-4266554400005204000053039865802BR5913Fulano de Tal6008BRASILIA62070503***63041D3D"
+
+func TestCRC16(t *testing.T) {
+	assert.Equal(t, uint16(0x29B1), CRC16("123456789"))
+	assert.Equal(t, uint16(0x1D3D), CRC16(brCodeManualExample[:len(brCodeManualExample)-4]))
+}
+
+func TestEncodeBRCode(t *testing.T) {
+	tests := []struct {
+		name    string
+		code    BRCode
+		want    string
+		wantErr bool
+	}{
+		{
+			name: "should_match_the_manual_example",
+			code: BRCode{
+				Key:          "123e4567-e12b-12d1-a456-426655440000",
+				MerchantName: "Fulano de Tal",
+				MerchantCity: "BRASILIA",
+			},
+			want: brCodeManualExample,
+		},
+		{
+			name: "should_write_amount_txid_and_drop_accents",
+			code: BRCode{
+				Initiation:   BRCODE_STATIC,
+				Key:          "loren@example.com",
+				Amount:       10.5,
+				MerchantName: "João Conceição",
+				MerchantCity: "São Paulo",
+				TxID:         "abc123",
+			},
+			want: "000201010211263900" + "14br.gov.bcb.pix0117loren@example.com" + "52040000530398654" + "0510.50" + "5802BR5914Joao Conceicao6009Sao Paulo62100506abc1236304",
+		},
+		{
+			name: "should_write_the_url_of_dynamic_codes",
+			code: BRCode{
+				Initiation:   BRCODE_DYNAMIC,
+				URL:          "pix.example.com/qr/9d36b84f",
+				MerchantName: "Loren",
+				MerchantCity: "BRASILIA",
+			},
+			want: "000201010212264900" + "14br.gov.bcb.pix2527pix.example.com/qr/9d36b84f" + "520400005303986" + "5802BR5905Loren6008BRASILIA62070503***6304",
+		},
+		{
+			name: "should_reject_account_information_over_99_characters",
+			code: BRCode{
+				Key:          "0123456789012345678901234567890123456789012345678901234567890123456789012345",
+				Description:  "description",
+				MerchantName: "Loren",
+				MerchantCity: "BRASILIA",
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := EncodeBRCode(tt.code)
+			if tt.wantErr {
+				assert.True(t, errors.Is(err, ErrInvalidBRCode))
+				return
+			}
+
+			assert.NoError(t, err)
+			if len(tt.want) == len(got) {
+				assert.Equal(t, tt.want, got)
+				return
+			}
+
+			// The checksum is left out of expectations that were not taken
+			// from a published code; it is checked by parsing instead.
+			assert.Equal(t, tt.want, got[:len(got)-4])
+			_, err = ParseBRCode(got)
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestParseBRCode(t *testing.T) {
+	dynamic, _ := EncodeBRCode(BRCode{
+		Initiation:   BRCODE_DYNAMIC,
+		URL:          "pix.example.com/qr/9d36b84f",
+		Amount:       25,
+		MerchantName: "Loren",
+		MerchantCity: "BRASILIA",
+	})
+
+	tests := []struct {
+		name    string
+		payload string
+		want    *BRCode
+		wantErr string
+	}{
+		{
+			name:    "should_parse_the_manual_example",
+			payload: " " + brCodeManualExample + "\n",
+			want: &BRCode{
+				Key:          "123e4567-e12b-12d1-a456-426655440000",
+				MerchantName: "Fulano de Tal",
+				MerchantCity: "BRASILIA",
+			},
+		},
+		{
+			name:    "should_parse_dynamic_codes",
+			payload: dynamic,
+			want: &BRCode{
+				Initiation:   BRCODE_DYNAMIC,
+				URL:          "pix.example.com/qr/9d36b84f",
+				Amount:       25,
+				MerchantName: "Loren",
+				MerchantCity: "BRASILIA",
+			},
+		},
+		{
+			name:    "should_reject_a_wrong_checksum",
+			payload: brCodeManualExample[:len(brCodeManualExample)-4] + "1D3E",
+			wantErr: "invalid br code: checksum mismatch",
+		},
+		{
+			name:    "should_reject_payloads_without_checksum",
+			payload: "000201",
+			wantErr: "invalid br code: missing checksum",
+		},
+		{
+			name:    "should_reject_fields_longer_than_the_payload",
+			payload: withChecksum("00020126990014br.gov.bcb.pix"),
+			wantErr: "invalid br code: invalid length of field 26",
+		},
+		{
+			name:    "should_reject_negative_field_lengths",
+			payload: withChecksum("00020126-1"),
+			wantErr: "invalid br code: invalid length of field 26",
+		},
+		{
+			name:    "should_reject_signed_field_lengths",
+			payload: withChecksum("00020126+4br.g"),
+			wantErr: "invalid br code: invalid length of field 26",
+		},
+		{
+			name:    "should_reject_codes_of_other_schemes",
+			payload: withChecksum("00020126180014br.gov.bcb.xyz5204000053039865802BR5905Loren6008BRASILIA"),
+			wantErr: "invalid br code: not a pix code",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseBRCode(tt.payload)
+			if tt.wantErr != "" {
+				assert.True(t, errors.Is(err, ErrInvalidBRCode))
+				assert.EqualError(t, err, tt.wantErr)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func withChecksum(payload string) string {
+	payload += "6304"
+
+	return payload + fmt.Sprintf("%04X", CRC16(payload))
+}
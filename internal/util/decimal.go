@@ -23,3 +23,14 @@ func ConvertToDecimal(number float64) decimal.Decimal {
 
 	return formattedNumber
 }
+
+// ParseAmount reads an optional amount setting, zero when unset, invalid or
+// negative.
+func ParseAmount(value string) float64 {
+	amount, err := decimal.NewFromString(value)
+	if err != nil || amount.IsNegative() {
+		return 0
+	}
+
+	return amount.InexactFloat64()
+}
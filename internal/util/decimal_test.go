@@ -63,3 +63,22 @@ func TestSub(t *testing.T) {
 		})
 	}
 }
+
+func TestParseAmount(t *testing.T) {
+	tests := []struct {
+		name  string
+		value string
+		want  float64
+	}{
+		{name: "should_parse_an_amount", value: "1000.50", want: 1000.5},
+		{name: "should_be_zero_when_unset", value: "", want: 0},
+		{name: "should_be_zero_when_invalid", value: "ten", want: 0},
+		{name: "should_be_zero_when_negative", value: "-1", want: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, ParseAmount(tt.value))
+		})
+	}
+}
@@ -7,8 +7,15 @@ import (
 )
 
 func GenerateJwtToken(key string, value interface{}, secret string) string {
+	return GenerateJwtClaims(map[string]interface{}{key: value}, secret)
+}
+
+// GenerateJwtClaims signs a token carrying every claim of values.
+func GenerateJwtClaims(values map[string]interface{}, secret string) string {
 	claims := sjwt.New()
-	claims.Set(key, value)
+	for key, value := range values {
+		claims.Set(key, value)
+	}
 	secretKey := []byte(secret)
 
 	return claims.Generate(secretKey)
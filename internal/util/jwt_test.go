@@ -26,3 +26,17 @@ func TestGenerateAndParseJwtToken(t *testing.T) {
 		assert.Empty(t, parsedValue, "Parsed value from invalid token should be empty")
 	})
 }
+
+func TestGenerateJwtClaims(t *testing.T) {
+	secret := "mySecretKey"
+
+	token := GenerateJwtClaims(map[string]interface{}{"account_id": 3, "token_version": 2}, secret)
+
+	accountID, err := ParseJwtToken("account_id", token, secret)
+	assert.NoError(t, err)
+	assert.Equal(t, "3", accountID)
+
+	tokenVersion, err := ParseJwtToken("token_version", token, secret)
+	assert.NoError(t, err)
+	assert.Equal(t, "2", tokenVersion)
+}
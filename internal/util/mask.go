@@ -0,0 +1,28 @@
+package util
+
+import (
+	"strings"
+	"unicode/utf8"
+)
+
+// MaskCPF keeps only the middle six digits, as the Central Bank recommends
+// for Pix receipts: 25462557035 becomes ***.625.570-**.
+func MaskCPF(cpf string) string {
+	if len(cpf) != 11 {
+		return strings.Repeat("*", len(cpf))
+	}
+
+	return "***." + cpf[3:6] + "." + cpf[6:9] + "-**"
+}
+
+// MaskName keeps the first name and abbreviates the others: "Loren Ipsum
+// Dolor" becomes "Loren I. D.".
+func MaskName(name string) string {
+	words := strings.Fields(name)
+	for i := 1; i < len(words); i++ {
+		initial, _ := utf8.DecodeRuneInString(words[i])
+		words[i] = string(initial) + "."
+	}
+
+	return strings.Join(words, " ")
+}
@@ -0,0 +1,62 @@
+package util
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMaskCPF(t *testing.T) {
+	tests := []struct {
+		name string
+		cpf  string
+		want string
+	}{
+		{
+			name: "should_keep_middle_digits",
+			cpf:  "25462557035",
+			want: "***.625.570-**",
+		},
+		{
+			name: "should_mask_everything_when_cpf_is_malformed",
+			cpf:  "1234",
+			want: "****",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, MaskCPF(tt.cpf))
+		})
+	}
+}
+
+func TestMaskName(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{
+			name:  "should_abbreviate_surnames",
+			input: "Loren Ipsum Dolor",
+			want:  "Loren I. D.",
+		},
+		{
+			name:  "should_keep_single_name",
+			input: "Loren",
+			want:  "Loren",
+		},
+		{
+			name:  "should_keep_accented_initials",
+			input: "João Álvares",
+			want:  "João Á.",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, MaskName(tt.input))
+		})
+	}
+}
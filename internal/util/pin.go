@@ -0,0 +1,41 @@
+package util
+
+import (
+	"golang.org/x/crypto/bcrypt"
+)
+
+const (
+	PIN_MIN_DIGITS = 4
+	PIN_MAX_DIGITS = 6
+)
+
+// ValidPIN tells whether a transaction PIN has only 4 to 6 digits.
+func ValidPIN(pin string) bool {
+	if len(pin) < PIN_MIN_DIGITS || len(pin) > PIN_MAX_DIGITS {
+		return false
+	}
+
+	for _, r := range pin {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+
+	return true
+}
+
+// GeneratePINHash hashes a transaction PIN with bcrypt. Unlike secrets, PINs
+// have few enough combinations for a fast hash to be reversed by brute force
+// if the hashes leak.
+func GeneratePINHash(pin string) (string, error) {
+	hash, err := bcrypt.GenerateFromPassword([]byte(pin), bcrypt.DefaultCost)
+	if err != nil {
+		return "", err
+	}
+
+	return string(hash), nil
+}
+
+func MatchPINHash(hash string, pin string) bool {
+	return bcrypt.CompareHashAndPassword([]byte(hash), []byte(pin)) == nil
+}
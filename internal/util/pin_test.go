@@ -0,0 +1,37 @@
+package util
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidPIN(t *testing.T) {
+	tests := []struct {
+		name string
+		pin  string
+		want bool
+	}{
+		{name: "should_accept_four_digits", pin: "1234", want: true},
+		{name: "should_accept_six_digits", pin: "000000", want: true},
+		{name: "should_reject_three_digits", pin: "123", want: false},
+		{name: "should_reject_seven_digits", pin: "1234567", want: false},
+		{name: "should_reject_letters", pin: "12a4", want: false},
+		{name: "should_reject_empty", pin: "", want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, ValidPIN(tt.pin))
+		})
+	}
+}
+
+func TestMatchPINHash(t *testing.T) {
+	hash, err := GeneratePINHash("4321")
+	assert.NoError(t, err)
+	assert.NotContains(t, hash, "4321")
+
+	assert.True(t, MatchPINHash(hash, "4321"))
+	assert.False(t, MatchPINHash(hash, "1234"))
+	assert.False(t, MatchPINHash("", "4321"))
+}
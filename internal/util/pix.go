@@ -0,0 +1,101 @@
+package util
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"math/big"
+	"net/mail"
+	"regexp"
+	"strings"
+)
+
+var (
+	phoneRegex = regexp.MustCompile(`^\+[1-9][0-9]{7,14}$`)
+	uuidRegex  = regexp.MustCompile(`^[0-9a-f]{8}-[0-9a-f]{4}-4[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`)
+)
+
+// GenerateUUID returns a random (version 4) UUID, the format of Pix random
+// keys.
+func GenerateUUID() string {
+	value := make([]byte, 16)
+	if _, err := rand.Read(value); err != nil {
+		panic(err)
+	}
+
+	value[6] = value[6]&0x0f | 0x40
+	value[8] = value[8]&0x3f | 0x80
+
+	encoded := hex.EncodeToString(value)
+
+	return fmt.Sprintf("%s-%s-%s-%s-%s", encoded[0:8], encoded[8:12], encoded[12:16], encoded[16:20], encoded[20:32])
+}
+
+// GenerateNumericCode returns a random code of the given number of digits,
+// with leading zeros.
+func GenerateNumericCode(digits int) string {
+	code := make([]byte, digits)
+	for i := range code {
+		n, err := rand.Int(rand.Reader, big.NewInt(10))
+		if err != nil {
+			panic(err)
+		}
+
+		code[i] = byte('0' + n.Int64())
+	}
+
+	return string(code)
+}
+
+// NormalizeEmail lower cases an address and reports whether it is a bare
+// address, without display name.
+func NormalizeEmail(email string) (string, bool) {
+	email = strings.ToLower(strings.TrimSpace(email))
+
+	address, err := mail.ParseAddress(email)
+	if err != nil || address.Address != email || len(email) > 77 {
+		return "", false
+	}
+
+	return email, true
+}
+
+// NormalizePhone strips formatting from a phone number and reports whether
+// it is in E.164, like +5561912345678.
+func NormalizePhone(phone string) (string, bool) {
+	phone = strings.Map(func(r rune) rune {
+		if r == ' ' || r == '-' || r == '(' || r == ')' {
+			return -1
+		}
+
+		return r
+	}, phone)
+
+	return phone, phoneRegex.MatchString(phone)
+}
+
+// IsUUID reports whether key is a lower case version 4 UUID.
+func IsUUID(key string) bool {
+	return uuidRegex.MatchString(key)
+}
+
+// MaskEmail keeps the first letter of the local part and the domain:
+// loren@example.com becomes l****@example.com.
+func MaskEmail(email string) string {
+	local, domain, found := strings.Cut(email, "@")
+	if !found || local == "" {
+		return strings.Repeat("*", len(email))
+	}
+
+	return local[:1] + strings.Repeat("*", len(local)-1) + "@" + domain
+}
+
+// MaskPhone keeps the country code and the last four digits: +5561912345678
+// becomes +55*******5678.
+func MaskPhone(phone string) string {
+	if len(phone) < 8 {
+		return strings.Repeat("*", len(phone))
+	}
+
+	return phone[:3] + strings.Repeat("*", len(phone)-7) + phone[len(phone)-4:]
+}
@@ -0,0 +1,95 @@
+package util
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGenerateUUID(t *testing.T) {
+	first, second := GenerateUUID(), GenerateUUID()
+
+	assert.True(t, IsUUID(first), first)
+	assert.True(t, IsUUID(second), second)
+	assert.NotEqual(t, first, second)
+}
+
+func TestGenerateNumericCode(t *testing.T) {
+	code := GenerateNumericCode(6)
+
+	assert.Len(t, code, 6)
+	assert.Regexp(t, `^[0-9]{6}$`, code)
+}
+
+func TestNormalizeEmail(t *testing.T) {
+	tests := []struct {
+		name   string
+		email  string
+		want   string
+		wantOk bool
+	}{
+		{
+			name:   "should_lower_case_and_trim",
+			email:  " Loren@Example.com ",
+			want:   "loren@example.com",
+			wantOk: true,
+		},
+		{
+			name:  "should_reject_display_names",
+			email: "Loren <loren@example.com>",
+		},
+		{
+			name:  "should_reject_missing_domain",
+			email: "loren",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := NormalizeEmail(tt.email)
+
+			assert.Equal(t, tt.wantOk, ok)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestNormalizePhone(t *testing.T) {
+	tests := []struct {
+		name   string
+		phone  string
+		want   string
+		wantOk bool
+	}{
+		{
+			name:   "should_strip_formatting",
+			phone:  "+55 (61) 91234-5678",
+			want:   "+5561912345678",
+			wantOk: true,
+		},
+		{
+			name:  "should_require_country_code",
+			phone: "61912345678",
+			want:  "61912345678",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := NormalizePhone(tt.phone)
+
+			assert.Equal(t, tt.wantOk, ok)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestMaskEmail(t *testing.T) {
+	assert.Equal(t, "l****@example.com", MaskEmail("loren@example.com"))
+	assert.Equal(t, "*****", MaskEmail("loren"))
+}
+
+func TestMaskPhone(t *testing.T) {
+	assert.Equal(t, "+55*******5678", MaskPhone("+5561912345678"))
+	assert.Equal(t, "****", MaskPhone("1234"))
+}
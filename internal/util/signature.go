@@ -0,0 +1,71 @@
+package util
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// SignPayload returns the value of the X-Webhook-Signature header:
+// "t=<unix timestamp>,v1=<hex HMAC-SHA256 of "<timestamp>.<body>">".
+// Signing the timestamp lets receivers reject replayed deliveries.
+func SignPayload(secret string, timestamp time.Time, body []byte) string {
+	unix := strconv.FormatInt(timestamp.Unix(), 10)
+
+	return fmt.Sprintf("t=%s,v1=%s", unix, hex.EncodeToString(signature(secret, unix, body)))
+}
+
+// CheckPayloadSignature verifies a header built by SignPayload, refusing
+// timestamps further than tolerance from now.
+func CheckPayloadSignature(secret string, header string, body []byte, tolerance time.Duration, now time.Time) bool {
+	var unix, v1 string
+	for _, part := range strings.Split(header, ",") {
+		key, value, _ := strings.Cut(part, "=")
+		switch key {
+		case "t":
+			unix = value
+		case "v1":
+			v1 = value
+		}
+	}
+
+	seconds, err := strconv.ParseInt(unix, 10, 64)
+	if err != nil {
+		return false
+	}
+
+	if age := now.Sub(time.Unix(seconds, 0)); age > tolerance || age < -tolerance {
+		return false
+	}
+
+	mac, err := hex.DecodeString(v1)
+	if err != nil {
+		return false
+	}
+
+	return hmac.Equal(mac, signature(secret, unix, body))
+}
+
+// GenerateRandomHex returns size random bytes hex encoded.
+func GenerateRandomHex(size int) string {
+	value := make([]byte, size)
+	if _, err := rand.Read(value); err != nil {
+		panic(err)
+	}
+
+	return hex.EncodeToString(value)
+}
+
+func signature(secret string, unix string, body []byte) []byte {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(unix))
+	mac.Write([]byte("."))
+	mac.Write(body)
+
+	return mac.Sum(nil)
+}
@@ -0,0 +1,45 @@
+package util
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPayloadSignature(t *testing.T) {
+	secret := "mySecretKey"
+	now := time.Unix(1792400000, 0)
+	body := []byte(`{"type":"transfer.created"}`)
+	header := SignPayload(secret, now, body)
+
+	t.Run("should_sign_timestamp_and_body", func(t *testing.T) {
+		assert.Equal(t, "t=1792400000,v1=", header[:16])
+		assert.Len(t, header, 16+64)
+	})
+
+	t.Run("should_accept_the_signature", func(t *testing.T) {
+		assert.True(t, CheckPayloadSignature(secret, header, body, 5*time.Minute, now.Add(time.Minute)))
+	})
+
+	t.Run("should_reject_changed_body", func(t *testing.T) {
+		assert.False(t, CheckPayloadSignature(secret, header, []byte(`{"type":"account.blocked"}`), 5*time.Minute, now))
+	})
+
+	t.Run("should_reject_another_secret", func(t *testing.T) {
+		assert.False(t, CheckPayloadSignature("another secret", header, body, 5*time.Minute, now))
+	})
+
+	t.Run("should_reject_old_timestamps", func(t *testing.T) {
+		assert.False(t, CheckPayloadSignature(secret, header, body, 5*time.Minute, now.Add(time.Hour)))
+	})
+
+	t.Run("should_reject_malformed_header", func(t *testing.T) {
+		assert.False(t, CheckPayloadSignature(secret, "v1=zz", body, 5*time.Minute, now))
+	})
+}
+
+func TestGenerateRandomHex(t *testing.T) {
+	assert.Len(t, GenerateRandomHex(16), 32)
+	assert.NotEqual(t, GenerateRandomHex(16), GenerateRandomHex(16))
+}
@@ -0,0 +1,93 @@
+package util
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// rfc6238Secret is the SHA1 secret of the RFC 6238 test vectors,
+// "12345678901234567890", base32 encoded.
+const rfc6238Secret = "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ"
+
+func TestGenerateTOTPCode(t *testing.T) {
+	tests := []struct {
+		name string
+		unix int64
+		want string
+	}{
+		{name: "should_match_the_rfc_vector_at_59", unix: 59, want: "287082"},
+		{name: "should_match_the_rfc_vector_at_1111111109", unix: 1111111109, want: "081804"},
+		{name: "should_match_the_rfc_vector_at_1234567890", unix: 1234567890, want: "005924"},
+		{name: "should_match_the_rfc_vector_at_2000000000", unix: 2000000000, want: "279037"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := GenerateTOTPCode(rfc6238Secret, TOTPStep(time.Unix(tt.unix, 0)))
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+
+	t.Run("should_fail_for_a_secret_that_is_not_base32", func(t *testing.T) {
+		_, err := GenerateTOTPCode("not base32!", 1)
+		assert.Error(t, err)
+	})
+}
+
+func TestMatchTOTPCode(t *testing.T) {
+	now := time.Unix(1234567890, 0)
+	step := TOTPStep(now)
+
+	tests := []struct {
+		name     string
+		code     string
+		wantStep int64
+		wantOk   bool
+	}{
+		{name: "should_match_the_current_step", code: "005924", wantStep: step, wantOk: true},
+		{name: "should_match_the_previous_step", code: mustTOTPCode(t, step-1), wantStep: step - 1, wantOk: true},
+		{name: "should_match_the_next_step", code: mustTOTPCode(t, step+1), wantStep: step + 1, wantOk: true},
+		{name: "should_not_match_two_steps_behind", code: mustTOTPCode(t, step-2), wantOk: false},
+		{name: "should_not_match_a_wrong_code", code: "000000", wantOk: false},
+		{name: "should_not_match_a_code_of_other_length", code: "5924", wantOk: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := MatchTOTPCode(rfc6238Secret, tt.code, now)
+			assert.Equal(t, tt.wantOk, ok)
+			assert.Equal(t, tt.wantStep, got)
+		})
+	}
+}
+
+func TestGenerateTOTPSecret(t *testing.T) {
+	secret := GenerateTOTPSecret()
+
+	assert.Len(t, secret, 32)
+	assert.NotEqual(t, secret, GenerateTOTPSecret())
+
+	_, err := GenerateTOTPCode(secret, 1)
+	assert.NoError(t, err)
+}
+
+func TestTOTPProvisioningURI(t *testing.T) {
+	got := TOTPProvisioningURI("Go Stone", "25462557035", rfc6238Secret)
+
+	assert.True(t, strings.HasPrefix(got, "otpauth://totp/Go%20Stone:25462557035?"))
+	assert.Contains(t, got, "secret="+rfc6238Secret)
+	assert.Contains(t, got, "issuer=Go%20Stone")
+	assert.Contains(t, got, "digits=6")
+	assert.Contains(t, got, "period=30")
+}
+
+func mustTOTPCode(t *testing.T, step int64) string {
+	code, err := GenerateTOTPCode(rfc6238Secret, step)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return code
+}
@@ -67,3 +67,51 @@ func validateCPFSumDigit(s string, table []int) int {
 
 	return sum
 }
+
+var CnpjValidator validator.Func = func(fl validator.FieldLevel) bool {
+	cnpj, ok := fl.Field().Interface().(string)
+
+	return ok && ValidateCNPJ(cnpj)
+}
+
+var cnpjDigitTable = []int{6, 5, 4, 3, 2, 9, 8, 7, 6, 5, 4, 3, 2}
+
+// ValidateCNPJ checks the 14 characters of a CNPJ, numeric or in the
+// alphanumeric format issued from July 2026, where the first twelve can also
+// be upper case letters. Characters count as their ASCII code minus 48, so
+// digits keep their value and the check digits are computed as before.
+func ValidateCNPJ(cnpj string) bool {
+	if len(cnpj) != 14 {
+		return false
+	}
+
+	for i := 0; i < len(cnpj); i++ {
+		c := cnpj[i]
+		if !(c >= '0' && c <= '9') && (i >= 12 || c < 'A' || c > 'Z') {
+			return false
+		}
+	}
+
+	for size := 12; size < 14; size++ {
+		if cnpjDigit(cnpj[:size]) != cnpj[size] {
+			return false
+		}
+	}
+
+	return true
+}
+
+func cnpjDigit(s string) byte {
+	table := cnpjDigitTable[len(cnpjDigitTable)-len(s):]
+
+	sum := 0
+	for i, weight := range table {
+		sum += int(s[i]-'0') * weight
+	}
+
+	if r := sum % 11; r >= 2 {
+		return byte('0' + 11 - r)
+	}
+
+	return '0'
+}
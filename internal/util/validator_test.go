@@ -32,3 +32,44 @@ func TestValidateCPF(t *testing.T) {
 		assert.Equal(t, 0, invalidSum, "Mismatched length input should return zero sum")
 	})
 }
+
+func TestValidateCNPJ(t *testing.T) {
+	tests := []struct {
+		name string
+		cnpj string
+		want bool
+	}{
+		{
+			name: "should_accept_a_numeric_cnpj",
+			cnpj: "11222333000181",
+			want: true,
+		},
+		{
+			name: "should_accept_an_alphanumeric_cnpj",
+			cnpj: "12ABC34501DE35",
+			want: true,
+		},
+		{
+			name: "should_reject_a_wrong_check_digit",
+			cnpj: "12ABC34501DE36",
+		},
+		{
+			name: "should_reject_lower_case_letters",
+			cnpj: "12abc34501de35",
+		},
+		{
+			name: "should_reject_letters_in_the_check_digits",
+			cnpj: "12ABC34501DE3A",
+		},
+		{
+			name: "should_reject_formatted_cnpjs",
+			cnpj: "11.222.333/0001-81",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, ValidateCNPJ(tt.cnpj))
+		})
+	}
+}
@@ -0,0 +1,45 @@
+package util
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base32"
+	"encoding/binary"
+	"fmt"
+	"strings"
+)
+
+const VERIFICATION_MAC_SIZE = 10
+
+var verificationEncoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+// GenerateVerificationCode encodes id together with a truncated
+// HMAC-SHA256 of payload, so the code can be traced back to its record and
+// checked against the record's current contents.
+func GenerateVerificationCode(id uint, payload string, secret string) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(payload))
+
+	code := binary.BigEndian.AppendUint64(nil, uint64(id))
+	code = append(code, mac.Sum(nil)[:VERIFICATION_MAC_SIZE]...)
+
+	return verificationEncoding.EncodeToString(code)
+}
+
+// ParseVerificationCode returns the id encoded in a verification code.
+func ParseVerificationCode(code string) (uint, error) {
+	decoded, err := verificationEncoding.DecodeString(strings.ToUpper(code))
+	if err != nil || len(decoded) != 8+VERIFICATION_MAC_SIZE {
+		return 0, fmt.Errorf("error to parse verification code")
+	}
+
+	return uint(binary.BigEndian.Uint64(decoded[:8])), nil
+}
+
+// CheckVerificationCode reports whether code was generated for id and
+// payload with secret.
+func CheckVerificationCode(code string, id uint, payload string, secret string) bool {
+	expected := GenerateVerificationCode(id, payload, secret)
+
+	return hmac.Equal([]byte(expected), []byte(strings.ToUpper(code)))
+}
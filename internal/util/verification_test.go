@@ -0,0 +1,36 @@
+package util
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVerificationCode(t *testing.T) {
+	secret := "mySecretKey"
+	code := GenerateVerificationCode(42, "42|1|2|10", secret)
+
+	t.Run("should_encode_the_id", func(t *testing.T) {
+		id, err := ParseVerificationCode(code)
+		assert.NoError(t, err)
+		assert.Equal(t, uint(42), id)
+	})
+
+	t.Run("should_accept_lowercase_codes", func(t *testing.T) {
+		assert.True(t, CheckVerificationCode(strings.ToLower(code), 42, "42|1|2|10", secret))
+	})
+
+	t.Run("should_reject_changed_payload", func(t *testing.T) {
+		assert.False(t, CheckVerificationCode(code, 42, "42|1|2|1000", secret))
+	})
+
+	t.Run("should_reject_another_secret", func(t *testing.T) {
+		assert.False(t, CheckVerificationCode(code, 42, "42|1|2|10", "another secret"))
+	})
+
+	t.Run("should_fail_to_parse_invalid_code", func(t *testing.T) {
+		_, err := ParseVerificationCode("not-a-code")
+		assert.Error(t, err)
+	})
+}